@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// Grant describes a permission to grant using GrantAll.
+type Grant struct {
+	Subject  Subject
+	Resource Resource
+	Level    models.PermissionLevel
+}
+
+// Revocation describes a permission to revoke using RevokeAll.
+type Revocation struct {
+	Subject  Subject
+	Resource Resource
+}
+
+// BatchError is returned by the batch helpers when one or more requests fail. Errors are indexed by the position of
+// the failed item in the batch.
+type BatchError struct {
+	Total  int
+	Errors map[int]error
+}
+
+// Error describes the first failure in the batch along with the number of failures.
+func (e *BatchError) Error() string {
+	indexes := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	first := indexes[0]
+	return fmt.Sprintf("%d of %d requests failed; item %d: %s", len(e.Errors), e.Total, first, e.Errors[first])
+}
+
+// runBatch calls a function for each item in a batch, with at most the configured number of calls running at once.
+// Items that haven't been started when the context is canceled fail with the context's error.
+func (c *Client) runBatch(ctx context.Context, n int, f func(i int) error) error {
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(map[int]error)
+	recordError := func(i int, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		errs[i] = err
+	}
+
+	semaphore := make(chan struct{}, c.batchConcurrency)
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			recordError(i, ctx.Err())
+			continue
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			if err := f(i); err != nil {
+				recordError(i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &BatchError{Total: n, Errors: errs}
+	}
+	return nil
+}
+
+// GrantAll grants each of the given permissions. Every grant is attempted even if some of them fail; the returned
+// error is a *BatchError if any of them do.
+func (c *Client) GrantAll(ctx context.Context, grants []Grant) error {
+	return c.runBatch(ctx, len(grants), func(i int) error {
+		_, err := c.Grant(ctx, grants[i].Subject, grants[i].Resource, grants[i].Level)
+		return err
+	})
+}
+
+// RevokeAll revokes each of the given permissions. Every revocation is attempted even if some of them fail; the
+// returned error is a *BatchError if any of them do.
+func (c *Client) RevokeAll(ctx context.Context, revocations []Revocation) error {
+	return c.runBatch(ctx, len(revocations), func(i int) error {
+		return c.Revoke(ctx, revocations[i].Subject, revocations[i].Resource)
+	})
+}
+
+// CheckAll determines which of the named resources of a single type a subject has at least the given level of
+// permission to access, using a single request. The result contains an entry for every name.
+func (c *Client) CheckAll(
+	ctx context.Context, subject Subject, resourceType string, names []string, level models.PermissionLevel,
+) (map[string]bool, error) {
+	permissions, err := c.ListForSubject(ctx, subject, &ListOptions{
+		ResourceType: resourceType,
+		MinLevel:     level,
+		Lookup:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(names))
+	for _, name := range names {
+		result[name] = false
+	}
+	for _, permission := range permissions {
+		if _, ok := result[*permission.Resource.Name]; ok {
+			result[*permission.Resource.Name] = true
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,224 @@
+// Package client is a Go client for the permissions service. It uses the request and response types in the models
+// package, which are generated from swagger.yml, and wraps the most common operations in methods that accept a
+// context. Requests that fail with a 5xx status or a network error are retried with exponential backoff; every
+// operation that this package exposes is idempotent, so retries are always safe.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// The default client settings.
+const (
+	DefaultMaxRetries       = 3
+	DefaultRetryDelay       = 100 * time.Millisecond
+	DefaultBatchConcurrency = 8
+)
+
+// Error is returned when the permissions service responds with an error status.
+type Error struct {
+	StatusCode int
+	Reason     string
+}
+
+// Error returns the reason for the error along with the response status code.
+func (e *Error) Error() string {
+	return fmt.Sprintf("permissions service returned status %d: %s", e.StatusCode, e.Reason)
+}
+
+// IsNotFound determines whether or not an error was caused by a 404 response from the permissions service.
+func IsNotFound(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+// Client sends requests to the permissions service. A Client is safe for concurrent use.
+type Client struct {
+	baseURL          *url.URL
+	httpClient       *http.Client
+	maxRetries       int
+	retryDelay       time.Duration
+	readYourWrites   bool
+	batchConcurrency int
+}
+
+// Option customizes a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to send requests. http.DefaultClient is used by default.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries sets the number of times that a failed request is retried and the delay before the first retry. The
+// delay doubles after each retry. Retries can be disabled by setting the maximum number of retries to zero.
+func WithRetries(maxRetries int, delay time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryDelay = delay
+	}
+}
+
+// WithReadYourWrites causes permission lookups to use the primary database even if the service is configured to use
+// a read replica, so that lookups always reflect changes made immediately before them.
+func WithReadYourWrites() Option {
+	return func(c *Client) {
+		c.readYourWrites = true
+	}
+}
+
+// WithBatchConcurrency sets the maximum number of requests that the batch helpers send at the same time.
+func WithBatchConcurrency(n int) Option {
+	return func(c *Client) {
+		c.batchConcurrency = n
+	}
+}
+
+// New returns a client for the permissions service at the given base URL.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid permissions service URL: %s", baseURL)
+	}
+
+	c := &Client{
+		baseURL:          u,
+		httpClient:       http.DefaultClient,
+		maxRetries:       DefaultMaxRetries,
+		retryDelay:       DefaultRetryDelay,
+		batchConcurrency: DefaultBatchConcurrency,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.batchConcurrency < 1 {
+		c.batchConcurrency = 1
+	}
+
+	return c, nil
+}
+
+// request describes a request to the permissions service. Each path segment is escaped separately.
+type request struct {
+	method   string
+	path     []string
+	query    url.Values
+	header   http.Header
+	body     interface{}
+	response interface{}
+}
+
+// buildURL returns the URL for a request.
+func (c *Client) buildURL(r *request) string {
+	segments := make([]string, len(r.path))
+	for i, segment := range r.path {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	u := *c.baseURL
+	u.Path = c.baseURL.Path + "/" + strings.Join(r.path, "/")
+	u.RawPath = c.baseURL.EscapedPath() + "/" + strings.Join(segments, "/")
+	u.RawQuery = r.query.Encode()
+	return u.String()
+}
+
+// retryable determines whether or not a failed attempt should be retried. A status code of zero means that no response
+// was received.
+func retryable(status int, err error) bool {
+	if status == 0 {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return status >= 500 && status != http.StatusNotImplemented
+}
+
+// attempt sends a request once, returning the response status code along with any error.
+func (c *Client) attempt(ctx context.Context, r *request, body []byte) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, c.buildURL(r), reader)
+	if err != nil {
+		return 0, err
+	}
+	for name, values := range r.header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Extract the reason from error responses.
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		e := &Error{StatusCode: resp.StatusCode, Reason: http.StatusText(resp.StatusCode)}
+		var errorOut models.ErrorOut
+		if err := json.NewDecoder(resp.Body).Decode(&errorOut); err == nil && errorOut.Reason != nil {
+			e.Reason = *errorOut.Reason
+		}
+		return resp.StatusCode, e
+	}
+
+	// Decode the response body if the caller wants it.
+	if r.response != nil {
+		if err := json.NewDecoder(resp.Body).Decode(r.response); err != nil {
+			return resp.StatusCode, fmt.Errorf("unable to decode the response body: %w", err)
+		}
+	} else {
+		io.Copy(ioutil.Discard, resp.Body) // nolint:errcheck
+	}
+
+	return resp.StatusCode, nil
+}
+
+// do sends a request, retrying it if it fails with a 5xx status or a network error.
+func (c *Client) do(ctx context.Context, r *request) error {
+	var body []byte
+	if r.body != nil {
+		var err error
+		if body, err = json.Marshal(r.body); err != nil {
+			return err
+		}
+	}
+
+	delay := c.retryDelay
+	for retries := 0; ; retries++ {
+		status, err := c.attempt(ctx, r, body)
+		if err == nil || retries >= c.maxRetries || !retryable(status, err) {
+			return err
+		}
+
+		// Wait before trying again.
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
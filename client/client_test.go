@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client for a server that responds using the given handler function.
+func newTestClient(t *testing.T, f http.HandlerFunc, opts ...Option) *Client {
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	c, err := New(server.URL+"/", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestNew(t *testing.T) {
+	for _, baseURL := range []string{"", "permissions", "://"} {
+		if _, err := New(baseURL); err == nil {
+			t.Errorf("no error returned for %q", baseURL)
+		}
+	}
+}
+
+func TestRetries(t *testing.T) {
+	var attempts int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			http.Error(w, `{"reason": "try again"}`, http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"permissions": []}`)) // nolint:errcheck
+	}, WithRetries(2, time.Millisecond))
+
+	permissions, err := c.ListForResource(context.Background(), Resource{Type: "app", Name: "a1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(permissions) != 0 || attempts != 3 {
+		t.Errorf("unexpected result after %d attempts: %v", attempts, permissions)
+	}
+
+	// The request should fail once the retries are exhausted.
+	atomic.StoreInt32(&attempts, -10)
+	_, err = c.ListForResource(context.Background(), Resource{Type: "app", Name: "a1"})
+	if e, ok := err.(*Error); !ok || e.StatusCode != http.StatusServiceUnavailable || e.Reason != "try again" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestNoRetriesForClientErrors(t *testing.T) {
+	var attempts int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}, WithRetries(2, time.Millisecond))
+
+	_, err := c.Grant(context.Background(), User("u1"), Resource{Type: "app", Name: "a1"}, "read")
+	if e, ok := err.(*Error); !ok || e.Reason != "Bad Request" || attempts != 1 {
+		t.Errorf("unexpected error after %d attempts: %v", attempts, err)
+	}
+}
+
+func TestPathEscaping(t *testing.T) {
+	var path string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	// Revoking a permission that doesn't exist isn't an error.
+	if err := c.Revoke(context.Background(), Group("g/1"), Resource{Type: "app", Name: "a 1"}); err != nil {
+		t.Error(err)
+	}
+	if path != "/permissions/resources/app/a%201/subjects/group/g%2F1" {
+		t.Errorf("unexpected path: %s", path)
+	}
+}
@@ -0,0 +1,114 @@
+// Package fake provides an in-process permissions service for tests of code that uses the permissions client. The
+// service runs the real API handlers against an in-memory database, so it behaves the same way as the deployed
+// service without requiring PostgreSQL or Grouper.
+package fake
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/cyverse-de/permissions/client"
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/models"
+	"github.com/cyverse-de/permissions/restapi"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/db/memory"
+)
+
+// groups is a Grouper client that reports group memberships added by tests.
+type groups struct {
+	mutex       sync.Mutex
+	memberships map[string][]*grouper.GroupInfo
+}
+
+// GroupsForSubject returns the groups that a subject has been added to.
+func (g *groups) GroupsForSubject(_ context.Context, subjectID string) ([]*grouper.GroupInfo, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return append([]*grouper.GroupInfo(nil), g.memberships[subjectID]...), nil
+}
+
+// AddSourceIDToPermissions does nothing, leaving the subject source IDs unset.
+func (g *groups) AddSourceIDToPermissions(_ context.Context, _ []*models.Permission) error {
+	return nil
+}
+
+// AddSourceIDToPermission does nothing, leaving the subject source ID unset.
+func (g *groups) AddSourceIDToPermission(_ context.Context, _ *models.Permission) error {
+	return nil
+}
+
+// Close does nothing.
+func (g *groups) Close() error {
+	return nil
+}
+
+// Server is an in-process permissions service along with a client that sends requests to it.
+type Server struct {
+	Client *client.Client
+	Store  permsdb.Store
+
+	server *httptest.Server
+	groups *groups
+}
+
+// NewServer starts a permissions service containing the given resource types. The client options are applied to the
+// server's client; retries are disabled unless the options enable them. The server must be closed when it's no
+// longer needed.
+func NewServer(resourceTypes []string, opts ...client.Option) (*Server, error) {
+	store := memory.NewStore()
+	if err := addResourceTypes(store, resourceTypes); err != nil {
+		return nil, err
+	}
+
+	g := &groups{memberships: make(map[string][]*grouper.GroupInfo)}
+	handler, err := restapi.NewHandler(store, g)
+	if err != nil {
+		return nil, err
+	}
+	server := httptest.NewServer(handler)
+
+	opts = append([]client.Option{client.WithRetries(0, 0)}, opts...)
+	c, err := client.New(server.URL, opts...)
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+
+	return &Server{Client: c, Store: store, server: server, groups: g}, nil
+}
+
+// addResourceTypes adds resource types to a store.
+func addResourceTypes(store permsdb.Store, names []string) error {
+	tx, err := store.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		name := name
+		if _, err := tx.AddNewResourceType(&models.ResourceTypeIn{Name: &name}); err != nil {
+			tx.Rollback() // nolint:errcheck
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// URL returns the base URL of the service.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// AddGroupMember adds a subject to a group, so that permission lookups for the subject include permissions granted
+// to the group.
+func (s *Server) AddGroupMember(groupID, subjectID string) {
+	s.groups.mutex.Lock()
+	defer s.groups.mutex.Unlock()
+	s.groups.memberships[subjectID] = append(s.groups.memberships[subjectID], &grouper.GroupInfo{ID: groupID})
+}
+
+// Close shuts down the service.
+func (s *Server) Close() {
+	s.server.Close()
+}
@@ -0,0 +1,122 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cyverse-de/permissions/client"
+	"github.com/cyverse-de/permissions/models"
+)
+
+func newServer(t *testing.T) *Server {
+	s, err := NewServer([]string{"app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestGrantCheckRevoke(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+	app := client.Resource{Type: "app", Name: "a1"}
+
+	if _, err := s.Client.Grant(ctx, client.User("u1"), app, models.PermissionLevelWrite); err != nil {
+		t.Fatal(err)
+	}
+	for level, expected := range map[models.PermissionLevel]bool{"read": true, "write": true, "own": false} {
+		allowed, err := s.Client.Check(ctx, client.User("u1"), app, level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allowed != expected {
+			t.Errorf("unexpected result for %s: %t", level, allowed)
+		}
+	}
+
+	// Revoke the permission twice; the second revocation should have no effect.
+	for i := 0; i < 2; i++ {
+		if err := s.Client.Revoke(ctx, client.User("u1"), app); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if allowed, err := s.Client.Check(ctx, client.User("u1"), app, "read"); err != nil || allowed {
+		t.Errorf("unexpected result after revoking the permission: %t, %v", allowed, err)
+	}
+
+	// Unknown resource types should be reported.
+	_, err := s.Client.Grant(ctx, client.User("u1"), client.Resource{Type: "tool", Name: "t1"}, "read")
+	if e, ok := err.(*client.Error); !ok || e.StatusCode != 400 {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGroupLookups(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+	s.AddGroupMember("g1", "u1")
+
+	grants := []client.Grant{
+		{Subject: client.Group("g1"), Resource: client.Resource{Type: "app", Name: "a1"}, Level: "read"},
+		{Subject: client.User("u1"), Resource: client.Resource{Type: "app", Name: "a2"}, Level: "own"},
+		{Subject: client.User("u2"), Resource: client.Resource{Type: "app", Name: "a3"}, Level: "own"},
+	}
+	if err := s.Client.GrantAll(ctx, grants); err != nil {
+		t.Fatal(err)
+	}
+
+	// Lookups should include permissions granted to groups.
+	result, err := s.Client.CheckAll(ctx, client.User("u1"), "app", []string{"a1", "a2", "a3", "a4"}, "read")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string]bool{"a1": true, "a2": true, "a3": false, "a4": false} {
+		if result[name] != expected {
+			t.Errorf("unexpected result for %s: %t", name, result[name])
+		}
+	}
+
+	// Direct listings shouldn't.
+	permissions, err := s.Client.ListForSubject(ctx, client.User("u1"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(permissions) != 1 || *permissions[0].Resource.Name != "a2" {
+		t.Errorf("unexpected permissions: %v", permissions)
+	}
+
+	// Revoke everything.
+	revocations := make([]client.Revocation, len(grants))
+	for i, g := range grants {
+		revocations[i] = client.Revocation{Subject: g.Subject, Resource: g.Resource}
+	}
+	if err := s.Client.RevokeAll(ctx, revocations); err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range grants {
+		permissions, err := s.Client.ListForResource(ctx, g.Resource)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(permissions) != 0 {
+			t.Errorf("unexpected permissions for %s: %v", g.Resource.Name, permissions)
+		}
+	}
+}
+
+func TestBatchErrors(t *testing.T) {
+	s := newServer(t)
+	grants := []client.Grant{
+		{Subject: client.User("u1"), Resource: client.Resource{Type: "app", Name: "a1"}, Level: "read"},
+		{Subject: client.User("u1"), Resource: client.Resource{Type: "tool", Name: "t1"}, Level: "read"},
+	}
+	err := s.Client.GrantAll(context.Background(), grants)
+	batchErr, ok := err.(*client.BatchError)
+	if !ok || len(batchErr.Errors) != 1 || batchErr.Errors[1] == nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if allowed, err := s.Client.Check(context.Background(), client.User("u1"), grants[0].Resource, "read"); !allowed {
+		t.Errorf("the successful grant wasn't applied: %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// Subject identifies a user or group.
+type Subject struct {
+	Type models.SubjectType
+	ID   string
+}
+
+// User returns the subject for the user with the given ID.
+func User(id string) Subject {
+	return Subject{Type: models.SubjectTypeUser, ID: id}
+}
+
+// Group returns the subject for the group with the given ID.
+func Group(id string) Subject {
+	return Subject{Type: models.SubjectTypeGroup, ID: id}
+}
+
+// Resource identifies a resource by type and name.
+type Resource struct {
+	Type string
+	Name string
+}
+
+// ListOptions limits the permissions listed for a subject. The zero value lists every permission granted directly to
+// the subject.
+type ListOptions struct {
+	// ResourceType limits the results to resources of a single type.
+	ResourceType string
+
+	// MinLevel limits the results to permissions granted at this level or higher.
+	MinLevel models.PermissionLevel
+
+	// Lookup includes permissions granted to groups that a user belongs to, listing only the most permissive
+	// permission for each resource.
+	Lookup bool
+}
+
+// lookupHeader returns the headers to send with permission lookups.
+func (c *Client) lookupHeader() http.Header {
+	header := make(http.Header)
+	if c.readYourWrites {
+		header.Set("X-Read-Your-Writes", "true")
+	}
+	return header
+}
+
+// lookupQuery returns the query parameters for a permission lookup.
+func lookupQuery(lookup bool, minLevel models.PermissionLevel) url.Values {
+	query := url.Values{"lookup": []string{strconv.FormatBool(lookup)}}
+	if minLevel != "" {
+		query.Set("min_level", string(minLevel))
+	}
+	return query
+}
+
+// permissionPath returns the path used to get, grant or revoke a single permission.
+func permissionPath(subject Subject, resource Resource) []string {
+	return []string{
+		"permissions", "resources", resource.Type, resource.Name, "subjects", string(subject.Type), subject.ID,
+	}
+}
+
+// Grant grants a subject permission to access a resource at the given level, replacing any permission that has
+// already been granted directly to the subject. The subject and resource are registered if necessary.
+func (c *Client) Grant(
+	ctx context.Context, subject Subject, resource Resource, level models.PermissionLevel,
+) (*models.Permission, error) {
+	var permission models.Permission
+	err := c.do(ctx, &request{
+		method:   http.MethodPut,
+		path:     permissionPath(subject, resource),
+		body:     &models.PermissionPutRequest{PermissionLevel: &level},
+		response: &permission,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+// Revoke revokes the permission granted directly to a subject for a resource. It isn't an error if the subject
+// doesn't have permission to access the resource.
+func (c *Client) Revoke(ctx context.Context, subject Subject, resource Resource) error {
+	err := c.do(ctx, &request{method: http.MethodDelete, path: permissionPath(subject, resource)})
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Check determines whether or not a subject has at least the given level of permission to access a resource, either
+// directly or, for users, through a group that the user belongs to.
+func (c *Client) Check(
+	ctx context.Context, subject Subject, resource Resource, level models.PermissionLevel,
+) (bool, error) {
+	var result models.PermissionList
+	err := c.do(ctx, &request{
+		method:   http.MethodGet,
+		path:     []string{"permissions", "subjects", string(subject.Type), subject.ID, resource.Type, resource.Name},
+		query:    lookupQuery(true, level),
+		header:   c.lookupHeader(),
+		response: &result,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(result.Permissions) > 0, nil
+}
+
+// ListForSubject lists the permissions granted to a subject.
+func (c *Client) ListForSubject(ctx context.Context, subject Subject, opts *ListOptions) ([]*models.Permission, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+	path := []string{"permissions", "subjects", string(subject.Type), subject.ID}
+	if opts.ResourceType != "" {
+		path = append(path, opts.ResourceType)
+	}
+
+	var result models.PermissionList
+	err := c.do(ctx, &request{
+		method:   http.MethodGet,
+		path:     path,
+		query:    lookupQuery(opts.Lookup, opts.MinLevel),
+		header:   c.lookupHeader(),
+		response: &result,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Permissions, nil
+}
+
+// ListForResource lists the permissions granted directly to subjects for a resource.
+func (c *Client) ListForResource(ctx context.Context, resource Resource) ([]*models.Permission, error) {
+	var result models.PermissionList
+	err := c.do(ctx, &request{
+		method:   http.MethodGet,
+		path:     []string{"permissions", "resources", resource.Type, resource.Name},
+		response: &result,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Permissions, nil
+}
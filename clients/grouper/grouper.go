@@ -1,10 +1,15 @@
 package grouper
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/cyverse-de/dbutil"
 	"github.com/cyverse-de/permissions/models"
+	"github.com/cyverse-de/permissions/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/lib/pq"
 )
@@ -15,11 +20,13 @@ type GroupInfo struct {
 	Name string
 }
 
-// Grouper is the interface implemented by a Grouper client instance.
+// Grouper is the interface implemented by a Grouper client instance. Queries are canceled if the context is canceled
+// or its deadline passes before the query completes.
 type Grouper interface {
-	GroupsForSubject(string) ([]*GroupInfo, error)
-	AddSourceIDToPermissions([]*models.Permission) error
-	AddSourceIDToPermission(*models.Permission) error
+	GroupsForSubject(context.Context, string) ([]*GroupInfo, error)
+	AddSourceIDToPermissions(context.Context, []*models.Permission) error
+	AddSourceIDToPermission(context.Context, *models.Permission) error
+	Close() error
 }
 
 // Client represents a Grouper client instance.
@@ -43,22 +50,54 @@ func NewGrouperClient(dbURI, prefix string) (*Client, error) {
 		return nil, err
 	}
 
+	return NewGrouperClientFromDB(db, prefix), nil
+}
+
+// NewGrouperClientFromDB creates and returns a new Grouper client that uses an existing database connection pool.
+func NewGrouperClientFromDB(db *sql.DB, prefix string) *Client {
 	return &Client{
 		db:     db,
 		prefix: prefix,
-	}, nil
+	}
+}
+
+// Ping verifies that the Grouper database is reachable.
+func (gc *Client) Ping(ctx context.Context) error {
+	return gc.db.PingContext(ctx)
+}
+
+// Close closes the connection pool used by the client.
+func (gc *Client) Close() error {
+	return gc.db.Close()
+}
+
+// startSpan starts a span for a Grouper query. The caller must end the span.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.StartSpan(
+		ctx, "grouper."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
 }
 
 // GroupsForSubject returns the list of groups that the subject with the given ID belongs to.
-func (gc *Client) GroupsForSubject(subjectID string) ([]*GroupInfo, error) {
+func (gc *Client) GroupsForSubject(ctx context.Context, subjectID string) ([]*GroupInfo, error) {
+	ctx, span := startSpan(ctx, "GroupsForSubject")
+	groups, err := gc.groupsForSubject(ctx, subjectID)
+	span.SetAttributes(attribute.Int("grouper.group_count", len(groups)))
+	return groups, tracing.EndSpan(span, err)
+}
+
+func (gc *Client) groupsForSubject(ctx context.Context, subjectID string) ([]*GroupInfo, error) {
 
 	// Query the database.
 	query := `SELECT group_id, group_name FROM grouper_memberships_v
             WHERE subject_id = $1 AND group_name LIKE $2 AND list_name = 'members'`
-	rows, err := gc.db.Query(query, subjectID, gc.prefix+"%")
+	rows, err := gc.db.QueryContext(ctx, query, subjectID, gc.prefix+"%")
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	// Extract the groups from the database.
 	groups := make([]*GroupInfo, 0)
@@ -70,11 +109,17 @@ func (gc *Client) GroupsForSubject(subjectID string) ([]*GroupInfo, error) {
 		groups = append(groups, &group)
 	}
 
-	return groups, nil
+	return groups, rows.Err()
 }
 
 // AddSourceIDToPermissions adds the subject source IDs to a slice of Permission objects.
-func (gc *Client) AddSourceIDToPermissions(permissions []*models.Permission) error {
+func (gc *Client) AddSourceIDToPermissions(ctx context.Context, permissions []*models.Permission) error {
+	ctx, span := startSpan(ctx, "AddSourceIDToPermissions")
+	span.SetAttributes(attribute.Int("grouper.permission_count", len(permissions)))
+	return tracing.EndSpan(span, gc.addSourceIDToPermissions(ctx, permissions))
+}
+
+func (gc *Client) addSourceIDToPermissions(ctx context.Context, permissions []*models.Permission) error {
 
 	// Get a list of subject identifiers.
 	subjectIDs := make([]string, 0)
@@ -85,7 +130,7 @@ func (gc *Client) AddSourceIDToPermissions(permissions []*models.Permission) err
 	// Query the database.
 	query := `SELECT subject_id, subject_source FROM grouper_members
             WHERE subject_id = ANY($1)`
-	rows, err := gc.db.Query(query, pq.Array(subjectIDs))
+	rows, err := gc.db.QueryContext(ctx, query, pq.Array(subjectIDs))
 	if err != nil {
 		return err
 	}
@@ -111,6 +156,6 @@ func (gc *Client) AddSourceIDToPermissions(permissions []*models.Permission) err
 }
 
 // AddSourceIDToPermission adds the subject source ID to a permission object.
-func (gc *Client) AddSourceIDToPermission(permission *models.Permission) error {
-	return gc.AddSourceIDToPermissions([]*models.Permission{permission})
+func (gc *Client) AddSourceIDToPermission(ctx context.Context, permission *models.Permission) error {
+	return gc.AddSourceIDToPermissions(ctx, []*models.Permission{permission})
 }
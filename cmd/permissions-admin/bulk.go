@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/cyverse-de/permissions/restapi/impl/bulk"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// The source for imports when no file is specified. Tests replace this.
+var stdin io.Reader = os.Stdin
+
+type exportCommand struct {
+	Format string `long:"format" default:"jsonl" choice:"jsonl" choice:"csv" description:"The export file format"`
+	File   string `long:"file" short:"f" description:"The file to write to instead of standard output"`
+}
+
+// Execute writes every resource type, subject, resource and permission in the database to a file.
+func (c *exportCommand) Execute(args []string) error {
+	out := stdout
+	if c.File != "" {
+		f, err := os.Create(c.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	writer, err := bulk.NewWriter(out, c.Format)
+	if err != nil {
+		return err
+	}
+
+	return withTxOptions(bulk.ExportTxOptions(), func(tx permsdb.Tx) error {
+		_, err := bulk.Export(tx, writer)
+		return err
+	})
+}
+
+type importCommand struct {
+	Format string `long:"format" default:"jsonl" choice:"jsonl" choice:"csv" description:"The import file format"`
+	File   string `long:"file" short:"f" description:"The file to read from instead of standard input"`
+}
+
+// Execute imports records from a file in a single transaction and displays the number of records of each kind.
+func (c *importCommand) Execute(args []string) error {
+	in := stdin
+	if c.File != "" {
+		f, err := os.Open(c.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	reader, err := bulk.NewReader(in, c.Format)
+	if err != nil {
+		return err
+	}
+
+	return withTx(func(tx permsdb.Tx) error {
+		summary, err := bulk.Import(tx, reader)
+		if err != nil {
+			return err
+		}
+
+		header := []string{"RESOURCE TYPES", "SUBJECTS", "RESOURCES", "PERMISSIONS"}
+		rows := [][]string{{
+			strconv.FormatInt(summary.ResourceTypes, 10),
+			strconv.FormatInt(summary.Subjects, 10),
+			strconv.FormatInt(summary.Resources, 10),
+			strconv.FormatInt(summary.Permissions, 10),
+		}}
+		return writeOutput(summary, header, rows)
+	})
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// Options used to identify a subject.
+type subjectOptions struct {
+	SubjectType string `long:"subject-type" required:"true" choice:"user" choice:"group" description:"The subject type"`
+	SubjectID   string `long:"subject-id" required:"true" description:"The external subject identifier"`
+}
+
+// Options used to identify a resource.
+type resourceOptions struct {
+	ResourceType string `long:"resource-type" required:"true" description:"The resource type name"`
+	ResourceName string `long:"resource-name" required:"true" description:"The resource name"`
+}
+
+// lookUpSubject looks up a subject, optionally adding it if it doesn't exist yet.
+func lookUpSubject(tx permsdb.Tx, subjectType, subjectID string, add bool) (*models.SubjectOut, error) {
+	subject, err := tx.GetSubject(models.ExternalSubjectID(subjectID), models.SubjectType(subjectType))
+	if err != nil {
+		return nil, err
+	}
+	if subject != nil {
+		return subject, nil
+	}
+	if !add {
+		return nil, fmt.Errorf("subject not found: %s/%s", subjectType, subjectID)
+	}
+
+	// Make sure that another subject with the same ID doesn't exist already.
+	exists, err := tx.SubjectIDExists(models.ExternalSubjectID(subjectID))
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("another subject with ID, %s, already exists", subjectID)
+	}
+
+	return tx.AddSubject(models.ExternalSubjectID(subjectID), models.SubjectType(subjectType))
+}
+
+// lookUpResourceType looks up a resource type by name.
+func lookUpResourceType(tx permsdb.Tx, name string) (*models.ResourceTypeOut, error) {
+	resourceType, err := tx.GetResourceTypeByName(&name)
+	if err != nil {
+		return nil, err
+	}
+	if resourceType == nil {
+		return nil, fmt.Errorf("resource type not found: %s", name)
+	}
+	return resourceType, nil
+}
+
+// lookUpResource looks up a resource, optionally adding it if it doesn't exist yet. The resource type must exist.
+func lookUpResource(tx permsdb.Tx, resourceTypeName, name string, add bool) (*models.ResourceOut, error) {
+	resourceType, err := lookUpResourceType(tx, resourceTypeName)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := tx.GetResourceByName(&name, resourceType.ID)
+	if err != nil {
+		return nil, err
+	}
+	if resource != nil {
+		return resource, nil
+	}
+	if !add {
+		return nil, fmt.Errorf("resource not found: %s/%s", resourceTypeName, name)
+	}
+
+	return tx.AddResource(&name, resourceType.ID)
+}
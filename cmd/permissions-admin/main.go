@@ -0,0 +1,132 @@
+// Command permissions-admin performs administrative tasks on the permissions database directly, without going
+// through the permissions service. It reads the same configuration file as the permissions service.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cyverse-de/configurate"
+	flags "github.com/jessevdk/go-flags"
+	"github.com/spf13/viper"
+
+	"github.com/cyverse-de/permissions/restapi"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// The supported output formats.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// Options that apply to every subcommand.
+var options struct {
+	CfgPath string `long:"config" default:"/etc/iplant/de/permissions.yaml" description:"The path to the config file"`
+	Output  string `long:"output" short:"o" default:"table" choice:"table" choice:"json" description:"The output format"`
+	DryRun  bool   `long:"dry-run" description:"Roll back any changes instead of committing them"`
+}
+
+// The subcommands.
+type adminCommand struct {
+	Grant        grantCommand        `command:"grant" description:"Grant a permission to a subject"`
+	Revoke       revokeCommand       `command:"revoke" description:"Revoke a permission from a subject"`
+	List         listCommand         `command:"list" description:"List permissions granted directly to subjects"`
+	Check        checkCommand        `command:"check" description:"Check the effective permission of a subject"`
+	Copy         copyCommand         `command:"copy" description:"Copy permissions from one subject to another"`
+	ResourceType resourceTypeCommand `command:"resource-type" description:"Manage resource types"`
+	Subject      subjectCommand      `command:"subject" description:"Manage subjects"`
+	Export       exportCommand       `command:"export" description:"Export the contents of the database"`
+	Import       importCommand       `command:"import" description:"Import the contents of an export file"`
+	Sync         syncCommand         `command:"sync" description:"Synchronize permissions with other databases"`
+	Reconcile    reconcileCommand    `command:"reconcile" description:"Find resources and groups that no longer exist"`
+}
+
+// The destination for command output. Tests replace this.
+var stdout io.Writer = os.Stdout
+
+// loadConfig loads the permissions service configuration.
+func loadConfig() (*viper.Viper, error) {
+	return configurate.InitDefaults(options.CfgPath, restapi.DefaultConfig)
+}
+
+// openStore opens the permissions database. Tests replace this.
+var openStore = func() (permsdb.Store, func(), error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	store, db, err := restapi.OpenStore(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return store, func() { db.Close() }, nil
+}
+
+// errNotPermitted is returned by commands that check permissions when the check fails.
+var errNotPermitted = errors.New("permission check failed")
+
+// withTx calls a function within a transaction. The transaction is committed if the function succeeds unless this is
+// a dry run, and rolled back otherwise.
+func withTx(f func(permsdb.Tx) error) error {
+	return withTxOptions(nil, f)
+}
+
+// withTxOptions is like withTx, but starts the transaction using the given options.
+func withTxOptions(opts *sql.TxOptions, f func(permsdb.Tx) error) error {
+	store, closeStore, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	tx, err := store.BeginTx(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+
+	if err := f(tx); err != nil {
+		tx.Rollback() // nolint:errcheck
+		return err
+	}
+
+	if options.DryRun {
+		return tx.Rollback()
+	}
+	return tx.Commit()
+}
+
+// run parses the command line and runs the selected subcommand, returning the process exit code.
+func run(args []string) int {
+	parser := flags.NewParser(&adminCommand{}, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Name = "permissions-admin"
+	parser.ShortDescription = "Administers the permissions database"
+	if _, err := parser.AddGroup("Global Options", "", &options); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if _, err := parser.ParseArgs(args); err != nil {
+		if fe, ok := err.(*flags.Error); ok && fe.Type == flags.ErrHelp {
+			fmt.Fprintln(stdout, err)
+			return 0
+		}
+		if err == errNotPermitted {
+			return 1
+		}
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/db/memory"
+	"github.com/cyverse-de/permissions/restapi/impl/db/sqlite"
+)
+
+// setUp replaces the store and output destination used by the commands. The returned buffer receives the output.
+func setUp(t *testing.T) *bytes.Buffer {
+	store := memory.NewStore()
+	origOpenStore, origStdout := openStore, stdout
+	openStore = func() (permsdb.Store, func(), error) {
+		return store, func() {}, nil
+	}
+	buf := &bytes.Buffer{}
+	stdout = buf
+	origStdin := stdin
+	t.Cleanup(func() {
+		openStore, stdout, stdin = origOpenStore, origStdout, origStdin
+		options.DryRun = false
+	})
+
+	return buf
+}
+
+// runCommand runs a command and fails the test if the exit code isn't the expected one.
+func runCommand(t *testing.T, buf *bytes.Buffer, expected int, args ...string) string {
+	buf.Reset()
+	if code := run(args); code != expected {
+		t.Fatalf("%s: unexpected exit code: %d", strings.Join(args, " "), code)
+	}
+	return buf.String()
+}
+
+// listPermissions lists all permissions in JSON format.
+func listPermissions(t *testing.T, buf *bytes.Buffer) []*models.Permission {
+	var list models.PermissionList
+	if err := json.Unmarshal([]byte(runCommand(t, buf, 0, "-o", "json", "list")), &list); err != nil {
+		t.Fatalf("unable to parse the permission list: %s", err)
+	}
+	return list.Permissions
+}
+
+func TestResourceTypes(t *testing.T) {
+	buf := setUp(t)
+
+	out := runCommand(t, buf, 0, "resource-type", "add", "--name", "app", "--description", "An app.")
+	if !strings.Contains(out, "An app.") {
+		t.Errorf("unexpected output: %s", out)
+	}
+	runCommand(t, buf, 1, "resource-type", "add", "--name", "app")
+
+	runCommand(t, buf, 0, "grant", "--subject-type", "user", "--subject-id", "u1",
+		"--resource-type", "app", "--resource-name", "a1", "--level", "read")
+	runCommand(t, buf, 1, "resource-type", "remove", "--name", "app")
+	runCommand(t, buf, 1, "resource-type", "remove", "--name", "analysis")
+}
+
+func TestGrantListRevoke(t *testing.T) {
+	buf := setUp(t)
+	runCommand(t, buf, 0, "resource-type", "add", "--name", "app")
+
+	grant := []string{"grant", "--subject-type", "user", "--subject-id", "u1",
+		"--resource-type", "app", "--resource-name", "a1", "--level", "read"}
+	runCommand(t, buf, 0, grant...)
+	grant[len(grant)-1] = "own"
+	runCommand(t, buf, 0, grant...)
+
+	permissions := listPermissions(t, buf)
+	if len(permissions) != 1 {
+		t.Fatalf("unexpected number of permissions: %d", len(permissions))
+	}
+	if *permissions[0].PermissionLevel != models.PermissionLevelOwn {
+		t.Errorf("unexpected permission level: %s", *permissions[0].PermissionLevel)
+	}
+
+	out := runCommand(t, buf, 0, "list", "--subject-type", "user", "--subject-id", "u1")
+	if !strings.Contains(out, "a1") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	revoke := []string{"revoke", "--subject-type", "user", "--subject-id", "u1",
+		"--resource-type", "app", "--resource-name", "a1"}
+	runCommand(t, buf, 0, revoke...)
+	if permissions := listPermissions(t, buf); len(permissions) != 0 {
+		t.Errorf("unexpected number of permissions: %d", len(permissions))
+	}
+	runCommand(t, buf, 1, revoke...)
+}
+
+func TestDryRun(t *testing.T) {
+	buf := setUp(t)
+	runCommand(t, buf, 0, "resource-type", "add", "--name", "app")
+
+	runCommand(t, buf, 0, "--dry-run", "grant", "--subject-type", "user", "--subject-id", "u1",
+		"--resource-type", "app", "--resource-name", "a1", "--level", "read")
+	options.DryRun = false
+	if permissions := listPermissions(t, buf); len(permissions) != 0 {
+		t.Errorf("unexpected number of permissions: %d", len(permissions))
+	}
+}
+
+func TestCheckAndCopy(t *testing.T) {
+	buf := setUp(t)
+	runCommand(t, buf, 0, "resource-type", "add", "--name", "app")
+	runCommand(t, buf, 0, "grant", "--subject-type", "user", "--subject-id", "u1",
+		"--resource-type", "app", "--resource-name", "a1", "--level", "write")
+
+	check := []string{"check", "--subject-type", "user", "--subject-id", "u2",
+		"--resource-type", "app", "--resource-name", "a1"}
+	runCommand(t, buf, 1, check...)
+
+	runCommand(t, buf, 0, "copy", "--source-type", "user", "--source-id", "u1",
+		"--dest-type", "user", "--dest-id", "u2")
+	runCommand(t, buf, 0, check...)
+	runCommand(t, buf, 0, append(check, "--level", "write")...)
+	runCommand(t, buf, 1, append(check, "--level", "own")...)
+}
+
+func TestSubjectRename(t *testing.T) {
+	buf := setUp(t)
+	runCommand(t, buf, 0, "resource-type", "add", "--name", "app")
+	runCommand(t, buf, 0, "grant", "--subject-type", "user", "--subject-id", "u1",
+		"--resource-type", "app", "--resource-name", "a1", "--level", "read")
+	runCommand(t, buf, 0, "grant", "--subject-type", "user", "--subject-id", "u2",
+		"--resource-type", "app", "--resource-name", "a1", "--level", "read")
+
+	runCommand(t, buf, 1, "subject", "rename", "--subject-type", "user", "--subject-id", "u1", "--new-subject-id", "u2")
+	runCommand(t, buf, 0, "subject", "rename", "--subject-type", "user", "--subject-id", "u1", "--new-subject-id", "u3")
+
+	out := runCommand(t, buf, 0, "list", "--subject-type", "user", "--subject-id", "u3")
+	if !strings.Contains(out, "a1") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	buf := setUp(t)
+	runCommand(t, buf, 0, "resource-type", "add", "--name", "app")
+	runCommand(t, buf, 0, "grant", "--subject-type", "user", "--subject-id", "u1",
+		"--resource-type", "app", "--resource-name", "a1", "--level", "read")
+	export := runCommand(t, buf, 0, "export", "--format", "csv")
+
+	// Import the export into an empty store.
+	buf = setUp(t)
+	stdin = strings.NewReader(export)
+	out := runCommand(t, buf, 0, "-o", "json", "import", "--format", "csv")
+	if !strings.Contains(out, `"permissions": 1`) {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	permissions := listPermissions(t, buf)
+	if len(permissions) != 1 {
+		t.Fatalf("unexpected number of permissions: %d", len(permissions))
+	}
+	if *permissions[0].PermissionLevel != models.PermissionLevelRead {
+		t.Errorf("unexpected permission level: %s", *permissions[0].PermissionLevel)
+	}
+}
+
+func TestSync(t *testing.T) {
+	buf := setUp(t)
+	dir := t.TempDir()
+	runCommand(t, buf, 0, "resource-type", "add", "--name", "tool")
+
+	// Create the source database.
+	sourcePath := filepath.Join(dir, "de.db")
+	source, err := sql.Open(sqlite.DriverName, sourcePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer source.Close()
+	if _, err := source.Exec("CREATE TABLE tools (id TEXT); INSERT INTO tools VALUES ('t1'), ('t2')"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write the configuration and mapping files.
+	cfgPath := filepath.Join(dir, "permissions.yaml")
+	if err := ioutil.WriteFile(cfgPath, []byte("grouperdb:\n  folder_name_prefix: de\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mappingPath := filepath.Join(dir, "sync.yaml")
+	mapping := `
+sources:
+  de:
+    uri: "sqlite://` + sourcePath + `"
+mappings:
+  - name: tools
+    source: de
+    query: "SELECT id AS resource_name FROM tools"
+    resource_type: tool
+    subject_type: group
+    subject_id: "${folder_name_prefix}:users:de-users"
+    permission_level: read
+variables:
+  - name: folder_name_prefix
+    config_key: grouperdb.folder_name_prefix
+`
+	if err := ioutil.WriteFile(mappingPath, []byte(mapping), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A dry run should report the changes without making them.
+	out := runCommand(t, buf, 0, "--config", cfgPath, "--dry-run", "sync", "--mapping", mappingPath)
+	if !strings.Contains(out, "de:users:de-users") || !strings.Contains(out, "2 added, 0 updated, 0 removed") {
+		t.Errorf("unexpected output: %s", out)
+	}
+	options.DryRun = false
+	if permissions := listPermissions(t, buf); len(permissions) != 0 {
+		t.Errorf("unexpected number of permissions: %d", len(permissions))
+	}
+
+	// Synchronize the permissions.
+	runCommand(t, buf, 0, "--config", cfgPath, "sync", "--mapping", mappingPath)
+	if permissions := listPermissions(t, buf); len(permissions) != 2 {
+		t.Errorf("unexpected number of permissions: %d", len(permissions))
+	}
+	out = runCommand(t, buf, 0, "--config", cfgPath, "sync", "--mapping", mappingPath)
+	if !strings.Contains(out, "0 added, 0 updated, 0 removed, 2 unchanged") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	buf := setUp(t)
+	dir := t.TempDir()
+	runCommand(t, buf, 0, "resource-type", "add", "--name", "tool")
+	for _, tool := range []string{"t1", "t3"} {
+		runCommand(t, buf, 0, "grant", "--subject-type", "user", "--subject-id", "u1",
+			"--resource-type", "tool", "--resource-name", tool, "--level", "read")
+	}
+
+	// Create the source database.
+	sourcePath := filepath.Join(dir, "de.db")
+	source, err := sql.Open(sqlite.DriverName, sourcePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer source.Close()
+	if _, err := source.Exec("CREATE TABLE tools (id TEXT); INSERT INTO tools VALUES ('t1'), ('t2')"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write the configuration and mapping files.
+	cfgPath := filepath.Join(dir, "permissions.yaml")
+	if err := ioutil.WriteFile(cfgPath, []byte("grouperdb:\n  folder_name_prefix: de\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mappingPath := filepath.Join(dir, "reconcile.yaml")
+	mapping := `
+sources:
+  de:
+    uri: "sqlite://` + sourcePath + `"
+reconcile:
+  resources:
+    - resource_type: tool
+      source: de
+      query: "SELECT id AS resource_name FROM tools"
+`
+	if err := ioutil.WriteFile(mappingPath, []byte(mapping), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reporting orphans shouldn't delete anything.
+	out := runCommand(t, buf, 0, "--config", cfgPath, "reconcile", "--mapping", mappingPath)
+	if !strings.Contains(out, "t3") || !strings.Contains(out, "1 orphaned resources and 0 orphaned subjects found") {
+		t.Errorf("unexpected output: %s", out)
+	}
+	if permissions := listPermissions(t, buf); len(permissions) != 2 {
+		t.Errorf("unexpected number of permissions: %d", len(permissions))
+	}
+
+	// Delete the orphans.
+	out = runCommand(t, buf, 0, "--config", cfgPath, "reconcile", "--mapping", mappingPath, "--delete")
+	if !strings.Contains(out, "1 orphaned resources and 0 orphaned subjects deleted") {
+		t.Errorf("unexpected output: %s", out)
+	}
+	permissions := listPermissions(t, buf)
+	if len(permissions) != 1 || *permissions[0].Resource.Name != "t1" {
+		t.Errorf("unexpected permissions: %v", permissions)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// writeOutput writes a value in the selected output format. The table rows are only used for table output.
+func writeOutput(value interface{}, header []string, rows [][]string) error {
+	if options.Output == outputJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// writePermissions writes a list of permissions.
+func writePermissions(permissions []*models.Permission) error {
+	header := []string{"ID", "SUBJECT TYPE", "SUBJECT ID", "RESOURCE TYPE", "RESOURCE NAME", "LEVEL"}
+	rows := make([][]string, len(permissions))
+	for i, p := range permissions {
+		rows[i] = []string{
+			string(*p.ID),
+			string(*p.Subject.SubjectType),
+			string(*p.Subject.SubjectID),
+			*p.Resource.ResourceType,
+			*p.Resource.Name,
+			string(*p.PermissionLevel),
+		}
+	}
+	return writeOutput(&models.PermissionList{Permissions: permissions}, header, rows)
+}
+
+// writePermission writes a single permission.
+func writePermission(permission *models.Permission) error {
+	if options.Output == outputJSON {
+		return writeOutput(permission, nil, nil)
+	}
+	return writePermissions([]*models.Permission{permission})
+}
+
+// writeResourceType writes a single resource type.
+func writeResourceType(resourceType *models.ResourceTypeOut) error {
+	header := []string{"ID", "NAME", "DESCRIPTION"}
+	rows := [][]string{{*resourceType.ID, *resourceType.Name, resourceType.Description}}
+	return writeOutput(resourceType, header, rows)
+}
+
+// writeSubject writes a single subject.
+func writeSubject(subject *models.SubjectOut) error {
+	header := []string{"ID", "SUBJECT TYPE", "SUBJECT ID"}
+	rows := [][]string{{string(*subject.ID), string(*subject.SubjectType), string(*subject.SubjectID)}}
+	return writeOutput(subject, header, rows)
+}
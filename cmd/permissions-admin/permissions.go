@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+type grantCommand struct {
+	subjectOptions
+	resourceOptions
+	Level string `long:"level" required:"true" choice:"read" choice:"write" choice:"admin" choice:"own" description:"The permission level"`
+}
+
+// Execute grants a permission to a subject, adding the subject and resource if necessary. Existing permissions for
+// the same subject and resource are replaced.
+func (c *grantCommand) Execute(args []string) error {
+	return withTx(func(tx permsdb.Tx) error {
+		subject, err := lookUpSubject(tx, c.SubjectType, c.SubjectID, true)
+		if err != nil {
+			return err
+		}
+
+		resource, err := lookUpResource(tx, c.ResourceType, c.ResourceName, true)
+		if err != nil {
+			return err
+		}
+
+		levelID, err := tx.GetPermissionLevelIDByName(models.PermissionLevel(c.Level))
+		if err != nil {
+			return err
+		}
+		if levelID == nil {
+			return fmt.Errorf("no permission level named, %s, found", c.Level)
+		}
+
+		permission, err := tx.UpsertPermission(*subject.ID, *resource.ID, *levelID)
+		if err != nil {
+			return err
+		}
+		return writePermission(permission)
+	})
+}
+
+type revokeCommand struct {
+	subjectOptions
+	resourceOptions
+}
+
+// Execute revokes a permission from a subject.
+func (c *revokeCommand) Execute(args []string) error {
+	return withTx(func(tx permsdb.Tx) error {
+		subject, err := lookUpSubject(tx, c.SubjectType, c.SubjectID, false)
+		if err != nil {
+			return err
+		}
+
+		resource, err := lookUpResource(tx, c.ResourceType, c.ResourceName, false)
+		if err != nil {
+			return err
+		}
+
+		permission, err := tx.GetPermission(*subject.ID, *resource.ID)
+		if err != nil {
+			return err
+		}
+		if permission == nil {
+			return fmt.Errorf(
+				"permission not found: %s/%s:%s/%s", c.SubjectType, c.SubjectID, c.ResourceType, c.ResourceName,
+			)
+		}
+
+		if err := tx.DeletePermission(*permission.ID); err != nil {
+			return err
+		}
+		return writePermission(permission)
+	})
+}
+
+type listCommand struct {
+	SubjectType  string `long:"subject-type" choice:"user" choice:"group" description:"Only list permissions granted to subjects of this type"`
+	SubjectID    string `long:"subject-id" description:"Only list permissions granted to the subject with this identifier"`
+	ResourceType string `long:"resource-type" description:"Only list permissions for resources of this type"`
+	ResourceName string `long:"resource-name" description:"Only list permissions for the resource with this name"`
+}
+
+// Execute lists permissions that have been granted directly to subjects.
+func (c *listCommand) Execute(args []string) error {
+	if (c.SubjectType == "") != (c.SubjectID == "") {
+		return fmt.Errorf("--subject-type and --subject-id must be used together")
+	}
+	if c.ResourceName != "" && c.ResourceType == "" {
+		return fmt.Errorf("--resource-name requires --resource-type")
+	}
+
+	return withTx(func(tx permsdb.Tx) error {
+		var permissions []*models.Permission
+		var err error
+		switch {
+		case c.SubjectID != "":
+			var subject *models.SubjectOut
+			if subject, err = lookUpSubject(tx, c.SubjectType, c.SubjectID, false); err != nil {
+				return err
+			}
+			var resourceType *string
+			if c.ResourceType != "" {
+				resourceType = &c.ResourceType
+			}
+			permissions, err = tx.FindSubjectPermissions(*subject.ID, resourceType, nil)
+		case c.ResourceName != "":
+			permissions, err = tx.ListResourcePermissions(c.ResourceType, c.ResourceName)
+		default:
+			permissions, err = tx.ListPermissions(nil)
+		}
+		if err != nil {
+			return err
+		}
+
+		return writePermissions(filterPermissions(permissions, c.ResourceType, c.ResourceName))
+	})
+}
+
+// filterPermissions removes permissions for resources other than the ones with the given resource type and name. An
+// empty resource type or name matches every resource.
+func filterPermissions(permissions []*models.Permission, resourceType, resourceName string) []*models.Permission {
+	filtered := make([]*models.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		if resourceType != "" && *p.Resource.ResourceType != resourceType {
+			continue
+		}
+		if resourceName != "" && *p.Resource.Name != resourceName {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	return filtered
+}
+
+type checkCommand struct {
+	subjectOptions
+	resourceOptions
+	Level  string `long:"level" choice:"read" choice:"write" choice:"admin" choice:"own" description:"The minimum permission level"`
+	Lookup bool   `long:"lookup" description:"Include permissions granted to groups that the subject belongs to"`
+}
+
+// Execute displays the effective permission of a subject for a resource. The command fails if the subject has no
+// permission for the resource, or if the permission is less than the minimum level.
+func (c *checkCommand) Execute(args []string) error {
+	subjectIDs := []string{c.SubjectID}
+	if c.Lookup && c.SubjectType == string(models.SubjectTypeUser) {
+		groupIDs, err := lookUpGroupIDs(c.SubjectID)
+		if err != nil {
+			return err
+		}
+		subjectIDs = append(groupIDs, c.SubjectID)
+	}
+
+	return withTx(func(tx permsdb.Tx) error {
+		var permissions []*models.Permission
+		var err error
+		if c.Level != "" {
+			permissions, err = tx.PermissionsForSubjectsAndResourceMinLevel(
+				subjectIDs, c.ResourceType, c.ResourceName, c.Level,
+			)
+		} else {
+			permissions, err = tx.PermissionsForSubjectsAndResource(subjectIDs, c.ResourceType, c.ResourceName)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := writePermissions(permissions); err != nil {
+			return err
+		}
+		if len(permissions) == 0 {
+			return errNotPermitted
+		}
+		return nil
+	})
+}
+
+// lookUpGroupIDs returns the identifiers of the groups that a user belongs to.
+func lookUpGroupIDs(subjectID string) ([]string, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := grouper.NewGrouperClient(
+		cfg.GetString("grouperdb.uri"), cfg.GetString("grouperdb.folder_name_prefix"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	groups, err := client.GroupsForSubject(context.Background(), subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := make([]string, len(groups))
+	for i, group := range groups {
+		groupIDs[i] = group.ID
+	}
+	return groupIDs, nil
+}
+
+type copyCommand struct {
+	SourceType string `long:"source-type" required:"true" choice:"user" choice:"group" description:"The source subject type"`
+	SourceID   string `long:"source-id" required:"true" description:"The source subject identifier"`
+	DestType   string `long:"dest-type" required:"true" choice:"user" choice:"group" description:"The destination subject type"`
+	DestID     string `long:"dest-id" required:"true" description:"The destination subject identifier"`
+}
+
+// Execute copies the permissions granted to one subject to another subject, adding the destination subject if
+// necessary. Existing permissions of the destination subject are only replaced by higher permission levels.
+func (c *copyCommand) Execute(args []string) error {
+	return withTx(func(tx permsdb.Tx) error {
+		source, err := lookUpSubject(tx, c.SourceType, c.SourceID, false)
+		if err != nil {
+			return err
+		}
+
+		dest, err := lookUpSubject(tx, c.DestType, c.DestID, true)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.CopyPermissions(source, dest); err != nil {
+			return err
+		}
+
+		permissions, err := tx.ListSubjectPermissions(*dest.ID)
+		if err != nil {
+			return err
+		}
+		return writePermissions(permissions)
+	})
+}
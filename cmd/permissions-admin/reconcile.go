@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cyverse-de/permissions/permsync"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+type reconcileCommand struct {
+	Mapping string `long:"mapping" short:"m" required:"true" description:"The path to the YAML mapping file"`
+	Delete  bool   `long:"delete" description:"Delete orphaned resources and subjects along with their permissions"`
+}
+
+// Execute reports the resources and group subjects that no longer exist in the databases described in a mapping
+// file, deleting them if requested. The sources of truth are queried again before anything is deleted, so that
+// resources and groups created while the command was running aren't deleted. Deletions are rolled back if this is a dry
+// run.
+func (c *reconcileCommand) Execute(args []string) error {
+	syncCfg, err := permsync.LoadConfig(c.Mapping)
+	if err != nil {
+		return err
+	}
+
+	// Connect to the databases that the reconcile checks query.
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	env, err := permsync.Open(syncCfg, cfg.GetString)
+	if err != nil {
+		return err
+	}
+	defer env.Close()
+
+	// Query the sources of truth before starting the transaction so that it isn't held open while the queries run.
+	sources, err := permsync.QuerySources(context.Background(), syncCfg, env)
+	if err != nil {
+		return err
+	}
+
+	return withTx(func(tx permsdb.Tx) error {
+		report, err := sources.Reconcile(tx)
+		if err != nil {
+			return err
+		}
+		if c.Delete {
+			if err := report.Recheck(context.Background(), syncCfg, env, tx); err != nil {
+				return err
+			}
+			if err := report.Delete(tx); err != nil {
+				return err
+			}
+		}
+		return writeReport(report, c.Delete)
+	})
+}
+
+// writeReport writes the orphans found by a reconcile, followed by a summary in table format.
+func writeReport(report *permsync.Report, deleted bool) error {
+	header := []string{"KIND", "TYPE", "NAME", "PERMISSIONS"}
+	rows := make([][]string, len(report.Orphans))
+	for i, o := range report.Orphans {
+		rows[i] = []string{o.Kind, o.Type, o.Name, strconv.FormatInt(o.Permissions, 10)}
+	}
+	if err := writeOutput(report, header, rows); err != nil {
+		return err
+	}
+
+	if options.Output == outputTable {
+		action := "found"
+		if deleted {
+			action = "deleted"
+		}
+		_, err := fmt.Fprintf(stdout, "\n%d orphaned resources and %d orphaned subjects %s\n",
+			report.Resources, report.Subjects, action)
+		return err
+	}
+	return nil
+}
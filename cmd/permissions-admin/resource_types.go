@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+type resourceTypeCommand struct {
+	Add    resourceTypeAddCommand    `command:"add" description:"Add a resource type"`
+	Remove resourceTypeRemoveCommand `command:"remove" description:"Remove a resource type that has no resources"`
+}
+
+type resourceTypeAddCommand struct {
+	Name        string `long:"name" required:"true" description:"The resource type name"`
+	Description string `long:"description" description:"A brief description of the resource type"`
+}
+
+// Execute adds a new resource type.
+func (c *resourceTypeAddCommand) Execute(args []string) error {
+	return withTx(func(tx permsdb.Tx) error {
+
+		// Verify that a resource type with the same name doesn't exist already.
+		existing, err := tx.GetResourceTypeByName(&c.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("a resource type named %s already exists", c.Name)
+		}
+
+		resourceType, err := tx.AddNewResourceType(&models.ResourceTypeIn{Name: &c.Name, Description: c.Description})
+		if err != nil {
+			return err
+		}
+		return writeResourceType(resourceType)
+	})
+}
+
+type resourceTypeRemoveCommand struct {
+	Name string `long:"name" required:"true" description:"The resource type name"`
+}
+
+// Execute removes a resource type. Resource types that have resources associated with them can't be removed.
+func (c *resourceTypeRemoveCommand) Execute(args []string) error {
+	return withTx(func(tx permsdb.Tx) error {
+		resourceType, err := lookUpResourceType(tx, c.Name)
+		if err != nil {
+			return err
+		}
+
+		// Verify that the resource type has no resources associated with it.
+		numResources, err := tx.CountResourcesOfType(resourceType.ID)
+		if err != nil {
+			return err
+		}
+		if numResources != 0 {
+			return fmt.Errorf("resource type has resources associated with it: %s", c.Name)
+		}
+
+		if err := tx.DeleteResourceType(resourceType.ID); err != nil {
+			return err
+		}
+		return writeResourceType(resourceType)
+	})
+}
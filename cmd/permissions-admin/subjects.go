@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+type subjectCommand struct {
+	Rename subjectRenameCommand `command:"rename" description:"Change the external identifier of a subject"`
+}
+
+type subjectRenameCommand struct {
+	subjectOptions
+	NewSubjectID string `long:"new-subject-id" required:"true" description:"The new external subject identifier"`
+}
+
+// Execute changes the external identifier of a subject. Permissions granted to the subject are retained.
+func (c *subjectRenameCommand) Execute(args []string) error {
+	return withTx(func(tx permsdb.Tx) error {
+		subject, err := lookUpSubject(tx, c.SubjectType, c.SubjectID, false)
+		if err != nil {
+			return err
+		}
+
+		// Make sure that another subject with the new ID doesn't exist already.
+		newSubjectID := models.ExternalSubjectID(c.NewSubjectID)
+		duplicate, err := tx.DuplicateSubjectExists(*subject.ID, newSubjectID)
+		if err != nil {
+			return err
+		}
+		if duplicate {
+			return fmt.Errorf("another subject with ID, %s, already exists", c.NewSubjectID)
+		}
+
+		updated, err := tx.UpdateSubject(*subject.ID, newSubjectID, *subject.SubjectType)
+		if err != nil {
+			return err
+		}
+		return writeSubject(updated)
+	})
+}
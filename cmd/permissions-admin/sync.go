@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cyverse-de/permissions/permsync"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+type syncCommand struct {
+	Mapping string `long:"mapping" short:"m" required:"true" description:"The path to the YAML mapping file"`
+}
+
+// Execute synchronizes permissions with the databases described in a mapping file and displays the changes. The
+// changes are rolled back if this is a dry run.
+func (c *syncCommand) Execute(args []string) error {
+	syncCfg, err := permsync.LoadConfig(c.Mapping)
+	if err != nil {
+		return err
+	}
+
+	// Connect to the databases that the mappings query.
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	env, err := permsync.Open(syncCfg, cfg.GetString)
+	if err != nil {
+		return err
+	}
+	defer env.Close()
+
+	return withTx(func(tx permsdb.Tx) error {
+		plan, err := permsync.NewPlan(context.Background(), syncCfg, env, tx)
+		if err != nil {
+			return err
+		}
+		if err := plan.Apply(tx); err != nil {
+			return err
+		}
+		return writePlan(plan)
+	})
+}
+
+// writePlan writes the changes made by a sync, followed by a summary in table format.
+func writePlan(plan *permsync.Plan) error {
+	header := []string{
+		"ACTION", "MAPPING", "RESOURCE TYPE", "RESOURCE NAME", "SUBJECT TYPE", "SUBJECT ID", "OLD LEVEL", "NEW LEVEL",
+	}
+	rows := make([][]string, len(plan.Changes))
+	for i, c := range plan.Changes {
+		rows[i] = []string{
+			c.Action, c.Mapping, c.ResourceType, c.ResourceName, c.SubjectType, c.SubjectID, c.OldLevel, c.NewLevel,
+		}
+	}
+	if err := writeOutput(plan, header, rows); err != nil {
+		return err
+	}
+
+	if options.Output == outputTable {
+		_, err := fmt.Fprintf(stdout, "\n%d added, %d updated, %d removed, %d unchanged\n",
+			plan.Added, plan.Updated, plan.Removed, plan.Unchanged)
+		return err
+	}
+	return nil
+}
@@ -4,8 +4,10 @@ import (
 	"database/sql"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"os"
+	"strings"
 
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/restapi"
@@ -41,17 +43,41 @@ func determineDEDatabaseURI(dedburi, dburi, dedbname string) (string, error) {
 	return uri.String(), nil
 }
 
-func cleanUpPerms(db *sql.DB) error {
-	if _, err := db.Exec("DELETE FROM subjects"); err != nil {
-		return err
-	}
-	if _, err := db.Exec("DELETE FROM resources"); err != nil {
-		return err
-	}
-	return nil
+// app describes an app listed in the DE database.
+type app struct {
+	id         string
+	isPublic   bool
+	isInternal sql.NullBool
+	username   *string
+}
+
+// summary records the number of apps that were registered or skipped.
+type summary struct {
+	public            int
+	private           int
+	alreadyRegistered int
+	noOwner           int
 }
 
-func listApps(deDb *sql.DB) (*sql.Rows, error) {
+// add adds the counts in another summary to this one.
+func (s *summary) add(other *summary) {
+	s.public += other.public
+	s.private += other.private
+	s.alreadyRegistered += other.alreadyRegistered
+	s.noOwner += other.noOwner
+}
+
+// String returns a human-readable description of the summary.
+func (s *summary) String() string {
+	return fmt.Sprintf(
+		"registered %d public apps and %d private apps; skipped %d apps that were already registered and %d apps "+
+			"without an owner",
+		s.public, s.private, s.alreadyRegistered, s.noOwner,
+	)
+}
+
+// listApps lists apps in the DE database in order of app ID, starting after the given app ID if one is provided.
+func listApps(deDb *sql.DB, after string) (*sql.Rows, error) {
 	query := `SELECT
 	              a.id,
 	              a.is_public,
@@ -64,169 +90,240 @@ func listApps(deDb *sql.DB) (*sql.Rows, error) {
 	               AND ac.name = 'Apps under development'
 	               AND NOT w.is_public) AS username,
 	              a.integrator_name = 'Internal DE Tools' as is_internal
-	          FROM app_listing a`
-	return deDb.Query(query)
+	          FROM app_listing a
+	          WHERE $1 = '' OR a.id::text > $1
+	          ORDER BY a.id::text`
+	return deDb.Query(query, after)
 }
 
-func defineDeUsersGroup(db *sql.DB, groupID string) error {
-	stmt := "INSERT INTO subjects (subject_ID, subject_type) VALUES ($1, 'group')"
-	if _, err := db.Exec(stmt, groupID); err != nil {
-		return err
+// lookUpSubjectID returns the internal ID of a subject, adding the subject to the database if necessary.
+func lookUpSubjectID(tx *sql.Tx, subjectType, externalSubjectID string) (string, error) {
+	stmt := `INSERT INTO subjects (subject_id, subject_type) VALUES ($1, $2)
+	         ON CONFLICT (subject_id) DO NOTHING`
+	if _, err := tx.Exec(stmt, externalSubjectID, subjectType); err != nil {
+		return "", err
 	}
-	return nil
-}
 
-func getSubjectID(db *sql.DB, subjectType, externalSubjectID string) (*string, error) {
+	// Look up the subject ID. The subject type has to be checked because subject IDs are unique across types.
 	query := "SELECT id FROM subjects WHERE subject_type = $1 AND subject_id = $2"
-	rows, err := db.Query(query, subjectType, externalSubjectID)
-	if err != nil {
-		return nil, err
+	var subjectID string
+	if err := tx.QueryRow(query, subjectType, externalSubjectID).Scan(&subjectID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("another subject with ID, %s, already exists", externalSubjectID)
+		}
+		return "", err
 	}
-	defer rows.Close()
+	return subjectID, nil
+}
 
-	// Quit now if there are no matching rows.
-	if !rows.Next() {
-		return nil, nil
+// lookUpResourceID returns the internal ID of an app resource, adding the resource to the database if necessary.
+func lookUpResourceID(tx *sql.Tx, appID string) (string, error) {
+	stmt := `INSERT INTO resources (name, resource_type_id)
+	         (SELECT $1, id FROM resource_types WHERE name = 'app')
+	         ON CONFLICT (resource_type_id, name) DO NOTHING`
+	if _, err := tx.Exec(stmt, appID); err != nil {
+		return "", err
 	}
 
-	// Extract the subject ID from the first row.
-	var subjectID *string
-	if err := rows.Scan(&subjectID); err != nil {
-		return nil, err
+	// Look up the resource ID.
+	query := `SELECT id FROM resources
+	          WHERE resource_type_id = (SELECT id FROM resource_types WHERE name = 'app')
+	          AND name = $1`
+	var resourceID string
+	if err := tx.QueryRow(query, appID).Scan(&resourceID); err != nil {
+		return "", err
 	}
-	return subjectID, nil
+	return resourceID, nil
 }
 
-func addSubject(db *sql.DB, subjectType, externalSubjectID string) (*string, error) {
-	query := "INSERT INTO subjects (subject_type, subject_id) VALUES ($1, $2) RETURNING id"
-	row := db.QueryRow(query, subjectType, externalSubjectID)
+// registerApp grants a permission for an app to a subject. Existing permissions for the same app and subject are
+// left unchanged. The return value indicates whether or not a permission was added.
+func registerApp(tx *sql.Tx, appID, subjectType, externalSubjectID, level string) (bool, error) {
 
-	// Extract the subject ID.
-	var subjectID *string
-	if err := row.Scan(&subjectID); err != nil {
-		logger.Log.Error(err)
-		return nil, err
+	// Look up the subject ID, adding the subject to the database if necessary.
+	subjectID, err := lookUpSubjectID(tx, subjectType, externalSubjectID)
+	if err != nil {
+		return false, err
 	}
-	return subjectID, nil
-}
 
-func lookUpSubjectID(db *sql.DB, subjectType, externalSubjectID string) (*string, error) {
-	subjectID, err := getSubjectID(db, subjectType, externalSubjectID)
+	// Look up the app ID, adding the app to the database if necessary.
+	resourceID, err := lookUpResourceID(tx, appID)
 	if err != nil {
-		return nil, err
+		return false, err
+	}
+
+	// Add the permission.
+	stmt := `INSERT INTO permissions (subject_id, resource_id, permission_level_id)
+	         (SELECT $1, $2, id FROM permission_levels WHERE name = $3)
+	         ON CONFLICT (subject_id, resource_id) DO NOTHING`
+	result, err := tx.Exec(stmt, subjectID, resourceID, level)
+	if err != nil {
+		return false, err
 	}
-	if subjectID != nil {
-		return subjectID, nil
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
 	}
-	return addSubject(db, subjectType, externalSubjectID)
+	return count > 0, nil
 }
 
-func getResourceID(db *sql.DB, appID string) (*string, error) {
-	query := `SELECT id FROM resources
-            WHERE resource_type_id = (SELECT id FROM resource_types WHERE name = 'app')
-            AND name = $1`
-	rows, err := db.Query(query, appID)
+// checkpoint records the progress of a conversion: the ID of the last app in the last batch that was committed, and
+// the totals for every batch committed so far, including batches committed by earlier runs.
+type checkpoint struct {
+	after   string
+	summary summary
+}
+
+// readCheckpoint reads a checkpoint file. The file contains the app ID on the first line and the totals on the second.
+// An empty checkpoint is returned if the file doesn't exist.
+func readCheckpoint(path string) (*checkpoint, error) {
+	contents, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &checkpoint{}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	// Quit now if there are no matching rows.
-	if !rows.Next() {
-		return nil, nil
-	}
 
-	// Extract the resource ID from the first row.
-	var resourceID *string
-	if err := rows.Scan(&resourceID); err != nil {
-		return nil, err
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	cp := &checkpoint{after: strings.TrimSpace(lines[0])}
+	if len(lines) > 1 {
+		s := &cp.summary
+		_, err := fmt.Sscanf(lines[1], "%d %d %d %d", &s.public, &s.private, &s.alreadyRegistered, &s.noOwner)
+		if err != nil {
+			return nil, fmt.Errorf("invalid totals in checkpoint file %s: %w", path, err)
+		}
 	}
-	return resourceID, nil
+	return cp, nil
 }
 
-func addResource(db *sql.DB, appID string) (*string, error) {
-	query := `INSERT INTO resources (name, resource_type_id)
-            (SELECT $1, id FROM resource_types WHERE name = 'app')
-            RETURNING id`
-	row := db.QueryRow(query, appID)
+// writeCheckpoint writes a checkpoint file. The file is replaced atomically so that an interrupted write can't leave
+// a truncated app ID behind.
+func writeCheckpoint(path string, cp *checkpoint) error {
+	s := &cp.summary
+	contents := fmt.Sprintf("%s\n%d %d %d %d\n", cp.after, s.public, s.private, s.alreadyRegistered, s.noOwner)
 
-	// Extract the resource ID.
-	var resourceID *string
-	if err := row.Scan(&resourceID); err != nil {
-		logger.Log.Error(err)
-		return nil, err
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, []byte(contents), 0644); err != nil {
+		return err
 	}
-	return resourceID, nil
+	return os.Rename(tmpPath, path)
 }
 
-func lookUpResourceID(db *sql.DB, appID string) (*string, error) {
-	resourceID, err := getResourceID(db, appID)
-	if err != nil {
-		return nil, err
-	}
-	if resourceID != nil {
-		return resourceID, nil
-	}
-	return addResource(db, appID)
+// converter registers apps in batches, recording its progress after each batch is committed. The summary contains the
+// totals for the whole conversion, including batches committed by earlier runs if the conversion was resumed.
+type converter struct {
+	db             *sql.DB
+	deUsersGroupID string
+	batchSize      int
+	checkpointPath string
+	summary        summary
+
+	// register grants a permission for an app. It's normally registerApp.
+	register func(tx *sql.Tx, appID, subjectType, externalSubjectID, level string) (bool, error)
 }
 
-func registerApp(db *sql.DB, appID, subjectType, externalSubjectID, level string) error {
-
-	// Look up the subject ID, adding the subject to the database if necessary.
-	subjectID, err := lookUpSubjectID(db, subjectType, externalSubjectID)
+// registerBatch registers a batch of apps in a single transaction.
+func (c *converter) registerBatch(apps []*app) error {
+	tx, err := c.db.Begin()
 	if err != nil {
 		return err
 	}
 
-	// Look up the app ID, adding the app to the database if necessary.
-	resourceID, err := lookUpResourceID(db, appID)
-	if err != nil {
+	// Register each app in the batch, counting the results separately so that the summary isn't affected if the
+	// transaction is rolled back.
+	var batchSummary summary
+	for _, a := range apps {
+		var registered bool
+		var err error
+		switch {
+		case a.isPublic || a.isInternal.Bool:
+			registered, err = c.register(tx, a.id, "group", c.deUsersGroupID, "read")
+			if registered {
+				batchSummary.public++
+			}
+		case a.username != nil:
+			registered, err = c.register(tx, a.id, "user", *a.username, "own")
+			if registered {
+				batchSummary.private++
+			}
+		default:
+			batchSummary.noOwner++
+			continue
+		}
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			return fmt.Errorf("unable to register app %s: %w", a.id, err)
+		}
+		if !registered {
+			batchSummary.alreadyRegistered++
+		}
+	}
+
+	// Commit the transaction and record the progress.
+	if err := tx.Commit(); err != nil {
 		return err
 	}
+	cp := &checkpoint{after: apps[len(apps)-1].id, summary: c.summary}
+	cp.summary.add(&batchSummary)
+	if err := writeCheckpoint(c.checkpointPath, cp); err != nil {
+		return err
+	}
+	c.summary = cp.summary
+	logger.Log.Infof("registered apps up to %s: %s", apps[len(apps)-1].id, &c.summary)
 
-	// Add the permission.
-	stmt := `INSERT INTO permissions (subject_id, resource_id, permission_level_id)
-           (SELECT $1, $2, id FROM permission_levels WHERE name = $3)`
-	_, err = db.Exec(stmt, *subjectID, resourceID, level)
-	return err
+	return nil
 }
 
-func runConversion(db, deDb *sql.DB, deUsersGroupID string) error {
-
-	// Clean up the permissions database.
-	if err := cleanUpPerms(db); err != nil {
+// defineDeUsersGroup adds the DE users group to the permissions database if it isn't there already.
+func (c *converter) defineDeUsersGroup() error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := lookUpSubjectID(tx, "group", c.deUsersGroupID); err != nil {
+		tx.Rollback() // nolint:errcheck
 		return err
 	}
+	return tx.Commit()
+}
+
+// run registers every app listed after the given app ID.
+func (c *converter) run(deDb *sql.DB, after string) error {
 
 	// Define the DE users group as a subject in the permissions database.
-	if err := defineDeUsersGroup(db, deUsersGroupID); err != nil {
+	if err := c.defineDeUsersGroup(); err != nil {
 		return err
 	}
 
 	// Get the app listing from the DE database.
-	apps, err := listApps(deDb)
+	rows, err := listApps(deDb, after)
 	if err != nil {
 		return err
 	}
-	defer apps.Close()
+	defer rows.Close()
 
-	// Register each app in the permissions database.
-	var appID, username *string
-	var isPublic, isInternal *bool
-	for apps.Next() {
-		if err := apps.Scan(&appID, &isPublic, &username, &isInternal); err != nil {
+	// Register the apps in batches.
+	batch := make([]*app, 0, c.batchSize)
+	for rows.Next() {
+		var a app
+		if err := rows.Scan(&a.id, &a.isPublic, &a.username, &a.isInternal); err != nil {
 			return err
 		}
-		if *isPublic || *isInternal {
-			if err := registerApp(db, *appID, "group", deUsersGroupID, "read"); err != nil {
-				return err
-			}
-		} else if username != nil {
-			if err := registerApp(db, *appID, "user", *username, "own"); err != nil {
+		batch = append(batch, &a)
+		if len(batch) == c.batchSize {
+			if err := c.registerBatch(batch); err != nil {
 				return err
 			}
+			batch = batch[:0]
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return c.registerBatch(batch)
+	}
 
 	return nil
 }
@@ -249,6 +346,13 @@ func main() {
 	config := flag.String("config", "", "The path to the configuration file.")
 	deDburi := flag.String("de-database-uri", "", "The URI to use when connecting to the DE database.")
 	deDbname := flag.String("de-database-name", "de", "The name of the DE database.")
+	batchSize := flag.Int("batch-size", 1000, "The number of apps to register in each transaction.")
+	checkpointPath := flag.String(
+		"checkpoint-file",
+		"app-registration.checkpoint",
+		"The file used to record the last app registered and the totals so far.",
+	)
+	resume := flag.Bool("resume", false, "Resume from the last app recorded in the checkpoint file.")
 	showVersion := flag.Bool("version", false, "Display version information and exit.")
 
 	// Parse the command line arguments.
@@ -264,6 +368,9 @@ func main() {
 	if *config == "" {
 		logger.Log.Fatal("--config must be set")
 	}
+	if *batchSize < 1 {
+		logger.Log.Fatal("--batch-size must be positive")
+	}
 
 	// Load the configuration file.
 	cfg, err := configurate.InitDefaults(*config, restapi.DefaultConfig)
@@ -318,8 +425,35 @@ func main() {
 		logger.Log.Fatal(err.Error())
 	}
 
+	// Determine where to start. The totals recorded in the checkpoint file are carried forward so that the summary
+	// covers the whole conversion.
+	cp := &checkpoint{}
+	if *resume {
+		if cp, err = readCheckpoint(*checkpointPath); err != nil {
+			logger.Log.Fatal(err.Error())
+		}
+		if cp.after != "" {
+			logger.Log.Infof("resuming after app %s", cp.after)
+		}
+	}
+
 	// Run the conversion.
-	if err := runConversion(db, deDb, deUsersGroupID); err != nil {
-		logger.Log.Fatal(err.Error())
+	c := &converter{
+		db:             db,
+		deUsersGroupID: deUsersGroupID,
+		batchSize:      *batchSize,
+		checkpointPath: *checkpointPath,
+		summary:        cp.summary,
+		register:       registerApp,
+	}
+	if err := c.run(deDb, cp.after); err != nil {
+		logger.Log.Errorf("%s", &c.summary)
+		logger.Log.Fatalf("%s; rerun with --resume to continue", err)
+	}
+
+	// The checkpoint file is no longer needed once every app has been registered.
+	if err := os.Remove(*checkpointPath); err != nil && !os.IsNotExist(err) {
+		logger.Log.Error(err)
 	}
+	fmt.Println(&c.summary)
 }
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app-registration.checkpoint")
+
+	// A missing checkpoint file means that the conversion starts at the beginning.
+	cp, err := readCheckpoint(path)
+	if err != nil {
+		t.Fatalf("unable to read the missing checkpoint: %s", err)
+	}
+	if *cp != (checkpoint{}) {
+		t.Errorf("unexpected checkpoint: %+v", cp)
+	}
+
+	// The app ID and totals should be read back unchanged.
+	expected := checkpoint{after: "app-2", summary: summary{public: 1, private: 2, alreadyRegistered: 3, noOwner: 4}}
+	if err := writeCheckpoint(path, &expected); err != nil {
+		t.Fatalf("unable to write the checkpoint: %s", err)
+	}
+	if cp, err = readCheckpoint(path); err != nil {
+		t.Fatalf("unable to read the checkpoint: %s", err)
+	}
+	if *cp != expected {
+		t.Errorf("unexpected checkpoint: %+v", cp)
+	}
+
+	// Checkpoint files without totals start the totals at zero.
+	if err := ioutil.WriteFile(path, []byte("app-3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cp, err = readCheckpoint(path); err != nil {
+		t.Fatalf("unable to read the checkpoint: %s", err)
+	}
+	if *cp != (checkpoint{after: "app-3"}) {
+		t.Errorf("unexpected checkpoint: %+v", cp)
+	}
+
+	// Invalid totals should be rejected.
+	if err := ioutil.WriteFile(path, []byte("app-3\nsome totals\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readCheckpoint(path); err == nil {
+		t.Error("expected invalid totals to be rejected")
+	}
+}
+
+// newTestConverter returns a converter that records registrations instead of writing them to the database. Apps in
+// the registered set are reported as already registered, and registering the app with the ID "broken" fails.
+func newTestConverter(t *testing.T, registered map[string]bool) *converter {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return &converter{
+		db:             db,
+		deUsersGroupID: "de-users",
+		batchSize:      10,
+		checkpointPath: filepath.Join(t.TempDir(), "app-registration.checkpoint"),
+		register: func(tx *sql.Tx, appID, subjectType, externalSubjectID, level string) (bool, error) {
+			if appID == "broken" {
+				return false, fmt.Errorf("unable to register %s", appID)
+			}
+			if registered[appID] {
+				return false, nil
+			}
+			registered[appID] = true
+			return true, nil
+		},
+	}
+}
+
+func TestRegisterBatch(t *testing.T) {
+	c := newTestConverter(t, map[string]bool{"a5": true})
+	username := "ipcdev"
+	batch := []*app{
+		{id: "a1", isPublic: true},
+		{id: "a2", isInternal: sql.NullBool{Bool: true, Valid: true}},
+		{id: "a3", username: &username},
+		{id: "a4"},
+		{id: "a5", isPublic: true},
+	}
+	if err := c.registerBatch(batch); err != nil {
+		t.Fatalf("unable to register the batch: %s", err)
+	}
+
+	// Verify the summary and the checkpoint.
+	expected := summary{public: 2, private: 1, alreadyRegistered: 1, noOwner: 1}
+	if c.summary != expected {
+		t.Errorf("unexpected summary: %+v", c.summary)
+	}
+	cp, err := readCheckpoint(c.checkpointPath)
+	if err != nil {
+		t.Fatalf("unable to read the checkpoint: %s", err)
+	}
+	if *cp != (checkpoint{after: "a5", summary: expected}) {
+		t.Errorf("unexpected checkpoint: %+v", cp)
+	}
+
+	// A failed batch shouldn't change the summary or the checkpoint.
+	if err := c.registerBatch([]*app{{id: "a6", isPublic: true}, {id: "broken", isPublic: true}}); err == nil {
+		t.Fatal("expected the batch to fail")
+	}
+	if c.summary != expected {
+		t.Errorf("unexpected summary after a failed batch: %+v", c.summary)
+	}
+	if cp, err = readCheckpoint(c.checkpointPath); err != nil || cp.after != "a5" || cp.summary != expected {
+		t.Errorf("unexpected checkpoint after a failed batch: %+v (%v)", cp, err)
+	}
+}
+
+func TestRegisterBatchResumed(t *testing.T) {
+	c := newTestConverter(t, map[string]bool{})
+	c.summary = summary{public: 10, private: 20, alreadyRegistered: 30, noOwner: 40}
+
+	// The totals from earlier runs should be carried forward.
+	if err := c.registerBatch([]*app{{id: "a1", isPublic: true}, {id: "a2"}}); err != nil {
+		t.Fatalf("unable to register the batch: %s", err)
+	}
+	expected := summary{public: 11, private: 20, alreadyRegistered: 30, noOwner: 41}
+	if c.summary != expected {
+		t.Errorf("unexpected summary: %+v", c.summary)
+	}
+	cp, err := readCheckpoint(c.checkpointPath)
+	if err != nil {
+		t.Fatalf("unable to read the checkpoint: %s", err)
+	}
+	if cp.summary != expected {
+		t.Errorf("unexpected checkpoint totals: %+v", cp.summary)
+	}
+}
@@ -0,0 +1,24 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// contextKey is the type of the key used to store a request-scoped logger in a context.
+type contextKey struct{}
+
+// NewContext returns a copy of the given context that carries a request-scoped logger.
+func NewContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, contextKey{}, entry)
+}
+
+// FromContext returns the request-scoped logger carried by the given context. The service-wide logger is returned if
+// the context doesn't carry a logger.
+func FromContext(ctx context.Context) *logrus.Entry {
+	if entry, ok := ctx.Value(contextKey{}).(*logrus.Entry); ok {
+		return entry
+	}
+	return Log
+}
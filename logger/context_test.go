@@ -0,0 +1,17 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	if FromContext(context.Background()) != Log {
+		t.Error("expected the service-wide logger for a context without a request-scoped logger")
+	}
+
+	entry := Log.WithField("request_id", "some-request-id")
+	if FromContext(NewContext(context.Background(), entry)) != entry {
+		t.Error("expected the request-scoped logger")
+	}
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS permission_levels;
+DROP TABLE IF EXISTS subjects;
+DROP TABLE IF EXISTS resources;
+DROP TABLE IF EXISTS resource_types;
+DROP TYPE IF EXISTS subject_type;
@@ -0,0 +1,71 @@
+--
+-- The initial permissions schema. Every statement is conditional so that this migration can be applied to databases
+-- that were created before the migrations were added to this repository.
+--
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_type t JOIN pg_namespace n ON t.typnamespace = n.oid
+        WHERE t.typname = 'subject_type' AND n.nspname = current_schema()
+    ) THEN
+        CREATE TYPE subject_type AS ENUM ('user', 'group');
+    END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS resource_types (
+    id uuid NOT NULL DEFAULT uuid_generate_v1(),
+    name text NOT NULL,
+    description text,
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS resource_types_name_unique
+    ON resource_types (lower(trim(regexp_replace(name, '\s+', ' ', 'g'))));
+
+CREATE TABLE IF NOT EXISTS resources (
+    id uuid NOT NULL DEFAULT uuid_generate_v1(),
+    name text NOT NULL,
+    resource_type_id uuid NOT NULL REFERENCES resource_types (id),
+    PRIMARY KEY (id),
+    UNIQUE (resource_type_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS subjects (
+    id uuid NOT NULL DEFAULT uuid_generate_v1(),
+    subject_id text NOT NULL,
+    subject_type subject_type NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (subject_id)
+);
+
+CREATE TABLE IF NOT EXISTS permission_levels (
+    id uuid NOT NULL DEFAULT uuid_generate_v1(),
+    name text NOT NULL,
+    description text,
+    precedence integer NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (name),
+    UNIQUE (precedence)
+);
+
+--
+-- Permission levels with lower precedence values are more permissive.
+--
+INSERT INTO permission_levels (name, description, precedence) VALUES
+    ('own', 'Owner', 0),
+    ('admin', 'Administrator', 1),
+    ('write', 'Write', 2),
+    ('read', 'Read', 3)
+ON CONFLICT (name) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id uuid NOT NULL DEFAULT uuid_generate_v1(),
+    subject_id uuid NOT NULL REFERENCES subjects (id) ON DELETE CASCADE,
+    resource_id uuid NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    permission_level_id uuid NOT NULL REFERENCES permission_levels (id),
+    PRIMARY KEY (id),
+    UNIQUE (subject_id, resource_id)
+);
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS permissions_version ON permissions;
+DROP TRIGGER IF EXISTS subjects_version ON subjects;
+DROP TRIGGER IF EXISTS resources_version ON resources;
+DROP TRIGGER IF EXISTS resource_types_version ON resource_types;
+
+DROP FUNCTION IF EXISTS increment_version();
+
+ALTER TABLE permissions DROP COLUMN IF EXISTS version;
+ALTER TABLE subjects DROP COLUMN IF EXISTS version;
+ALTER TABLE resources DROP COLUMN IF EXISTS version;
+ALTER TABLE resource_types DROP COLUMN IF EXISTS version;
@@ -0,0 +1,33 @@
+--
+-- Version numbers are used for optimistic concurrency control. Each table gets a version column that is incremented
+-- automatically whenever a row is modified.
+--
+ALTER TABLE resource_types ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE resources ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE subjects ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE permissions ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION increment_version() RETURNS trigger AS $$
+BEGIN
+    IF NEW IS DISTINCT FROM OLD THEN
+        NEW.version := OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS resource_types_version ON resource_types;
+CREATE TRIGGER resource_types_version BEFORE UPDATE ON resource_types
+    FOR EACH ROW EXECUTE PROCEDURE increment_version();
+
+DROP TRIGGER IF EXISTS resources_version ON resources;
+CREATE TRIGGER resources_version BEFORE UPDATE ON resources
+    FOR EACH ROW EXECUTE PROCEDURE increment_version();
+
+DROP TRIGGER IF EXISTS subjects_version ON subjects;
+CREATE TRIGGER subjects_version BEFORE UPDATE ON subjects
+    FOR EACH ROW EXECUTE PROCEDURE increment_version();
+
+DROP TRIGGER IF EXISTS permissions_version ON permissions;
+CREATE TRIGGER permissions_version BEFORE UPDATE ON permissions
+    FOR EACH ROW EXECUTE PROCEDURE increment_version();
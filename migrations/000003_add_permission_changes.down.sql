@@ -0,0 +1,19 @@
+DROP TRIGGER IF EXISTS permission_changes_seq ON permission_changes;
+DROP TRIGGER IF EXISTS resource_types_rename ON resource_types;
+DROP TRIGGER IF EXISTS resources_rename ON resources;
+DROP TRIGGER IF EXISTS subjects_rename ON subjects;
+DROP TRIGGER IF EXISTS resources_delete_permissions ON resources;
+DROP TRIGGER IF EXISTS subjects_delete_permissions ON subjects;
+DROP TRIGGER IF EXISTS permissions_changes ON permissions;
+
+DROP FUNCTION IF EXISTS assign_permission_change_seq();
+DROP FUNCTION IF EXISTS record_resource_type_rename();
+DROP FUNCTION IF EXISTS record_resource_rename();
+DROP FUNCTION IF EXISTS record_subject_rename();
+DROP FUNCTION IF EXISTS delete_resource_permissions();
+DROP FUNCTION IF EXISTS delete_subject_permissions();
+DROP FUNCTION IF EXISTS record_permission_changes();
+DROP FUNCTION IF EXISTS record_permission_change(text, uuid, uuid, uuid, uuid);
+
+DROP TABLE IF EXISTS permission_changes;
+DROP TABLE IF EXISTS permission_change_sequence;
@@ -0,0 +1,202 @@
+--
+-- Every change to a permission is recorded in permission_changes so that clients can keep local copies of access
+-- control lists up to date. The changes are recorded by triggers, so they're captured no matter how the permissions
+-- table is modified. Renaming a subject, resource or resource type changes how its permissions are identified, so a
+-- rename is recorded as the revocation of each affected permission under the old name followed by a grant under the
+-- new name.
+--
+-- Sequence numbers are assigned when a transaction commits, by a deferred trigger that increments the counter in
+-- permission_change_sequence. The counter row stays locked until the transaction completes, so the sequence numbers
+-- are contiguous and reflect the order in which the changes were committed. A client that has seen every change up to
+-- a sequence number will never see a change with a lower sequence number appear later.
+--
+CREATE TABLE IF NOT EXISTS permission_change_sequence (
+    id boolean NOT NULL DEFAULT true CHECK (id),
+    value bigint NOT NULL,
+    PRIMARY KEY (id)
+);
+
+INSERT INTO permission_change_sequence (value) VALUES (0) ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS permission_changes (
+    id bigserial NOT NULL,
+    seq bigint UNIQUE,
+    change_type text NOT NULL CHECK (change_type IN ('granted', 'updated', 'revoked')),
+    permission_id uuid NOT NULL,
+    subject_id text NOT NULL,
+    subject_type subject_type NOT NULL,
+    resource_type text NOT NULL,
+    resource_name text NOT NULL,
+    permission_level text NOT NULL,
+    changed_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS permission_changes_changed_at_index ON permission_changes (changed_at);
+
+--
+-- Records a change to a single permission. The arguments are the change type, the permission ID, the internal subject
+-- ID, the resource ID and the permission level ID.
+--
+CREATE OR REPLACE FUNCTION record_permission_change(text, uuid, uuid, uuid, uuid) RETURNS void AS $$
+    INSERT INTO permission_changes (
+        change_type, permission_id, subject_id, subject_type, resource_type, resource_name, permission_level
+    )
+    SELECT $1, $2, s.subject_id, s.subject_type, rt.name, r.name, pl.name
+    FROM subjects s, resources r, resource_types rt, permission_levels pl
+    WHERE s.id = $3 AND r.id = $4 AND rt.id = r.resource_type_id AND pl.id = $5;
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION record_permission_changes() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM record_permission_change(
+            'granted', NEW.id, NEW.subject_id, NEW.resource_id, NEW.permission_level_id
+        );
+    ELSIF TG_OP = 'DELETE' THEN
+        PERFORM record_permission_change(
+            'revoked', OLD.id, OLD.subject_id, OLD.resource_id, OLD.permission_level_id
+        );
+    ELSIF NEW.subject_id IS DISTINCT FROM OLD.subject_id OR NEW.resource_id IS DISTINCT FROM OLD.resource_id THEN
+        PERFORM record_permission_change(
+            'revoked', OLD.id, OLD.subject_id, OLD.resource_id, OLD.permission_level_id
+        );
+        PERFORM record_permission_change(
+            'granted', NEW.id, NEW.subject_id, NEW.resource_id, NEW.permission_level_id
+        );
+    ELSIF NEW.permission_level_id IS DISTINCT FROM OLD.permission_level_id THEN
+        PERFORM record_permission_change(
+            'updated', NEW.id, NEW.subject_id, NEW.resource_id, NEW.permission_level_id
+        );
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS permissions_changes ON permissions;
+CREATE TRIGGER permissions_changes AFTER INSERT OR UPDATE OR DELETE ON permissions
+    FOR EACH ROW EXECUTE PROCEDURE record_permission_changes();
+
+--
+-- Permissions that are removed by cascading deletes can't be recorded because the subject or resource is already gone
+-- by the time the permissions are deleted, so the permissions are deleted explicitly beforehand instead.
+--
+CREATE OR REPLACE FUNCTION delete_subject_permissions() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM permissions WHERE subject_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS subjects_delete_permissions ON subjects;
+CREATE TRIGGER subjects_delete_permissions BEFORE DELETE ON subjects
+    FOR EACH ROW EXECUTE PROCEDURE delete_subject_permissions();
+
+CREATE OR REPLACE FUNCTION delete_resource_permissions() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM permissions WHERE resource_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS resources_delete_permissions ON resources;
+CREATE TRIGGER resources_delete_permissions BEFORE DELETE ON resources
+    FOR EACH ROW EXECUTE PROCEDURE delete_resource_permissions();
+
+--
+-- Renames.
+--
+CREATE OR REPLACE FUNCTION record_subject_rename() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO permission_changes (
+        change_type, permission_id, subject_id, subject_type, resource_type, resource_name, permission_level
+    )
+    SELECT c.change_type, p.id,
+           CASE c.change_type WHEN 'revoked' THEN OLD.subject_id ELSE NEW.subject_id END,
+           CASE c.change_type WHEN 'revoked' THEN OLD.subject_type ELSE NEW.subject_type END,
+           rt.name, r.name, pl.name
+    FROM (VALUES (1, 'revoked'), (2, 'granted')) AS c (ord, change_type)
+    CROSS JOIN permissions p
+    JOIN resources r ON p.resource_id = r.id
+    JOIN resource_types rt ON r.resource_type_id = rt.id
+    JOIN permission_levels pl ON p.permission_level_id = pl.id
+    WHERE p.subject_id = NEW.id
+    ORDER BY c.ord, p.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS subjects_rename ON subjects;
+CREATE TRIGGER subjects_rename AFTER UPDATE OF subject_id, subject_type ON subjects
+    FOR EACH ROW
+    WHEN (NEW.subject_id IS DISTINCT FROM OLD.subject_id OR NEW.subject_type IS DISTINCT FROM OLD.subject_type)
+    EXECUTE PROCEDURE record_subject_rename();
+
+CREATE OR REPLACE FUNCTION record_resource_rename() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO permission_changes (
+        change_type, permission_id, subject_id, subject_type, resource_type, resource_name, permission_level
+    )
+    SELECT c.change_type, p.id, s.subject_id, s.subject_type, rt.name,
+           CASE c.change_type WHEN 'revoked' THEN OLD.name ELSE NEW.name END,
+           pl.name
+    FROM (VALUES (1, 'revoked', OLD.resource_type_id), (2, 'granted', NEW.resource_type_id))
+        AS c (ord, change_type, resource_type_id)
+    JOIN resource_types rt ON c.resource_type_id = rt.id
+    CROSS JOIN permissions p
+    JOIN subjects s ON p.subject_id = s.id
+    JOIN permission_levels pl ON p.permission_level_id = pl.id
+    WHERE p.resource_id = NEW.id
+    ORDER BY c.ord, p.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS resources_rename ON resources;
+CREATE TRIGGER resources_rename AFTER UPDATE OF name, resource_type_id ON resources
+    FOR EACH ROW
+    WHEN (NEW.name IS DISTINCT FROM OLD.name OR NEW.resource_type_id IS DISTINCT FROM OLD.resource_type_id)
+    EXECUTE PROCEDURE record_resource_rename();
+
+CREATE OR REPLACE FUNCTION record_resource_type_rename() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO permission_changes (
+        change_type, permission_id, subject_id, subject_type, resource_type, resource_name, permission_level
+    )
+    SELECT c.change_type, p.id, s.subject_id, s.subject_type,
+           CASE c.change_type WHEN 'revoked' THEN OLD.name ELSE NEW.name END,
+           r.name, pl.name
+    FROM (VALUES (1, 'revoked'), (2, 'granted')) AS c (ord, change_type)
+    CROSS JOIN resources r
+    JOIN permissions p ON p.resource_id = r.id
+    JOIN subjects s ON p.subject_id = s.id
+    JOIN permission_levels pl ON p.permission_level_id = pl.id
+    WHERE r.resource_type_id = NEW.id
+    ORDER BY c.ord, p.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS resource_types_rename ON resource_types;
+CREATE TRIGGER resource_types_rename AFTER UPDATE OF name ON resource_types
+    FOR EACH ROW
+    WHEN (NEW.name IS DISTINCT FROM OLD.name)
+    EXECUTE PROCEDURE record_resource_type_rename();
+
+--
+-- Sequence numbers.
+--
+CREATE OR REPLACE FUNCTION assign_permission_change_seq() RETURNS trigger AS $$
+DECLARE
+    next_seq bigint;
+BEGIN
+    UPDATE permission_change_sequence SET value = value + 1 RETURNING value INTO next_seq;
+    UPDATE permission_changes SET seq = next_seq WHERE id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS permission_changes_seq ON permission_changes;
+CREATE CONSTRAINT TRIGGER permission_changes_seq AFTER INSERT ON permission_changes
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE PROCEDURE assign_permission_change_seq();
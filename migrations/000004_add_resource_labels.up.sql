@@ -0,0 +1,13 @@
+--
+-- Resources may be tagged with arbitrary key/value labels, which can be used to filter resource and permission
+-- listings. Each resource has at most one value for each key. The primary key is used to look up the labels of a
+-- resource, and the key and value index is used to find the resources that have a label.
+--
+CREATE TABLE IF NOT EXISTS resource_labels (
+    resource_id uuid NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    key text NOT NULL,
+    value text NOT NULL,
+    PRIMARY KEY (resource_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS resource_labels_key_value_index ON resource_labels (key, value);
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS permissions_version ON permissions;
+CREATE TRIGGER permissions_version BEFORE UPDATE ON permissions
+    FOR EACH ROW EXECUTE PROCEDURE increment_version();
+
+DROP FUNCTION IF EXISTS next_permission_version();
+
+ALTER TABLE permissions ALTER COLUMN version SET DEFAULT 1;
+
+DROP SEQUENCE IF EXISTS permission_versions;
@@ -0,0 +1,21 @@
+--
+-- Permission version numbers are drawn from a sequence so that they're never reused, even if a permission is revoked
+-- and granted again. The sequence starts after the highest version number that's already in use.
+--
+CREATE SEQUENCE IF NOT EXISTS permission_versions;
+SELECT setval('permission_versions', coalesce((SELECT max(version) FROM permissions), 0) + 1, false);
+
+ALTER TABLE permissions ALTER COLUMN version SET DEFAULT nextval('permission_versions');
+
+CREATE OR REPLACE FUNCTION next_permission_version() RETURNS trigger AS $$
+BEGIN
+    IF NEW IS DISTINCT FROM OLD THEN
+        NEW.version := nextval('permission_versions');
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS permissions_version ON permissions;
+CREATE TRIGGER permissions_version BEFORE UPDATE ON permissions
+    FOR EACH ROW EXECUTE PROCEDURE next_permission_version();
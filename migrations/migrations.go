@@ -0,0 +1,290 @@
+// Package migrations contains the versioned database schema migrations for the permissions service. The SQL scripts
+// are embedded in the binary and applied in order, with the applied versions being tracked in a schema version table
+// in the permissions schema.
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cyverse-de/permissions/logger"
+)
+
+//go:embed *.sql
+var scripts embed.FS
+
+// versionTable is the name of the table used to record which migrations have been applied.
+const versionTable = "schema_migrations"
+
+// lockID is the key of the advisory lock used to prevent concurrent migrations.
+const lockID = 7276311046526245225
+
+// scriptNamePattern matches the names of migration scripts, for example: 000001_initial_schema.up.sql.
+var scriptNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration represents a single schema migration.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes whether or not a single migration has been applied.
+type Status struct {
+	Migration *Migration
+	AppliedAt *time.Time
+}
+
+// Load returns the list of embedded migrations, sorted by version.
+func Load() ([]*Migration, error) {
+	return loadFrom(scripts)
+}
+
+// loadFrom returns the list of migrations in a file system, sorted by version.
+func loadFrom(fsys fs.FS) ([]*Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the up and down scripts by version number.
+	migrationFor := make(map[int]*Migration)
+	for _, name := range names {
+		match := scriptNamePattern.FindStringSubmatch(name)
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration script name: %s", name)
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, err
+		}
+
+		// Load the script.
+		contents, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Add the script to its migration.
+		migration := migrationFor[version]
+		if migration == nil {
+			migration = &Migration{Version: version, Name: match[2]}
+			migrationFor[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("conflicting names for migration %d: %s, %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(contents)
+		} else {
+			migration.Down = string(contents)
+		}
+	}
+
+	// Build the sorted list of migrations, making sure that each one has an up script.
+	migrations := make([]*Migration, 0, len(migrationFor))
+	for _, migration := range migrationFor {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d has no up script", migration.Version)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Migrator applies and reverts schema migrations.
+type Migrator struct {
+	db         *sql.DB
+	schema     string
+	migrations []*Migration
+}
+
+// NewMigrator returns a migrator for the embedded migrations that operates on the given database schema.
+func NewMigrator(db *sql.DB, schema string) (*Migrator, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, schema: schema, migrations: migrations}, nil
+}
+
+// begin starts a transaction for a migration. The schema and the schema version table are created if they don't
+// exist, and an advisory lock is obtained so that only one migration can run at a time.
+func (m *Migrator) begin() (*sql.Tx, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the transaction.
+	statements := []string{
+		"SELECT pg_advisory_xact_lock(" + strconv.FormatInt(lockID, 10) + ")",
+		fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", m.schema),
+		fmt.Sprintf("SET LOCAL search_path TO %s, public", m.schema),
+		`CREATE TABLE IF NOT EXISTS ` + versionTable + ` (
+		     version integer NOT NULL PRIMARY KEY,
+		     name text NOT NULL,
+		     applied_at timestamp with time zone NOT NULL DEFAULT now()
+		 )`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			tx.Rollback() // nolint:errcheck
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
+
+// appliedVersions returns the times at which each applied migration was applied, indexed by version.
+func appliedVersions(tx *sql.Tx) (map[int]time.Time, error) {
+	rows, err := tx.Query("SELECT version, applied_at FROM " + versionTable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// apply applies a single migration if it hasn't been applied already. The return value indicates whether or not the
+// migration was applied.
+func (m *Migrator) apply(migration *Migration) (bool, error) {
+	tx, err := m.begin()
+	if err != nil {
+		return false, err
+	}
+
+	// Skip the migration if another process applied it first.
+	applied, err := appliedVersions(tx)
+	if err != nil {
+		tx.Rollback() // nolint:errcheck
+		return false, err
+	}
+	if _, ok := applied[migration.Version]; ok {
+		return false, tx.Rollback()
+	}
+
+	// Apply the migration.
+	if _, err := tx.Exec(migration.Up); err != nil {
+		tx.Rollback() // nolint:errcheck
+		return false, fmt.Errorf("migration %d (%s) failed: %s", migration.Version, migration.Name, err)
+	}
+	stmt := "INSERT INTO " + versionTable + " (version, name) VALUES ($1, $2)"
+	if _, err := tx.Exec(stmt, migration.Version, migration.Name); err != nil {
+		tx.Rollback() // nolint:errcheck
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// revert reverts a single migration.
+func (m *Migrator) revert(migration *Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("migration %d (%s) can't be reverted", migration.Version, migration.Name)
+	}
+
+	tx, err := m.begin()
+	if err != nil {
+		return err
+	}
+
+	// Revert the migration.
+	if _, err := tx.Exec(migration.Down); err != nil {
+		tx.Rollback() // nolint:errcheck
+		return fmt.Errorf("reverting migration %d (%s) failed: %s", migration.Version, migration.Name, err)
+	}
+	stmt := "DELETE FROM " + versionTable + " WHERE version = $1"
+	if _, err := tx.Exec(stmt, migration.Version); err != nil {
+		tx.Rollback() // nolint:errcheck
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Status returns the status of every known migration, sorted by version.
+func (m *Migrator) Status() ([]*Status, error) {
+	tx, err := m.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	// Determine which migrations have been applied.
+	applied, err := appliedVersions(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the list of statuses.
+	statuses := make([]*Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = &Status{Migration: migration}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+
+	return statuses, nil
+}
+
+// Up applies all pending migrations in order and returns the number of migrations that were applied.
+func (m *Migrator) Up() (int, error) {
+	count := 0
+	for _, migration := range m.migrations {
+		applied, err := m.apply(migration)
+		if err != nil {
+			return count, err
+		}
+		if applied {
+			logger.Log.Infof("applied migration %d (%s)", migration.Version, migration.Name)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Down reverts up to the given number of the most recently applied migrations and returns the number of migrations
+// that were reverted.
+func (m *Migrator) Down(steps int) (int, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return 0, err
+	}
+
+	// Revert the applied migrations in reverse order.
+	count := 0
+	for i := len(statuses) - 1; i >= 0 && count < steps; i-- {
+		if statuses[i].AppliedAt == nil {
+			continue
+		}
+		migration := statuses[i].Migration
+		if err := m.revert(migration); err != nil {
+			return count, err
+		}
+		logger.Log.Infof("reverted migration %d (%s)", migration.Version, migration.Name)
+		count++
+	}
+	return count, nil
+}
@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("unable to load the embedded migrations: %s", err)
+	}
+
+	// The migration versions should be sequential and every migration should be reversible.
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("unexpected migration version: %d", migration.Version)
+		}
+		if migration.Down == "" {
+			t.Errorf("migration %d has no down script", migration.Version)
+		}
+	}
+}
+
+func TestLoadFromSorted(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_second.up.sql":   {Data: []byte("SELECT 2")},
+		"000001_first.up.sql":    {Data: []byte("SELECT 1")},
+		"000001_first.down.sql":  {Data: []byte("SELECT -1")},
+		"000010_seventh.up.sql":  {Data: []byte("SELECT 10")},
+		"000002_second.down.sql": {Data: []byte("SELECT -2")},
+	}
+
+	migrations, err := loadFrom(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(migrations) != 3 {
+		t.Fatalf("unexpected number of migrations: %d", len(migrations))
+	}
+
+	expected := []struct {
+		version int
+		name    string
+		up      string
+		down    string
+	}{
+		{1, "first", "SELECT 1", "SELECT -1"},
+		{2, "second", "SELECT 2", "SELECT -2"},
+		{10, "seventh", "SELECT 10", ""},
+	}
+	for i, e := range expected {
+		m := migrations[i]
+		if m.Version != e.version || m.Name != e.name || m.Up != e.up || m.Down != e.down {
+			t.Errorf("unexpected migration at index %d: %+v", i, m)
+		}
+	}
+}
+
+func TestLoadFromInvalidName(t *testing.T) {
+	fsys := fstest.MapFS{"first.up.sql": {Data: []byte("SELECT 1")}}
+	if _, err := loadFrom(fsys); err == nil {
+		t.Error("expected an error for an invalid script name")
+	}
+}
+
+func TestLoadFromMissingUp(t *testing.T) {
+	fsys := fstest.MapFS{"000001_first.down.sql": {Data: []byte("SELECT -1")}}
+	if _, err := loadFrom(fsys); err == nil {
+		t.Error("expected an error for a migration without an up script")
+	}
+}
+
+func TestLoadFromConflictingNames(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000001_first.up.sql":   {Data: []byte("SELECT 1")},
+		"000001_other.down.sql": {Data: []byte("SELECT -1")},
+	}
+	if _, err := loadFrom(fsys); err == nil {
+		t.Error("expected an error for conflicting migration names")
+	}
+}
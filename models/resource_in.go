@@ -19,6 +19,9 @@ import (
 // swagger:model resource_in
 type ResourceIn struct {
 
+	// Arbitrary key/value labels used to categorize the resource, such as the service that owns it or its sensitivity. Label keys consist of letters, digits, hyphens, underscores, periods and slashes, and must begin and end with a letter or digit. Label values follow the same rules, except that slashes aren't allowed and values may be empty. Keys and values are limited to 63 characters and a resource may have at most 64 labels.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// The resource name.
 	// Required: true
 	// Min Length: 1
@@ -25,6 +25,9 @@ type ResourceOut struct {
 	// Min Length: 36
 	ID *string `json:"id"`
 
+	// The resource labels. This field is omitted if the resource has no labels. Labels aren't included in the resources listed in permissions.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// The resource name.
 	// Required: true
 	// Min Length: 1
@@ -19,6 +19,9 @@ import (
 // swagger:model resource_update
 type ResourceUpdate struct {
 
+	// The new resource labels, which replace all of the existing labels. The existing labels are kept if this field is omitted.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// The new resource name.
 	// Required: true
 	// Min Length: 1
@@ -0,0 +1,211 @@
+// Package permsync synchronizes permissions with other databases. A YAML mapping file describes the permissions that
+// should exist in terms of SQL queries against those databases. The permissions that the queries produce are compared
+// with the current permissions, and only the differences are applied. The mapping file may also describe the resources
+// and groups that should exist, so that resources and subjects that no longer exist elsewhere can be found.
+package permsync
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config is the contents of a mapping file.
+type Config struct {
+	Sources   map[string]*SourceConfig `yaml:"sources"`
+	Variables []*Variable              `yaml:"variables"`
+	Mappings  []*Mapping               `yaml:"mappings"`
+	Reconcile *ReconcileConfig         `yaml:"reconcile"`
+}
+
+// SourceConfig describes how to connect to a database that mappings can query. The URI may either be specified
+// directly, in which case it may refer to environment variables as ${NAME}, or taken from a setting in the permissions
+// service configuration.
+type SourceConfig struct {
+	URI       string `yaml:"uri"`
+	ConfigKey string `yaml:"config_key"`
+}
+
+// Variable defines a value that can be referred to as ${name} in mapping fields and queries. The value may be a
+// literal, a setting in the permissions service configuration or the result of a query that returns a single value.
+type Variable struct {
+	Name      string `yaml:"name"`
+	Value     string `yaml:"value"`
+	ConfigKey string `yaml:"config_key"`
+	Source    string `yaml:"source"`
+	Query     string `yaml:"query"`
+}
+
+// Mapping describes a set of permissions. The query must return a resource_name column. The subject type, subject ID
+// and permission level are taken from the subject_type, subject_id and permission_level columns if the query returns
+// them, and from the corresponding mapping fields otherwise. Rows containing null values are skipped.
+//
+// If Prune is set then permissions for resources of the mapping's resource type that match every subject and
+// permission level field specified in the mapping, but that aren't produced by any mapping, are removed.
+type Mapping struct {
+	Name            string `yaml:"name"`
+	Source          string `yaml:"source"`
+	Query           string `yaml:"query"`
+	ResourceType    string `yaml:"resource_type"`
+	SubjectType     string `yaml:"subject_type"`
+	SubjectID       string `yaml:"subject_id"`
+	PermissionLevel string `yaml:"permission_level"`
+	Prune           bool   `yaml:"prune"`
+}
+
+// ReconcileConfig describes the sources of truth used to find orphaned resources and group subjects.
+type ReconcileConfig struct {
+	Resources []*ResourceCheck `yaml:"resources"`
+	Groups    *GroupCheck      `yaml:"groups"`
+}
+
+// ResourceCheck describes the resources of a single type that should exist. The query must return a resource_name
+// column. Resources of the type whose names the query doesn't return are orphans.
+type ResourceCheck struct {
+	ResourceType string `yaml:"resource_type"`
+	Source       string `yaml:"source"`
+	Query        string `yaml:"query"`
+}
+
+// GroupCheck describes the groups that should exist. The query must return a subject_id column, and defaults to a
+// query that lists every group in the Grouper database. Group subjects whose IDs the query doesn't return are orphans.
+type GroupCheck struct {
+	Source string `yaml:"source"`
+	Query  string `yaml:"query"`
+}
+
+// defaultGroupQuery lists every group in the Grouper database.
+const defaultGroupQuery = "SELECT id AS subject_id FROM grouper_groups"
+
+// countSet returns the number of non-empty strings among its arguments.
+func countSet(values ...string) int {
+	count := 0
+	for _, value := range values {
+		if value != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// Validate verifies that the configuration is complete and internally consistent.
+func (c *Config) Validate() error {
+	for name, source := range c.Sources {
+		if countSet(source.URI, source.ConfigKey) != 1 {
+			return fmt.Errorf("source %s: exactly one of uri and config_key must be specified", name)
+		}
+	}
+
+	// Validate the variables.
+	for i, variable := range c.Variables {
+		if variable.Name == "" {
+			return fmt.Errorf("variable %d: name is required", i+1)
+		}
+		if countSet(variable.Value, variable.ConfigKey, variable.Query) != 1 {
+			return fmt.Errorf("variable %s: exactly one of value, config_key and query must be specified", variable.Name)
+		}
+		if variable.Query != "" && c.Sources[variable.Source] == nil {
+			return fmt.Errorf("variable %s: unknown source: %s", variable.Name, variable.Source)
+		}
+	}
+
+	// Validate the mappings.
+	if len(c.Mappings) == 0 && c.Reconcile == nil {
+		return fmt.Errorf("no mappings or reconcile checks defined")
+	}
+	names := make(map[string]bool, len(c.Mappings))
+	for i, mapping := range c.Mappings {
+		if mapping.Name == "" {
+			return fmt.Errorf("mapping %d: name is required", i+1)
+		}
+		if names[mapping.Name] {
+			return fmt.Errorf("mapping %s: duplicate mapping name", mapping.Name)
+		}
+		names[mapping.Name] = true
+		if c.Sources[mapping.Source] == nil {
+			return fmt.Errorf("mapping %s: unknown source: %s", mapping.Name, mapping.Source)
+		}
+		if mapping.Query == "" {
+			return fmt.Errorf("mapping %s: query is required", mapping.Name)
+		}
+		if mapping.ResourceType == "" {
+			return fmt.Errorf("mapping %s: resource_type is required", mapping.Name)
+		}
+	}
+
+	if c.Reconcile != nil {
+		return c.Reconcile.validate(c.Sources)
+	}
+	return nil
+}
+
+// validate verifies that the reconcile checks are complete and refer to sources that exist.
+func (c *ReconcileConfig) validate(sources map[string]*SourceConfig) error {
+	if len(c.Resources) == 0 && c.Groups == nil {
+		return fmt.Errorf("reconcile: no checks defined")
+	}
+
+	// Validate the resource checks.
+	resourceTypes := make(map[string]bool, len(c.Resources))
+	for i, check := range c.Resources {
+		if check.ResourceType == "" {
+			return fmt.Errorf("reconcile resource check %d: resource_type is required", i+1)
+		}
+		if resourceTypes[check.ResourceType] {
+			return fmt.Errorf("reconcile resource check %s: duplicate resource type", check.ResourceType)
+		}
+		resourceTypes[check.ResourceType] = true
+		if sources[check.Source] == nil {
+			return fmt.Errorf("reconcile resource check %s: unknown source: %s", check.ResourceType, check.Source)
+		}
+		if check.Query == "" {
+			return fmt.Errorf("reconcile resource check %s: query is required", check.ResourceType)
+		}
+	}
+
+	// Validate the group check.
+	if c.Groups != nil && sources[c.Groups.Source] == nil {
+		return fmt.Errorf("reconcile group check: unknown source: %s", c.Groups.Source)
+	}
+
+	return nil
+}
+
+// ParseConfig parses and validates the contents of a mapping file.
+func ParseConfig(data []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// LoadConfig reads, parses and validates a mapping file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// expand replaces references to variables in a string. References to names that aren't defined as variables are
+// replaced with the values of environment variables.
+func expand(s string, variables map[string]string) string {
+	return os.Expand(s, func(name string) string {
+		if value, ok := variables[name]; ok {
+			return value
+		}
+		return os.Getenv(name)
+	})
+}
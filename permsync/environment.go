@@ -0,0 +1,61 @@
+package permsync
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/cyverse-de/permissions/restapi/impl/db/sqlite"
+)
+
+// Environment provides the database connections and configuration settings that a mapping file refers to.
+type Environment struct {
+	Sources map[string]*sql.DB
+	Setting func(key string) string
+}
+
+// Open connects to every source in the configuration, looking up settings using the given function. Both PostgreSQL
+// and SQLite URIs are supported. The PostgreSQL driver must be registered by the caller.
+func Open(cfg *Config, setting func(key string) string) (*Environment, error) {
+	env := &Environment{Sources: make(map[string]*sql.DB, len(cfg.Sources)), Setting: setting}
+
+	for name, source := range cfg.Sources {
+		uri := expand(source.URI, nil)
+		if source.ConfigKey != "" {
+			uri = setting(source.ConfigKey)
+		}
+		if uri == "" {
+			env.Close()
+			return nil, fmt.Errorf("source %s: no database URI configured", name)
+		}
+
+		// Connect to the database.
+		var db *sql.DB
+		var err error
+		if dsn, ok := sqlite.ParseURI(uri); ok {
+			db, err = sql.Open(sqlite.DriverName, dsn)
+		} else {
+			db, err = sql.Open("postgres", uri)
+		}
+		if err == nil {
+			err = db.Ping()
+		}
+		if err != nil {
+			if db != nil {
+				db.Close()
+			}
+			env.Close()
+			return nil, fmt.Errorf("source %s: %w", name, err)
+		}
+
+		env.Sources[name] = db
+	}
+
+	return env, nil
+}
+
+// Close closes every database connection in the environment.
+func (e *Environment) Close() {
+	for _, db := range e.Sources {
+		db.Close()
+	}
+}
@@ -0,0 +1,441 @@
+package permsync
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cyverse-de/permissions/models"
+	"github.com/cyverse-de/permissions/restapi/impl/bulk"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/db/memory"
+	"github.com/cyverse-de/permissions/restapi/impl/db/sqlite"
+)
+
+const testConfig = `
+sources:
+  de:
+    uri: "sqlite::memory:"
+variables:
+  - name: prefix
+    config_key: grouperdb.folder_name_prefix
+  - name: de_users
+    source: de
+    query: "SELECT id FROM grouper_groups WHERE name = '${prefix}:users:de-users'"
+mappings:
+  - name: public-apps
+    source: de
+    query: "SELECT id AS resource_name FROM apps WHERE is_public"
+    resource_type: app
+    subject_type: group
+    subject_id: "${de_users}"
+    permission_level: read
+    prune: true
+  - name: private-apps
+    source: de
+    query: "SELECT id AS resource_name, owner AS subject_id FROM apps WHERE NOT is_public"
+    resource_type: App
+    subject_type: user
+    permission_level: own
+`
+
+// newSource creates a database containing apps and Grouper groups.
+func newSource(t *testing.T) *sql.DB {
+	db, err := sql.Open(sqlite.DriverName, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	statements := []string{
+		"CREATE TABLE apps (id TEXT, is_public BOOLEAN, owner TEXT)",
+		"CREATE TABLE grouper_groups (id TEXT, name TEXT)",
+		"INSERT INTO grouper_groups VALUES ('g1', 'de:users:de-users'), ('g2', 'de:users:other')",
+		"INSERT INTO apps VALUES ('a1', 1, NULL), ('a2', 0, 'u1'), ('a3', 0, NULL)",
+	}
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return db
+}
+
+// newStore creates a permissions store containing the app resource type.
+func newStore(t *testing.T) permsdb.Store {
+	store := memory.NewStore()
+	withTx(t, store, func(tx permsdb.Tx) {
+		name := "app"
+		if _, err := tx.AddNewResourceType(&models.ResourceTypeIn{Name: &name}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	return store
+}
+
+// withTx calls a function within a transaction, committing the transaction if the function doesn't fail the test.
+func withTx(t *testing.T, store permsdb.Store, f func(permsdb.Tx)) {
+	tx, err := store.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+	f(tx)
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sync builds a plan and applies it.
+func sync(t *testing.T, cfg *Config, env *Environment, store permsdb.Store) *Plan {
+	var plan *Plan
+	withTx(t, store, func(tx permsdb.Tx) {
+		var err error
+		if plan, err = NewPlan(context.Background(), cfg, env, tx); err != nil {
+			t.Fatal(err)
+		}
+		if err := plan.Apply(tx); err != nil {
+			t.Fatal(err)
+		}
+	})
+	return plan
+}
+
+// listPermissions returns a short description of every permission in the store.
+func listPermissions(t *testing.T, store permsdb.Store) []string {
+	var result []string
+	withTx(t, store, func(tx permsdb.Tx) {
+		err := tx.EachPermission(func(p *models.Permission) error {
+			result = append(result, strings.Join([]string{
+				*p.Resource.Name, string(*p.Subject.SubjectType), string(*p.Subject.SubjectID), string(*p.PermissionLevel),
+			}, ":"))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	return result
+}
+
+func checkPermissions(t *testing.T, store permsdb.Store, expected ...string) {
+	actual := listPermissions(t, store)
+	if strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf("unexpected permissions: %v", actual)
+	}
+}
+
+func checkCounts(t *testing.T, plan *Plan, added, updated, removed, unchanged int64) {
+	if plan.Added != added || plan.Updated != updated || plan.Removed != removed || plan.Unchanged != unchanged {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	if int64(len(plan.Changes)) != added+updated+removed {
+		t.Errorf("unexpected number of changes: %d", len(plan.Changes))
+	}
+}
+
+func TestSync(t *testing.T) {
+	cfg, err := ParseConfig([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := newSource(t)
+	env := &Environment{
+		Sources: map[string]*sql.DB{"de": source},
+		Setting: func(key string) string { return map[string]string{"grouperdb.folder_name_prefix": "de"}[key] },
+	}
+	store := newStore(t)
+
+	// The first sync should add every permission.
+	plan := sync(t, cfg, env, store)
+	checkCounts(t, plan, 2, 0, 0, 0)
+	if c := plan.Changes[0]; c.Action != ActionAdd || c.Mapping != "public-apps" || c.NewLevel != "read" {
+		t.Errorf("unexpected change: %+v", c)
+	}
+	checkPermissions(t, store, "a1:group:g1:read", "a2:user:u1:own")
+
+	// Syncing again shouldn't change anything.
+	plan = sync(t, cfg, env, store)
+	checkCounts(t, plan, 0, 0, 0, 2)
+
+	// Permissions outside the scope of the pruning mapping should be retained.
+	withTx(t, store, func(tx permsdb.Tx) {
+		subject, err := tx.AddSubject("u2", "user")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resource, err := tx.GetResourceByNameAndType("a1", "app")
+		if err != nil {
+			t.Fatal(err)
+		}
+		levelID, err := tx.GetPermissionLevelIDByName(models.PermissionLevelWrite)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tx.UpsertPermission(*subject.ID, *resource.ID, *levelID); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Make the public app private and the private app public.
+	statements := []string{
+		"UPDATE apps SET is_public = 0, owner = 'u2' WHERE id = 'a1'",
+		"UPDATE apps SET is_public = 1 WHERE id = 'a2'",
+	}
+	for _, stmt := range statements {
+		if _, err := source.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The de-users permission should move from a1 to a2, the permission for u2 should be upgraded, and the existing
+	// owner of a2 should be retained because the private app mapping doesn't prune permissions.
+	plan = sync(t, cfg, env, store)
+	checkCounts(t, plan, 1, 1, 1, 0)
+	expected := []string{"remove:a1:g1:read:", "update:a1:u2:write:own", "add:a2:g1::read"}
+	for i, c := range plan.Changes {
+		actual := strings.Join([]string{c.Action, c.ResourceName, c.SubjectID, c.OldLevel, c.NewLevel}, ":")
+		if actual != expected[i] {
+			t.Errorf("unexpected change %d: %s", i, actual)
+		}
+	}
+	checkPermissions(t, store, "a1:user:u2:own", "a2:user:u1:own", "a2:group:g1:read")
+}
+
+func TestSyncErrors(t *testing.T) {
+	source := newSource(t)
+	env := &Environment{Sources: map[string]*sql.DB{"de": source}, Setting: func(string) string { return "" }}
+	store := newStore(t)
+
+	mapping := `
+sources:
+  de:
+    uri: "sqlite::memory:"
+mappings:
+  - name: m
+    source: de
+    resource_type: app
+`
+	tests := []struct {
+		extra  string
+		reason string
+	}{
+		{"    query: SELECT id FROM apps\n    subject_type: user\n    subject_id: u\n    permission_level: own",
+			"resource_name column"},
+		{"    query: SELECT id AS resource_name FROM apps\n    subject_type: user\n    permission_level: own",
+			"subject_id must"},
+		{"    query: SELECT id AS resource_name FROM apps\n    subject_type: robot\n    subject_id: r\n" +
+			"    permission_level: own", "invalid subject type"},
+		{"    query: SELECT id AS resource_name, 'none' AS permission_level FROM apps\n    subject_type: user\n" +
+			"    subject_id: u", "invalid permission level"},
+		{"    query: SELECT id AS resource_name FROM apps\n    resource_type: tool\n    subject_type: user\n" +
+			"    subject_id: u\n    permission_level: own", "no resource type"},
+	}
+
+	for _, test := range tests {
+		data := mapping + test.extra
+		if strings.Contains(test.extra, "resource_type") {
+			data = strings.Replace(data, "    resource_type: app\n", "", 1)
+		}
+		cfg, err := ParseConfig([]byte(data))
+		if err != nil {
+			t.Errorf("unable to parse the configuration: %s\n%s", err, data)
+			continue
+		}
+
+		tx, err := store.Begin(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = NewPlan(context.Background(), cfg, env, tx)
+		tx.Rollback() // nolint:errcheck
+		if err == nil || !strings.Contains(err.Error(), test.reason) {
+			t.Errorf("unexpected error for %s: %v", test.extra, err)
+		}
+	}
+}
+
+const reconcileConfig = `
+sources:
+  de:
+    uri: "sqlite::memory:"
+reconcile:
+  resources:
+    - resource_type: app
+      source: de
+      query: "SELECT id AS resource_name FROM apps"
+  groups:
+    source: de
+`
+
+func TestReconcile(t *testing.T) {
+	syncCfg, err := ParseConfig([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := ParseConfig([]byte(reconcileConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := &Environment{
+		Sources: map[string]*sql.DB{"de": newSource(t)},
+		Setting: func(key string) string { return map[string]string{"grouperdb.folder_name_prefix": "de"}[key] },
+	}
+	store := newStore(t)
+	sync(t, syncCfg, env, store)
+
+	// Add a permission for an app that doesn't exist and a permission for a group that doesn't exist.
+	withTx(t, store, func(tx permsdb.Tx) {
+		importer := bulk.NewImporter(tx)
+		records := []*bulk.Record{
+			{ResourceType: "app", ResourceName: "a9", SubjectType: "group", SubjectID: "g1", PermissionLevel: "read"},
+			{ResourceType: "app", ResourceName: "a2", SubjectType: "group", SubjectID: "g9", PermissionLevel: "write"},
+		}
+		for _, record := range records {
+			record.Kind = bulk.KindPermission
+			if err := importer.Apply(record); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	// Both the app and the group should be reported, but nothing should be deleted yet.
+	var report *Report
+	withTx(t, store, func(tx permsdb.Tx) {
+		if report, err = Reconcile(context.Background(), cfg, env, tx); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if report.Resources != 1 || report.Subjects != 1 || len(report.Orphans) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	expected := []string{"resource:app:a9:1", "subject:group:g9:1"}
+	for i, o := range report.Orphans {
+		actual := fmt.Sprintf("%s:%s:%s:%d", o.Kind, o.Type, o.Name, o.Permissions)
+		if actual != expected[i] {
+			t.Errorf("unexpected orphan %d: %s", i, actual)
+		}
+	}
+	checkPermissions(t, store, "a1:group:g1:read", "a2:user:u1:own", "a2:group:g9:write", "a9:group:g1:read")
+
+	// An app that was added to the source of truth after the sources were queried should no longer be reported once
+	// the report has been rechecked.
+	if _, err := env.Sources["de"].Exec("INSERT INTO apps VALUES ('a9', 1, NULL)"); err != nil {
+		t.Fatal(err)
+	}
+	withTx(t, store, func(tx permsdb.Tx) {
+		if err := report.Recheck(context.Background(), cfg, env, tx); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if report.Resources != 0 || report.Subjects != 1 || len(report.Orphans) != 1 || report.Orphans[0].Name != "g9" {
+		t.Fatalf("unexpected report after the recheck: %+v", report)
+	}
+	if _, err := env.Sources["de"].Exec("DELETE FROM apps WHERE id = 'a9'"); err != nil {
+		t.Fatal(err)
+	}
+	withTx(t, store, func(tx permsdb.Tx) {
+		if report, err = Reconcile(context.Background(), cfg, env, tx); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Deleting the orphans should remove their permissions.
+	withTx(t, store, func(tx permsdb.Tx) {
+		if err := report.Delete(tx); err != nil {
+			t.Fatal(err)
+		}
+	})
+	checkPermissions(t, store, "a1:group:g1:read", "a2:user:u1:own")
+
+	// A source of truth that returns nothing is treated as an error rather than reporting everything.
+	if _, err := env.Sources["de"].Exec("DELETE FROM grouper_groups"); err != nil {
+		t.Fatal(err)
+	}
+	withTx(t, store, func(tx permsdb.Tx) {
+		_, err := Reconcile(context.Background(), cfg, env, tx)
+		if err == nil || !strings.Contains(err.Error(), "returned no rows") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestQuerySources(t *testing.T) {
+	syncCfg, err := ParseConfig([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := ParseConfig([]byte(reconcileConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := &Environment{
+		Sources: map[string]*sql.DB{"de": newSource(t)},
+		Setting: func(key string) string { return map[string]string{"grouperdb.folder_name_prefix": "de"}[key] },
+	}
+	store := newStore(t)
+	sync(t, syncCfg, env, store)
+
+	// Query the sources and close them, so that any further queries fail.
+	sources, err := QuerySources(context.Background(), cfg, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.Close()
+
+	// The orphans should be found without querying the sources again.
+	withTx(t, store, func(tx permsdb.Tx) {
+		report, err := sources.Reconcile(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(report.Orphans) != 0 {
+			t.Errorf("unexpected report: %+v", report)
+		}
+	})
+}
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		data   string
+		reason string
+	}{
+		{"sources: {}\n", "no mappings"},
+		{"sources:\n  de: {}\nmappings:\n  - {name: m, source: de, query: q, resource_type: app}\n", "exactly one of uri"},
+		{"mappings:\n  - {name: m, source: de, query: q, resource_type: app}\n", "unknown source"},
+		{"sources:\n  de: {uri: u}\nmappings:\n  - {name: m, source: de, resource_type: app}\n", "query is required"},
+		{"sources:\n  de: {uri: u}\nmappings:\n  - {name: m, source: de, query: q}\n", "resource_type is required"},
+		{
+			"sources:\n  de: {uri: u}\nmappings:\n  - {name: m, source: de, query: q, resource_type: a}\n" +
+				"  - {name: m, source: de, query: q, resource_type: a}\n",
+			"duplicate mapping name",
+		},
+		{
+			"sources:\n  de: {uri: u}\nvariables:\n  - {name: v, value: a, query: q, source: de}\n" +
+				"mappings:\n  - {name: m, source: de, query: q, resource_type: a}\n",
+			"exactly one of value",
+		},
+		{"sources:\n  de: {uri: u}\nmapping: []\n", "not found"},
+		{"sources:\n  de: {uri: u}\nreconcile: {}\n", "no checks defined"},
+		{"sources:\n  de: {uri: u}\nreconcile:\n  resources:\n    - {resource_type: app, source: x, query: q}\n",
+			"unknown source"},
+		{"sources:\n  de: {uri: u}\nreconcile:\n  groups: {source: x}\n", "unknown source"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseConfig([]byte(test.data))
+		if err == nil || !strings.Contains(err.Error(), test.reason) {
+			t.Errorf("unexpected error for %q: %v", test.data, err)
+		}
+	}
+}
+
+func TestExampleConfig(t *testing.T) {
+	if _, err := LoadConfig("../conversions/de-sync.yaml"); err != nil {
+		t.Error(err)
+	}
+}
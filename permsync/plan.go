@@ -0,0 +1,393 @@
+package permsync
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/cyverse-de/permissions/models"
+	"github.com/cyverse-de/permissions/restapi/impl/bulk"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// The actions that can appear in a plan.
+const (
+	ActionAdd    = "add"
+	ActionUpdate = "update"
+	ActionRemove = "remove"
+)
+
+// levelPrecedence ranks the permission levels from least to most permissive.
+var levelPrecedence = map[string]int{"read": 1, "write": 2, "admin": 3, "own": 4}
+
+// permissionKey identifies a permission by resource and subject.
+type permissionKey struct {
+	resourceType string
+	resourceName string
+	subjectType  string
+	subjectID    string
+}
+
+// grant is a permission level produced by a mapping.
+type grant struct {
+	mapping string
+	level   string
+}
+
+// Change is a single difference between the permissions produced by the mappings and the current permissions.
+type Change struct {
+	Action       string `json:"action"`
+	Mapping      string `json:"mapping"`
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	SubjectType  string `json:"subject_type"`
+	SubjectID    string `json:"subject_id"`
+	OldLevel     string `json:"old_level,omitempty"`
+	NewLevel     string `json:"new_level,omitempty"`
+
+	permissionID models.PermissionID
+}
+
+// Plan lists the changes required to synchronize the permissions, along with the number of changes of each kind and
+// the number of permissions produced by the mappings that already exist.
+type Plan struct {
+	Changes   []*Change `json:"changes"`
+	Added     int64     `json:"added"`
+	Updated   int64     `json:"updated"`
+	Removed   int64     `json:"removed"`
+	Unchanged int64     `json:"unchanged"`
+}
+
+// planner contains the state used to build a plan.
+type planner struct {
+	ctx           context.Context
+	cfg           *Config
+	env           *Environment
+	tx            permsdb.Tx
+	variables     map[string]string
+	resourceTypes map[string]string
+	desired       map[permissionKey]*grant
+	current       map[permissionKey]*models.Permission
+}
+
+// querySingleValue executes a query that returns exactly one value.
+func querySingleValue(ctx context.Context, db *sql.DB, query string) (string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	// Extract the value from the first row.
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("the query returned no rows")
+	}
+	var value sql.NullString
+	if err := rows.Scan(&value); err != nil {
+		return "", err
+	}
+	if !value.Valid {
+		return "", fmt.Errorf("the query returned a null value")
+	}
+
+	// Verify that there aren't any other rows.
+	if rows.Next() {
+		return "", fmt.Errorf("the query returned more than one row")
+	}
+	return value.String, rows.Err()
+}
+
+// resolveVariables determines the value of each variable. Variables may refer to variables defined before them.
+func resolveVariables(ctx context.Context, cfg *Config, env *Environment) (map[string]string, error) {
+	variables := make(map[string]string, len(cfg.Variables))
+	for _, variable := range cfg.Variables {
+		switch {
+		case variable.Value != "":
+			variables[variable.Name] = expand(variable.Value, variables)
+		case variable.ConfigKey != "":
+			variables[variable.Name] = env.Setting(variable.ConfigKey)
+		default:
+			db := env.Sources[variable.Source]
+			value, err := querySingleValue(ctx, db, expand(variable.Query, variables))
+			if err != nil {
+				return nil, fmt.Errorf("variable %s: %w", variable.Name, err)
+			}
+			variables[variable.Name] = value
+		}
+	}
+	return variables, nil
+}
+
+// resolveResourceTypes looks up the resource type used by each mapping. Resource type names are compared without
+// regard to case or whitespace, so the names in the mapping file may differ slightly from the names in the database.
+func (p *planner) resolveResourceTypes() error {
+	p.resourceTypes = make(map[string]string, len(p.cfg.Mappings))
+	for _, mapping := range p.cfg.Mappings {
+		name := expand(mapping.ResourceType, p.variables)
+		resourceType, err := p.tx.GetResourceTypeByName(&name)
+		if err != nil {
+			return err
+		}
+		if resourceType == nil {
+			return fmt.Errorf("mapping %s: no resource type named, %s, found", mapping.Name, name)
+		}
+		p.resourceTypes[mapping.Name] = *resourceType.Name
+	}
+	return nil
+}
+
+// validateGrant returns an error if a subject type or permission level produced by a mapping isn't recognized.
+func validateGrant(subjectType, level string) error {
+	if err := models.SubjectType(subjectType).Validate(nil); err != nil {
+		return fmt.Errorf("invalid subject type: %s", subjectType)
+	}
+	if _, ok := levelPrecedence[level]; !ok {
+		return fmt.Errorf("invalid permission level: %s", level)
+	}
+	return nil
+}
+
+// addDesired records a permission produced by a mapping. The most permissive level wins if more than one mapping
+// produces a permission for the same resource and subject.
+func (p *planner) addDesired(key permissionKey, g *grant) {
+	existing := p.desired[key]
+	if existing == nil || levelPrecedence[g.level] > levelPrecedence[existing.level] {
+		p.desired[key] = g
+	}
+}
+
+// runMapping executes the query for a mapping and records the permissions that it produces.
+func (p *planner) runMapping(mapping *Mapping) error {
+	db := p.env.Sources[mapping.Source]
+	rows, err := db.QueryContext(p.ctx, expand(mapping.Query, p.variables))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// Determine where each field comes from.
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	indexes := make(map[string]int, len(columns))
+	for i, column := range columns {
+		indexes[column] = i
+	}
+	if _, ok := indexes["resource_name"]; !ok {
+		return fmt.Errorf("the query didn't return a resource_name column")
+	}
+	static := map[string]string{
+		"subject_type":     expand(mapping.SubjectType, p.variables),
+		"subject_id":       expand(mapping.SubjectID, p.variables),
+		"permission_level": expand(mapping.PermissionLevel, p.variables),
+	}
+	for field, value := range static {
+		if _, ok := indexes[field]; !ok && value == "" {
+			return fmt.Errorf("%s must either be specified in the mapping or returned by the query", field)
+		}
+	}
+
+	// Record the permission produced by each row.
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+
+		// Extract the field values, skipping rows that contain nulls.
+		fields := make(map[string]string, 4)
+		skip := false
+		for _, field := range []string{"resource_name", "subject_type", "subject_id", "permission_level"} {
+			if i, ok := indexes[field]; ok {
+				skip = skip || !values[i].Valid
+				fields[field] = values[i].String
+			} else {
+				fields[field] = static[field]
+			}
+		}
+		if skip {
+			continue
+		}
+
+		if err := validateGrant(fields["subject_type"], fields["permission_level"]); err != nil {
+			return err
+		}
+		key := permissionKey{
+			resourceType: p.resourceTypes[mapping.Name],
+			resourceName: fields["resource_name"],
+			subjectType:  fields["subject_type"],
+			subjectID:    fields["subject_id"],
+		}
+		p.addDesired(key, &grant{mapping: mapping.Name, level: fields["permission_level"]})
+	}
+
+	return rows.Err()
+}
+
+// loadCurrent loads the current permissions for every resource type used by the mappings.
+func (p *planner) loadCurrent() error {
+	managed := make(map[string]bool, len(p.resourceTypes))
+	for _, name := range p.resourceTypes {
+		managed[name] = true
+	}
+
+	p.current = make(map[permissionKey]*models.Permission)
+	return p.tx.EachPermission(func(permission *models.Permission) error {
+		if managed[*permission.Resource.ResourceType] {
+			key := permissionKey{
+				resourceType: *permission.Resource.ResourceType,
+				resourceName: *permission.Resource.Name,
+				subjectType:  string(*permission.Subject.SubjectType),
+				subjectID:    string(*permission.Subject.SubjectID),
+			}
+			p.current[key] = permission
+		}
+		return nil
+	})
+}
+
+// inPruneScope determines whether a mapping is responsible for removing an existing permission that no mapping
+// produces.
+func (p *planner) inPruneScope(mapping *Mapping, key permissionKey, permission *models.Permission) bool {
+	matches := func(field, value string) bool {
+		expected := expand(field, p.variables)
+		return expected == "" || expected == value
+	}
+	return mapping.Prune &&
+		p.resourceTypes[mapping.Name] == key.resourceType &&
+		matches(mapping.SubjectType, key.subjectType) &&
+		matches(mapping.SubjectID, key.subjectID) &&
+		matches(mapping.PermissionLevel, string(*permission.PermissionLevel))
+}
+
+// buildChanges compares the permissions produced by the mappings with the current permissions.
+func (p *planner) buildChanges() *Plan {
+	plan := &Plan{Changes: make([]*Change, 0)}
+	newChange := func(action, mapping string, key permissionKey) *Change {
+		return &Change{
+			Action:       action,
+			Mapping:      mapping,
+			ResourceType: key.resourceType,
+			ResourceName: key.resourceName,
+			SubjectType:  key.subjectType,
+			SubjectID:    key.subjectID,
+		}
+	}
+
+	// Add or update permissions that don't match the permissions produced by the mappings.
+	for key, g := range p.desired {
+		permission := p.current[key]
+		switch {
+		case permission == nil:
+			change := newChange(ActionAdd, g.mapping, key)
+			change.NewLevel = g.level
+			plan.Changes = append(plan.Changes, change)
+			plan.Added++
+		case string(*permission.PermissionLevel) != g.level:
+			change := newChange(ActionUpdate, g.mapping, key)
+			change.OldLevel = string(*permission.PermissionLevel)
+			change.NewLevel = g.level
+			plan.Changes = append(plan.Changes, change)
+			plan.Updated++
+		default:
+			plan.Unchanged++
+		}
+	}
+
+	// Remove permissions that aren't produced by any mapping if a mapping that prunes permissions is responsible for
+	// them.
+	for key, permission := range p.current {
+		if p.desired[key] != nil {
+			continue
+		}
+		for _, mapping := range p.cfg.Mappings {
+			if p.inPruneScope(mapping, key, permission) {
+				change := newChange(ActionRemove, mapping.Name, key)
+				change.OldLevel = string(*permission.PermissionLevel)
+				change.permissionID = *permission.ID
+				plan.Changes = append(plan.Changes, change)
+				plan.Removed++
+				break
+			}
+		}
+	}
+
+	// Sort the changes so that the plan is easy to read.
+	sort.Slice(plan.Changes, func(i, j int) bool {
+		a, b := plan.Changes[i], plan.Changes[j]
+		switch {
+		case a.ResourceType != b.ResourceType:
+			return a.ResourceType < b.ResourceType
+		case a.ResourceName != b.ResourceName:
+			return a.ResourceName < b.ResourceName
+		case a.SubjectType != b.SubjectType:
+			return a.SubjectType < b.SubjectType
+		default:
+			return a.SubjectID < b.SubjectID
+		}
+	})
+
+	return plan
+}
+
+// NewPlan determines the changes required to make the permissions in the transaction match the permissions produced
+// by the mappings in the configuration. The transaction isn't modified.
+func NewPlan(ctx context.Context, cfg *Config, env *Environment, tx permsdb.Tx) (*Plan, error) {
+	if len(cfg.Mappings) == 0 {
+		return nil, fmt.Errorf("no mappings defined")
+	}
+	p := &planner{ctx: ctx, cfg: cfg, env: env, tx: tx, desired: make(map[permissionKey]*grant)}
+
+	var err error
+	if p.variables, err = resolveVariables(ctx, cfg, env); err != nil {
+		return nil, err
+	}
+	if err := p.resolveResourceTypes(); err != nil {
+		return nil, err
+	}
+	for _, mapping := range cfg.Mappings {
+		if err := p.runMapping(mapping); err != nil {
+			return nil, fmt.Errorf("mapping %s: %w", mapping.Name, err)
+		}
+	}
+	if err := p.loadCurrent(); err != nil {
+		return nil, err
+	}
+
+	return p.buildChanges(), nil
+}
+
+// Apply makes the changes in the plan. Subjects and resources are added as needed. Subjects and resources whose
+// permissions are all removed are left in place.
+func (plan *Plan) Apply(tx permsdb.Tx) error {
+	importer := bulk.NewImporter(tx)
+	for _, change := range plan.Changes {
+		var err error
+		if change.Action == ActionRemove {
+			err = tx.DeletePermission(change.permissionID)
+		} else {
+			err = importer.Apply(&bulk.Record{
+				Kind:            bulk.KindPermission,
+				ResourceType:    change.ResourceType,
+				ResourceName:    change.ResourceName,
+				SubjectType:     change.SubjectType,
+				SubjectID:       change.SubjectID,
+				PermissionLevel: change.NewLevel,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("unable to %s the permission for %s/%s on %s/%s: %w", change.Action,
+				change.SubjectType, change.SubjectID, change.ResourceType, change.ResourceName, err)
+		}
+	}
+	return nil
+}
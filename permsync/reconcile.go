@@ -0,0 +1,335 @@
+package permsync
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// The kinds of orphans that can appear in a report.
+const (
+	OrphanResource = "resource"
+	OrphanSubject  = "subject"
+)
+
+// Orphan is a resource or group subject that no longer exists in its source of truth. The type is the resource type
+// name for resources and the subject type for subjects.
+type Orphan struct {
+	Kind        string `json:"kind"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Permissions int64  `json:"permissions"`
+
+	id string
+}
+
+// Report lists the orphaned resources and subjects found by the reconcile checks, along with the number of orphans
+// of each kind.
+type Report struct {
+	Orphans   []*Orphan `json:"orphans"`
+	Resources int64     `json:"resources"`
+	Subjects  int64     `json:"subjects"`
+}
+
+// orphanKey identifies a resource or subject by kind, type and name.
+type orphanKey struct {
+	kind string
+	typ  string
+	name string
+}
+
+// resourceNames contains the resource names returned by the query in a resource check.
+type resourceNames struct {
+	check        string
+	resourceType string
+	names        map[string]bool
+}
+
+// Sources contains the resource names and group IDs returned by the source-of-truth queries in the reconcile checks.
+// The sources are queried separately from the permissions database so that the transaction used to find the orphans
+// isn't held open while the queries run.
+type Sources struct {
+	resources []*resourceNames
+	groups    map[string]bool
+}
+
+// sourceQuerier contains the state used to query the sources of truth.
+type sourceQuerier struct {
+	ctx       context.Context
+	env       *Environment
+	variables map[string]string
+}
+
+// reconciler contains the state used to build a report.
+type reconciler struct {
+	tx      permsdb.Tx
+	sources *Sources
+	report  *Report
+	orphans map[orphanKey]*Orphan
+}
+
+// queryNames executes a query and returns the set of non-null values in the given column. An error is returned if
+// the query returns no values, because treating every resource or subject as an orphan is far more likely to be the
+// result of a misconfigured query than an accurate reflection of the source of truth.
+func (q *sourceQuerier) queryNames(source, query, column string) (map[string]bool, error) {
+	rows, err := q.env.Sources[source].QueryContext(q.ctx, expand(query, q.variables))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Find the column.
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	index := -1
+	for i, name := range columns {
+		if name == column {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("the query didn't return a %s column", column)
+	}
+
+	// Extract the values.
+	names := make(map[string]bool)
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		if values[index].Valid {
+			names[values[index].String] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("the query returned no rows")
+	}
+
+	return names, nil
+}
+
+// addOrphan records an orphaned resource or subject.
+func (r *reconciler) addOrphan(orphan *Orphan) {
+	r.report.Orphans = append(r.report.Orphans, orphan)
+	r.orphans[orphanKey{kind: orphan.Kind, typ: orphan.Type, name: orphan.Name}] = orphan
+	if orphan.Kind == OrphanResource {
+		r.report.Resources++
+	} else {
+		r.report.Subjects++
+	}
+}
+
+// QuerySources runs the source-of-truth queries in the reconcile checks in the configuration.
+func QuerySources(ctx context.Context, cfg *Config, env *Environment) (*Sources, error) {
+	if cfg.Reconcile == nil {
+		return nil, fmt.Errorf("no reconcile checks defined")
+	}
+	q := &sourceQuerier{ctx: ctx, env: env}
+	sources := &Sources{resources: make([]*resourceNames, 0, len(cfg.Reconcile.Resources))}
+
+	var err error
+	if q.variables, err = resolveVariables(ctx, cfg, env); err != nil {
+		return nil, err
+	}
+
+	// Query the resource names.
+	for _, check := range cfg.Reconcile.Resources {
+		names, err := q.queryNames(check.Source, check.Query, "resource_name")
+		if err != nil {
+			return nil, fmt.Errorf("reconcile resource check %s: %w", check.ResourceType, err)
+		}
+		sources.resources = append(sources.resources, &resourceNames{
+			check:        check.ResourceType,
+			resourceType: expand(check.ResourceType, q.variables),
+			names:        names,
+		})
+	}
+
+	// Query the group IDs.
+	if groups := cfg.Reconcile.Groups; groups != nil {
+		query := groups.Query
+		if query == "" {
+			query = defaultGroupQuery
+		}
+		if sources.groups, err = q.queryNames(groups.Source, query, "subject_id"); err != nil {
+			return nil, fmt.Errorf("reconcile group check: %w", err)
+		}
+	}
+
+	return sources, nil
+}
+
+// checkResources records every resource of the checked types whose name isn't returned by the corresponding query.
+func (r *reconciler) checkResources() error {
+	expected := make(map[string]map[string]bool, len(r.sources.resources))
+	for _, check := range r.sources.resources {
+		resourceType, err := r.tx.GetResourceTypeByName(&check.resourceType)
+		if err != nil {
+			return err
+		}
+		if resourceType == nil {
+			return fmt.Errorf(
+				"reconcile resource check %s: no resource type named, %s, found", check.check, check.resourceType,
+			)
+		}
+		expected[*resourceType.Name] = check.names
+	}
+
+	return r.tx.EachResource(func(resource *models.ResourceOut) error {
+		if names, ok := expected[*resource.ResourceType]; ok && !names[*resource.Name] {
+			r.addOrphan(&Orphan{
+				Kind: OrphanResource,
+				Type: *resource.ResourceType,
+				Name: *resource.Name,
+				id:   *resource.ID,
+			})
+		}
+		return nil
+	})
+}
+
+// checkGroups records every group subject whose ID isn't returned by the group query.
+func (r *reconciler) checkGroups() error {
+	return r.tx.EachSubject(func(subject *models.SubjectOut) error {
+		if *subject.SubjectType == models.SubjectTypeGroup && !r.sources.groups[string(*subject.SubjectID)] {
+			r.addOrphan(&Orphan{
+				Kind: OrphanSubject,
+				Type: string(*subject.SubjectType),
+				Name: string(*subject.SubjectID),
+				id:   string(*subject.ID),
+			})
+		}
+		return nil
+	})
+}
+
+// countPermissions counts the permissions that would be removed along with each orphan.
+func (r *reconciler) countPermissions() error {
+	return r.tx.EachPermission(func(permission *models.Permission) error {
+		resource, subject := permission.Resource, permission.Subject
+		keys := []orphanKey{
+			{kind: OrphanResource, typ: *resource.ResourceType, name: *resource.Name},
+			{kind: OrphanSubject, typ: string(*subject.SubjectType), name: string(*subject.SubjectID)},
+		}
+		for _, key := range keys {
+			if orphan := r.orphans[key]; orphan != nil {
+				orphan.Permissions++
+			}
+		}
+		return nil
+	})
+}
+
+// Reconcile compares the resources and group subjects in the transaction with the sources of truth described by the
+// reconcile checks in the configuration, and reports the resources and subjects that no longer exist. The
+// transaction isn't modified.
+func Reconcile(ctx context.Context, cfg *Config, env *Environment, tx permsdb.Tx) (*Report, error) {
+	sources, err := QuerySources(ctx, cfg, env)
+	if err != nil {
+		return nil, err
+	}
+	return sources.Reconcile(tx)
+}
+
+// Reconcile compares the resources and group subjects in the transaction with the results of the source-of-truth
+// queries, and reports the resources and subjects that no longer exist. The transaction isn't modified.
+func (s *Sources) Reconcile(tx permsdb.Tx) (*Report, error) {
+	r := &reconciler{
+		tx:      tx,
+		sources: s,
+		report:  &Report{Orphans: make([]*Orphan, 0)},
+		orphans: make(map[orphanKey]*Orphan),
+	}
+
+	if len(s.resources) > 0 {
+		if err := r.checkResources(); err != nil {
+			return nil, err
+		}
+	}
+	if s.groups != nil {
+		if err := r.checkGroups(); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.countPermissions(); err != nil {
+		return nil, err
+	}
+
+	// Sort the orphans so that the report is easy to read.
+	sort.SliceStable(r.report.Orphans, func(i, j int) bool {
+		a, b := r.report.Orphans[i], r.report.Orphans[j]
+		switch {
+		case a.Kind != b.Kind:
+			return a.Kind < b.Kind
+		case a.Type != b.Type:
+			return a.Type < b.Type
+		default:
+			return a.Name < b.Name
+		}
+	})
+
+	return r.report, nil
+}
+
+// Recheck queries the sources of truth again and removes the orphans that are no longer orphaned from the report. A
+// resource or subject that was added to both its source of truth and the permissions database after the sources used
+// to build the report were queried would otherwise be reported as an orphan, so this should be called before the
+// orphans are deleted.
+func (report *Report) Recheck(ctx context.Context, cfg *Config, env *Environment, tx permsdb.Tx) error {
+	current, err := Reconcile(ctx, cfg, env, tx)
+	if err != nil {
+		return err
+	}
+	stillOrphaned := make(map[string]bool, len(current.Orphans))
+	for _, orphan := range current.Orphans {
+		stillOrphaned[orphan.id] = true
+	}
+
+	// Keep only the orphans that are still orphaned.
+	orphans := make([]*Orphan, 0, len(report.Orphans))
+	report.Resources, report.Subjects = 0, 0
+	for _, orphan := range report.Orphans {
+		if !stillOrphaned[orphan.id] {
+			continue
+		}
+		orphans = append(orphans, orphan)
+		if orphan.Kind == OrphanResource {
+			report.Resources++
+		} else {
+			report.Subjects++
+		}
+	}
+	report.Orphans = orphans
+
+	return nil
+}
+
+// Delete removes the orphans in the report, along with any permissions granted to or for them.
+func (report *Report) Delete(tx permsdb.Tx) error {
+	for _, orphan := range report.Orphans {
+		var err error
+		if orphan.Kind == OrphanResource {
+			err = tx.DeleteResource(&orphan.id)
+		} else {
+			err = tx.DeleteSubject(models.InternalSubjectID(orphan.id))
+		}
+		if err != nil {
+			return fmt.Errorf("unable to delete the %s %s/%s: %w", orphan.Kind, orphan.Type, orphan.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package restapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/cyverse-de/permissions/logger"
+)
+
+// pruneInterval is the amount of time between attempts to remove old permission changes.
+const pruneInterval = time.Hour
+
+// pruneChanges removes the permission changes that are older than the retention period.
+func pruneChanges(ctx context.Context, retention time.Duration) error {
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	count, err := tx.PruneChanges(time.Now().Add(-retention))
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if count > 0 {
+		logger.Log.Infof("removed %d permission changes older than %s", count, retention)
+	}
+	return nil
+}
+
+// startChangePruner removes permission changes that are older than the retention period every hour until the
+// returned function is called. Changes are kept indefinitely if the retention period is zero.
+func startChangePruner(retention time.Duration) func() {
+	if retention <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(pruneInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := pruneChanges(ctx, retention); err != nil {
+					logger.Log.Errorf("unable to remove old permission changes: %s", err)
+				}
+			}
+		}
+	}()
+
+	return cancel
+}
@@ -51,6 +51,276 @@ func init() {
         }
       }
     },
+    "/changes": {
+      "get": {
+        "description": "Lists the permission changes recorded after the given sequence number, oldest first. If no sequence number is given then no changes are listed, and the response contains the latest sequence number so that the client knows where to start. Clients that send an Accept header containing text/event-stream receive a stream of Server-Sent Events instead: each change is sent as a change event whose ID is its sequence number, and an expired event is sent if the client falls too far behind. This endpoint isn't subject to the request timeout.",
+        "produces": [
+          "application/json",
+          "text/event-stream"
+        ],
+        "tags": [
+          "changes"
+        ],
+        "summary": "List Permission Changes",
+        "operationId": "listChanges",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 0,
+            "description": "The sequence number of the last change that the client has seen. Only changes after this one are listed.",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "default": 1000,
+            "maximum": 10000,
+            "minimum": 1,
+            "description": "The maximum number of changes to list.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "The maximum amount of time to wait for a change to arrive if there are no changes after the sequence number, as a Go duration such as 30s. The wait may be at most 5m. Requests don't wait by default.",
+            "name": "wait",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "The sequence number of the last event received by a reconnecting event stream client. This header takes precedence over the since parameter.",
+            "name": "Last-Event-ID",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "object",
+              "required": [
+                "changes",
+                "last_seq"
+              ],
+              "properties": {
+                "changes": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "change_type": {
+                        "description": "The type of change: granted, updated or revoked.",
+                        "type": "string"
+                      },
+                      "changed_at": {
+                        "type": "string",
+                        "format": "date-time"
+                      },
+                      "permission_id": {
+                        "type": "string"
+                      },
+                      "permission_level": {
+                        "type": "string"
+                      },
+                      "resource_name": {
+                        "type": "string"
+                      },
+                      "resource_type": {
+                        "type": "string"
+                      },
+                      "seq": {
+                        "type": "integer",
+                        "format": "int64"
+                      },
+                      "subject_id": {
+                        "type": "string"
+                      },
+                      "subject_type": {
+                        "type": "string"
+                      }
+                    }
+                  }
+                },
+                "last_seq": {
+                  "description": "The sequence number to pass as the since parameter in the next request.",
+                  "type": "integer",
+                  "format": "int64"
+                }
+              }
+            }
+          },
+          "400": {
+            "$ref": "#/responses/bad_request"
+          },
+          "406": {
+            "description": "Not Acceptable: event streams aren't supported by the server",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "410": {
+            "description": "Gone: the changes after the sequence number are no longer available. The client must reload the permissions that it's interested in and start again from the latest sequence number.",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      }
+    },
+    "/export": {
+      "get": {
+        "description": "Streams every resource type, subject, resource and permission in the database, one record per line or row. Resource types are written first, followed by subjects, resources and permissions, so that every record only refers to entities that appear earlier in the file. The response is sent as it's generated, so the connection is closed without completing the response if an error occurs part way through the export.",
+        "produces": [
+          "application/x-ndjson",
+          "text/csv"
+        ],
+        "tags": [
+          "bulk"
+        ],
+        "summary": "Export Permissions",
+        "operationId": "exportPermissions",
+        "parameters": [
+          {
+            "enum": [
+              "jsonl",
+              "csv"
+            ],
+            "type": "string",
+            "default": "jsonl",
+            "description": "The format of the exported file: JSON Lines or CSV.",
+            "name": "format",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/bad_request"
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      }
+    },
+    "/healthz": {
+      "get": {
+        "description": "Determines whether or not the service is alive. This check doesn't depend on any of the databases used by the service, so a database outage won't cause the service to be restarted.",
+        "tags": [
+          "status"
+        ],
+        "summary": "Liveness Check",
+        "operationId": "getHealthz",
+        "responses": {
+          "200": {
+            "description": "Alive",
+            "schema": {
+              "$ref": "#/definitions/health_status"
+            }
+          }
+        }
+      }
+    },
+    "/import": {
+      "post": {
+        "description": "Imports a file in the format produced by the export endpoint in a single transaction. Subjects, resources and resource types that don't exist yet are added, resource type descriptions are updated, and permissions replace any existing permission for the same subject and resource. Nothing is changed if any record is invalid. The response lists the number of records of each kind that were imported.",
+        "consumes": [
+          "application/x-ndjson",
+          "text/csv"
+        ],
+        "tags": [
+          "bulk"
+        ],
+        "summary": "Import Permissions",
+        "operationId": "importPermissions",
+        "parameters": [
+          {
+            "description": "The records to import.",
+            "name": "records",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "enum": [
+              "jsonl",
+              "csv"
+            ],
+            "type": "string",
+            "default": "jsonl",
+            "description": "The format of the imported file: JSON Lines or CSV.",
+            "name": "format",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the import should be validated without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "permissions": {
+                  "type": "integer"
+                },
+                "resource_types": {
+                  "type": "integer"
+                },
+                "resources": {
+                  "type": "integer"
+                },
+                "subjects": {
+                  "type": "integer"
+                }
+              }
+            }
+          },
+          "202": {
+            "description": "Accepted: the file was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "permissions": {
+                  "type": "integer"
+                },
+                "resource_types": {
+                  "type": "integer"
+                },
+                "resources": {
+                  "type": "integer"
+                },
+                "subjects": {
+                  "type": "integer"
+                }
+              }
+            }
+          },
+          "400": {
+            "$ref": "#/responses/bad_request"
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      }
+    },
     "/permissions": {
       "get": {
         "description": "Lists all permissions in the permission database. The total number of permissions for all resources is likely to be quite large, so callers should be prepared to handle the response body. If this endpoint is used more frequently than anticipated, limit and offset parameters will be added for paging later.",
@@ -59,6 +329,11 @@ func init() {
         ],
         "summary": "List Permissions",
         "operationId": "listPermissions",
+        "parameters": [
+          {
+            "$ref": "#/parameters/label_selector"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK",
@@ -66,6 +341,9 @@ func init() {
               "$ref": "#/definitions/permission_list"
             }
           },
+          "400": {
+            "$ref": "#/responses/bad_request"
+          },
           "500": {
             "$ref": "#/responses/internal_server_error"
           }
@@ -87,6 +365,13 @@ func init() {
             "schema": {
               "$ref": "#/definitions/permission_grant_request"
             }
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
           }
         ],
         "responses": {
@@ -96,6 +381,9 @@ func init() {
               "$ref": "#/definitions/permission"
             }
           },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
           "400": {
             "$ref": "#/responses/bad_request"
           },
@@ -113,6 +401,14 @@ func init() {
         ],
         "summary": "Look Up Abbreviated Permissions by Subject and Resource Type",
         "operationId": "bySubjectAndResourceTypeAbbreviated",
+        "parameters": [
+          {
+            "$ref": "#/parameters/read_your_writes"
+          },
+          {
+            "$ref": "#/parameters/label_selector"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK",
@@ -188,6 +484,12 @@ func init() {
             "description": "OK",
             "schema": {
               "$ref": "#/definitions/permission_list"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The current version of the access control list for the resource."
+              }
             }
           },
           "500": {
@@ -195,67 +497,96 @@ func init() {
           }
         }
       },
-      "parameters": [
-        {
-          "type": "string",
-          "description": "The resource type name.",
-          "name": "resource_type",
-          "in": "path",
-          "required": true
-        },
-        {
-          "type": "string",
-          "description": "The resource name.",
-          "name": "resource_name",
-          "in": "path",
-          "required": true
-        }
-      ]
-    },
-    "/permissions/resources/{resource_type}/{resource_name}/subjects/{subject_type}/{subject_id}": {
       "put": {
-        "description": "Grants permission to access a resource to a subject. If the subject already has permission to access the resource then the permission level will be updated (assuming the new permission level is different from the existing permission level). Neither the resource nor the subject needs to be registered in the database before this endpoint is called; they will be added to the database if necessary. This endpoint will return an error response if the subject ID is already in use and associated with a different subject type. It will also return an error if either the specified resource type or permission level does not exist.",
+        "description": "Replaces the access control list for a resource. Subjects in the request body are granted the specified permission levels, and permissions for subjects that aren't listed in the request body are revoked. Subjects and the resource itself are created if they don't exist yet. All changes are applied in a single transaction, and the response body lists the changes that were made. If the If-Match header is specified then the access control list is only replaced if its current version, as reported in the ETag header when the resource permissions are listed, matches the header value.",
         "tags": [
           "permissions"
         ],
-        "summary": "Grant Permission to a Resource",
-        "operationId": "putPermission",
+        "summary": "Replace Resource Permissions",
+        "operationId": "replaceResourcePermissions",
         "parameters": [
           {
-            "description": "The permission level to assign.",
-            "name": "permission",
+            "description": "The complete access control list for the resource.",
+            "name": "acl",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/permission_put_request"
+              "$ref": "#/definitions/resource_acl_in"
             }
+          },
+          {
+            "type": "string",
+            "description": "The version of the access control list that the changes are based on.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "OK",
             "schema": {
-              "$ref": "#/definitions/permission"
+              "$ref": "#/definitions/change_set"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The updated version of the access control list for the resource."
+              }
             }
           },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
           "400": {
             "$ref": "#/responses/bad_request"
           },
+          "412": {
+            "$ref": "#/responses/precondition_failed"
+          },
           "500": {
             "$ref": "#/responses/internal_server_error"
           }
         }
       },
       "delete": {
-        "description": "Removes a permission entry from the database. This endpoint will return an error status if the resource type, resource, subject or the permission itself does not exist.",
+        "description": "Revokes all permissions that have been granted for a resource in a single transaction. Permissions granted at the own level can optionally be retained. The response body lists the permissions that were revoked.",
         "tags": [
           "permissions"
         ],
-        "summary": "Revoke Permission to a Resource",
-        "operationId": "revokePermission",
+        "summary": "Revoke All Resource Permissions",
+        "operationId": "revokeResourcePermissions",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if permissions granted at the own level should be retained. This parameter is optional and defaults to False.",
+            "name": "exclude_owners",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "OK"
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/permission_list"
+            }
+          },
+          "202": {
+            "$ref": "#/responses/dry_run"
           },
           "404": {
             "$ref": "#/responses/not_found"
@@ -279,54 +610,227 @@ func init() {
           "name": "resource_name",
           "in": "path",
           "required": true
-        },
+        }
+      ]
+    },
+    "/permissions/resources/{resource_type}/{resource_name}/copy": {
+      "post": {
+        "description": "Copies all permissions that have been granted for one resource to one or more other resources. Destination resources that don't exist yet are created. The merge policy determines what happens when a subject already has permission to access a destination resource: keep-higher retains the more permissive of the two permission levels, overwrite replaces the existing permission level with the permission level from the source resource, and skip-existing leaves the existing permission unchanged.",
+        "tags": [
+          "permissions"
+        ],
+        "summary": "Copy Permissions Between Resources",
+        "operationId": "copyResourcePermissions",
+        "parameters": [
+          {
+            "description": "The destination resources.",
+            "name": "destResources",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/resources_in"
+            }
+          },
+          {
+            "enum": [
+              "keep-higher",
+              "overwrite",
+              "skip-existing"
+            ],
+            "type": "string",
+            "default": "keep-higher",
+            "description": "The policy to use when a subject already has permission to access a destination resource. This parameter is optional and defaults to keep-higher.",
+            "name": "merge_policy",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
+          "400": {
+            "$ref": "#/responses/bad_request"
+          },
+          "404": {
+            "$ref": "#/responses/not_found"
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      },
+      "parameters": [
         {
-          "enum": [
-            "user",
-            "group"
-          ],
           "type": "string",
-          "description": "The subject type name.",
-          "name": "subject_type",
+          "description": "The resource type name.",
+          "name": "resource_type",
           "in": "path",
           "required": true
         },
         {
           "type": "string",
-          "description": "The external subject identifier.",
-          "name": "subject_id",
+          "description": "The resource name.",
+          "name": "resource_name",
           "in": "path",
           "required": true
         }
       ]
     },
-    "/permissions/subjects/{subject_type}/{subject_id}": {
+    "/permissions/resources/{resource_type}/{resource_name}/subjects/{subject_type}/{subject_id}": {
       "get": {
-        "description": "Looks up all permissions granted to a subject. If lookup mode is enabled and the subject is a user, the most lenient permissions granted to the subject or any groups the subject belongs to will be listed. If lookup mode is not enabled or the subject is a group then only permissions assigned directly to the subject will be listed. This endpoint will return an error status if the subject ID is in use and associated with a different subject type.",
+        "description": "Returns the permission that has been granted directly to a subject for a resource. Unlike the permission lookup endpoints, this endpoint doesn't take group memberships into account. The current version of the permission is returned in the ETag header.",
         "tags": [
           "permissions"
         ],
-        "summary": "Look Up by Subject",
-        "operationId": "bySubject",
+        "summary": "Get a Permission",
+        "operationId": "getPermission",
         "responses": {
           "200": {
             "description": "OK",
             "schema": {
-              "$ref": "#/definitions/permission_list"
+              "$ref": "#/definitions/permission"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The current version of the permission."
+              }
             }
           },
-          "400": {
-            "$ref": "#/responses/bad_request"
+          "404": {
+            "$ref": "#/responses/not_found"
           },
           "500": {
             "$ref": "#/responses/internal_server_error"
           }
         }
       },
-      "parameters": [
-        {
-          "enum": [
-            "user",
+      "put": {
+        "description": "Grants permission to access a resource to a subject. If the subject already has permission to access the resource then the permission level will be updated (assuming the new permission level is different from the existing permission level). Neither the resource nor the subject needs to be registered in the database before this endpoint is called; they will be added to the database if necessary. This endpoint will return an error response if the subject ID is already in use and associated with a different subject type. It will also return an error if either the specified resource type or permission level does not exist.",
+        "tags": [
+          "permissions"
+        ],
+        "summary": "Grant Permission to a Resource",
+        "operationId": "putPermission",
+        "parameters": [
+          {
+            "description": "The permission level to assign.",
+            "name": "permission",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/permission_put_request"
+            }
+          },
+          {
+            "type": "string",
+            "description": "The version of the permission that the changes are based on.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/permission"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The updated version of the permission."
+              }
+            }
+          },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
+          "400": {
+            "$ref": "#/responses/bad_request"
+          },
+          "412": {
+            "$ref": "#/responses/precondition_failed"
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      },
+      "delete": {
+        "description": "Removes a permission entry from the database. This endpoint will return an error status if the resource type, resource, subject or the permission itself does not exist.",
+        "tags": [
+          "permissions"
+        ],
+        "summary": "Revoke Permission to a Resource",
+        "operationId": "revokePermission",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The version of the permission that the request is based on.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
+          "404": {
+            "$ref": "#/responses/not_found"
+          },
+          "412": {
+            "$ref": "#/responses/precondition_failed"
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "The resource type name.",
+          "name": "resource_type",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "The resource name.",
+          "name": "resource_name",
+          "in": "path",
+          "required": true
+        },
+        {
+          "enum": [
+            "user",
             "group"
           ],
           "type": "string",
@@ -341,13 +845,102 @@ func init() {
           "name": "subject_id",
           "in": "path",
           "required": true
+        }
+      ]
+    },
+    "/permissions/subjects/{subject_type}/{subject_id}": {
+      "get": {
+        "description": "Looks up all permissions granted to a subject. If lookup mode is enabled and the subject is a user, the most lenient permissions granted to the subject or any groups the subject belongs to will be listed. If lookup mode is not enabled or the subject is a group then only permissions assigned directly to the subject will be listed. This endpoint will return an error status if the subject ID is in use and associated with a different subject type.",
+        "tags": [
+          "permissions"
+        ],
+        "summary": "Look Up by Subject",
+        "operationId": "bySubject",
+        "parameters": [
+          {
+            "$ref": "#/parameters/read_your_writes"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if a permission lookup should be performed. A permission lookup differs from standard permisison retrieval in two ways. First, only the most permissive permission level available to the subject is returned for any given resource. Second, if the subject happens to be a user then permissions granted to groups that the user belongs to are also included in the results. This parameter is optional and defaults to False.",
+            "name": "lookup",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/permission_list"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/bad_request"
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      },
+      "delete": {
+        "description": "Revokes all permissions that have been granted directly to a subject in a single transaction. The permissions that are revoked can optionally be limited to a single resource type or to permissions of at least the given minimum permission level. Permissions granted to groups that the subject belongs to are not revoked. The response body lists the permissions that were revoked.",
+        "tags": [
+          "permissions"
+        ],
+        "summary": "Revoke All Subject Permissions",
+        "operationId": "revokeSubjectPermissions",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The name of the resource type to revoke permissions for. Permissions for all resource types are revoked by default.",
+            "name": "resource_type",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/permission_list"
+            }
+          },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
+          "404": {
+            "$ref": "#/responses/not_found"
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      },
+      "parameters": [
+        {
+          "enum": [
+            "user",
+            "group"
+          ],
+          "type": "string",
+          "description": "The subject type name.",
+          "name": "subject_type",
+          "in": "path",
+          "required": true
         },
         {
-          "type": "boolean",
-          "default": false,
-          "description": "True if a permission lookup should be performed. A permission lookup differs from standard permisison retrieval in two ways. First, only the most permissive permission level available to the subject is returned for any given resource. Second, if the subject happens to be a user then permissions granted to groups that the user belongs to are also included in the results. This parameter is optional and defaults to False.",
-          "name": "lookup",
-          "in": "query"
+          "type": "string",
+          "description": "The external subject identifier.",
+          "name": "subject_id",
+          "in": "path",
+          "required": true
         },
         {
           "enum": [
@@ -380,12 +973,22 @@ func init() {
             "schema": {
               "$ref": "#/definitions/subjects_in"
             }
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "OK"
           },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
           "400": {
             "$ref": "#/responses/bad_request"
           },
@@ -423,6 +1026,14 @@ func init() {
         ],
         "summary": "Look Up by Subject and Resource Type",
         "operationId": "bySubjectAndResourceType",
+        "parameters": [
+          {
+            "$ref": "#/parameters/read_your_writes"
+          },
+          {
+            "$ref": "#/parameters/label_selector"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK",
@@ -493,6 +1104,11 @@ func init() {
         ],
         "summary": "Look Up by Subject and Resource",
         "operationId": "bySubjectAndResource",
+        "parameters": [
+          {
+            "$ref": "#/parameters/read_your_writes"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK",
@@ -562,6 +1178,30 @@ func init() {
         }
       ]
     },
+    "/readyz": {
+      "get": {
+        "description": "Determines whether or not the service is ready to handle requests. The databases used by the service are checked, and the status and latency of each check is reported. A 503 status is returned if any required dependency is unavailable.",
+        "tags": [
+          "status"
+        ],
+        "summary": "Readiness Check",
+        "operationId": "getReadyz",
+        "responses": {
+          "200": {
+            "description": "Ready",
+            "schema": {
+              "$ref": "#/definitions/health_status"
+            }
+          },
+          "503": {
+            "description": "Not Ready",
+            "schema": {
+              "$ref": "#/definitions/health_status"
+            }
+          }
+        }
+      }
+    },
     "/resource_types": {
       "get": {
         "description": "Lists resource types known to the permissions service. A resource type represents a class of resources to which permissions may be applied. For example, the Discovery environment has apps collectively defined as a single resource type in the permissions service.",
@@ -635,12 +1275,22 @@ func init() {
             "name": "resource_type_name",
             "in": "query",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "Deleted"
           },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
           "400": {
             "$ref": "#/responses/bad_request"
           },
@@ -654,6 +1304,33 @@ func init() {
       }
     },
     "/resource_types/{id}": {
+      "get": {
+        "description": "Returns information about a single resource type. The current version of the resource type is returned in the ETag header.",
+        "tags": [
+          "resource_types"
+        ],
+        "summary": "Get a Resource Type",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/resource_type_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The current version of the resource type."
+              }
+            }
+          },
+          "404": {
+            "$ref": "#/responses/not_found"
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      },
       "put": {
         "description": "Updates the name or description of a resource type. The new name of the resource type must be unique if one is provided.",
         "tags": [
@@ -669,6 +1346,12 @@ func init() {
             "schema": {
               "$ref": "#/definitions/resource_type_in"
             }
+          },
+          {
+            "type": "string",
+            "description": "The version of the resource type that the changes are based on.",
+            "name": "If-Match",
+            "in": "header"
           }
         ],
         "responses": {
@@ -676,6 +1359,12 @@ func init() {
             "description": "Updated",
             "schema": {
               "$ref": "#/definitions/resource_type_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The updated version of the resource type."
+              }
             }
           },
           "400": {
@@ -684,6 +1373,9 @@ func init() {
           "404": {
             "$ref": "#/responses/not_found"
           },
+          "412": {
+            "$ref": "#/responses/precondition_failed"
+          },
           "500": {
             "$ref": "#/responses/internal_server_error"
           }
@@ -695,10 +1387,22 @@ func init() {
           "resource_types"
         ],
         "summary": "Delete a Resource Type",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "Deleted"
           },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
           "400": {
             "$ref": "#/responses/bad_request"
           },
@@ -740,6 +1444,9 @@ func init() {
             "description": "The resource name to search for.",
             "name": "resource_name",
             "in": "query"
+          },
+          {
+            "$ref": "#/parameters/label_selector"
           }
         ],
         "responses": {
@@ -749,6 +1456,9 @@ func init() {
               "$ref": "#/definitions/resources_out"
             }
           },
+          "400": {
+            "$ref": "#/responses/bad_request"
+          },
           "500": {
             "$ref": "#/responses/internal_server_error"
           }
@@ -808,12 +1518,22 @@ func init() {
             "name": "resource_name",
             "in": "query",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "OK"
           },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
           "404": {
             "$ref": "#/responses/not_found"
           },
@@ -824,22 +1544,56 @@ func init() {
       }
     },
     "/resources/{id}": {
-      "put": {
-        "description": "Updates a resource in the database.",
+      "get": {
+        "description": "Returns information about a single resource. The current version of the resource is returned in the ETag header.",
         "tags": [
           "resources"
         ],
-        "summary": "Update a Resource",
-        "operationId": "updateResource",
-        "parameters": [
-          {
-            "description": "The updated resource information.",
-            "name": "resource_update",
-            "in": "body",
-            "required": true,
+        "summary": "Get a Resource",
+        "operationId": "getResource",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/resource_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The current version of the resource."
+              }
+            }
+          },
+          "404": {
+            "$ref": "#/responses/not_found"
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      },
+      "put": {
+        "description": "Updates a resource in the database.",
+        "tags": [
+          "resources"
+        ],
+        "summary": "Update a Resource",
+        "operationId": "updateResource",
+        "parameters": [
+          {
+            "description": "The updated resource information.",
+            "name": "resource_update",
+            "in": "body",
+            "required": true,
             "schema": {
               "$ref": "#/definitions/resource_update"
             }
+          },
+          {
+            "type": "string",
+            "description": "The version of the resource that the changes are based on.",
+            "name": "If-Match",
+            "in": "header"
           }
         ],
         "responses": {
@@ -847,6 +1601,12 @@ func init() {
             "description": "OK",
             "schema": {
               "$ref": "#/definitions/resource_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The updated version of the resource."
+              }
             }
           },
           "400": {
@@ -855,6 +1615,9 @@ func init() {
           "404": {
             "$ref": "#/responses/not_found"
           },
+          "412": {
+            "$ref": "#/responses/precondition_failed"
+          },
           "500": {
             "$ref": "#/responses/internal_server_error"
           }
@@ -867,10 +1630,22 @@ func init() {
         ],
         "summary": "Delete a Resource",
         "operationId": "deleteResource",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK"
           },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
           "404": {
             "$ref": "#/responses/not_found"
           },
@@ -985,12 +1760,22 @@ func init() {
             "name": "subject_type",
             "in": "query",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "OK"
           },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
           "404": {
             "$ref": "#/responses/not_found"
           },
@@ -1001,6 +1786,34 @@ func init() {
       }
     },
     "/subjects/{id}": {
+      "get": {
+        "description": "Returns information about a single subject. The current version of the subject is returned in the ETag header.",
+        "tags": [
+          "subjects"
+        ],
+        "summary": "Get a Subject",
+        "operationId": "getSubject",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/subject_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The current version of the subject."
+              }
+            }
+          },
+          "404": {
+            "$ref": "#/responses/not_found"
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      },
       "put": {
         "description": "Updates a subject in the database. For full use of the permissions service, the subject should be present in Grouper and have the same subject ID in Grouper and the permissions service.",
         "tags": [
@@ -1017,6 +1830,12 @@ func init() {
             "schema": {
               "$ref": "#/definitions/subject_in"
             }
+          },
+          {
+            "type": "string",
+            "description": "The version of the subject that the changes are based on.",
+            "name": "If-Match",
+            "in": "header"
           }
         ],
         "responses": {
@@ -1024,6 +1843,12 @@ func init() {
             "description": "OK",
             "schema": {
               "$ref": "#/definitions/subject_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The updated version of the subject."
+              }
             }
           },
           "400": {
@@ -1032,6 +1857,9 @@ func init() {
           "404": {
             "$ref": "#/responses/not_found"
           },
+          "412": {
+            "$ref": "#/responses/precondition_failed"
+          },
           "500": {
             "$ref": "#/responses/internal_server_error"
           }
@@ -1044,10 +1872,76 @@ func init() {
         ],
         "summary": "Delete a Subject",
         "operationId": "deleteSubject",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK"
           },
+          "202": {
+            "$ref": "#/responses/dry_run"
+          },
+          "404": {
+            "$ref": "#/responses/not_found"
+          },
+          "500": {
+            "$ref": "#/responses/internal_server_error"
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "The subject ID.",
+          "name": "id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/subjects/{id}/merge": {
+      "post": {
+        "description": "Folds a subject into the subject with the given external subject ID and subject type. Permissions granted to the source subject are moved to the target subject. If both subjects have been granted permission to the same resource then the most permissive permission level is retained and the resource is listed as a conflict in the response body. The source subject is removed from the database once its permissions have been moved. If the target subject doesn't exist yet then the source subject is simply renamed. If dry run mode is enabled then the response body describes the effect of the merge but no changes are made to the database.",
+        "tags": [
+          "subjects"
+        ],
+        "summary": "Merge a Subject into Another Subject",
+        "operationId": "mergeSubject",
+        "parameters": [
+          {
+            "description": "The subject to merge the source subject into.",
+            "name": "target",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/subject_in"
+            }
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the merge should be previewed without making any changes to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/subject_merge_result"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/bad_request"
+          },
           "404": {
             "$ref": "#/responses/not_found"
           },
@@ -1112,6 +2006,99 @@ func init() {
         }
       }
     },
+    "change_set": {
+      "description": "The changes that a request made, or would have made, to the database.",
+      "type": "object",
+      "properties": {
+        "added_permissions": {
+          "description": "The permissions that were granted.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/permission"
+          }
+        },
+        "added_resources": {
+          "description": "The resources that were added.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/resource_out"
+          }
+        },
+        "added_subjects": {
+          "description": "The subjects that were added.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/subject_out"
+          }
+        },
+        "removed_permissions": {
+          "description": "The permissions that were revoked.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/permission"
+          }
+        },
+        "removed_resource_types": {
+          "description": "The resource types that were removed.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/resource_type_out"
+          }
+        },
+        "removed_resources": {
+          "description": "The resources that were removed.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/resource_out"
+          }
+        },
+        "removed_subjects": {
+          "description": "The subjects that were removed.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/subject_out"
+          }
+        },
+        "updated_permissions": {
+          "description": "The permissions whose levels were changed.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/permission_update"
+          }
+        }
+      }
+    },
+    "dependency_status": {
+      "type": "object",
+      "required": [
+        "name",
+        "status",
+        "latency_ms"
+      ],
+      "properties": {
+        "error": {
+          "description": "The reason the dependency check failed.",
+          "type": "string"
+        },
+        "latency_ms": {
+          "description": "The amount of time taken to check the dependency, in milliseconds.",
+          "type": "number",
+          "format": "double"
+        },
+        "name": {
+          "description": "The name of the dependency.",
+          "type": "string"
+        },
+        "required": {
+          "description": "True if the service can't handle requests without the dependency.",
+          "type": "boolean"
+        },
+        "status": {
+          "description": "The status of the dependency: ok or error.",
+          "type": "string"
+        }
+      }
+    },
     "error_out": {
       "description": "The standard format for an error response body.",
       "type": "object",
@@ -1132,6 +2119,25 @@ func init() {
       "maxLength": 64,
       "minLength": 1
     },
+    "health_status": {
+      "type": "object",
+      "required": [
+        "status",
+        "dependencies"
+      ],
+      "properties": {
+        "dependencies": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dependency_status"
+          }
+        },
+        "status": {
+          "description": "The overall status of the service: ok, error or shutting_down.",
+          "type": "string"
+        }
+      }
+    },
     "internal_subject_id": {
       "description": "The internal subject identifier.",
       "type": "string",
@@ -1162,6 +2168,30 @@ func init() {
         }
       }
     },
+    "permission_conflict": {
+      "description": "A resource to which both subjects in a merge have been granted permissions.",
+      "type": "object",
+      "required": [
+        "resource",
+        "source_level",
+        "target_level",
+        "resolved_level"
+      ],
+      "properties": {
+        "resolved_level": {
+          "$ref": "#/definitions/permission_level"
+        },
+        "resource": {
+          "$ref": "#/definitions/resource_out"
+        },
+        "source_level": {
+          "$ref": "#/definitions/permission_level"
+        },
+        "target_level": {
+          "$ref": "#/definitions/permission_level"
+        }
+      }
+    },
     "permission_grant_request": {
       "description": "Information for granting permission to a user.",
       "type": "object",
@@ -1226,6 +2256,54 @@ func init() {
         }
       }
     },
+    "permission_update": {
+      "description": "A permission whose level has been changed.",
+      "type": "object",
+      "required": [
+        "permission",
+        "previous_level"
+      ],
+      "properties": {
+        "permission": {
+          "$ref": "#/definitions/permission"
+        },
+        "previous_level": {
+          "$ref": "#/definitions/permission_level"
+        }
+      }
+    },
+    "resource_acl_entry": {
+      "description": "A single entry in the access control list for a resource.",
+      "type": "object",
+      "required": [
+        "subject",
+        "permission_level"
+      ],
+      "properties": {
+        "permission_level": {
+          "$ref": "#/definitions/permission_level"
+        },
+        "subject": {
+          "$ref": "#/definitions/subject_in"
+        }
+      }
+    },
+    "resource_acl_in": {
+      "description": "The complete access control list for a resource.",
+      "type": "object",
+      "required": [
+        "permissions"
+      ],
+      "properties": {
+        "permissions": {
+          "description": "The list of subjects that should have access to the resource and their permission levels.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/resource_acl_entry"
+          }
+        }
+      }
+    },
     "resource_in": {
       "description": "An incoming resource.",
       "type": "object",
@@ -1234,6 +2312,13 @@ func init() {
         "resource_type"
       ],
       "properties": {
+        "labels": {
+          "description": "Arbitrary key/value labels used to categorize the resource, such as the service that owns it or its sensitivity. Label keys consist of letters, digits, hyphens, underscores, periods and slashes, and must begin and end with a letter or digit. Label values follow the same rules, except that slashes aren't allowed and values may be empty. Keys and values are limited to 63 characters and a resource may have at most 64 labels.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
         "name": {
           "description": "The resource name.",
           "type": "string",
@@ -1261,6 +2346,13 @@ func init() {
           "maxLength": 36,
           "minLength": 36
         },
+        "labels": {
+          "description": "The resource labels. This field is omitted if the resource has no labels. Labels aren't included in the resources listed in permissions.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
         "name": {
           "description": "The resource name.",
           "type": "string",
@@ -1339,6 +2431,13 @@ func init() {
         "name"
       ],
       "properties": {
+        "labels": {
+          "description": "The new resource labels, which replace all of the existing labels. The existing labels are kept if this field is omitted.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
         "name": {
           "description": "The new resource name.",
           "type": "string",
@@ -1346,6 +2445,22 @@ func init() {
         }
       }
     },
+    "resources_in": {
+      "description": "An incoming list of resources.",
+      "type": "object",
+      "required": [
+        "resources"
+      ],
+      "properties": {
+        "resources": {
+          "description": "The list of resources.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/resource_in"
+          }
+        }
+      }
+    },
     "resources_out": {
       "description": "A list of resources.",
       "type": "object",
@@ -1403,12 +2518,40 @@ func init() {
         }
       }
     },
-    "subject_out": {
-      "description": "An outgoing subject.",
+    "subject_merge_result": {
+      "description": "The result of merging one subject into another.",
       "type": "object",
       "required": [
-        "id",
-        "subject_id",
+        "subject",
+        "permissions",
+        "conflicts"
+      ],
+      "properties": {
+        "conflicts": {
+          "description": "The resources to which both subjects had been granted permissions.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/permission_conflict"
+          }
+        },
+        "permissions": {
+          "description": "The target subject's permissions to the resources that the source subject had access to.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/permission"
+          }
+        },
+        "subject": {
+          "$ref": "#/definitions/subject_out"
+        }
+      }
+    },
+    "subject_out": {
+      "description": "An outgoing subject.",
+      "type": "object",
+      "required": [
+        "id",
+        "subject_id",
         "subject_type",
         "subject_source_id"
       ],
@@ -1474,6 +2617,21 @@ func init() {
       }
     }
   },
+  "parameters": {
+    "label_selector": {
+      "type": "string",
+      "description": "Limits the results to resources whose labels match the selector: a comma-separated list of requirements, all of which must be met. Each requirement has one of these forms: key=value, key!=value, key in (value1, value2), key notin (value1, value2), key or !key. A resource without the label meets key!=value and key notin requirements. All resources match by default.",
+      "name": "label_selector",
+      "in": "query"
+    },
+    "read_your_writes": {
+      "type": "boolean",
+      "default": false,
+      "description": "True if the lookup should be performed using the primary database even if a read replica is configured. This can be used to ensure that the results reflect changes that were made immediately before the lookup. This header is optional and defaults to False.",
+      "name": "X-Read-Your-Writes",
+      "in": "header"
+    }
+  },
   "responses": {
     "bad_request": {
       "description": "Bad Request",
@@ -1481,6 +2639,12 @@ func init() {
         "$ref": "#/definitions/error_out"
       }
     },
+    "dry_run": {
+      "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+      "schema": {
+        "$ref": "#/definitions/change_set"
+      }
+    },
     "internal_server_error": {
       "description": "Internal Server Error",
       "schema": {
@@ -1492,6 +2656,12 @@ func init() {
       "schema": {
         "$ref": "#/definitions/error_out"
       }
+    },
+    "precondition_failed": {
+      "description": "Precondition Failed",
+      "schema": {
+        "$ref": "#/definitions/error_out"
+      }
     }
   }
 }`))
@@ -1529,6 +2699,294 @@ func init() {
         }
       }
     },
+    "/changes": {
+      "get": {
+        "description": "Lists the permission changes recorded after the given sequence number, oldest first. If no sequence number is given then no changes are listed, and the response contains the latest sequence number so that the client knows where to start. Clients that send an Accept header containing text/event-stream receive a stream of Server-Sent Events instead: each change is sent as a change event whose ID is its sequence number, and an expired event is sent if the client falls too far behind. This endpoint isn't subject to the request timeout.",
+        "produces": [
+          "application/json",
+          "text/event-stream"
+        ],
+        "tags": [
+          "changes"
+        ],
+        "summary": "List Permission Changes",
+        "operationId": "listChanges",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 0,
+            "description": "The sequence number of the last change that the client has seen. Only changes after this one are listed.",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "default": 1000,
+            "maximum": 10000,
+            "minimum": 1,
+            "description": "The maximum number of changes to list.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "The maximum amount of time to wait for a change to arrive if there are no changes after the sequence number, as a Go duration such as 30s. The wait may be at most 5m. Requests don't wait by default.",
+            "name": "wait",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "The sequence number of the last event received by a reconnecting event stream client. This header takes precedence over the since parameter.",
+            "name": "Last-Event-ID",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "object",
+              "required": [
+                "changes",
+                "last_seq"
+              ],
+              "properties": {
+                "changes": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "change_type": {
+                        "description": "The type of change: granted, updated or revoked.",
+                        "type": "string"
+                      },
+                      "changed_at": {
+                        "type": "string",
+                        "format": "date-time"
+                      },
+                      "permission_id": {
+                        "type": "string"
+                      },
+                      "permission_level": {
+                        "type": "string"
+                      },
+                      "resource_name": {
+                        "type": "string"
+                      },
+                      "resource_type": {
+                        "type": "string"
+                      },
+                      "seq": {
+                        "type": "integer",
+                        "format": "int64"
+                      },
+                      "subject_id": {
+                        "type": "string"
+                      },
+                      "subject_type": {
+                        "type": "string"
+                      }
+                    }
+                  }
+                },
+                "last_seq": {
+                  "description": "The sequence number to pass as the since parameter in the next request.",
+                  "type": "integer",
+                  "format": "int64"
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "406": {
+            "description": "Not Acceptable: event streams aren't supported by the server",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "410": {
+            "description": "Gone: the changes after the sequence number are no longer available. The client must reload the permissions that it's interested in and start again from the latest sequence number.",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          }
+        }
+      }
+    },
+    "/export": {
+      "get": {
+        "description": "Streams every resource type, subject, resource and permission in the database, one record per line or row. Resource types are written first, followed by subjects, resources and permissions, so that every record only refers to entities that appear earlier in the file. The response is sent as it's generated, so the connection is closed without completing the response if an error occurs part way through the export.",
+        "produces": [
+          "application/x-ndjson",
+          "text/csv"
+        ],
+        "tags": [
+          "bulk"
+        ],
+        "summary": "Export Permissions",
+        "operationId": "exportPermissions",
+        "parameters": [
+          {
+            "enum": [
+              "jsonl",
+              "csv"
+            ],
+            "type": "string",
+            "default": "jsonl",
+            "description": "The format of the exported file: JSON Lines or CSV.",
+            "name": "format",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          }
+        }
+      }
+    },
+    "/healthz": {
+      "get": {
+        "description": "Determines whether or not the service is alive. This check doesn't depend on any of the databases used by the service, so a database outage won't cause the service to be restarted.",
+        "tags": [
+          "status"
+        ],
+        "summary": "Liveness Check",
+        "operationId": "getHealthz",
+        "responses": {
+          "200": {
+            "description": "Alive",
+            "schema": {
+              "$ref": "#/definitions/health_status"
+            }
+          }
+        }
+      }
+    },
+    "/import": {
+      "post": {
+        "description": "Imports a file in the format produced by the export endpoint in a single transaction. Subjects, resources and resource types that don't exist yet are added, resource type descriptions are updated, and permissions replace any existing permission for the same subject and resource. Nothing is changed if any record is invalid. The response lists the number of records of each kind that were imported.",
+        "consumes": [
+          "application/x-ndjson",
+          "text/csv"
+        ],
+        "tags": [
+          "bulk"
+        ],
+        "summary": "Import Permissions",
+        "operationId": "importPermissions",
+        "parameters": [
+          {
+            "description": "The records to import.",
+            "name": "records",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "enum": [
+              "jsonl",
+              "csv"
+            ],
+            "type": "string",
+            "default": "jsonl",
+            "description": "The format of the imported file: JSON Lines or CSV.",
+            "name": "format",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the import should be validated without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "permissions": {
+                  "type": "integer"
+                },
+                "resource_types": {
+                  "type": "integer"
+                },
+                "resources": {
+                  "type": "integer"
+                },
+                "subjects": {
+                  "type": "integer"
+                }
+              }
+            }
+          },
+          "202": {
+            "description": "Accepted: the file was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "permissions": {
+                  "type": "integer"
+                },
+                "resource_types": {
+                  "type": "integer"
+                },
+                "resources": {
+                  "type": "integer"
+                },
+                "subjects": {
+                  "type": "integer"
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          }
+        }
+      }
+    },
     "/permissions": {
       "get": {
         "description": "Lists all permissions in the permission database. The total number of permissions for all resources is likely to be quite large, so callers should be prepared to handle the response body. If this endpoint is used more frequently than anticipated, limit and offset parameters will be added for paging later.",
@@ -1537,6 +2995,14 @@ func init() {
         ],
         "summary": "List Permissions",
         "operationId": "listPermissions",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Limits the results to resources whose labels match the selector: a comma-separated list of requirements, all of which must be met. Each requirement has one of these forms: key=value, key!=value, key in (value1, value2), key notin (value1, value2), key or !key. A resource without the label meets key!=value and key notin requirements. All resources match by default.",
+            "name": "label_selector",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK",
@@ -1544,6 +3010,12 @@ func init() {
               "$ref": "#/definitions/permission_list"
             }
           },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
           "500": {
             "description": "Internal Server Error",
             "schema": {
@@ -1568,6 +3040,13 @@ func init() {
             "schema": {
               "$ref": "#/definitions/permission_grant_request"
             }
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
           }
         ],
         "responses": {
@@ -1577,6 +3056,12 @@ func init() {
               "$ref": "#/definitions/permission"
             }
           },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
           "400": {
             "description": "Bad Request",
             "schema": {
@@ -1600,6 +3085,21 @@ func init() {
         ],
         "summary": "Look Up Abbreviated Permissions by Subject and Resource Type",
         "operationId": "bySubjectAndResourceTypeAbbreviated",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the lookup should be performed using the primary database even if a read replica is configured. This can be used to ensure that the results reflect changes that were made immediately before the lookup. This header is optional and defaults to False.",
+            "name": "X-Read-Your-Writes",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Limits the results to resources whose labels match the selector: a comma-separated list of requirements, all of which must be met. Each requirement has one of these forms: key=value, key!=value, key in (value1, value2), key notin (value1, value2), key or !key. A resource without the label meets key!=value and key notin requirements. All resources match by default.",
+            "name": "label_selector",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK",
@@ -1680,7 +3180,270 @@ func init() {
           "200": {
             "description": "OK",
             "schema": {
-              "$ref": "#/definitions/permission_list"
+              "$ref": "#/definitions/permission_list"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The current version of the access control list for the resource."
+              }
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "Replaces the access control list for a resource. Subjects in the request body are granted the specified permission levels, and permissions for subjects that aren't listed in the request body are revoked. Subjects and the resource itself are created if they don't exist yet. All changes are applied in a single transaction, and the response body lists the changes that were made. If the If-Match header is specified then the access control list is only replaced if its current version, as reported in the ETag header when the resource permissions are listed, matches the header value.",
+        "tags": [
+          "permissions"
+        ],
+        "summary": "Replace Resource Permissions",
+        "operationId": "replaceResourcePermissions",
+        "parameters": [
+          {
+            "description": "The complete access control list for the resource.",
+            "name": "acl",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/resource_acl_in"
+            }
+          },
+          {
+            "type": "string",
+            "description": "The version of the access control list that the changes are based on.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The updated version of the access control list for the resource."
+              }
+            }
+          },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "412": {
+            "description": "Precondition Failed",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Revokes all permissions that have been granted for a resource in a single transaction. Permissions granted at the own level can optionally be retained. The response body lists the permissions that were revoked.",
+        "tags": [
+          "permissions"
+        ],
+        "summary": "Revoke All Resource Permissions",
+        "operationId": "revokeResourcePermissions",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if permissions granted at the own level should be retained. This parameter is optional and defaults to False.",
+            "name": "exclude_owners",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/permission_list"
+            }
+          },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "The resource type name.",
+          "name": "resource_type",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "The resource name.",
+          "name": "resource_name",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/permissions/resources/{resource_type}/{resource_name}/copy": {
+      "post": {
+        "description": "Copies all permissions that have been granted for one resource to one or more other resources. Destination resources that don't exist yet are created. The merge policy determines what happens when a subject already has permission to access a destination resource: keep-higher retains the more permissive of the two permission levels, overwrite replaces the existing permission level with the permission level from the source resource, and skip-existing leaves the existing permission unchanged.",
+        "tags": [
+          "permissions"
+        ],
+        "summary": "Copy Permissions Between Resources",
+        "operationId": "copyResourcePermissions",
+        "parameters": [
+          {
+            "description": "The destination resources.",
+            "name": "destResources",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/resources_in"
+            }
+          },
+          {
+            "enum": [
+              "keep-higher",
+              "overwrite",
+              "skip-existing"
+            ],
+            "type": "string",
+            "default": "keep-higher",
+            "description": "The policy to use when a subject already has permission to access a destination resource. This parameter is optional and defaults to keep-higher.",
+            "name": "merge_policy",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "The resource type name.",
+          "name": "resource_type",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "The resource name.",
+          "name": "resource_name",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/permissions/resources/{resource_type}/{resource_name}/subjects/{subject_type}/{subject_id}": {
+      "get": {
+        "description": "Returns the permission that has been granted directly to a subject for a resource. Unlike the permission lookup endpoints, this endpoint doesn't take group memberships into account. The current version of the permission is returned in the ETag header.",
+        "tags": [
+          "permissions"
+        ],
+        "summary": "Get a Permission",
+        "operationId": "getPermission",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/permission"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The current version of the permission."
+              }
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/error_out"
             }
           },
           "500": {
@@ -1691,24 +3454,6 @@ func init() {
           }
         }
       },
-      "parameters": [
-        {
-          "type": "string",
-          "description": "The resource type name.",
-          "name": "resource_type",
-          "in": "path",
-          "required": true
-        },
-        {
-          "type": "string",
-          "description": "The resource name.",
-          "name": "resource_name",
-          "in": "path",
-          "required": true
-        }
-      ]
-    },
-    "/permissions/resources/{resource_type}/{resource_name}/subjects/{subject_type}/{subject_id}": {
       "put": {
         "description": "Grants permission to access a resource to a subject. If the subject already has permission to access the resource then the permission level will be updated (assuming the new permission level is different from the existing permission level). Neither the resource nor the subject needs to be registered in the database before this endpoint is called; they will be added to the database if necessary. This endpoint will return an error response if the subject ID is already in use and associated with a different subject type. It will also return an error if either the specified resource type or permission level does not exist.",
         "tags": [
@@ -1725,6 +3470,19 @@ func init() {
             "schema": {
               "$ref": "#/definitions/permission_put_request"
             }
+          },
+          {
+            "type": "string",
+            "description": "The version of the permission that the changes are based on.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
           }
         ],
         "responses": {
@@ -1732,6 +3490,18 @@ func init() {
             "description": "OK",
             "schema": {
               "$ref": "#/definitions/permission"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The updated version of the permission."
+              }
+            }
+          },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
             }
           },
           "400": {
@@ -1740,6 +3510,12 @@ func init() {
               "$ref": "#/definitions/error_out"
             }
           },
+          "412": {
+            "description": "Precondition Failed",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
           "500": {
             "description": "Internal Server Error",
             "schema": {
@@ -1755,16 +3531,43 @@ func init() {
         ],
         "summary": "Revoke Permission to a Resource",
         "operationId": "revokePermission",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The version of the permission that the request is based on.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK"
           },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
           "404": {
             "description": "Not Found",
             "schema": {
               "$ref": "#/definitions/error_out"
             }
           },
+          "412": {
+            "description": "Precondition Failed",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
           "500": {
             "description": "Internal Server Error",
             "schema": {
@@ -1816,6 +3619,22 @@ func init() {
         ],
         "summary": "Look Up by Subject",
         "operationId": "bySubject",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the lookup should be performed using the primary database even if a read replica is configured. This can be used to ensure that the results reflect changes that were made immediately before the lookup. This header is optional and defaults to False.",
+            "name": "X-Read-Your-Writes",
+            "in": "header"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if a permission lookup should be performed. A permission lookup differs from standard permisison retrieval in two ways. First, only the most permissive permission level available to the subject is returned for any given resource. Second, if the subject happens to be a user then permissions granted to groups that the user belongs to are also included in the results. This parameter is optional and defaults to False.",
+            "name": "lookup",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK",
@@ -1837,6 +3656,55 @@ func init() {
           }
         }
       },
+      "delete": {
+        "description": "Revokes all permissions that have been granted directly to a subject in a single transaction. The permissions that are revoked can optionally be limited to a single resource type or to permissions of at least the given minimum permission level. Permissions granted to groups that the subject belongs to are not revoked. The response body lists the permissions that were revoked.",
+        "tags": [
+          "permissions"
+        ],
+        "summary": "Revoke All Subject Permissions",
+        "operationId": "revokeSubjectPermissions",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The name of the resource type to revoke permissions for. Permissions for all resource types are revoked by default.",
+            "name": "resource_type",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/permission_list"
+            }
+          },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          }
+        }
+      },
       "parameters": [
         {
           "enum": [
@@ -1856,13 +3724,6 @@ func init() {
           "in": "path",
           "required": true
         },
-        {
-          "type": "boolean",
-          "default": false,
-          "description": "True if a permission lookup should be performed. A permission lookup differs from standard permisison retrieval in two ways. First, only the most permissive permission level available to the subject is returned for any given resource. Second, if the subject happens to be a user then permissions granted to groups that the user belongs to are also included in the results. This parameter is optional and defaults to False.",
-          "name": "lookup",
-          "in": "query"
-        },
         {
           "enum": [
             "read",
@@ -1894,12 +3755,25 @@ func init() {
             "schema": {
               "$ref": "#/definitions/subjects_in"
             }
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "OK"
           },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
           "400": {
             "description": "Bad Request",
             "schema": {
@@ -1943,6 +3817,21 @@ func init() {
         ],
         "summary": "Look Up by Subject and Resource Type",
         "operationId": "bySubjectAndResourceType",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the lookup should be performed using the primary database even if a read replica is configured. This can be used to ensure that the results reflect changes that were made immediately before the lookup. This header is optional and defaults to False.",
+            "name": "X-Read-Your-Writes",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Limits the results to resources whose labels match the selector: a comma-separated list of requirements, all of which must be met. Each requirement has one of these forms: key=value, key!=value, key in (value1, value2), key notin (value1, value2), key or !key. A resource without the label meets key!=value and key notin requirements. All resources match by default.",
+            "name": "label_selector",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK",
@@ -2019,6 +3908,15 @@ func init() {
         ],
         "summary": "Look Up by Subject and Resource",
         "operationId": "bySubjectAndResource",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the lookup should be performed using the primary database even if a read replica is configured. This can be used to ensure that the results reflect changes that were made immediately before the lookup. This header is optional and defaults to False.",
+            "name": "X-Read-Your-Writes",
+            "in": "header"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK",
@@ -2094,6 +3992,30 @@ func init() {
         }
       ]
     },
+    "/readyz": {
+      "get": {
+        "description": "Determines whether or not the service is ready to handle requests. The databases used by the service are checked, and the status and latency of each check is reported. A 503 status is returned if any required dependency is unavailable.",
+        "tags": [
+          "status"
+        ],
+        "summary": "Readiness Check",
+        "operationId": "getReadyz",
+        "responses": {
+          "200": {
+            "description": "Ready",
+            "schema": {
+              "$ref": "#/definitions/health_status"
+            }
+          },
+          "503": {
+            "description": "Not Ready",
+            "schema": {
+              "$ref": "#/definitions/health_status"
+            }
+          }
+        }
+      }
+    },
     "/resource_types": {
       "get": {
         "description": "Lists resource types known to the permissions service. A resource type represents a class of resources to which permissions may be applied. For example, the Discovery environment has apps collectively defined as a single resource type in the permissions service.",
@@ -2176,12 +4098,25 @@ func init() {
             "name": "resource_type_name",
             "in": "query",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "Deleted"
           },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
           "400": {
             "description": "Bad Request",
             "schema": {
@@ -2204,6 +4139,39 @@ func init() {
       }
     },
     "/resource_types/{id}": {
+      "get": {
+        "description": "Returns information about a single resource type. The current version of the resource type is returned in the ETag header.",
+        "tags": [
+          "resource_types"
+        ],
+        "summary": "Get a Resource Type",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/resource_type_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The current version of the resource type."
+              }
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          }
+        }
+      },
       "put": {
         "description": "Updates the name or description of a resource type. The new name of the resource type must be unique if one is provided.",
         "tags": [
@@ -2219,6 +4187,12 @@ func init() {
             "schema": {
               "$ref": "#/definitions/resource_type_in"
             }
+          },
+          {
+            "type": "string",
+            "description": "The version of the resource type that the changes are based on.",
+            "name": "If-Match",
+            "in": "header"
           }
         ],
         "responses": {
@@ -2226,6 +4200,12 @@ func init() {
             "description": "Updated",
             "schema": {
               "$ref": "#/definitions/resource_type_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The updated version of the resource type."
+              }
             }
           },
           "400": {
@@ -2240,6 +4220,12 @@ func init() {
               "$ref": "#/definitions/error_out"
             }
           },
+          "412": {
+            "description": "Precondition Failed",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
           "500": {
             "description": "Internal Server Error",
             "schema": {
@@ -2254,10 +4240,25 @@ func init() {
           "resource_types"
         ],
         "summary": "Delete a Resource Type",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "Deleted"
           },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
           "400": {
             "description": "Bad Request",
             "schema": {
@@ -2308,6 +4309,12 @@ func init() {
             "description": "The resource name to search for.",
             "name": "resource_name",
             "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Limits the results to resources whose labels match the selector: a comma-separated list of requirements, all of which must be met. Each requirement has one of these forms: key=value, key!=value, key in (value1, value2), key notin (value1, value2), key or !key. A resource without the label meets key!=value and key notin requirements. All resources match by default.",
+            "name": "label_selector",
+            "in": "query"
           }
         ],
         "responses": {
@@ -2317,6 +4324,12 @@ func init() {
               "$ref": "#/definitions/resources_out"
             }
           },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
           "500": {
             "description": "Internal Server Error",
             "schema": {
@@ -2385,12 +4398,25 @@ func init() {
             "name": "resource_name",
             "in": "query",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "OK"
           },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
           "404": {
             "description": "Not Found",
             "schema": {
@@ -2407,6 +4433,40 @@ func init() {
       }
     },
     "/resources/{id}": {
+      "get": {
+        "description": "Returns information about a single resource. The current version of the resource is returned in the ETag header.",
+        "tags": [
+          "resources"
+        ],
+        "summary": "Get a Resource",
+        "operationId": "getResource",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/resource_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The current version of the resource."
+              }
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          }
+        }
+      },
       "put": {
         "description": "Updates a resource in the database.",
         "tags": [
@@ -2423,6 +4483,12 @@ func init() {
             "schema": {
               "$ref": "#/definitions/resource_update"
             }
+          },
+          {
+            "type": "string",
+            "description": "The version of the resource that the changes are based on.",
+            "name": "If-Match",
+            "in": "header"
           }
         ],
         "responses": {
@@ -2430,6 +4496,12 @@ func init() {
             "description": "OK",
             "schema": {
               "$ref": "#/definitions/resource_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The updated version of the resource."
+              }
             }
           },
           "400": {
@@ -2444,6 +4516,12 @@ func init() {
               "$ref": "#/definitions/error_out"
             }
           },
+          "412": {
+            "description": "Precondition Failed",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
           "500": {
             "description": "Internal Server Error",
             "schema": {
@@ -2459,10 +4537,25 @@ func init() {
         ],
         "summary": "Delete a Resource",
         "operationId": "deleteResource",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK"
           },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
           "404": {
             "description": "Not Found",
             "schema": {
@@ -2592,11 +4685,60 @@ func init() {
             "name": "subject_type",
             "in": "query",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
           }
+        }
+      }
+    },
+    "/subjects/{id}": {
+      "get": {
+        "description": "Returns information about a single subject. The current version of the subject is returned in the ETag header.",
+        "tags": [
+          "subjects"
         ],
+        "summary": "Get a Subject",
+        "operationId": "getSubject",
         "responses": {
           "200": {
-            "description": "OK"
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/subject_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The current version of the subject."
+              }
+            }
           },
           "404": {
             "description": "Not Found",
@@ -2611,9 +4753,7 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/subjects/{id}": {
+      },
       "put": {
         "description": "Updates a subject in the database. For full use of the permissions service, the subject should be present in Grouper and have the same subject ID in Grouper and the permissions service.",
         "tags": [
@@ -2630,6 +4770,12 @@ func init() {
             "schema": {
               "$ref": "#/definitions/subject_in"
             }
+          },
+          {
+            "type": "string",
+            "description": "The version of the subject that the changes are based on.",
+            "name": "If-Match",
+            "in": "header"
           }
         ],
         "responses": {
@@ -2637,6 +4783,12 @@ func init() {
             "description": "OK",
             "schema": {
               "$ref": "#/definitions/subject_out"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The updated version of the subject."
+              }
             }
           },
           "400": {
@@ -2651,6 +4803,12 @@ func init() {
               "$ref": "#/definitions/error_out"
             }
           },
+          "412": {
+            "description": "Precondition Failed",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
           "500": {
             "description": "Internal Server Error",
             "schema": {
@@ -2666,10 +4824,88 @@ func init() {
         ],
         "summary": "Delete a Subject",
         "operationId": "deleteSubject",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "OK"
           },
+          "202": {
+            "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+            "schema": {
+              "$ref": "#/definitions/change_set"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "The subject ID.",
+          "name": "id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/subjects/{id}/merge": {
+      "post": {
+        "description": "Folds a subject into the subject with the given external subject ID and subject type. Permissions granted to the source subject are moved to the target subject. If both subjects have been granted permission to the same resource then the most permissive permission level is retained and the resource is listed as a conflict in the response body. The source subject is removed from the database once its permissions have been moved. If the target subject doesn't exist yet then the source subject is simply renamed. If dry run mode is enabled then the response body describes the effect of the merge but no changes are made to the database.",
+        "tags": [
+          "subjects"
+        ],
+        "summary": "Merge a Subject into Another Subject",
+        "operationId": "mergeSubject",
+        "parameters": [
+          {
+            "description": "The subject to merge the source subject into.",
+            "name": "target",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/subject_in"
+            }
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "True if the merge should be previewed without making any changes to the database. This parameter is optional and defaults to False.",
+            "name": "dry_run",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/subject_merge_result"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error_out"
+            }
+          },
           "404": {
             "description": "Not Found",
             "schema": {
@@ -2740,6 +4976,99 @@ func init() {
         }
       }
     },
+    "change_set": {
+      "description": "The changes that a request made, or would have made, to the database.",
+      "type": "object",
+      "properties": {
+        "added_permissions": {
+          "description": "The permissions that were granted.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/permission"
+          }
+        },
+        "added_resources": {
+          "description": "The resources that were added.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/resource_out"
+          }
+        },
+        "added_subjects": {
+          "description": "The subjects that were added.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/subject_out"
+          }
+        },
+        "removed_permissions": {
+          "description": "The permissions that were revoked.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/permission"
+          }
+        },
+        "removed_resource_types": {
+          "description": "The resource types that were removed.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/resource_type_out"
+          }
+        },
+        "removed_resources": {
+          "description": "The resources that were removed.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/resource_out"
+          }
+        },
+        "removed_subjects": {
+          "description": "The subjects that were removed.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/subject_out"
+          }
+        },
+        "updated_permissions": {
+          "description": "The permissions whose levels were changed.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/permission_update"
+          }
+        }
+      }
+    },
+    "dependency_status": {
+      "type": "object",
+      "required": [
+        "name",
+        "status",
+        "latency_ms"
+      ],
+      "properties": {
+        "error": {
+          "description": "The reason the dependency check failed.",
+          "type": "string"
+        },
+        "latency_ms": {
+          "description": "The amount of time taken to check the dependency, in milliseconds.",
+          "type": "number",
+          "format": "double"
+        },
+        "name": {
+          "description": "The name of the dependency.",
+          "type": "string"
+        },
+        "required": {
+          "description": "True if the service can't handle requests without the dependency.",
+          "type": "boolean"
+        },
+        "status": {
+          "description": "The status of the dependency: ok or error.",
+          "type": "string"
+        }
+      }
+    },
     "error_out": {
       "description": "The standard format for an error response body.",
       "type": "object",
@@ -2760,6 +5089,25 @@ func init() {
       "maxLength": 64,
       "minLength": 1
     },
+    "health_status": {
+      "type": "object",
+      "required": [
+        "status",
+        "dependencies"
+      ],
+      "properties": {
+        "dependencies": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dependency_status"
+          }
+        },
+        "status": {
+          "description": "The overall status of the service: ok, error or shutting_down.",
+          "type": "string"
+        }
+      }
+    },
     "internal_subject_id": {
       "description": "The internal subject identifier.",
       "type": "string",
@@ -2790,6 +5138,30 @@ func init() {
         }
       }
     },
+    "permission_conflict": {
+      "description": "A resource to which both subjects in a merge have been granted permissions.",
+      "type": "object",
+      "required": [
+        "resource",
+        "source_level",
+        "target_level",
+        "resolved_level"
+      ],
+      "properties": {
+        "resolved_level": {
+          "$ref": "#/definitions/permission_level"
+        },
+        "resource": {
+          "$ref": "#/definitions/resource_out"
+        },
+        "source_level": {
+          "$ref": "#/definitions/permission_level"
+        },
+        "target_level": {
+          "$ref": "#/definitions/permission_level"
+        }
+      }
+    },
     "permission_grant_request": {
       "description": "Information for granting permission to a user.",
       "type": "object",
@@ -2854,6 +5226,54 @@ func init() {
         }
       }
     },
+    "permission_update": {
+      "description": "A permission whose level has been changed.",
+      "type": "object",
+      "required": [
+        "permission",
+        "previous_level"
+      ],
+      "properties": {
+        "permission": {
+          "$ref": "#/definitions/permission"
+        },
+        "previous_level": {
+          "$ref": "#/definitions/permission_level"
+        }
+      }
+    },
+    "resource_acl_entry": {
+      "description": "A single entry in the access control list for a resource.",
+      "type": "object",
+      "required": [
+        "subject",
+        "permission_level"
+      ],
+      "properties": {
+        "permission_level": {
+          "$ref": "#/definitions/permission_level"
+        },
+        "subject": {
+          "$ref": "#/definitions/subject_in"
+        }
+      }
+    },
+    "resource_acl_in": {
+      "description": "The complete access control list for a resource.",
+      "type": "object",
+      "required": [
+        "permissions"
+      ],
+      "properties": {
+        "permissions": {
+          "description": "The list of subjects that should have access to the resource and their permission levels.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/resource_acl_entry"
+          }
+        }
+      }
+    },
     "resource_in": {
       "description": "An incoming resource.",
       "type": "object",
@@ -2862,6 +5282,13 @@ func init() {
         "resource_type"
       ],
       "properties": {
+        "labels": {
+          "description": "Arbitrary key/value labels used to categorize the resource, such as the service that owns it or its sensitivity. Label keys consist of letters, digits, hyphens, underscores, periods and slashes, and must begin and end with a letter or digit. Label values follow the same rules, except that slashes aren't allowed and values may be empty. Keys and values are limited to 63 characters and a resource may have at most 64 labels.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
         "name": {
           "description": "The resource name.",
           "type": "string",
@@ -2889,6 +5316,13 @@ func init() {
           "maxLength": 36,
           "minLength": 36
         },
+        "labels": {
+          "description": "The resource labels. This field is omitted if the resource has no labels. Labels aren't included in the resources listed in permissions.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
         "name": {
           "description": "The resource name.",
           "type": "string",
@@ -2967,6 +5401,13 @@ func init() {
         "name"
       ],
       "properties": {
+        "labels": {
+          "description": "The new resource labels, which replace all of the existing labels. The existing labels are kept if this field is omitted.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
         "name": {
           "description": "The new resource name.",
           "type": "string",
@@ -2974,6 +5415,22 @@ func init() {
         }
       }
     },
+    "resources_in": {
+      "description": "An incoming list of resources.",
+      "type": "object",
+      "required": [
+        "resources"
+      ],
+      "properties": {
+        "resources": {
+          "description": "The list of resources.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/resource_in"
+          }
+        }
+      }
+    },
     "resources_out": {
       "description": "A list of resources.",
       "type": "object",
@@ -3031,6 +5488,34 @@ func init() {
         }
       }
     },
+    "subject_merge_result": {
+      "description": "The result of merging one subject into another.",
+      "type": "object",
+      "required": [
+        "subject",
+        "permissions",
+        "conflicts"
+      ],
+      "properties": {
+        "conflicts": {
+          "description": "The resources to which both subjects had been granted permissions.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/permission_conflict"
+          }
+        },
+        "permissions": {
+          "description": "The target subject's permissions to the resources that the source subject had access to.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/permission"
+          }
+        },
+        "subject": {
+          "$ref": "#/definitions/subject_out"
+        }
+      }
+    },
     "subject_out": {
       "description": "An outgoing subject.",
       "type": "object",
@@ -3102,6 +5587,21 @@ func init() {
       }
     }
   },
+  "parameters": {
+    "label_selector": {
+      "type": "string",
+      "description": "Limits the results to resources whose labels match the selector: a comma-separated list of requirements, all of which must be met. Each requirement has one of these forms: key=value, key!=value, key in (value1, value2), key notin (value1, value2), key or !key. A resource without the label meets key!=value and key notin requirements. All resources match by default.",
+      "name": "label_selector",
+      "in": "query"
+    },
+    "read_your_writes": {
+      "type": "boolean",
+      "default": false,
+      "description": "True if the lookup should be performed using the primary database even if a read replica is configured. This can be used to ensure that the results reflect changes that were made immediately before the lookup. This header is optional and defaults to False.",
+      "name": "X-Read-Your-Writes",
+      "in": "header"
+    }
+  },
   "responses": {
     "bad_request": {
       "description": "Bad Request",
@@ -3109,6 +5609,12 @@ func init() {
         "$ref": "#/definitions/error_out"
       }
     },
+    "dry_run": {
+      "description": "Accepted: the request was valid but no changes were made because dry run mode was enabled",
+      "schema": {
+        "$ref": "#/definitions/change_set"
+      }
+    },
     "internal_server_error": {
       "description": "Internal Server Error",
       "schema": {
@@ -3120,6 +5626,12 @@ func init() {
       "schema": {
         "$ref": "#/definitions/error_out"
       }
+    },
+    "precondition_failed": {
+      "description": "Precondition Failed",
+      "schema": {
+        "$ref": "#/definitions/error_out"
+      }
     }
   }
 }`))
@@ -0,0 +1,64 @@
+package restapi
+
+import (
+	"net"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/permissionspb"
+	"github.com/cyverse-de/permissions/restapi/impl/rpc"
+)
+
+// grpcService manages the gRPC server, which runs alongside the REST API on a separate port.
+type grpcService struct {
+	server *grpc.Server
+	health *health.Server
+}
+
+// startGRPCServer starts serving the gRPC API on the configured address. Nothing is started if no address is
+// configured. The listener is opened immediately so that an unusable address prevents the service from starting.
+func startGRPCServer(cfg *viper.Viper) (*grpcService, error) {
+	address := cfg.GetString("grpc.address")
+	if address == "" {
+		return nil, nil
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &grpcService{
+		server: grpc.NewServer(grpc.UnaryInterceptor(rpc.UnaryInterceptor(requestTimeout))),
+		health: health.NewServer(),
+	}
+	permissionspb.RegisterPermissionsServer(s.server, rpc.NewServer(store, grouperClient))
+	healthpb.RegisterHealthServer(s.server, s.health)
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil {
+			logger.Log.Errorf("gRPC server failed: %s", err)
+		}
+	}()
+
+	logger.Log.Infof("serving the gRPC API on %s", listener.Addr())
+	return s, nil
+}
+
+// beginShutdown causes the gRPC health check to start failing.
+func (s *grpcService) beginShutdown() {
+	if s != nil {
+		s.health.Shutdown()
+	}
+}
+
+// stop stops accepting calls and waits for in-flight calls to complete.
+func (s *grpcService) stop() {
+	if s != nil {
+		logger.Log.Info("Stopping the gRPC server.")
+		s.server.GracefulStop()
+	}
+}
@@ -0,0 +1,34 @@
+package restapi
+
+import (
+	"net/http"
+
+	errors "github.com/go-openapi/errors"
+	"github.com/go-openapi/loads"
+	httpkit "github.com/go-openapi/runtime"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/restapi/impl/bulk"
+	"github.com/cyverse-de/permissions/restapi/impl/changes"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/operations"
+)
+
+// NewHandler returns a handler that serves the permissions API using the given store and Grouper client, without
+// loading the service configuration or connecting to any databases. This allows the API to be served in-process, for
+// example by test fakes. The readiness check doesn't check any dependencies, and requests aren't subject to a timeout.
+func NewHandler(store permsdb.Store, grouperClient grouper.Grouper) (http.Handler, error) {
+	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	api := operations.NewPermissionsAPI(swaggerSpec)
+	api.ServeError = errors.ServeError
+	api.JSONConsumer = httpkit.JSONConsumer()
+	api.JSONProducer = httpkit.JSONProducer()
+	registerHandlers(api, store, grouperClient, nil, 0)
+
+	feed := changes.NewFeed(store, changes.DefaultPollInterval)
+	return changes.WithEndpoint(bulk.WithEndpoints(api.Serve(setupMiddlewares), store), feed), nil
+}
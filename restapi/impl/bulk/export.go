@@ -0,0 +1,88 @@
+package bulk
+
+import (
+	"database/sql"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// Summary contains the number of records of each kind that were exported or imported.
+type Summary struct {
+	ResourceTypes int64 `json:"resource_types"`
+	Subjects      int64 `json:"subjects"`
+	Resources     int64 `json:"resources"`
+	Permissions   int64 `json:"permissions"`
+}
+
+// ExportTxOptions returns the options used to start export transactions. Exports read several tables one after the
+// other, so they use a read-only transaction in the primary database with an isolation level that ensures that every
+// table is read from the same snapshot.
+func ExportTxOptions() *sql.TxOptions {
+	return &sql.TxOptions{ReadOnly: true, Isolation: sql.LevelRepeatableRead}
+}
+
+// Export writes every resource type, subject, resource and permission in the database. Resource types are written
+// first, followed by subjects, resources and permissions, so that every record only refers to entities that appear
+// earlier in the file.
+func Export(tx permsdb.Tx, w Writer) (*Summary, error) {
+	summary := &Summary{}
+
+	// Export the resource types.
+	err := tx.EachResourceType(func(resourceType *models.ResourceTypeOut) error {
+		summary.ResourceTypes++
+		return w.Write(&Record{
+			Kind:         KindResourceType,
+			ResourceType: *resourceType.Name,
+			Description:  resourceType.Description,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Export the subjects.
+	err = tx.EachSubject(func(subject *models.SubjectOut) error {
+		summary.Subjects++
+		return w.Write(&Record{
+			Kind:        KindSubject,
+			SubjectType: string(*subject.SubjectType),
+			SubjectID:   string(*subject.SubjectID),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Export the resources.
+	err = tx.EachResource(func(resource *models.ResourceOut) error {
+		summary.Resources++
+		return w.Write(&Record{
+			Kind:         KindResource,
+			ResourceType: *resource.ResourceType,
+			ResourceName: *resource.Name,
+			Labels:       resource.Labels,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Export the permissions.
+	err = tx.EachPermission(func(permission *models.Permission) error {
+		summary.Permissions++
+		return w.Write(&Record{
+			Kind:            KindPermission,
+			ResourceType:    *permission.Resource.ResourceType,
+			ResourceName:    *permission.Resource.Name,
+			SubjectType:     string(*permission.Subject.SubjectType),
+			SubjectID:       string(*permission.Subject.SubjectID),
+			PermissionLevel: string(*permission.PermissionLevel),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, w.Flush()
+}
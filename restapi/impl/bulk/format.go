@@ -0,0 +1,258 @@
+// Package bulk exports the contents of the permissions database to, and imports them from, newline-delimited JSON
+// (JSONL) and CSV files. Both formats use the same flat record layout, and records are read and written one at a time
+// so that large databases can be transferred without loading everything into memory.
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// The supported file formats.
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// The kinds of records that can appear in a file.
+const (
+	KindResourceType = "resource_type"
+	KindSubject      = "subject"
+	KindResource     = "resource"
+	KindPermission   = "permission"
+)
+
+// Record is a single entry in an export file. The fields that are used depend on the kind of record: resource type
+// records use the resource type and description, subject records use the subject type and ID, resource records use
+// the resource type, name and labels, and permission records use every field except for the description and labels.
+type Record struct {
+	Kind            string            `json:"kind"`
+	ResourceType    string            `json:"resource_type,omitempty"`
+	ResourceName    string            `json:"resource_name,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	SubjectType     string            `json:"subject_type,omitempty"`
+	SubjectID       string            `json:"subject_id,omitempty"`
+	PermissionLevel string            `json:"permission_level,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+}
+
+// csvHeader lists the CSV columns in the order in which they're written. The labels column is always last.
+var csvHeader = []string{
+	"kind", "resource_type", "resource_name", "description", "subject_type", "subject_id", "permission_level", "labels",
+}
+
+// labelsColumn is the position of the labels column in csvHeader.
+var labelsColumn = len(csvHeader) - 1
+
+// formatLabels formats a set of resource labels for a CSV column as a list of key=value pairs separated by
+// semicolons, sorted by key.
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ";")
+}
+
+// parseLabels parses a set of resource labels from a CSV column. An empty column contains no labels.
+func parseLabels(column string) (map[string]string, error) {
+	if column == "" {
+		return nil, nil
+	}
+
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(column, ";") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid label: %s", pair)
+		}
+		if _, ok := labels[parts[0]]; ok {
+			return nil, fmt.Errorf("duplicate label: %s", parts[0])
+		}
+		labels[parts[0]] = parts[1]
+	}
+	return labels, nil
+}
+
+// fields returns pointers to the string fields of the record in the same order as the CSV columns. The labels aren't
+// included.
+func (r *Record) fields() []*string {
+	return []*string{
+		&r.Kind, &r.ResourceType, &r.ResourceName, &r.Description, &r.SubjectType, &r.SubjectID, &r.PermissionLevel,
+	}
+}
+
+// ContentType returns the MIME type used for a file format.
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+// ValidateFormat returns an error if a file format isn't supported.
+func ValidateFormat(format string) error {
+	if format != FormatJSONL && format != FormatCSV {
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+	return nil
+}
+
+// Writer writes records to a file.
+type Writer interface {
+	Write(record *Record) error
+
+	// Flush writes any buffered data. It must be called after the last record has been written.
+	Flush() error
+}
+
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *jsonlWriter) Write(record *Record) error {
+	return w.encoder.Encode(record)
+}
+
+func (w *jsonlWriter) Flush() error {
+	return nil
+}
+
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvWriter) Write(record *Record) error {
+	if !w.headerWritten {
+		if err := w.writer.Write(csvHeader); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	fields := record.fields()
+	row := make([]string, len(fields), len(fields)+1)
+	for i, field := range fields {
+		row[i] = *field
+	}
+	return w.writer.Write(append(row, formatLabels(record.Labels)))
+}
+
+func (w *csvWriter) Flush() error {
+	if !w.headerWritten {
+		if err := w.writer.Write(csvHeader); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// NewWriter returns a Writer for the given file format.
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case FormatJSONL:
+		return &jsonlWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	default:
+		return nil, ValidateFormat(format)
+	}
+}
+
+// Reader reads records from a file. Read returns io.EOF once every record has been read.
+type Reader interface {
+	Read() (*Record, error)
+}
+
+type jsonlReader struct {
+	decoder *json.Decoder
+}
+
+func (r *jsonlReader) Read() (*Record, error) {
+	var record Record
+	if err := r.decoder.Decode(&record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns []int
+}
+
+// readHeader reads the CSV header and determines which column corresponds to each record field. The columns may
+// appear in any order, and columns for unused fields may be omitted.
+func (r *csvReader) readHeader() error {
+	header, err := r.reader.Read()
+	if err == io.EOF {
+		return fmt.Errorf("the CSV header is missing")
+	}
+	if err != nil {
+		return err
+	}
+
+	// Map each header column to its position in the record.
+	positions := make(map[string]int, len(csvHeader))
+	for i, name := range csvHeader {
+		positions[name] = i
+	}
+	r.columns = make([]int, len(header))
+	for i, name := range header {
+		position, ok := positions[name]
+		if !ok {
+			return fmt.Errorf("unrecognized CSV column: %s", name)
+		}
+		r.columns[i] = position
+	}
+
+	return nil
+}
+
+func (r *csvReader) Read() (*Record, error) {
+	if r.columns == nil {
+		if err := r.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	row, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	var record Record
+	fields := record.fields()
+	for i, value := range row {
+		if r.columns[i] != labelsColumn {
+			*fields[r.columns[i]] = value
+			continue
+		}
+		if record.Labels, err = parseLabels(value); err != nil {
+			return nil, err
+		}
+	}
+	return &record, nil
+}
+
+// NewReader returns a Reader for the given file format.
+func NewReader(r io.Reader, format string) (Reader, error) {
+	switch format {
+	case FormatJSONL:
+		decoder := json.NewDecoder(r)
+		decoder.DisallowUnknownFields()
+		return &jsonlReader{decoder: decoder}, nil
+	case FormatCSV:
+		return &csvReader{reader: csv.NewReader(r)}, nil
+	default:
+		return nil, ValidateFormat(format)
+	}
+}
@@ -0,0 +1,156 @@
+package bulk
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// The paths of the bulk export and import endpoints.
+const (
+	ExportPath = "/export"
+	ImportPath = "/import"
+)
+
+// writeError writes an error response in the same format as the other endpoints.
+func writeError(w http.ResponseWriter, code int, reason string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(&models.ErrorOut{Reason: &reason}) // nolint:errcheck
+}
+
+// requestFormat returns the file format selected by the format query parameter, which defaults to JSONL.
+func requestFormat(r *http.Request) (string, error) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = FormatJSONL
+	}
+	return format, ValidateFormat(format)
+}
+
+// serveExport streams a consistent snapshot of the contents of the database to the client. The response status has
+// already been sent by the time most errors can occur, so the connection is aborted instead in order to prevent the
+// client from mistaking a partial export for a complete one.
+func serveExport(store permsdb.Store, w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.FromContext(ctx)
+
+	// Determine the output format.
+	format, err := requestFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Start a transaction for the export.
+	tx, err := store.BeginTx(ctx, ExportTxOptions())
+	if err != nil {
+		log.Error(err)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	// Stream the export.
+	writer, err := NewWriter(w, format)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", ContentType(format))
+	if _, err := Export(tx, writer); err != nil {
+		log.Errorf("export failed: %s", err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// serveImport imports records from the request body in a single transaction. The transaction is rolled back instead
+// of being committed if the dry_run query parameter is set to true.
+func serveImport(store permsdb.Store, w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.FromContext(ctx)
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	// Determine the input format.
+	format, err := requestFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reader, err := NewReader(r.Body, format)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Start a transaction for the import.
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		log.Error(err)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Import the records.
+	summary, err := Import(tx, reader)
+	if err != nil {
+		tx.Rollback() // nolint:errcheck
+		var invalidRecord *InvalidRecordError
+		if errors.As(err, &invalidRecord) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error(err)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Roll back the transaction if this is a dry run, and commit it otherwise.
+	code := http.StatusOK
+	if dryRun {
+		err = tx.Rollback()
+		code = http.StatusAccepted
+	} else {
+		err = tx.Commit()
+	}
+	if err != nil {
+		tx.Rollback() // nolint:errcheck
+		log.Error(err)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(summary) // nolint:errcheck
+}
+
+// WithEndpoints returns a handler that serves the bulk export and import endpoints and passes all other requests on
+// to the given handler. These endpoints stream their request and response bodies, so they're served outside of the
+// generated API.
+func WithEndpoints(handler http.Handler, store permsdb.Store) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ExportPath:
+			if r.Method != http.MethodGet {
+				w.Header().Set("Allow", http.MethodGet)
+				writeError(w, http.StatusMethodNotAllowed, "method not allowed: "+r.Method)
+				return
+			}
+			serveExport(store, w, r)
+		case ImportPath:
+			if r.Method != http.MethodPost {
+				w.Header().Set("Allow", http.MethodPost)
+				writeError(w, http.StatusMethodNotAllowed, "method not allowed: "+r.Method)
+				return
+			}
+			serveImport(store, w, r)
+		default:
+			handler.ServeHTTP(w, r)
+		}
+	})
+}
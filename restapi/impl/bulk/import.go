@@ -0,0 +1,240 @@
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// InvalidRecordError indicates that an import failed because of a problem with the file being imported rather than
+// a problem with the database.
+type InvalidRecordError struct {
+	Record int
+	Reason string
+}
+
+func (e *InvalidRecordError) Error() string {
+	if e.Record == 0 {
+		return e.Reason
+	}
+	return fmt.Sprintf("record %d: %s", e.Record, e.Reason)
+}
+
+// Importer applies records to a transaction one at a time.
+type Importer struct {
+	tx       permsdb.Tx
+	levelIDs map[string]string
+	summary  *Summary
+}
+
+// NewImporter returns an Importer that applies records to the given transaction.
+func NewImporter(tx permsdb.Tx) *Importer {
+	return &Importer{tx: tx, levelIDs: make(map[string]string), summary: &Summary{}}
+}
+
+// Summary returns the number of records of each kind that have been applied.
+func (im *Importer) Summary() *Summary {
+	return im.summary
+}
+
+// invalid is a helper function used to report invalid records. The record number is filled in by Import.
+func invalid(format string, args ...interface{}) error {
+	return &InvalidRecordError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// requireFields returns an error if any of the named fields is empty. The arguments alternate between field names and
+// values.
+func requireFields(record *Record, namesAndValues ...string) error {
+	for i := 0; i < len(namesAndValues); i += 2 {
+		if namesAndValues[i+1] == "" {
+			return invalid("%s records require %s", record.Kind, namesAndValues[i])
+		}
+	}
+	return nil
+}
+
+// lookupError converts an error returned by one of the shared get-or-add helpers to an InvalidRecordError if it was
+// caused by a problem with the record.
+func lookupError(err error) error {
+	var invalidArgument *permsdb.InvalidArgumentError
+	var notFound *permsdb.NotFoundError
+	if errors.As(err, &invalidArgument) || errors.As(err, &notFound) {
+		return invalid("%s", err)
+	}
+	return err
+}
+
+// validateSubjectType returns an error if a subject type isn't recognized.
+func validateSubjectType(subjectType string) error {
+	if err := models.SubjectType(subjectType).Validate(nil); err != nil {
+		return invalid("invalid subject type: %s", subjectType)
+	}
+	return nil
+}
+
+// upsertResourceType adds a resource type if it doesn't exist yet, or updates its description if it does.
+func (im *Importer) upsertResourceType(record *Record) error {
+	if err := requireFields(record, "resource_type", record.ResourceType); err != nil {
+		return err
+	}
+
+	// Add the resource type if it doesn't exist yet.
+	resourceType, err := im.tx.GetResourceTypeByName(&record.ResourceType)
+	if err != nil {
+		return err
+	}
+	if resourceType == nil {
+		resourceTypeIn := &models.ResourceTypeIn{Name: &record.ResourceType, Description: record.Description}
+		_, err = im.tx.AddNewResourceType(resourceTypeIn)
+		return err
+	}
+
+	// Update the description if it has changed.
+	if resourceType.Description != record.Description {
+		resourceTypeIn := &models.ResourceTypeIn{Name: resourceType.Name, Description: record.Description}
+		_, err = im.tx.UpdateResourceType(resourceType.ID, resourceTypeIn)
+	}
+	return err
+}
+
+// getOrAddSubject looks up a subject, adding it if it doesn't exist yet.
+func (im *Importer) getOrAddSubject(record *Record) (*models.SubjectOut, error) {
+	if err := requireFields(record, "subject_type", record.SubjectType, "subject_id", record.SubjectID); err != nil {
+		return nil, err
+	}
+	if err := validateSubjectType(record.SubjectType); err != nil {
+		return nil, err
+	}
+
+	subjectID := models.ExternalSubjectID(record.SubjectID)
+	subject, _, err := permsdb.GetOrAddSubject(im.tx, subjectID, models.SubjectType(record.SubjectType))
+	if err != nil {
+		return nil, lookupError(err)
+	}
+	return subject, nil
+}
+
+// getOrAddResource looks up a resource, adding it if it doesn't exist yet. The resource type must exist. Resource
+// records also replace the labels of the resource, so a resource record without labels removes them.
+func (im *Importer) getOrAddResource(record *Record) (*models.ResourceOut, error) {
+	err := requireFields(record, "resource_type", record.ResourceType, "resource_name", record.ResourceName)
+	if err != nil {
+		return nil, err
+	}
+	if err := permsdb.ValidateLabels(record.Labels); err != nil {
+		return nil, invalid("%s", err)
+	}
+
+	// Look up the resource, adding it if it doesn't exist yet.
+	resource, _, err := permsdb.GetOrAddResource(im.tx, record.ResourceType, record.ResourceName)
+	if err != nil {
+		return nil, lookupError(err)
+	}
+
+	// Replace the labels. The version of the resource only changes if its labels do.
+	if record.Kind == KindResource {
+		if err := im.tx.SetResourceLabels(resource.ID, record.Labels); err != nil {
+			return nil, err
+		}
+	}
+
+	return resource, nil
+}
+
+// getPermissionLevelID returns the ID of a permission level. Permission level IDs are cached for the duration of the
+// import.
+func (im *Importer) getPermissionLevelID(record *Record) (string, error) {
+	if err := requireFields(record, "permission_level", record.PermissionLevel); err != nil {
+		return "", err
+	}
+	if id, ok := im.levelIDs[record.PermissionLevel]; ok {
+		return id, nil
+	}
+
+	// Look up the permission level.
+	id, err := im.tx.GetPermissionLevelIDByName(models.PermissionLevel(record.PermissionLevel))
+	if err != nil {
+		return "", err
+	}
+	if id == nil {
+		return "", invalid("no permission level named, %s, found", record.PermissionLevel)
+	}
+
+	im.levelIDs[record.PermissionLevel] = *id
+	return *id, nil
+}
+
+// upsertPermission grants a permission to a subject, replacing any existing permission for the same resource.
+func (im *Importer) upsertPermission(record *Record) error {
+	subject, err := im.getOrAddSubject(record)
+	if err != nil {
+		return err
+	}
+
+	resource, err := im.getOrAddResource(record)
+	if err != nil {
+		return err
+	}
+
+	levelID, err := im.getPermissionLevelID(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = im.tx.UpsertPermission(*subject.ID, *resource.ID, levelID)
+	return err
+}
+
+// Apply applies a single record. Problems with the record are reported using an InvalidRecordError.
+func (im *Importer) Apply(record *Record) error {
+	var err error
+	switch record.Kind {
+	case KindResourceType:
+		im.summary.ResourceTypes++
+		err = im.upsertResourceType(record)
+	case KindSubject:
+		im.summary.Subjects++
+		_, err = im.getOrAddSubject(record)
+	case KindResource:
+		im.summary.Resources++
+		_, err = im.getOrAddResource(record)
+	case KindPermission:
+		im.summary.Permissions++
+		err = im.upsertPermission(record)
+	default:
+		err = invalid("unrecognized record kind: %s", record.Kind)
+	}
+	return err
+}
+
+// Import reads records and applies them to the database. Entities that don't exist yet are added, resource type
+// descriptions are updated and permissions replace any existing permission for the same subject and resource, so
+// importing the same file more than once has the same effect as importing it once. Records may refer to entities that
+// are added by earlier records in the same file. Problems with the file being imported are reported using an
+// InvalidRecordError. The caller is responsible for committing or rolling back the transaction.
+func Import(tx permsdb.Tx, r Reader) (*Summary, error) {
+	im := NewImporter(tx)
+
+	for n := 1; ; n++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, &InvalidRecordError{Record: n, Reason: err.Error()}
+		}
+
+		if err := im.Apply(record); err != nil {
+			if e, ok := err.(*InvalidRecordError); ok {
+				e.Record = n
+				return nil, e
+			}
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+	}
+
+	return im.summary, nil
+}
@@ -0,0 +1,124 @@
+// Package changes serves the permission change feed, which allows clients that keep local copies of access control
+// lists to apply changes as they happen instead of listing every permission repeatedly.
+//
+// Every change to a permission is assigned a sequence number when it's committed. Sequence numbers are contiguous and
+// reflect the order in which the changes were committed, so a client only needs to remember the last sequence number
+// that it has seen. The feed is served at GET /changes, either as a JSON document, optionally waiting for changes to
+// arrive (long polling), or as a stream of Server-Sent Events.
+package changes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// Limits on the number of changes returned in a single response.
+const (
+	DefaultLimit = 1000
+	MaxLimit     = 10000
+)
+
+// MaxWait is the longest that a request may wait for changes to arrive.
+const MaxWait = 5 * time.Minute
+
+// DefaultPollInterval is the default amount of time to wait between checks for new changes.
+const DefaultPollInterval = time.Second
+
+// ErrExpired is returned when a client asks for changes after a sequence number that is no longer available, either
+// because the changes have been pruned or because the sequence number is in the future. The client must reload the
+// permissions that it's interested in and start following the feed again from the latest sequence number.
+var ErrExpired = errors.New("the requested changes are no longer available; reload the permissions and start again " +
+	"from the latest sequence number")
+
+// Feed reads permission changes from a store.
+type Feed struct {
+	store        permsdb.Store
+	pollInterval time.Duration
+}
+
+// NewFeed returns a feed that reads changes from the given store, checking for new changes at the given interval
+// while waiting for them to arrive.
+func NewFeed(store permsdb.Store, pollInterval time.Duration) *Feed {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	return &Feed{store: store, pollInterval: pollInterval}
+}
+
+// begin starts a read-only transaction in the primary database. The feed is never read from a replica, because a
+// replica that lags behind the primary database could make a sequence number that a client has already seen appear to
+// be in the future, which would cause a spurious ErrExpired.
+func (f *Feed) begin(ctx context.Context) (permsdb.Tx, error) {
+	return f.store.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+}
+
+// Latest returns the sequence number of the most recent change.
+func (f *Feed) Latest(ctx context.Context) (int64, error) {
+	tx, err := f.begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Commit() // nolint:errcheck
+
+	return tx.LatestChangeSeq()
+}
+
+// Read returns up to limit changes with sequence numbers greater than since. ErrExpired is returned if any of the
+// changes after since are no longer available.
+func (f *Feed) Read(ctx context.Context, since int64, limit int) ([]*permsdb.Change, error) {
+	tx, err := f.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Commit() // nolint:errcheck
+
+	// The latest sequence number has to be read first. Changes may be committed between the two queries, but that
+	// only causes the listing to include changes after the latest sequence number, which is harmless.
+	latest, err := tx.LatestChangeSeq()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := tx.ListChanges(since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that no changes are missing.
+	if since > latest || since < latest && (len(changes) == 0 || changes[0].Seq != since+1) {
+		return nil, ErrExpired
+	}
+
+	return changes, nil
+}
+
+// Wait returns up to limit changes with sequence numbers greater than since, waiting up to the given amount of time
+// for at least one change to arrive. An empty list is returned if no changes arrive in time.
+func (f *Feed) Wait(ctx context.Context, since int64, limit int, wait time.Duration) ([]*permsdb.Change, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		changes, err := f.Read(ctx, since, limit)
+		if err != nil || len(changes) > 0 {
+			return changes, err
+		}
+
+		// Give up if the deadline has passed, and wait until the next check otherwise.
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return changes, nil
+		}
+		if remaining > f.pollInterval {
+			remaining = f.pollInterval
+		}
+		timer := time.NewTimer(remaining)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
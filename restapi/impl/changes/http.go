@@ -0,0 +1,250 @@
+package changes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// Path is the path of the change feed endpoint.
+const Path = "/changes"
+
+// keepAliveInterval is the amount of time between comments sent to keep idle event streams open.
+const keepAliveInterval = 15 * time.Second
+
+// Response is the body of a JSON change feed response. LastSeq is the sequence number to request changes after in the
+// next request.
+type Response struct {
+	Changes []*permsdb.Change `json:"changes"`
+	LastSeq int64             `json:"last_seq"`
+}
+
+// writeError writes an error response in the same format as the other endpoints.
+func writeError(w http.ResponseWriter, code int, reason string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(&models.ErrorOut{Reason: &reason}) // nolint:errcheck
+}
+
+// request contains the parsed query parameters of a change feed request. The since parameter is nil if the client
+// didn't provide one.
+type request struct {
+	since *int64
+	limit int
+	wait  time.Duration
+}
+
+// parseRequest parses the query parameters of a change feed request. For event streams, the standard Last-Event-ID
+// header takes precedence over the since parameter so that clients resume where they left off when they reconnect.
+func parseRequest(r *http.Request) (*request, error) {
+	query := r.URL.Query()
+	req := &request{limit: DefaultLimit}
+
+	since := query.Get("since")
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		since = lastEventID
+	}
+	if since != "" {
+		value, err := strconv.ParseInt(since, 10, 64)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("invalid sequence number: %s", since)
+		}
+		req.since = &value
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 || value > MaxLimit {
+			return nil, fmt.Errorf("the limit must be an integer from 1 to %d: %s", MaxLimit, limit)
+		}
+		req.limit = value
+	}
+
+	if wait := query.Get("wait"); wait != "" {
+		value, err := time.ParseDuration(wait)
+		if err != nil || value < 0 || value > MaxWait {
+			return nil, fmt.Errorf("the wait time must be a duration from 0s to %s: %s", MaxWait, wait)
+		}
+		req.wait = value
+	}
+
+	return req, nil
+}
+
+// wantsEventStream returns true if the client has asked for a stream of Server-Sent Events.
+func wantsEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// serveJSON lists the changes after the requested sequence number, waiting for them to arrive if the client asked to.
+// If no sequence number was given then no changes are listed, and the latest sequence number is returned so that the
+// client knows where to start.
+func (f *Feed) serveJSON(w http.ResponseWriter, r *http.Request, req *request) {
+	ctx := r.Context()
+	log := logger.FromContext(ctx)
+
+	// Determine where to start.
+	if req.since == nil {
+		latest, err := f.Latest(ctx)
+		if err != nil {
+			log.Error(err)
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&Response{Changes: []*permsdb.Change{}, LastSeq: latest}) // nolint:errcheck
+		return
+	}
+
+	// Look up the changes.
+	changes, err := f.Wait(ctx, *req.since, req.limit, req.wait)
+	if errors.Is(err, ErrExpired) {
+		writeError(w, http.StatusGone, err.Error())
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		log.Error(err)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Send the response.
+	lastSeq := *req.since
+	if len(changes) > 0 {
+		lastSeq = changes[len(changes)-1].Seq
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&Response{Changes: changes, LastSeq: lastSeq}) // nolint:errcheck
+}
+
+// serveEvents streams changes to the client as Server-Sent Events until the client disconnects. Each change is sent
+// as a change event whose ID is the sequence number of the change. If the client falls too far behind then an expired
+// event is sent and the stream is closed. The stream starts at the latest change if no sequence number was given.
+func (f *Feed) serveEvents(w http.ResponseWriter, r *http.Request, req *request) {
+	ctx := r.Context()
+	log := logger.FromContext(ctx)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusNotAcceptable, "event streams are not supported by this server")
+		return
+	}
+
+	// Determine where to start.
+	var since int64
+	if req.since != nil {
+		since = *req.since
+	} else {
+		latest, err := f.Latest(ctx)
+		if err != nil {
+			log.Error(err)
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		since = latest
+	}
+
+	// Report expired sequence numbers before the stream starts, when a status code can still be sent.
+	changes, err := f.Read(ctx, since, req.limit)
+	if errors.Is(err, ErrExpired) {
+		writeError(w, http.StatusGone, err.Error())
+		return
+	}
+	if err != nil {
+		log.Error(err)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	lastWrite := time.Now()
+	for {
+		// Send the changes.
+		for _, change := range changes {
+			data, err := json.Marshal(change)
+			if err != nil {
+				log.Error(err)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: change\ndata: %s\n\n", change.Seq, data); err != nil {
+				return
+			}
+			since = change.Seq
+		}
+
+		// Keep the connection open if it has been idle for too long.
+		if len(changes) > 0 {
+			lastWrite = time.Now()
+		} else if time.Since(lastWrite) >= keepAliveInterval {
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			lastWrite = time.Now()
+		}
+		flusher.Flush()
+
+		// Wait for more changes.
+		changes, err = f.Wait(ctx, since, req.limit, keepAliveInterval)
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, ErrExpired) {
+			fmt.Fprintf(w, "event: expired\ndata: %s\n\n", err) // nolint:errcheck
+			flusher.Flush()
+			return
+		}
+		if err != nil {
+			log.Errorf("unable to read permission changes: %s", err)
+			return
+		}
+	}
+}
+
+// ServeHTTP serves the change feed.
+func (f *Feed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed: "+r.Method)
+		return
+	}
+
+	req, err := parseRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if wantsEventStream(r) {
+		f.serveEvents(w, r, req)
+	} else {
+		f.serveJSON(w, r, req)
+	}
+}
+
+// WithEndpoint returns a handler that serves the change feed and passes all other requests on to the given handler.
+// Requests for changes can wait for a long time, so they're served outside of the generated API and aren't subject to
+// the request timeout.
+func WithEndpoint(handler http.Handler, feed *Feed) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == Path {
+			feed.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// The types of permission changes.
+const (
+	ChangeGranted = "granted"
+	ChangeUpdated = "updated"
+	ChangeRevoked = "revoked"
+)
+
+// Change describes a single change to a permission. Sequence numbers are contiguous and are assigned in the order in
+// which the changes were committed. The permission level of a revoked permission is the level it had when it was
+// revoked.
+type Change struct {
+	Seq             int64     `json:"seq"`
+	ChangeType      string    `json:"change_type"`
+	PermissionID    string    `json:"permission_id"`
+	SubjectID       string    `json:"subject_id"`
+	SubjectType     string    `json:"subject_type"`
+	ResourceType    string    `json:"resource_type"`
+	ResourceName    string    `json:"resource_name"`
+	PermissionLevel string    `json:"permission_level"`
+	ChangedAt       time.Time `json:"changed_at"`
+}
+
+// LatestChangeSeq returns the sequence number of the most recently committed permission change, or zero if no changes
+// have been committed.
+func LatestChangeSeq(ctx context.Context, tx *sql.Tx) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "LatestChangeSeq")
+	defer endSpan(span, &err)
+
+	var seq int64
+	err = tx.QueryRowContext(ctx, "SELECT value FROM permission_change_sequence").Scan(&seq)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return seq, err
+}
+
+// ListChanges lists up to limit permission changes with sequence numbers greater than since, in sequence order.
+func ListChanges(ctx context.Context, tx *sql.Tx, since int64, limit int) (_ []*Change, err error) {
+	ctx, span := startSpan(ctx, "ListChanges")
+	defer endSpan(span, &err)
+
+	query := `SELECT seq, change_type, permission_id, subject_id, subject_type, resource_type, resource_name,
+	                 permission_level, changed_at
+	          FROM permission_changes
+	          WHERE seq > $1
+	          ORDER BY seq
+	          LIMIT $2`
+	rows, err := tx.QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := make([]*Change, 0)
+	for rows.Next() {
+		var c Change
+		err := rows.Scan(
+			&c.Seq, &c.ChangeType, &c.PermissionID, &c.SubjectID, &c.SubjectType, &c.ResourceType, &c.ResourceName,
+			&c.PermissionLevel, &c.ChangedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &c)
+	}
+
+	return changes, rows.Err()
+}
+
+// PruneChanges removes permission changes that were made before the given time and returns the number of changes
+// removed. Transactions don't always commit in the order in which they started, so the timestamps of changes aren't
+// strictly increasing. Every change up to the last one made before the cutoff is removed, which ensures that the
+// remaining sequence numbers are still contiguous.
+func PruneChanges(ctx context.Context, tx *sql.Tx, before time.Time) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "PruneChanges")
+	defer endSpan(span, &err)
+
+	stmt := `DELETE FROM permission_changes
+	         WHERE seq <= (SELECT max(seq) FROM permission_changes WHERE changed_at < $1)`
+	result, err := tx.ExecContext(ctx, stmt, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
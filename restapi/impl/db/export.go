@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// eachRow executes a query and calls a function for each row in the result set. Rows are read from the database as
+// they're needed rather than all at once.
+func eachRow(ctx context.Context, tx *sql.Tx, query string, f func(*sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := f(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// EachResourceType calls a function for every resource type, sorted by name.
+func EachResourceType(ctx context.Context, tx *sql.Tx, f func(*models.ResourceTypeOut) error) (err error) {
+	ctx, span := startSpan(ctx, "EachResourceType")
+	defer endSpan(span, &err)
+
+	query := "SELECT id, name, description FROM resource_types ORDER BY name"
+	return eachRow(ctx, tx, query, func(rows *sql.Rows) error {
+		var resourceType models.ResourceTypeOut
+		if err := rows.Scan(&resourceType.ID, &resourceType.Name, &resourceType.Description); err != nil {
+			return err
+		}
+		return f(&resourceType)
+	})
+}
+
+// EachSubject calls a function for every subject, sorted by subject type and external subject ID.
+func EachSubject(ctx context.Context, tx *sql.Tx, f func(*models.SubjectOut) error) (err error) {
+	ctx, span := startSpan(ctx, "EachSubject")
+	defer endSpan(span, &err)
+
+	query := "SELECT id, subject_id, subject_type FROM subjects ORDER BY subject_type, subject_id"
+	return eachRow(ctx, tx, query, func(rows *sql.Rows) error {
+		var subjectDto SubjectDTO
+		if err := rows.Scan(&subjectDto.ID, &subjectDto.SubjectID, &subjectDto.SubjectType); err != nil {
+			return err
+		}
+		return f(subjectDto.ToSubjectOut())
+	})
+}
+
+// LabeledResourceScanner builds resources from rows containing the ID, name and type name of a resource followed by
+// the key and value of one of its labels, which are null if the resource has no labels. The rows for each resource
+// must be adjacent. Each resource is passed to a function once all of its rows have been scanned, so Flush must be
+// called after the last row.
+type LabeledResourceScanner struct {
+	f       func(*models.ResourceOut) error
+	current *models.ResourceOut
+}
+
+// NewLabeledResourceScanner returns a LabeledResourceScanner that passes each resource to the given function.
+func NewLabeledResourceScanner(f func(*models.ResourceOut) error) *LabeledResourceScanner {
+	return &LabeledResourceScanner{f: f}
+}
+
+// Scan scans a single row, passing the previous resource to the function if the row belongs to a different resource.
+func (s *LabeledResourceScanner) Scan(rows *sql.Rows) error {
+	var resource models.ResourceOut
+	var key, value sql.NullString
+	if err := rows.Scan(&resource.ID, &resource.Name, &resource.ResourceType, &key, &value); err != nil {
+		return err
+	}
+
+	// Pass the previous resource to the function if this row belongs to a different one.
+	if s.current != nil && *s.current.ID != *resource.ID {
+		if err := s.Flush(); err != nil {
+			return err
+		}
+	}
+	if s.current == nil {
+		s.current = &resource
+	}
+
+	// Add the label if there is one.
+	if key.Valid {
+		if s.current.Labels == nil {
+			s.current.Labels = make(map[string]string)
+		}
+		s.current.Labels[key.String] = value.String
+	}
+
+	return nil
+}
+
+// Flush passes the resource that's currently being built to the function, if there is one.
+func (s *LabeledResourceScanner) Flush() error {
+	if s.current == nil {
+		return nil
+	}
+	resource := s.current
+	s.current = nil
+	return s.f(resource)
+}
+
+// EachResource calls a function for every resource, sorted by resource type name and resource name. The labels of
+// each resource are included.
+func EachResource(ctx context.Context, tx *sql.Tx, f func(*models.ResourceOut) error) (err error) {
+	ctx, span := startSpan(ctx, "EachResource")
+	defer endSpan(span, &err)
+
+	query := `SELECT r.id, r.name, t.name AS resource_type, l.key, l.value
+	          FROM resources r JOIN resource_types t ON r.resource_type_id = t.id
+	          LEFT JOIN resource_labels l ON r.id = l.resource_id
+	          ORDER BY t.name, r.name, l.key`
+	scanner := NewLabeledResourceScanner(f)
+	if err := eachRow(ctx, tx, query, scanner.Scan); err != nil {
+		return err
+	}
+	return scanner.Flush()
+}
+
+// EachPermission calls a function for every permission granted directly to a subject, sorted by resource type name,
+// resource name, subject type and external subject ID.
+func EachPermission(ctx context.Context, tx *sql.Tx, f func(*models.Permission) error) (err error) {
+	ctx, span := startSpan(ctx, "EachPermission")
+	defer endSpan(span, &err)
+
+	query := `SELECT p.id AS id,
+	                 s.id AS internal_subject_id,
+	                 s.subject_id AS subject_id,
+	                 s.subject_type AS subject_type,
+	                 r.id AS resource_id,
+	                 r.name AS resource_name,
+	                 rt.name AS resource_type,
+	                 pl.name AS permission_level
+	          FROM permissions p
+	          JOIN permission_levels pl ON p.permission_level_id = pl.id
+	          JOIN subjects s ON p.subject_id = s.id
+	          JOIN resources r ON p.resource_id = r.id
+	          JOIN resource_types rt ON r.resource_type_id = rt.id
+	          ORDER BY rt.name, r.name, s.subject_type, s.subject_id`
+	return eachRow(ctx, tx, query, func(rows *sql.Rows) error {
+		var dto PermissionDTO
+		err := rows.Scan(
+			&dto.ID, &dto.InternalSubjectID, &dto.SubjectID, &dto.SubjectType, &dto.ResourceID,
+			&dto.ResourceName, &dto.ResourceType, &dto.PermissionLevel,
+		)
+		if err != nil {
+			return err
+		}
+		return f(dto.ToPermission())
+	})
+}
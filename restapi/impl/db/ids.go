@@ -0,0 +1,50 @@
+package db
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The timestamp embedded in the most recently generated identifier.
+var (
+	idMu        sync.Mutex
+	idTimestamp int64
+)
+
+// NewID generates a UUID containing a timestamp followed by random bits, in the style of version 7 UUIDs. Each new
+// identifier sorts after the previous one so that, as with the time-based identifiers generated by the database,
+// results sorted by identifier are listed in roughly the order in which the entities were created.
+func NewID() string {
+	idMu.Lock()
+	timestamp := time.Now().UnixNano() / int64(time.Millisecond)
+	if timestamp <= idTimestamp {
+		timestamp = idTimestamp + 1
+	}
+	idTimestamp = timestamp
+	idMu.Unlock()
+
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[0:8], uint64(timestamp)<<16)
+	if _, err := rand.Read(b[6:]); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x70
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ParseID validates an identifier and converts it to its canonical, lower-case form. Identifiers are compared without
+// regard to case, just as they are in PostgreSQL.
+func ParseID(id string) (string, error) {
+	if !uuidPattern.MatchString(id) {
+		return "", fmt.Errorf("invalid input syntax for type uuid: \"%s\"", id)
+	}
+	return strings.ToLower(id), nil
+}
@@ -0,0 +1,32 @@
+package db
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestIDsAreOrdered(t *testing.T) {
+	ids := make([]string, 1000)
+	for i := range ids {
+		ids[i] = NewID()
+		if _, err := ParseID(ids[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !sort.StringsAreSorted(ids) {
+		t.Error("identifiers were not generated in sorted order")
+	}
+}
+
+func TestParseID(t *testing.T) {
+	id, err := ParseID("1A960034-969A-46A7-B6B5-3F1866258CAB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "1a960034-969a-46a7-b6b5-3f1866258cab" {
+		t.Errorf("unexpected canonical identifier: %s", id)
+	}
+	if _, err := ParseID("not-a-uuid"); err == nil {
+		t.Error("expected an invalid identifier to be rejected")
+	}
+}
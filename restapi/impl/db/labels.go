@@ -0,0 +1,262 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Limits on resource labels.
+const (
+	MaxLabels           = 64
+	MaxLabelKeyLength   = 63
+	MaxLabelValueLength = 63
+)
+
+// Label keys consist of letters, digits, hyphens, underscores, periods and slashes, and must begin and end with a
+// letter or digit. Label values follow the same rules except that slashes aren't allowed and values may be empty.
+// Keeping these characters out of labels allows selectors to be parsed without any quoting rules.
+var (
+	labelKeyPattern   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+	labelValuePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?)?$`)
+)
+
+// validateLabelKey returns an error if a string isn't a valid label key.
+func validateLabelKey(key string) error {
+	if len(key) > MaxLabelKeyLength || !labelKeyPattern.MatchString(key) {
+		return fmt.Errorf(
+			"invalid label key, '%s': keys must be 1 to %d letters, digits, hyphens, underscores, periods or "+
+				"slashes beginning and ending with a letter or digit", key, MaxLabelKeyLength,
+		)
+	}
+	return nil
+}
+
+// validateLabelValue returns an error if a string isn't a valid label value.
+func validateLabelValue(value string) error {
+	if len(value) > MaxLabelValueLength || !labelValuePattern.MatchString(value) {
+		return fmt.Errorf(
+			"invalid label value, '%s': values must be at most %d letters, digits, hyphens, underscores or "+
+				"periods beginning and ending with a letter or digit", value, MaxLabelValueLength,
+		)
+	}
+	return nil
+}
+
+// ValidateLabels returns an error if a set of resource labels contains an invalid key or value or too many labels.
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("a resource may have at most %d labels", MaxLabels)
+	}
+
+	// Sort the keys so that the same error is reported every time.
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := validateLabelKey(key); err != nil {
+			return err
+		}
+		if err := validateLabelValue(labels[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SameLabels returns true if two sets of resource labels are identical. Nil and empty sets are considered identical.
+func SameLabels(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// The operators that can appear in label requirements. Equality and inequality requirements are represented as set
+// requirements with a single value.
+const (
+	LabelIn           = "in"
+	LabelNotIn        = "notin"
+	LabelExists       = "exists"
+	LabelDoesNotExist = "!"
+)
+
+// LabelRequirement is a single condition in a label selector.
+type LabelRequirement struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// Matches returns true if a set of resource labels satisfies the requirement. As with inequality requirements, a
+// resource without the label satisfies a notin requirement.
+func (r *LabelRequirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case LabelExists:
+		return ok
+	case LabelDoesNotExist:
+		return !ok
+	case LabelIn:
+		return ok && containsString(r.Values, value)
+	case LabelNotIn:
+		return !ok || !containsString(r.Values, value)
+	default:
+		return false
+	}
+}
+
+// condition returns a SQL condition that is true for resources that satisfy the requirement. The resource ID column
+// is the column in the enclosing query that contains the ID of the resource being tested.
+func (r *LabelRequirement) condition(resourceIDColumn string) sq.Sqlizer {
+	query := fmt.Sprintf("SELECT 1 FROM resource_labels l WHERE l.resource_id = %s AND l.key = ?", resourceIDColumn)
+	args := []interface{}{r.Key}
+	if len(r.Values) > 0 {
+		query += " AND l.value IN (" + sq.Placeholders(len(r.Values)) + ")"
+		for _, value := range r.Values {
+			args = append(args, value)
+		}
+	}
+
+	if r.Operator == LabelNotIn || r.Operator == LabelDoesNotExist {
+		return sq.Expr("NOT EXISTS ("+query+")", args...)
+	}
+	return sq.Expr("EXISTS ("+query+")", args...)
+}
+
+// LabelSelector filters resources by their labels. A resource matches a selector if it satisfies every requirement
+// in the selector, so an empty selector matches every resource.
+type LabelSelector []*LabelRequirement
+
+// Matches returns true if a set of resource labels satisfies every requirement in the selector.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Condition returns a SQL condition that is true for resources that match the selector, or nil if the selector is
+// empty. The condition refers to the resource_labels table and uses question mark placeholders, so it can be used
+// in queries built for either PostgreSQL or SQLite.
+func (s LabelSelector) Condition(resourceIDColumn string) sq.Sqlizer {
+	if len(s) == 0 {
+		return nil
+	}
+	conditions := make(sq.And, len(s))
+	for i, r := range s {
+		conditions[i] = r.condition(resourceIDColumn)
+	}
+	return conditions
+}
+
+// setRequirementPattern matches set-based requirements such as "env in (prod, qa)".
+var setRequirementPattern = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\(([^()]*)\)$`)
+
+// ParseLabelSelector parses a comma-separated list of label requirements. Each requirement has one of these forms:
+//
+//	key=value, key==value   the resource has the label with the given value
+//	key!=value              the resource doesn't have the label with the given value
+//	key in (v1, v2)         the resource has the label with one of the given values
+//	key notin (v1, v2)      the resource doesn't have the label with any of the given values
+//	key                     the resource has the label
+//	!key                    the resource doesn't have the label
+//
+// A nil selector is returned if the string is empty.
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+
+	var result LabelSelector
+	for _, requirement := range splitLabelSelector(selector) {
+		r, err := parseLabelRequirement(strings.TrimSpace(requirement))
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector, '%s': %s", selector, err)
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// splitLabelSelector splits a label selector into requirements at the commas that aren't enclosed in parentheses.
+func splitLabelSelector(selector string) []string {
+	var requirements []string
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				requirements = append(requirements, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(requirements, selector[start:])
+}
+
+// parseLabelRequirement parses a single label requirement.
+func parseLabelRequirement(requirement string) (*LabelRequirement, error) {
+	if requirement == "" {
+		return nil, fmt.Errorf("empty requirement")
+	}
+
+	// Determine the key, operator and values.
+	var r *LabelRequirement
+	if m := setRequirementPattern.FindStringSubmatch(requirement); m != nil {
+		values := strings.Split(m[3], ",")
+		for i, value := range values {
+			values[i] = strings.TrimSpace(value)
+		}
+		r = &LabelRequirement{Key: m[1], Operator: m[2], Values: values}
+	} else if strings.HasPrefix(requirement, "!") && !strings.Contains(requirement, "=") {
+		r = &LabelRequirement{Key: strings.TrimSpace(requirement[1:]), Operator: LabelDoesNotExist}
+	} else if i := strings.Index(requirement, "!="); i >= 0 {
+		value := strings.TrimSpace(requirement[i+2:])
+		r = &LabelRequirement{Key: strings.TrimSpace(requirement[:i]), Operator: LabelNotIn, Values: []string{value}}
+	} else if i := strings.Index(requirement, "="); i >= 0 {
+		value := strings.TrimSpace(strings.TrimPrefix(requirement[i+1:], "="))
+		r = &LabelRequirement{Key: strings.TrimSpace(requirement[:i]), Operator: LabelIn, Values: []string{value}}
+	} else {
+		r = &LabelRequirement{Key: requirement, Operator: LabelExists}
+	}
+
+	// Validate the key and values.
+	if err := validateLabelKey(r.Key); err != nil {
+		return nil, err
+	}
+	for _, value := range r.Values {
+		if err := validateLabelValue(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// containsString returns true if a slice contains the given string.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	labels := map[string]string{"env": "prod", "team": "de", "example.org/tier": "1"}
+	for selector, expected := range map[string]bool{
+		"":                             true,
+		"env=prod":                     true,
+		"env==prod":                    true,
+		"env = qa":                     false,
+		"env!=qa":                      true,
+		"env!=prod":                    false,
+		"missing!=prod":                true,
+		"env in (qa, prod)":            true,
+		"env notin (qa,prod)":          false,
+		"missing notin (qa)":           true,
+		"team":                         true,
+		"!team":                        false,
+		"!missing":                     true,
+		"env=prod,team=de":             true,
+		"env in (prod), team in (qa)":  false,
+		"example.org/tier=1, !missing": true,
+	} {
+		s, err := ParseLabelSelector(selector)
+		if err != nil {
+			t.Errorf("unable to parse %q: %s", selector, err)
+			continue
+		}
+		if actual := s.Matches(labels); actual != expected {
+			t.Errorf("unexpected result for %q: %t", selector, actual)
+		}
+	}
+}
+
+func TestParseInvalidLabelSelector(t *testing.T) {
+	for _, selector := range []string{"env=prod,", "=prod", "env in (prod", "-env", "env=a b", "env=prod/qa"} {
+		if _, err := ParseLabelSelector(selector); err == nil {
+			t.Errorf("expected %q to be rejected", selector)
+		}
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	if err := ValidateLabels(map[string]string{"example.org/env": "prod", "empty": ""}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	for _, labels := range []map[string]string{{"": "x"}, {"env": "a/b"}, {"-env": "prod"}, {"env": "prod-"}} {
+		if err := ValidateLabels(labels); err == nil {
+			t.Errorf("expected %v to be rejected", labels)
+		}
+	}
+}
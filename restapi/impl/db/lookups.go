@@ -0,0 +1,91 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// InvalidArgumentError indicates that an operation failed because of a problem with its arguments rather than a
+// problem with the database.
+type InvalidArgumentError struct {
+	Reason string
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return e.Reason
+}
+
+// NotFoundError indicates that an operation failed because an entity that it refers to doesn't exist.
+type NotFoundError struct {
+	Reason string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Reason
+}
+
+// GetOrAddSubject looks up a subject, adding it if it doesn't exist yet. The second return value is true if the
+// subject was added. An InvalidArgumentError is returned if the subject ID is empty or another subject with the same
+// ID already exists; any other error is a problem with the database.
+func GetOrAddSubject(
+	tx Tx, subjectID models.ExternalSubjectID, subjectType models.SubjectType,
+) (*models.SubjectOut, bool, error) {
+	if subjectID == "" {
+		return nil, false, &InvalidArgumentError{Reason: "subject ID is required"}
+	}
+
+	// Attempt to look up the subject.
+	subject, err := tx.GetSubject(subjectID, subjectType)
+	if err != nil || subject != nil {
+		return subject, false, err
+	}
+
+	// Make sure that another subject with the same ID doesn't exist already.
+	exists, err := tx.SubjectIDExists(subjectID)
+	if err != nil {
+		return nil, false, err
+	}
+	if exists {
+		reason := fmt.Sprintf("another subject with ID, %s, already exists", string(subjectID))
+		return nil, false, &InvalidArgumentError{Reason: reason}
+	}
+
+	// Add the subject.
+	subject, err = tx.AddSubject(subjectID, subjectType)
+	if err != nil {
+		return nil, false, err
+	}
+	return subject, true, nil
+}
+
+// GetOrAddResource looks up a resource, adding it if it doesn't exist yet. The second return value is true if the
+// resource was added. An InvalidArgumentError is returned if the resource name is empty, and a NotFoundError is
+// returned if the resource type doesn't exist; any other error is a problem with the database.
+func GetOrAddResource(tx Tx, resourceTypeName, name string) (*models.ResourceOut, bool, error) {
+	if name == "" {
+		return nil, false, &InvalidArgumentError{Reason: "resource name is required"}
+	}
+
+	// Look up the resource type.
+	resourceType, err := tx.GetResourceTypeByName(&resourceTypeName)
+	if err != nil {
+		return nil, false, err
+	}
+	if resourceType == nil {
+		return nil, false, &NotFoundError{Reason: fmt.Sprintf("no resource type named, %s, found", resourceTypeName)}
+	}
+
+	// Attempt to look up the resource.
+	resource, err := tx.GetResourceByName(&name, resourceType.ID)
+	if err != nil || resource != nil {
+		return resource, false, err
+	}
+
+	// Add the resource.
+	resource, err = tx.AddResource(&name, resourceType.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	return resource, true, nil
+}
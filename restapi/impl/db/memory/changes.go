@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// permissionState describes a permission in a version of the data, using the same fields as a change record.
+type permissionState struct {
+	seq    int64
+	change permsdb.Change
+}
+
+// sameTarget returns true if two permission states refer to the same subject and resource.
+func (s *permissionState) sameTarget(other *permissionState) bool {
+	return s.change.SubjectID == other.change.SubjectID && s.change.SubjectType == other.change.SubjectType &&
+		s.change.ResourceType == other.change.ResourceType && s.change.ResourceName == other.change.ResourceName
+}
+
+// permissionStates returns the state of every permission, sorted in the order in which the permissions were inserted.
+func (d *data) permissionStates() []*permissionState {
+	states := make([]*permissionState, 0, len(d.permissions))
+	for _, p := range d.permissions {
+		s := d.subjects[p.subjectID]
+		r := d.resources[p.resourceID]
+		states = append(states, &permissionState{
+			seq: p.seq,
+			change: permsdb.Change{
+				PermissionID:    p.id,
+				SubjectID:       s.subjectID,
+				SubjectType:     s.subjectType,
+				ResourceType:    d.resourceTypes[r.resourceTypeID].name,
+				ResourceName:    r.name,
+				PermissionLevel: d.permissionLevels[p.permissionLevelID].name,
+			},
+		})
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].seq < states[j].seq })
+	return states
+}
+
+// addChange appends a change to the change log.
+func (d *data) addChange(changeType string, state *permissionState, now time.Time) {
+	d.changeSeq++
+	change := state.change
+	change.Seq = d.changeSeq
+	change.ChangeType = changeType
+	change.ChangedAt = now
+	d.changes = append(d.changes, &change)
+}
+
+// recordChanges compares the permissions in two versions of the data and records the differences in the change log of
+// the newer version. A permission whose subject or resource was renamed is recorded as a revocation followed by a
+// grant, in the same way as the other stores.
+func recordChanges(before, after *data, now time.Time) {
+	beforeStates := before.permissionStates()
+	afterStates := after.permissionStates()
+
+	// Index both versions of the permissions.
+	beforeByID := make(map[string]*permissionState, len(beforeStates))
+	for _, state := range beforeStates {
+		beforeByID[state.change.PermissionID] = state
+	}
+	afterByID := make(map[string]*permissionState, len(afterStates))
+	for _, state := range afterStates {
+		afterByID[state.change.PermissionID] = state
+	}
+
+	// Record revoked and updated permissions.
+	for _, old := range beforeStates {
+		current, ok := afterByID[old.change.PermissionID]
+		switch {
+		case !ok || !old.sameTarget(current):
+			after.addChange(permsdb.ChangeRevoked, old, now)
+		case old.change.PermissionLevel != current.change.PermissionLevel:
+			after.addChange(permsdb.ChangeUpdated, current, now)
+		}
+	}
+
+	// Record granted permissions.
+	for _, current := range afterStates {
+		old, ok := beforeByID[current.change.PermissionID]
+		if !ok || !old.sameTarget(current) {
+			after.addChange(permsdb.ChangeGranted, current, now)
+		}
+	}
+}
+
+// LatestChangeSeq returns the sequence number of the most recently committed permission change, or zero if no changes
+// have been committed.
+func (t *tx) LatestChangeSeq() (int64, error) {
+	return t.data.changeSeq, nil
+}
+
+// ListChanges lists up to limit permission changes with sequence numbers greater than since, in sequence order.
+func (t *tx) ListChanges(since int64, limit int) ([]*permsdb.Change, error) {
+	start := sort.Search(len(t.data.changes), func(i int) bool { return t.data.changes[i].Seq > since })
+	end := len(t.data.changes)
+	if end-start > limit {
+		end = start + limit
+	}
+
+	changes := make([]*permsdb.Change, end-start)
+	for i, change := range t.data.changes[start:end] {
+		copied := *change
+		changes[i] = &copied
+	}
+	return changes, nil
+}
+
+// PruneChanges removes every permission change up to the last one made before the given time and returns the number
+// of changes removed.
+func (t *tx) PruneChanges(before time.Time) (int64, error) {
+	count := 0
+	for i, change := range t.data.changes {
+		if change.ChangedAt.Before(before) {
+			count = i + 1
+		}
+	}
+	t.data.changes = t.data.changes[count:]
+	return int64(count), nil
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// EachResourceType calls a function for every resource type, sorted by name.
+func (t *tx) EachResourceType(f func(*models.ResourceTypeOut) error) error {
+	resourceTypes := t.sortedResourceTypes()
+	sort.SliceStable(resourceTypes, func(i, j int) bool { return resourceTypes[i].name < resourceTypes[j].name })
+	for _, rt := range resourceTypes {
+		if err := f(rt.toResourceTypeOut()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EachSubject calls a function for every subject, sorted by subject type and external subject ID.
+func (t *tx) EachSubject(f func(*models.SubjectOut) error) error {
+	subjects, err := t.ListSubjects(nil, nil)
+	if err != nil {
+		return err
+	}
+	for _, s := range subjects {
+		if err := f(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EachResource calls a function for every resource, sorted by resource type name and resource name.
+func (t *tx) EachResource(f func(*models.ResourceOut) error) error {
+	resources := t.sortedResources()
+	sort.SliceStable(resources, func(i, j int) bool {
+		iType := t.data.resourceTypes[resources[i].resourceTypeID].name
+		jType := t.data.resourceTypes[resources[j].resourceTypeID].name
+		if iType != jType {
+			return iType < jType
+		}
+		return resources[i].name < resources[j].name
+	})
+	for _, r := range resources {
+		if err := f(t.toResourceOut(r)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EachPermission calls a function for every permission granted directly to a subject, sorted by resource type name,
+// resource name, subject type and external subject ID.
+func (t *tx) EachPermission(f func(*models.Permission) error) error {
+	rows := t.permissionRows(func(*permissionRow) bool { return true })
+	sort.SliceStable(rows, func(i, j int) bool {
+		switch {
+		case rows[i].resourceType.name != rows[j].resourceType.name:
+			return rows[i].resourceType.name < rows[j].resourceType.name
+		case rows[i].resource.name != rows[j].resource.name:
+			return rows[i].resource.name < rows[j].resource.name
+		case rows[i].subject.subjectType != rows[j].subject.subjectType:
+			return subjectTypeOrder[rows[i].subject.subjectType] < subjectTypeOrder[rows[j].subject.subjectType]
+		default:
+			return rows[i].subject.subjectID < rows[j].subject.subjectID
+		}
+	})
+	for _, row := range rows {
+		if err := f(row.toPermission()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,589 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// permissionRow is a permission along with its subject, resource, resource type and permission level.
+type permissionRow struct {
+	permission   *permission
+	subject      *subject
+	resource     *resource
+	resourceType *resourceType
+	level        *permissionLevel
+}
+
+func (row *permissionRow) toPermission() *models.Permission {
+	id := models.PermissionID(row.permission.id)
+	level := models.PermissionLevel(row.level.name)
+	return &models.Permission{
+		ID:              &id,
+		PermissionLevel: &level,
+		Resource: &models.ResourceOut{
+			ID:           stringPtr(row.resource.id),
+			Name:         stringPtr(row.resource.name),
+			ResourceType: stringPtr(row.resourceType.name),
+		},
+		Subject: row.subject.toSubjectOut(),
+	}
+}
+
+func (row *permissionRow) toAbbreviatedPermission() *models.AbbreviatedPermission {
+	id := models.PermissionID(row.permission.id)
+	level := models.PermissionLevel(row.level.name)
+	return &models.AbbreviatedPermission{
+		ID:              &id,
+		PermissionLevel: &level,
+		ResourceName:    stringPtr(row.resource.name),
+		ResourceType:    stringPtr(row.resourceType.name),
+	}
+}
+
+// toPermissionList converts a list of permission rows to a list of permissions.
+func toPermissionList(rows []*permissionRow) []*models.Permission {
+	permissions := make([]*models.Permission, len(rows))
+	for i, row := range rows {
+		permissions[i] = row.toPermission()
+	}
+	return permissions
+}
+
+// permissionRows returns the permissions that satisfy a filter in the order in which they were inserted.
+func (t *tx) permissionRows(filter func(*permissionRow) bool) []*permissionRow {
+	rows := make([]*permissionRow, 0)
+	for _, p := range t.data.permissions {
+		r := t.data.resources[p.resourceID]
+		row := &permissionRow{
+			permission:   p,
+			subject:      t.data.subjects[p.subjectID],
+			resource:     r,
+			resourceType: t.data.resourceTypes[r.resourceTypeID],
+			level:        t.data.permissionLevels[p.permissionLevelID],
+		}
+		if filter(row) {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].permission.seq < rows[j].permission.seq })
+	return rows
+}
+
+// sortBySubjectID sorts a list of permission rows by external subject ID.
+func sortBySubjectID(rows []*permissionRow) {
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].subject.subjectID < rows[j].subject.subjectID })
+}
+
+// sortByResource sorts a list of permission rows by resource type name and resource name.
+func sortByResource(rows []*permissionRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].resourceType.name != rows[j].resourceType.name {
+			return rows[i].resourceType.name < rows[j].resourceType.name
+		}
+		return rows[i].resource.name < rows[j].resource.name
+	})
+}
+
+// levelByName returns the permission level with the given name or nil if there is no such permission level.
+func (t *tx) levelByName(name string) *permissionLevel {
+	for _, level := range t.data.permissionLevels {
+		if level.name == name {
+			return level
+		}
+	}
+	return nil
+}
+
+// atLeast returns a filter that matches permissions that are at least as permissive as the named permission level. If
+// the permission level doesn't exist then the filter doesn't match any permissions.
+func (t *tx) atLeast(minLevel string) func(*permissionRow) bool {
+	level := t.levelByName(minLevel)
+	return func(row *permissionRow) bool {
+		return level != nil && row.level.precedence <= level.precedence
+	}
+}
+
+// subjectIn returns a filter that matches permissions granted to any of the subjects with the given external IDs.
+func subjectIn(subjectIDs []string) func(*permissionRow) bool {
+	wanted := make(map[string]bool, len(subjectIDs))
+	for _, subjectID := range subjectIDs {
+		wanted[subjectID] = true
+	}
+	return func(row *permissionRow) bool {
+		return wanted[row.subject.subjectID]
+	}
+}
+
+// all combines several filters into a single filter that only matches permissions that satisfy all of them.
+func all(filters ...func(*permissionRow) bool) func(*permissionRow) bool {
+	return func(row *permissionRow) bool {
+		for _, filter := range filters {
+			if !filter(row) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// mostPermissive selects the most permissive of the permissions that satisfy a filter for each resource. The results
+// are sorted by resource ID.
+func (t *tx) mostPermissive(filter func(*permissionRow) bool) []*permissionRow {
+	selected := make(map[string]*permissionRow)
+	for _, row := range t.permissionRows(filter) {
+		current := selected[row.resource.id]
+		if current == nil || row.level.precedence < current.level.precedence {
+			selected[row.resource.id] = row
+		}
+	}
+
+	// Sort the selected permissions by resource ID.
+	rows := make([]*permissionRow, 0, len(selected))
+	for _, row := range selected {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].resource.id < rows[j].resource.id })
+
+	return rows
+}
+
+// ListPermissions lists all existing permissions for resources that match the label selector.
+func (t *tx) ListPermissions(selector permsdb.LabelSelector) ([]*models.Permission, error) {
+	rows := t.permissionRows(labelsMatch(selector))
+	sort.SliceStable(rows, func(i, j int) bool {
+		switch {
+		case rows[i].subject.subjectID != rows[j].subject.subjectID:
+			return rows[i].subject.subjectID < rows[j].subject.subjectID
+		case rows[i].resource.name != rows[j].resource.name:
+			return rows[i].resource.name < rows[j].resource.name
+		default:
+			return rows[i].level.precedence < rows[j].level.precedence
+		}
+	})
+	return toPermissionList(rows), nil
+}
+
+// ListResourcePermissions lists permissions associated with a specific resource.
+func (t *tx) ListResourcePermissions(resourceTypeName, resourceName string) ([]*models.Permission, error) {
+	rows := t.permissionRows(func(row *permissionRow) bool {
+		return row.resourceType.name == resourceTypeName && row.resource.name == resourceName
+	})
+	sortBySubjectID(rows)
+	return toPermissionList(rows), nil
+}
+
+// ListSubjectPermissions lists permissions granted directly to the subject with the given internal ID.
+func (t *tx) ListSubjectPermissions(id models.InternalSubjectID) ([]*models.Permission, error) {
+	return t.FindSubjectPermissions(id, nil, nil)
+}
+
+// FindSubjectPermissions lists permissions granted directly to the subject with the given internal ID, optionally
+// limiting the results to a single resource type or to a minimum permission level.
+func (t *tx) FindSubjectPermissions(
+	id models.InternalSubjectID, resourceTypeName, minLevel *string,
+) ([]*models.Permission, error) {
+	key, err := permsdb.ParseID(string(id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the filter.
+	filters := []func(*permissionRow) bool{
+		func(row *permissionRow) bool { return row.subject.id == key },
+	}
+	if resourceTypeName != nil {
+		filters = append(filters, func(row *permissionRow) bool { return row.resourceType.name == *resourceTypeName })
+	}
+	if minLevel != nil {
+		filters = append(filters, t.atLeast(*minLevel))
+	}
+
+	rows := t.permissionRows(all(filters...))
+	sortByResource(rows)
+	return toPermissionList(rows), nil
+}
+
+// FindResourcePermissions lists permissions granted for the resource with the given ID, optionally excluding
+// permissions granted at the given permission level.
+func (t *tx) FindResourcePermissions(
+	resourceID string, excludedLevel *models.PermissionLevel,
+) ([]*models.Permission, error) {
+	key, err := permsdb.ParseID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := t.permissionRows(func(row *permissionRow) bool {
+		return row.resource.id == key && (excludedLevel == nil || row.level.name != string(*excludedLevel))
+	})
+	sortBySubjectID(rows)
+	return toPermissionList(rows), nil
+}
+
+// GetPermissionByID obtains information about a specific permission.
+func (t *tx) GetPermissionByID(permissionID string) (*models.Permission, error) {
+	key, err := permsdb.ParseID(permissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := t.permissionRows(func(row *permissionRow) bool { return row.permission.id == key })
+	if len(rows) < 1 {
+		return nil, nil
+	}
+	return rows[0].toPermission(), nil
+}
+
+// findPermission returns a subject's permission to a resource or nil if the permission doesn't exist.
+func (t *tx) findPermission(subjectID, resourceID string) *permission {
+	for _, p := range t.data.permissions {
+		if p.subjectID == subjectID && p.resourceID == resourceID {
+			return p
+		}
+	}
+	return nil
+}
+
+// lookUpPermission returns a subject's permission to a resource or nil if the permission doesn't exist.
+func (t *tx) lookUpPermission(subjectID models.InternalSubjectID, resourceID string) (*permission, error) {
+	subjectKey, err := permsdb.ParseID(string(subjectID))
+	if err != nil {
+		return nil, err
+	}
+	resourceKey, err := permsdb.ParseID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	return t.findPermission(subjectKey, resourceKey), nil
+}
+
+// GetPermission gets a subject's permission to a specific resource if it exists.
+func (t *tx) GetPermission(subjectID models.InternalSubjectID, resourceID string) (*models.Permission, error) {
+	p, err := t.lookUpPermission(subjectID, resourceID)
+	if err != nil || p == nil {
+		return nil, err
+	}
+	return t.GetPermissionByID(p.id)
+}
+
+// GetPermissionLevelIDByName returns the identifier for the permission level with the given name.
+func (t *tx) GetPermissionLevelIDByName(level models.PermissionLevel) (*string, error) {
+	permissionLevel := t.levelByName(string(level))
+	if permissionLevel == nil {
+		return nil, nil
+	}
+	return stringPtr(permissionLevel.id), nil
+}
+
+// GetPermissionVersion returns the current version of a subject's permission to a resource.
+func (t *tx) GetPermissionVersion(subjectID models.InternalSubjectID, resourceID string) (*int64, error) {
+	p, err := t.lookUpPermission(subjectID, resourceID)
+	if err != nil || p == nil {
+		return nil, err
+	}
+	version := p.version
+	return &version, nil
+}
+
+// LockPermissionVersion returns the current version of a subject's permission to a resource.
+// Locking isn't required because transactions are serialized.
+func (t *tx) LockPermissionVersion(subjectID models.InternalSubjectID, resourceID string) (*int64, error) {
+	return t.GetPermissionVersion(subjectID, resourceID)
+}
+
+// setPermission grants a permission to a subject or, if the subject already has permission to access the resource,
+// replaces the subject's existing permission level with the result of the resolve function.
+func (t *tx) setPermission(
+	subjectID, resourceID, permissionLevelID string, resolve func(existing, proposed *permissionLevel) *permissionLevel,
+) *permission {
+	proposed := t.data.permissionLevels[permissionLevelID]
+
+	// Update the existing permission if there is one. The version only changes if the permission level does.
+	if p := t.findPermission(subjectID, resourceID); p != nil {
+		level := resolve(t.data.permissionLevels[p.permissionLevelID], proposed)
+		if level.id != p.permissionLevelID {
+			p.permissionLevelID = level.id
+			p.version = t.data.nextPermissionVersion()
+		}
+		return p
+	}
+
+	// Add a new permission.
+	p := &permission{
+		seq:               t.data.nextSeq(),
+		id:                permsdb.NewID(),
+		subjectID:         subjectID,
+		resourceID:        resourceID,
+		permissionLevelID: permissionLevelID,
+		version:           t.data.nextPermissionVersion(),
+	}
+	t.data.permissions[p.id] = p
+	return p
+}
+
+// Functions used to resolve conflicts between existing and proposed permission levels.
+func keepExisting(existing, _ *permissionLevel) *permissionLevel { return existing }
+func keepProposed(_, proposed *permissionLevel) *permissionLevel { return proposed }
+func keepHigher(existing, proposed *permissionLevel) *permissionLevel {
+	if proposed.precedence < existing.precedence {
+		return proposed
+	}
+	return existing
+}
+
+// UpsertPermission updates a permission or inserts it if it doesn't exist.
+func (t *tx) UpsertPermission(
+	subjectID models.InternalSubjectID,
+	resourceID string,
+	permissionLevelID string,
+) (*models.Permission, error) {
+	s, err := t.lookUpSubject(subjectID)
+	if err != nil {
+		return nil, err
+	}
+	r, err := t.lookUpResource(&resourceID)
+	if err != nil {
+		return nil, err
+	}
+	levelKey, err := permsdb.ParseID(permissionLevelID)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil || r == nil || t.data.permissionLevels[levelKey] == nil {
+		return nil, fmt.Errorf("insert on table \"permissions\" violates foreign key constraint")
+	}
+
+	p := t.setPermission(s.id, r.id, levelKey, keepProposed)
+	return t.GetPermissionByID(p.id)
+}
+
+// DeletePermission removes a permission.
+func (t *tx) DeletePermission(id models.PermissionID) error {
+	key, err := permsdb.ParseID(string(id))
+	if err != nil {
+		return err
+	}
+	if t.data.permissions[key] == nil {
+		return fmt.Errorf("no permissions deleted for id %s", id)
+	}
+	delete(t.data.permissions, key)
+	return nil
+}
+
+// DeletePermissions removes multiple permissions.
+func (t *tx) DeletePermissions(permissions []*models.Permission) error {
+	count := 0
+	for _, permission := range permissions {
+		key, err := permsdb.ParseID(string(*permission.ID))
+		if err != nil {
+			return err
+		}
+		if t.data.permissions[key] != nil {
+			delete(t.data.permissions, key)
+			count++
+		}
+	}
+
+	// Verify that the expected number of permissions was deleted.
+	if count != len(permissions) {
+		return fmt.Errorf("expected to delete %d permissions but %d were deleted", len(permissions), count)
+	}
+
+	return nil
+}
+
+// CopyPermissions copies permissions from one subject to another. If the destination subject already has permission
+// to access one of the resources then the more permissive of the two permission levels is retained.
+func (t *tx) CopyPermissions(source, dest *models.SubjectOut) error {
+	sourceSubject, err := t.lookUpSubject(*source.ID)
+	if err != nil {
+		return err
+	}
+	destSubject, err := t.lookUpSubject(*dest.ID)
+	if err != nil {
+		return err
+	}
+	if destSubject == nil {
+		return fmt.Errorf("insert on table \"permissions\" violates foreign key constraint")
+	}
+
+	// Copy the permissions.
+	rows := t.permissionRows(func(row *permissionRow) bool {
+		return sourceSubject != nil && row.subject.id == sourceSubject.id
+	})
+	for _, row := range rows {
+		t.setPermission(destSubject.id, row.resource.id, row.level.id, keepHigher)
+	}
+
+	return nil
+}
+
+// CopyResourcePermissions copies permissions from one resource to another. The merge policy determines how conflicts
+// with existing permissions for the destination resource are resolved.
+func (t *tx) CopyResourcePermissions(source, dest *models.ResourceOut, mergePolicy string) error {
+
+	// Determine how to handle conflicts.
+	var resolve func(existing, proposed *permissionLevel) *permissionLevel
+	switch mergePolicy {
+	case permsdb.MergePolicyKeepHigher:
+		resolve = keepHigher
+	case permsdb.MergePolicyOverwrite:
+		resolve = keepProposed
+	case permsdb.MergePolicySkipExisting:
+		resolve = keepExisting
+	default:
+		return fmt.Errorf("unrecognized merge policy: %s", mergePolicy)
+	}
+
+	// Look up the resources.
+	sourceResource, err := t.lookUpResource(source.ID)
+	if err != nil {
+		return err
+	}
+	destResource, err := t.lookUpResource(dest.ID)
+	if err != nil {
+		return err
+	}
+	if destResource == nil {
+		return fmt.Errorf("insert on table \"permissions\" violates foreign key constraint")
+	}
+
+	// Copy the permissions.
+	rows := t.permissionRows(func(row *permissionRow) bool {
+		return sourceResource != nil && row.resource.id == sourceResource.id
+	})
+	for _, row := range rows {
+		t.setPermission(row.subject.id, destResource.id, row.level.id, resolve)
+	}
+
+	return nil
+}
+
+// ListPermissionConflicts lists the resources to which both the source and destination subjects have been granted
+// permissions. The resolved permission level for each conflict is the level that CopyPermissions would retain.
+func (t *tx) ListPermissionConflicts(source, dest *models.SubjectOut) ([]*models.PermissionConflict, error) {
+	sourceKey, err := permsdb.ParseID(string(*source.ID))
+	if err != nil {
+		return nil, err
+	}
+	destKey, err := permsdb.ParseID(string(*dest.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the source permissions for resources that the destination subject also has permission to access.
+	rows := t.permissionRows(func(row *permissionRow) bool {
+		return row.subject.id == sourceKey && t.findPermission(destKey, row.resource.id) != nil
+	})
+	sortByResource(rows)
+
+	// Build the list of conflicts.
+	conflicts := make([]*models.PermissionConflict, len(rows))
+	for i, row := range rows {
+		destLevel := t.data.permissionLevels[t.findPermission(destKey, row.resource.id).permissionLevelID]
+		sourceLevelName := models.PermissionLevel(row.level.name)
+		destLevelName := models.PermissionLevel(destLevel.name)
+		resolvedLevelName := models.PermissionLevel(keepHigher(destLevel, row.level).name)
+		conflicts[i] = &models.PermissionConflict{
+			Resource:      row.toPermission().Resource,
+			SourceLevel:   &sourceLevelName,
+			TargetLevel:   &destLevelName,
+			ResolvedLevel: &resolvedLevelName,
+		}
+	}
+
+	return conflicts, nil
+}
+
+// PermissionsForSubjects lists the most permissive permission granted to zero or more subjects for each resource.
+func (t *tx) PermissionsForSubjects(subjectIds []string) ([]*models.Permission, error) {
+	return toPermissionList(t.mostPermissive(subjectIn(subjectIds))), nil
+}
+
+// PermissionsForSubjectsMinLevel lists permissions of at least the given level granted to zero or more subjects.
+func (t *tx) PermissionsForSubjectsMinLevel(subjectIds []string, minLevel string) ([]*models.Permission, error) {
+	return toPermissionList(t.mostPermissive(all(subjectIn(subjectIds), t.atLeast(minLevel)))), nil
+}
+
+// resourceTypeIs returns a filter that matches permissions for resources of the named resource type.
+func resourceTypeIs(resourceTypeName string) func(*permissionRow) bool {
+	return func(row *permissionRow) bool {
+		return row.resourceType.name == resourceTypeName
+	}
+}
+
+// resourceIs returns a filter that matches permissions for the resource with the given type and name.
+func resourceIs(resourceTypeName, resourceName string) func(*permissionRow) bool {
+	return func(row *permissionRow) bool {
+		return row.resourceType.name == resourceTypeName && row.resource.name == resourceName
+	}
+}
+
+// labelsMatch returns a filter that matches permissions for resources that match the label selector.
+func labelsMatch(selector permsdb.LabelSelector) func(*permissionRow) bool {
+	return func(row *permissionRow) bool {
+		return selector.Matches(row.resource.labels)
+	}
+}
+
+// PermissionsForSubjectsAndResourceType lists permissions that have been granted to zero or more subjects for the
+// specified type of resource, limited to resources that match the label selector.
+func (t *tx) PermissionsForSubjectsAndResourceType(
+	subjectIds []string, resourceTypeName string, selector permsdb.LabelSelector,
+) ([]*models.Permission, error) {
+	rows := t.mostPermissive(all(subjectIn(subjectIds), resourceTypeIs(resourceTypeName), labelsMatch(selector)))
+	return toPermissionList(rows), nil
+}
+
+// PermissionsForSubjectsAndResourceTypeMinLevel lists permissions of at least the minimum level that have been
+// granted to zero or more subjects for the specified type of resource, limited to resources that match the label
+// selector.
+func (t *tx) PermissionsForSubjectsAndResourceTypeMinLevel(
+	subjectIds []string, resourceTypeName, minLevel string, selector permsdb.LabelSelector,
+) ([]*models.Permission, error) {
+	filter := all(subjectIn(subjectIds), resourceTypeIs(resourceTypeName), t.atLeast(minLevel), labelsMatch(selector))
+	return toPermissionList(t.mostPermissive(filter)), nil
+}
+
+// PermissionsForSubjectsAndResource lists permissions granted to zero or more subjects for a specific resource.
+func (t *tx) PermissionsForSubjectsAndResource(
+	subjectIds []string, resourceTypeName, resourceName string,
+) ([]*models.Permission, error) {
+	rows := t.mostPermissive(all(subjectIn(subjectIds), resourceIs(resourceTypeName, resourceName)))
+	return toPermissionList(rows), nil
+}
+
+// PermissionsForSubjectsAndResourceMinLevel lists permissions of at least the minimum level that have been granted
+// to zero or more subjects for a specific resource.
+func (t *tx) PermissionsForSubjectsAndResourceMinLevel(
+	subjectIds []string, resourceTypeName, resourceName, minLevel string,
+) ([]*models.Permission, error) {
+	filter := all(subjectIn(subjectIds), resourceIs(resourceTypeName, resourceName), t.atLeast(minLevel))
+	return toPermissionList(t.mostPermissive(filter)), nil
+}
+
+// AbbreviatedPermissionsForSubjectAndResourceType lists permissions for a subject and resource type. If the
+// minLevel parameter is specified, permissions that don't meet or exceed the minimum level will be omitted
+// from the results. Only permissions for resources that match the label selector are listed.
+func (t *tx) AbbreviatedPermissionsForSubjectAndResourceType(
+	subjectIDs []string, resourceTypeName string, minLevel *string, selector permsdb.LabelSelector,
+) ([]*models.AbbreviatedPermission, error) {
+
+	// Build the filter.
+	filter := all(subjectIn(subjectIDs), resourceTypeIs(resourceTypeName), labelsMatch(selector))
+	if minLevel != nil {
+		filter = all(filter, t.atLeast(*minLevel))
+	}
+
+	// Build the list of abbreviated permissions.
+	rows := t.mostPermissive(filter)
+	permissions := make([]*models.AbbreviatedPermission, len(rows))
+	for i, row := range rows {
+		permissions[i] = row.toAbbreviatedPermission()
+	}
+
+	return permissions, nil
+}
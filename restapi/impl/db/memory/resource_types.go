@@ -0,0 +1,185 @@
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+func (rt *resourceType) toResourceTypeOut() *models.ResourceTypeOut {
+	return &models.ResourceTypeOut{
+		ID:          stringPtr(rt.id),
+		Name:        stringPtr(rt.name),
+		Description: rt.description,
+	}
+}
+
+// sortedResourceTypes returns the resource types in the order in which they were inserted.
+func (t *tx) sortedResourceTypes() []*resourceType {
+	resourceTypes := make([]*resourceType, 0, len(t.data.resourceTypes))
+	for _, rt := range t.data.resourceTypes {
+		resourceTypes = append(resourceTypes, rt)
+	}
+	sort.Slice(resourceTypes, func(i, j int) bool { return resourceTypes[i].seq < resourceTypes[j].seq })
+	return resourceTypes
+}
+
+// lookUpResourceType returns the resource type with the given ID or nil if the resource type doesn't exist.
+func (t *tx) lookUpResourceType(id *string) (*resourceType, error) {
+	key, err := permsdb.ParseID(*id)
+	if err != nil {
+		return nil, err
+	}
+	return t.data.resourceTypes[key], nil
+}
+
+// checkResourceTypeName verifies that no resource type other than the one with the given ID has a name that is
+// equivalent to the given name.
+func (t *tx) checkResourceTypeName(id, name string) error {
+	key := resourceTypeNameKey(name)
+	for _, rt := range t.data.resourceTypes {
+		if rt.id != id && resourceTypeNameKey(rt.name) == key {
+			return fmt.Errorf("duplicate key value violates unique constraint \"resource_types_name_unique\"")
+		}
+	}
+	return nil
+}
+
+// ListResourceTypes lists all defined resource types.
+func (t *tx) ListResourceTypes(resourceTypeName *string) ([]*models.ResourceTypeOut, error) {
+	resourceTypes := make([]*models.ResourceTypeOut, 0)
+	for _, rt := range t.sortedResourceTypes() {
+		if resourceTypeName == nil || rt.name == *resourceTypeName {
+			resourceTypes = append(resourceTypes, rt.toResourceTypeOut())
+		}
+	}
+	return resourceTypes, nil
+}
+
+// GetResourceTypeByName gets information about the resource type with the given name. Names are compared without
+// regard to case or whitespace.
+func (t *tx) GetResourceTypeByName(name *string) (*models.ResourceTypeOut, error) {
+	key := resourceTypeNameKey(*name)
+	for _, rt := range t.data.resourceTypes {
+		if resourceTypeNameKey(rt.name) == key {
+			return rt.toResourceTypeOut(), nil
+		}
+	}
+	return nil, nil
+}
+
+// GetDuplicateResourceTypeByName returns information about a resource type other than the one with the given ID that
+// has the given name.
+func (t *tx) GetDuplicateResourceTypeByName(id *string, name *string) (*models.ResourceTypeOut, error) {
+	key, err := permsdb.ParseID(*id)
+	if err != nil {
+		return nil, err
+	}
+
+	nameKey := resourceTypeNameKey(*name)
+	for _, rt := range t.data.resourceTypes {
+		if rt.id != key && resourceTypeNameKey(rt.name) == nameKey {
+			return rt.toResourceTypeOut(), nil
+		}
+	}
+	return nil, nil
+}
+
+// GetResourceType gets information about the resource type with the given ID.
+func (t *tx) GetResourceType(id *string) (*models.ResourceTypeOut, error) {
+	rt, err := t.lookUpResourceType(id)
+	if err != nil || rt == nil {
+		return nil, err
+	}
+	return rt.toResourceTypeOut(), nil
+}
+
+// ResourceTypeExists determines whether or not the resource type with the given ID exists.
+func (t *tx) ResourceTypeExists(id *string) (bool, error) {
+	rt, err := t.lookUpResourceType(id)
+	return rt != nil, err
+}
+
+// AddNewResourceType adds a new resource type.
+func (t *tx) AddNewResourceType(resourceTypeIn *models.ResourceTypeIn) (*models.ResourceTypeOut, error) {
+	if err := t.checkResourceTypeName("", *resourceTypeIn.Name); err != nil {
+		return nil, err
+	}
+
+	rt := &resourceType{
+		seq:         t.data.nextSeq(),
+		id:          permsdb.NewID(),
+		name:        normalizeResourceTypeName(*resourceTypeIn.Name),
+		description: resourceTypeIn.Description,
+		version:     1,
+	}
+	t.data.resourceTypes[rt.id] = rt
+
+	return rt.toResourceTypeOut(), nil
+}
+
+// UpdateResourceType updates a resource type.
+func (t *tx) UpdateResourceType(id *string, resourceTypeIn *models.ResourceTypeIn) (*models.ResourceTypeOut, error) {
+	rt, err := t.lookUpResourceType(id)
+	if err != nil {
+		return nil, err
+	}
+	if rt == nil {
+		return nil, sql.ErrNoRows
+	}
+	if err := t.checkResourceTypeName(rt.id, *resourceTypeIn.Name); err != nil {
+		return nil, err
+	}
+
+	// The version only changes if the resource type does.
+	name := normalizeResourceTypeName(*resourceTypeIn.Name)
+	if rt.name != name || rt.description != resourceTypeIn.Description {
+		rt.name = name
+		rt.description = resourceTypeIn.Description
+		rt.version++
+	}
+
+	return rt.toResourceTypeOut(), nil
+}
+
+// DeleteResourceType removes a resource type.
+func (t *tx) DeleteResourceType(id *string) error {
+	rt, err := t.lookUpResourceType(id)
+	if err != nil {
+		return err
+	}
+	if rt == nil {
+		return fmt.Errorf("no resource types deleted for id %s", *id)
+	}
+
+	// Resource types can't be deleted while resources of that type exist.
+	for _, r := range t.data.resources {
+		if r.resourceTypeID == rt.id {
+			return fmt.Errorf(
+				"update or delete on table \"resource_types\" violates foreign key constraint on table \"resources\"",
+			)
+		}
+	}
+
+	delete(t.data.resourceTypes, rt.id)
+	return nil
+}
+
+// GetResourceTypeVersion returns the current version of the resource type with the given ID.
+func (t *tx) GetResourceTypeVersion(id *string) (*int64, error) {
+	rt, err := t.lookUpResourceType(id)
+	if err != nil || rt == nil {
+		return nil, err
+	}
+	version := rt.version
+	return &version, nil
+}
+
+// LockResourceTypeVersion returns the current version of the resource type with the given ID.
+// Locking isn't required because transactions are serialized.
+func (t *tx) LockResourceTypeVersion(id *string) (*int64, error) {
+	return t.GetResourceTypeVersion(id)
+}
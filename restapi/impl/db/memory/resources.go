@@ -0,0 +1,257 @@
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+func (t *tx) toResourceOut(r *resource) *models.ResourceOut {
+	return &models.ResourceOut{
+		ID:           stringPtr(r.id),
+		Labels:       copyLabels(r.labels),
+		Name:         stringPtr(r.name),
+		ResourceType: stringPtr(t.data.resourceTypes[r.resourceTypeID].name),
+	}
+}
+
+// copyLabels returns a copy of a set of resource labels, or nil if there are no labels.
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(labels))
+	for key, value := range labels {
+		result[key] = value
+	}
+	return result
+}
+
+// sortedResources returns the resources in the order in which they were inserted.
+func (t *tx) sortedResources() []*resource {
+	resources := make([]*resource, 0, len(t.data.resources))
+	for _, r := range t.data.resources {
+		resources = append(resources, r)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].seq < resources[j].seq })
+	return resources
+}
+
+// lookUpResource returns the resource with the given ID or nil if the resource doesn't exist.
+func (t *tx) lookUpResource(id *string) (*resource, error) {
+	key, err := permsdb.ParseID(*id)
+	if err != nil {
+		return nil, err
+	}
+	return t.data.resources[key], nil
+}
+
+// findResource returns the resource with the given name and resource type ID or nil if there is no such resource.
+func (t *tx) findResource(name, resourceTypeID string) *resource {
+	for _, r := range t.data.resources {
+		if r.name == name && r.resourceTypeID == resourceTypeID {
+			return r
+		}
+	}
+	return nil
+}
+
+// CountResourcesOfType counts the number of resources of the given type.
+func (t *tx) CountResourcesOfType(resourceTypeID *string) (int64, error) {
+	key, err := permsdb.ParseID(*resourceTypeID)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, r := range t.data.resources {
+		if r.resourceTypeID == key {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ResourceExists determines whether or not the resource with the given ID exists.
+func (t *tx) ResourceExists(id *string) (bool, error) {
+	r, err := t.lookUpResource(id)
+	return r != nil, err
+}
+
+// GetResource returns information about the resource with the given ID.
+func (t *tx) GetResource(id *string) (*models.ResourceOut, error) {
+	r, err := t.lookUpResource(id)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	return t.toResourceOut(r), nil
+}
+
+// GetResourceByName obtains information about the resource with the given name and resource type ID.
+func (t *tx) GetResourceByName(name *string, resourceTypeID *string) (*models.ResourceOut, error) {
+	key, err := permsdb.ParseID(*resourceTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	r := t.findResource(*name, key)
+	if r == nil {
+		return nil, nil
+	}
+	return t.toResourceOut(r), nil
+}
+
+// GetResourceByNameAndType obtains information about the resource with the given name and type.
+func (t *tx) GetResourceByNameAndType(name, resourceTypeName string) (*models.ResourceOut, error) {
+	for _, r := range t.data.resources {
+		if r.name == name && t.data.resourceTypes[r.resourceTypeID].name == resourceTypeName {
+			return t.toResourceOut(r), nil
+		}
+	}
+	return nil, nil
+}
+
+// GetDuplicateResourceByName obtains information about a resource other than the one with the given ID that has the
+// same type as that resource and the given name.
+func (t *tx) GetDuplicateResourceByName(id *string, name *string) (*models.ResourceOut, error) {
+	r, err := t.lookUpResource(id)
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	duplicate := t.findResource(*name, r.resourceTypeID)
+	if duplicate == nil || duplicate.id == r.id {
+		return nil, nil
+	}
+	return t.toResourceOut(duplicate), nil
+}
+
+// AddResource adds a resource.
+func (t *tx) AddResource(name *string, resourceTypeID *string) (*models.ResourceOut, error) {
+	rt, err := t.lookUpResourceType(resourceTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if rt == nil {
+		return nil, fmt.Errorf("insert on table \"resources\" violates foreign key constraint: %s", *resourceTypeID)
+	}
+	if t.findResource(*name, rt.id) != nil {
+		return nil, fmt.Errorf("duplicate key value violates unique constraint \"resources_resource_type_id_name_key\"")
+	}
+
+	r := &resource{
+		seq:            t.data.nextSeq(),
+		id:             permsdb.NewID(),
+		name:           *name,
+		resourceTypeID: rt.id,
+		version:        1,
+	}
+	t.data.resources[r.id] = r
+
+	return t.toResourceOut(r), nil
+}
+
+// UpdateResource updates a resource.
+func (t *tx) UpdateResource(id *string, name *string) (*models.ResourceOut, error) {
+	r, err := t.lookUpResource(id)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	// The version only changes if the resource does.
+	if r.name != *name {
+		if t.findResource(*name, r.resourceTypeID) != nil {
+			return nil, fmt.Errorf("duplicate key value violates unique constraint \"resources_resource_type_id_name_key\"")
+		}
+		r.name = *name
+		r.version++
+	}
+
+	return t.toResourceOut(r), nil
+}
+
+// ListResources lists resources, optionally filtering by resource type, resource name and labels.
+func (t *tx) ListResources(
+	resourceTypeName, resourceName *string, selector permsdb.LabelSelector,
+) ([]*models.ResourceOut, error) {
+	resources := make([]*models.ResourceOut, 0)
+	for _, r := range t.sortedResources() {
+		if resourceTypeName != nil && t.data.resourceTypes[r.resourceTypeID].name != *resourceTypeName {
+			continue
+		}
+		if resourceName != nil && r.name != *resourceName {
+			continue
+		}
+		if !selector.Matches(r.labels) {
+			continue
+		}
+		resources = append(resources, t.toResourceOut(r))
+	}
+	return resources, nil
+}
+
+// SetResourceLabels replaces the labels of the resource with the given ID. The version of the resource is incremented
+// if the labels change.
+func (t *tx) SetResourceLabels(id *string, labels map[string]string) error {
+	r, err := t.lookUpResource(id)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return sql.ErrNoRows
+	}
+
+	if !permsdb.SameLabels(r.labels, labels) {
+		r.labels = copyLabels(labels)
+		r.version++
+	}
+	return nil
+}
+
+// DeleteResource removes a resource along with any permissions that have been granted for it.
+func (t *tx) DeleteResource(id *string) error {
+	r, err := t.lookUpResource(id)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return fmt.Errorf("no resources deleted for id %s", *id)
+	}
+
+	delete(t.data.resources, r.id)
+	for permissionID, p := range t.data.permissions {
+		if p.resourceID == r.id {
+			delete(t.data.permissions, permissionID)
+		}
+	}
+
+	return nil
+}
+
+// LockResource validates the resource ID. Locking isn't required because transactions are serialized.
+func (t *tx) LockResource(id *string) error {
+	_, err := t.lookUpResource(id)
+	return err
+}
+
+// GetResourceVersion returns the current version of the resource with the given ID.
+func (t *tx) GetResourceVersion(id *string) (*int64, error) {
+	r, err := t.lookUpResource(id)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	version := r.version
+	return &version, nil
+}
+
+// LockResourceVersion returns the current version of the resource with the given ID.
+// Locking isn't required because transactions are serialized.
+func (t *tx) LockResourceVersion(id *string) (*int64, error) {
+	return t.GetResourceVersion(id)
+}
@@ -0,0 +1,238 @@
+// Package memory provides an implementation of the permissions database interface that keeps all of its data in
+// memory. The data are lost when the process exits, so this implementation is primarily intended for testing and for
+// running the service locally without a database.
+//
+// Transactions are serialized: beginning a transaction blocks until every other transaction has been either committed
+// or rolled back. Each transaction operates on its own copy of the data, which replaces the shared copy when the
+// transaction is committed.
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+type resourceType struct {
+	seq         int64
+	id          string
+	name        string
+	description string
+	version     int64
+}
+
+type resource struct {
+	seq            int64
+	id             string
+	name           string
+	resourceTypeID string
+	version        int64
+
+	// The labels are replaced rather than modified when they change, so they can be shared between snapshots.
+	labels map[string]string
+}
+
+type subject struct {
+	seq         int64
+	id          string
+	subjectID   string
+	subjectType string
+	version     int64
+}
+
+type permissionLevel struct {
+	id         string
+	name       string
+	precedence int
+}
+
+type permission struct {
+	seq               int64
+	id                string
+	subjectID         string
+	resourceID        string
+	permissionLevelID string
+	version           int64
+}
+
+// data contains the entire contents of a store. The sequence numbers assigned to each entity record the order in
+// which the entities were inserted. Permission version numbers are drawn from a separate counter so that they're never
+// reused, even if a permission is revoked and granted again. The permission change log is only appended to when a
+// transaction is committed, so it's shared between copies of the data rather than being copied.
+type data struct {
+	seq               int64
+	resourceTypes     map[string]*resourceType
+	resources         map[string]*resource
+	subjects          map[string]*subject
+	permissionLevels  map[string]*permissionLevel
+	permissions       map[string]*permission
+	permissionVersion int64
+	changeSeq         int64
+	changes           []*permsdb.Change
+}
+
+// nextSeq returns the next available sequence number.
+func (d *data) nextSeq() int64 {
+	d.seq++
+	return d.seq
+}
+
+// nextPermissionVersion returns the next available permission version number.
+func (d *data) nextPermissionVersion() int64 {
+	d.permissionVersion++
+	return d.permissionVersion
+}
+
+// clone returns a deep copy of the data. Permission levels never change, so they're shared.
+func (d *data) clone() *data {
+	c := &data{
+		seq:               d.seq,
+		resourceTypes:     make(map[string]*resourceType, len(d.resourceTypes)),
+		resources:         make(map[string]*resource, len(d.resources)),
+		subjects:          make(map[string]*subject, len(d.subjects)),
+		permissionLevels:  d.permissionLevels,
+		permissions:       make(map[string]*permission, len(d.permissions)),
+		permissionVersion: d.permissionVersion,
+		changeSeq:         d.changeSeq,
+		changes:           d.changes,
+	}
+	for id, rt := range d.resourceTypes {
+		copied := *rt
+		c.resourceTypes[id] = &copied
+	}
+	for id, r := range d.resources {
+		copied := *r
+		c.resources[id] = &copied
+	}
+	for id, s := range d.subjects {
+		copied := *s
+		c.subjects[id] = &copied
+	}
+	for id, p := range d.permissions {
+		copied := *p
+		c.permissions[id] = &copied
+	}
+	return c
+}
+
+// Store is a permsdb.Store that keeps all of its data in memory.
+type Store struct {
+	lock chan struct{}
+	data *data
+}
+
+// NewStore returns a new, empty in-memory store. The store contains the standard permission levels but no other data.
+func NewStore() *Store {
+	levels := []string{"own", "admin", "write", "read"}
+	permissionLevels := make(map[string]*permissionLevel, len(levels))
+	for precedence, name := range levels {
+		id := permsdb.NewID()
+		permissionLevels[id] = &permissionLevel{id: id, name: name, precedence: precedence}
+	}
+
+	return &Store{
+		lock: make(chan struct{}, 1),
+		data: &data{
+			resourceTypes:    make(map[string]*resourceType),
+			resources:        make(map[string]*resource),
+			subjects:         make(map[string]*subject),
+			permissionLevels: permissionLevels,
+			permissions:      make(map[string]*permission),
+		},
+	}
+}
+
+// Begin starts a new transaction, waiting for any active transaction to complete first. An error is returned if the
+// context is canceled before the transaction can be started.
+func (s *Store) Begin(ctx context.Context) (permsdb.Tx, error) {
+	select {
+	case s.lock <- struct{}{}:
+		return &tx{store: s, data: s.data.clone()}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// BeginReadOnly starts a new transaction. There are no read replicas, so this is the same as Begin.
+func (s *Store) BeginReadOnly(ctx context.Context) (permsdb.Tx, error) {
+	return s.Begin(ctx)
+}
+
+// BeginTx starts a new transaction. Transactions are serialized, so the options are ignored.
+func (s *Store) BeginTx(ctx context.Context, _ *sql.TxOptions) (permsdb.Tx, error) {
+	return s.Begin(ctx)
+}
+
+// Clear removes all subjects, resources, resource types, permissions and permission changes from the store.
+func (s *Store) Clear() {
+	s.lock <- struct{}{}
+	defer func() { <-s.lock }()
+
+	s.data = &data{
+		resourceTypes:    make(map[string]*resourceType),
+		resources:        make(map[string]*resource),
+		subjects:         make(map[string]*subject),
+		permissionLevels: s.data.permissionLevels,
+		permissions:      make(map[string]*permission),
+	}
+}
+
+// tx is a transaction in an in-memory store.
+type tx struct {
+	store *Store
+	data  *data
+	done  bool
+}
+
+// Commit records the permission changes made within the transaction and makes them visible to subsequent
+// transactions.
+func (t *tx) Commit() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	recordChanges(t.store.data, t.data, time.Now())
+	t.store.data = t.data
+	<-t.store.lock
+	return nil
+}
+
+// Rollback discards the changes made within the transaction.
+func (t *tx) Rollback() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	<-t.store.lock
+	return nil
+}
+
+// TryLock always obtains the lock because transactions are serialized.
+func (t *tx) TryLock(key int64) (bool, error) {
+	if t.done {
+		return false, sql.ErrTxDone
+	}
+	return true, nil
+}
+
+// normalizeResourceTypeName collapses runs of whitespace in a resource type name to single spaces and removes any
+// leading or trailing whitespace.
+func normalizeResourceTypeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// resourceTypeNameKey returns the key used to determine whether or not two resource type names are equivalent.
+func resourceTypeNameKey(name string) string {
+	return strings.ToLower(normalizeResourceTypeName(name))
+}
+
+// stringPtr returns a pointer to a copy of a string.
+func stringPtr(s string) *string {
+	return &s
+}
+
+// Verify that the in-memory store implements the database interface.
+var _ permsdb.Store = (*Store)(nil)
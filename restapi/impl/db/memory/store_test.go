@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+func addResourceType(t *testing.T, store *Store, name string) *models.ResourceTypeOut {
+	tx, err := store.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt, err := tx.AddNewResourceType(&models.ResourceTypeIn{Name: &name})
+	if err != nil {
+		tx.Rollback() // nolint:errcheck
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	return rt
+}
+
+func TestRollback(t *testing.T) {
+	store := NewStore()
+	addResourceType(t, store, "app")
+
+	// Add a resource type and roll back the transaction.
+	tx, err := store.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "analysis"
+	if _, err := tx.AddNewResourceType(&models.ResourceTypeIn{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rolling back a second time should fail.
+	if err := tx.Rollback(); err == nil {
+		t.Error("expected rolling back a completed transaction to fail")
+	}
+
+	// Only the first resource type should exist.
+	tx, err = store.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+	resourceTypes, err := tx.ListResourceTypes(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resourceTypes) != 1 || *resourceTypes[0].Name != "app" {
+		t.Errorf("unexpected resource types after rollback: %v", resourceTypes)
+	}
+}
+
+func TestResourceTypeNameNormalization(t *testing.T) {
+	store := NewStore()
+	rt := addResourceType(t, store, "  Some \t  Type ")
+	if *rt.Name != "Some Type" {
+		t.Errorf("unexpected resource type name: %q", *rt.Name)
+	}
+
+	tx, err := store.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	// Lookups by name should ignore case and whitespace.
+	name := "some  type"
+	found, err := tx.GetResourceTypeByName(&name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == nil || *found.ID != *rt.ID {
+		t.Errorf("resource type not found by equivalent name")
+	}
+
+	// So should the uniqueness constraint.
+	if _, err := tx.AddNewResourceType(&models.ResourceTypeIn{Name: &name}); err == nil {
+		t.Error("expected adding a resource type with an equivalent name to fail")
+	}
+}
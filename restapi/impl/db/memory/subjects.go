@@ -0,0 +1,235 @@
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// subjectTypeOrder lists the valid subject types in the order in which they're sorted.
+var subjectTypeOrder = map[string]int{
+	string(models.SubjectTypeUser):  0,
+	string(models.SubjectTypeGroup): 1,
+}
+
+// validateSubjectType returns an error if a subject type isn't recognized.
+func validateSubjectType(subjectType string) error {
+	if _, ok := subjectTypeOrder[subjectType]; !ok {
+		return fmt.Errorf("invalid input value for enum subject_type: \"%s\"", subjectType)
+	}
+	return nil
+}
+
+func (s *subject) toSubjectOut() *models.SubjectOut {
+	id := models.InternalSubjectID(s.id)
+	subjectID := models.ExternalSubjectID(s.subjectID)
+	subjectType := models.SubjectType(s.subjectType)
+	return &models.SubjectOut{
+		ID:          &id,
+		SubjectID:   &subjectID,
+		SubjectType: &subjectType,
+	}
+}
+
+// lookUpSubject returns the subject with the given internal ID or nil if the subject doesn't exist.
+func (t *tx) lookUpSubject(id models.InternalSubjectID) (*subject, error) {
+	key, err := permsdb.ParseID(string(id))
+	if err != nil {
+		return nil, err
+	}
+	return t.data.subjects[key], nil
+}
+
+// findSubject returns the subject with the given external ID or nil if the subject doesn't exist.
+func (t *tx) findSubject(subjectID models.ExternalSubjectID) *subject {
+	for _, s := range t.data.subjects {
+		if s.subjectID == string(subjectID) {
+			return s
+		}
+	}
+	return nil
+}
+
+// AddSubject adds a subject.
+func (t *tx) AddSubject(
+	subjectID models.ExternalSubjectID,
+	subjectType models.SubjectType,
+) (*models.SubjectOut, error) {
+	if err := validateSubjectType(string(subjectType)); err != nil {
+		return nil, err
+	}
+	if t.findSubject(subjectID) != nil {
+		return nil, fmt.Errorf("duplicate key value violates unique constraint \"subjects_subject_id_key\"")
+	}
+
+	s := &subject{
+		seq:         t.data.nextSeq(),
+		id:          permsdb.NewID(),
+		subjectID:   string(subjectID),
+		subjectType: string(subjectType),
+		version:     1,
+	}
+	t.data.subjects[s.id] = s
+
+	return s.toSubjectOut(), nil
+}
+
+// UpdateSubject updates a subject.
+func (t *tx) UpdateSubject(
+	id models.InternalSubjectID,
+	subjectID models.ExternalSubjectID,
+	subjectType models.SubjectType,
+) (*models.SubjectOut, error) {
+	if err := validateSubjectType(string(subjectType)); err != nil {
+		return nil, err
+	}
+	s, err := t.lookUpSubject(id)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, sql.ErrNoRows
+	}
+	if duplicate := t.findSubject(subjectID); duplicate != nil && duplicate.id != s.id {
+		return nil, fmt.Errorf("duplicate key value violates unique constraint \"subjects_subject_id_key\"")
+	}
+
+	// The version only changes if the subject does.
+	if s.subjectID != string(subjectID) || s.subjectType != string(subjectType) {
+		s.subjectID = string(subjectID)
+		s.subjectType = string(subjectType)
+		s.version++
+	}
+
+	return s.toSubjectOut(), nil
+}
+
+// SubjectIDExists determines whether or not the subject with the given external ID exists.
+func (t *tx) SubjectIDExists(subjectID models.ExternalSubjectID) (bool, error) {
+	return t.findSubject(subjectID) != nil, nil
+}
+
+// SubjectExists determines whether or not the subject with the given internal ID exists.
+func (t *tx) SubjectExists(id models.InternalSubjectID) (bool, error) {
+	s, err := t.lookUpSubject(id)
+	return s != nil, err
+}
+
+// DuplicateSubjectExists determines whether or not a subject with the same external subject ID and a different
+// internal subject ID exists.
+func (t *tx) DuplicateSubjectExists(id models.InternalSubjectID, subjectID models.ExternalSubjectID) (bool, error) {
+	key, err := permsdb.ParseID(string(id))
+	if err != nil {
+		return false, err
+	}
+	s := t.findSubject(subjectID)
+	return s != nil && s.id != key, nil
+}
+
+// ListSubjects lists subjects, optionally filtering by subject type and external subject ID.
+func (t *tx) ListSubjects(subjectType, subjectID *string) ([]*models.SubjectOut, error) {
+	if subjectType != nil {
+		if err := validateSubjectType(*subjectType); err != nil {
+			return nil, err
+		}
+	}
+
+	// Find the matching subjects.
+	matches := make([]*subject, 0)
+	for _, s := range t.data.subjects {
+		if subjectType != nil && s.subjectType != *subjectType {
+			continue
+		}
+		if subjectID != nil && s.subjectID != *subjectID {
+			continue
+		}
+		matches = append(matches, s)
+	}
+
+	// Sort the subjects by subject type and external subject ID.
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].subjectType != matches[j].subjectType {
+			return subjectTypeOrder[matches[i].subjectType] < subjectTypeOrder[matches[j].subjectType]
+		}
+		return matches[i].subjectID < matches[j].subjectID
+	})
+
+	// Build the list of subjects.
+	subjects := make([]*models.SubjectOut, len(matches))
+	for i, s := range matches {
+		subjects[i] = s.toSubjectOut()
+	}
+	return subjects, nil
+}
+
+// DeleteSubject removes a subject along with any permissions that have been granted to it.
+func (t *tx) DeleteSubject(id models.InternalSubjectID) error {
+	s, err := t.lookUpSubject(id)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return fmt.Errorf("no subjects deleted for id %s", id)
+	}
+
+	delete(t.data.subjects, s.id)
+	for permissionID, p := range t.data.permissions {
+		if p.subjectID == s.id {
+			delete(t.data.permissions, permissionID)
+		}
+	}
+
+	return nil
+}
+
+// GetSubject obtains information about the subject with the given external ID and subject type.
+func (t *tx) GetSubject(
+	subjectID models.ExternalSubjectID,
+	subjectType models.SubjectType,
+) (*models.SubjectOut, error) {
+	if err := validateSubjectType(string(subjectType)); err != nil {
+		return nil, err
+	}
+	s := t.findSubject(subjectID)
+	if s == nil || s.subjectType != string(subjectType) {
+		return nil, nil
+	}
+	return s.toSubjectOut(), nil
+}
+
+// GetSubjectByExternalID returns information about the subject with the given external ID.
+func (t *tx) GetSubjectByExternalID(subjectID models.ExternalSubjectID) (*models.SubjectOut, error) {
+	s := t.findSubject(subjectID)
+	if s == nil {
+		return nil, nil
+	}
+	return s.toSubjectOut(), nil
+}
+
+// GetSubjectByID returns information about the subject with the given internal ID.
+func (t *tx) GetSubjectByID(id models.InternalSubjectID) (*models.SubjectOut, error) {
+	s, err := t.lookUpSubject(id)
+	if err != nil || s == nil {
+		return nil, err
+	}
+	return s.toSubjectOut(), nil
+}
+
+// GetSubjectVersion returns the current version of the subject with the given internal ID.
+func (t *tx) GetSubjectVersion(id models.InternalSubjectID) (*int64, error) {
+	s, err := t.lookUpSubject(id)
+	if err != nil || s == nil {
+		return nil, err
+	}
+	version := s.version
+	return &version, nil
+}
+
+// LockSubjectVersion returns the current version of the subject with the given internal ID.
+// Locking isn't required because transactions are serialized.
+func (t *tx) LockSubjectVersion(id models.InternalSubjectID) (*int64, error) {
+	return t.GetSubjectVersion(id)
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -50,35 +51,24 @@ func rowsToAbbreviatedPermissionList(rows *sql.Rows) ([]*models.AbbreviatedPermi
 	return permissions, nil
 }
 
-// ListPermissions lists all existing permissions.
-func ListPermissions(tx *sql.Tx) ([]*models.Permission, error) {
+// ListPermissions lists all existing permissions for resources that match the label selector.
+func ListPermissions(ctx context.Context, tx *sql.Tx, selector LabelSelector) (_ []*models.Permission, err error) {
+	ctx, span := startSpan(ctx, "ListPermissions")
+	defer endSpan(span, &err)
 
-	// Query the database.
-	query := `SELECT p.id AS id,
-	                 s.id AS internal_subject_id,
-	                 s.subject_id AS subject_id,
-	                 s.subject_type AS subject_type,
-	                 r.id AS resource_id,
-	                 r.name AS resource_name,
-	                 rt.name AS resource_type,
-	                 pl.name AS permission_level
-	          FROM permissions p
-	          JOIN permission_levels pl ON p.permission_level_id = pl.id
-	          JOIN subjects s ON p.subject_id = s.id
-	          JOIN resources r ON p.resource_id = r.id
-	          JOIN resource_types rt ON r.resource_type_id = rt.id
-	          ORDER BY s.subject_id, r.name, pl.precedence`
-	rows, err := tx.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	builder := permissionListBuilder().
+		Where(selector.Condition("r.id")).
+		OrderBy("s.subject_id", "r.name", "pl.precedence")
 
-	return rowsToPermissionList(rows)
+	return queryPermissionList(ctx, tx, builder)
 }
 
 // ListResourcePermissions lists permissions associated with a specific resource.
-func ListResourcePermissions(tx *sql.Tx, resourceTypeName, resourceName string) ([]*models.Permission, error) {
+func ListResourcePermissions(
+	ctx context.Context, tx *sql.Tx, resourceTypeName, resourceName string,
+) (_ []*models.Permission, err error) {
+	ctx, span := startSpan(ctx, "ListResourcePermissions")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := `SELECT p.id AS id,
@@ -96,7 +86,7 @@ func ListResourcePermissions(tx *sql.Tx, resourceTypeName, resourceName string)
 	          JOIN resource_types rt ON r.resource_type_id = rt.id
             WHERE rt.name = $1 AND r.name = $2
 	          ORDER BY s.subject_id`
-	rows, err := tx.Query(query, resourceTypeName, resourceName)
+	rows, err := tx.QueryContext(ctx, query, resourceTypeName, resourceName)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +96,10 @@ func ListResourcePermissions(tx *sql.Tx, resourceTypeName, resourceName string)
 }
 
 // PermissionsForSubjects lists permissions granted to zero or more subjects.
-func PermissionsForSubjects(tx *sql.Tx, subjectIds []string) ([]*models.Permission, error) {
+func PermissionsForSubjects(ctx context.Context, tx *sql.Tx, subjectIds []string) (_ []*models.Permission, err error) {
+	ctx, span := startSpan(ctx, "PermissionsForSubjects")
+	defer endSpan(span, &err)
+
 	sa := StringArray(subjectIds)
 
 	// Query the database.
@@ -127,7 +120,7 @@ func PermissionsForSubjects(tx *sql.Tx, subjectIds []string) ([]*models.Permissi
 	          WHERE s.subject_id = any($1)
 	          WINDOW w AS (PARTITION BY r.id ORDER BY pl.precedence)
             ORDER BY r.id`
-	rows, err := tx.Query(query, &sa)
+	rows, err := tx.QueryContext(ctx, query, &sa)
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +130,12 @@ func PermissionsForSubjects(tx *sql.Tx, subjectIds []string) ([]*models.Permissi
 }
 
 // PermissionsForSubjectsMinLevel lists permissions of at least the given level granted to zero or more subjects.
-func PermissionsForSubjectsMinLevel(tx *sql.Tx, subjectIds []string, minLevel string) ([]*models.Permission, error) {
+func PermissionsForSubjectsMinLevel(
+	ctx context.Context, tx *sql.Tx, subjectIds []string, minLevel string,
+) (_ []*models.Permission, err error) {
+	ctx, span := startSpan(ctx, "PermissionsForSubjectsMinLevel")
+	defer endSpan(span, &err)
+
 	sa := StringArray(subjectIds)
 
 	// Query the database.
@@ -159,7 +157,7 @@ func PermissionsForSubjectsMinLevel(tx *sql.Tx, subjectIds []string, minLevel st
             AND pl.precedence <= (SELECT precedence FROM permission_levels WHERE name = $2)
 	          WINDOW w AS (PARTITION BY r.id ORDER BY pl.precedence)
             ORDER BY r.id`
-	rows, err := tx.Query(query, &sa, minLevel)
+	rows, err := tx.QueryContext(ctx, query, &sa, minLevel)
 	if err != nil {
 		return nil, err
 	}
@@ -169,74 +167,37 @@ func PermissionsForSubjectsMinLevel(tx *sql.Tx, subjectIds []string, minLevel st
 }
 
 // PermissionsForSubjectsAndResourceType lists permissions that have been granted to zero or more subjects for the
-// specified type of resource.
+// specified type of resource, limited to resources that match the label selector.
 func PermissionsForSubjectsAndResourceType(
-	tx *sql.Tx, subjectIds []string, resourceTypeName string,
-) ([]*models.Permission, error) {
-	sa := StringArray(subjectIds)
+	ctx context.Context, tx *sql.Tx, subjectIds []string, resourceTypeName string, selector LabelSelector,
+) (_ []*models.Permission, err error) {
+	ctx, span := startSpan(ctx, "PermissionsForSubjectsAndResourceType")
+	defer endSpan(span, &err)
 
-	// Query the database.
-	query := `SELECT DISTINCT ON (r.id)
-	              first_value(p.id) OVER w AS id,
-	              first_value(s.id) OVER w AS internal_subject_id,
-	              first_value(s.subject_id) OVER w AS subject_id,
-	              first_value(s.subject_type) OVER w AS subject_type,
-	              r.id AS resource_id,
-	              first_value(r.name) OVER w AS resource_name,
-	              first_value(rt.name) OVER w AS resource_type,
-	              first_value(pl.name) OVER w AS permission_level
-	          FROM permissions p
-	          JOIN permission_levels pl ON p.permission_level_id = pl.id
-	          JOIN subjects s ON p.subject_id = s.id
-	          JOIN resources r ON p.resource_id = r.id
-	          JOIN resource_types rt ON r.resource_type_id = rt.id
-	          WHERE s.subject_id = any($1)
-            AND rt.Name = $2
-	          WINDOW w AS (PARTITION BY r.id ORDER BY pl.precedence)
-            ORDER BY r.id`
-	rows, err := tx.Query(query, &sa, resourceTypeName)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	builder := mostPermissiveBuilder().
+		Where(sq.Eq{"s.subject_id": subjectIds}).
+		Where(sq.Eq{"rt.name": resourceTypeName}).
+		Where(selector.Condition("r.id"))
 
-	return rowsToPermissionList(rows)
+	return queryPermissionList(ctx, tx, builder)
 }
 
 // PermissionsForSubjectsAndResourceTypeMinLevel lists permissions of at least the minimum level that have been
-// granted to zero or more subjects for the specified type of resource.
+// granted to zero or more subjects for the specified type of resource, limited to resources that match the label
+// selector.
 func PermissionsForSubjectsAndResourceTypeMinLevel(
-	tx *sql.Tx, subjectIds []string, resourceTypeName, minLevel string,
-) ([]*models.Permission, error) {
-	sa := StringArray(subjectIds)
+	ctx context.Context, tx *sql.Tx, subjectIds []string, resourceTypeName, minLevel string, selector LabelSelector,
+) (_ []*models.Permission, err error) {
+	ctx, span := startSpan(ctx, "PermissionsForSubjectsAndResourceTypeMinLevel")
+	defer endSpan(span, &err)
 
-	// Query the database.
-	query := `SELECT DISTINCT ON (r.id)
-	              first_value(p.id) OVER w AS id,
-	              first_value(s.id) OVER w AS internal_subject_id,
-	              first_value(s.subject_id) OVER w AS subject_id,
-	              first_value(s.subject_type) OVER w AS subject_type,
-	              r.id AS resource_id,
-	              first_value(r.name) OVER w AS resource_name,
-	              first_value(rt.name) OVER w AS resource_type,
-	              first_value(pl.name) OVER w AS permission_level
-	          FROM permissions p
-	          JOIN permission_levels pl ON p.permission_level_id = pl.id
-	          JOIN subjects s ON p.subject_id = s.id
-	          JOIN resources r ON p.resource_id = r.id
-	          JOIN resource_types rt ON r.resource_type_id = rt.id
-	          WHERE s.subject_id = any($1)
-            AND rt.Name = $2
-            AND pl.precedence <= (SELECT precedence FROM permission_levels WHERE name = $3)
-	          WINDOW w AS (PARTITION BY r.id ORDER BY pl.precedence)
-            ORDER BY r.id`
-	rows, err := tx.Query(query, &sa, resourceTypeName, minLevel)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	builder := mostPermissiveBuilder().
+		Where(sq.Eq{"s.subject_id": subjectIds}).
+		Where(sq.Eq{"rt.name": resourceTypeName}).
+		Where(permissionLevelPrecedenceExpression("pl.precedence <=", minLevel)).
+		Where(selector.Condition("r.id"))
 
-	return rowsToPermissionList(rows)
+	return queryPermissionList(ctx, tx, builder)
 }
 
 // permissionLevelPrecedenceExpression returns a SelectBuilder representing a permission level precedence
@@ -251,10 +212,13 @@ func permissionLevelPrecedenceExpression(prefix, permissionLevel string) sq.Sele
 
 // AbbreviatedPermissionsForSubjectAndResourceType lists permissions for a subject and resource type. If the
 // minLevel parameter is specified, permissions that don't meet or exceed the minimum level will be omitted
-// from the results.
+// from the results. Only permissions for resources that match the label selector are listed.
 func AbbreviatedPermissionsForSubjectAndResourceType(
-	tx *sql.Tx, subjectIDs []string, resourceTypeName string, minLevel *string,
-) ([]*models.AbbreviatedPermission, error) {
+	ctx context.Context, tx *sql.Tx, subjectIDs []string, resourceTypeName string, minLevel *string,
+	selector LabelSelector,
+) (_ []*models.AbbreviatedPermission, err error) {
+	ctx, span := startSpan(ctx, "AbbreviatedPermissionsForSubjectAndResourceType")
+	defer endSpan(span, &err)
 
 	// Begin building the query.
 	builder := psql.Select(
@@ -276,6 +240,9 @@ func AbbreviatedPermissionsForSubjectAndResourceType(
 		builder = builder.Where(permissionLevelPrecedenceExpression("pl.precedence <=", *minLevel))
 	}
 
+	// Add the label selector.
+	builder = builder.Where(selector.Condition("r.id"))
+
 	// Add the window and the ORDER BY clause. The ORDER BY clause has to appear here because Squirrel doesn't have
 	// explicit support for the WINDOW clause.
 	builder = builder.Suffix("WINDOW w AS (PARTITION BY r.id ORDER BY pl.precedence) ORDER BY r.id")
@@ -287,7 +254,7 @@ func AbbreviatedPermissionsForSubjectAndResourceType(
 	}
 
 	// Execute the query.
-	rows, err := tx.Query(query, args...)
+	rows, err := tx.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -298,8 +265,11 @@ func AbbreviatedPermissionsForSubjectAndResourceType(
 
 // PermissionsForSubjectsAndResource lists permissions granted to zero or more subjects for a specific resource.
 func PermissionsForSubjectsAndResource(
-	tx *sql.Tx, subjectIds []string, resourceTypeName, resourceName string,
-) ([]*models.Permission, error) {
+	ctx context.Context, tx *sql.Tx, subjectIds []string, resourceTypeName, resourceName string,
+) (_ []*models.Permission, err error) {
+	ctx, span := startSpan(ctx, "PermissionsForSubjectsAndResource")
+	defer endSpan(span, &err)
+
 	sa := StringArray(subjectIds)
 
 	// Query the database.
@@ -322,7 +292,7 @@ func PermissionsForSubjectsAndResource(
 	          AND r.name = $3
 	          WINDOW w AS (PARTITION BY r.id ORDER BY pl.precedence)
             ORDER BY r.id`
-	rows, err := tx.Query(query, &sa, resourceTypeName, resourceName)
+	rows, err := tx.QueryContext(ctx, query, &sa, resourceTypeName, resourceName)
 	if err != nil {
 		return nil, err
 	}
@@ -334,8 +304,11 @@ func PermissionsForSubjectsAndResource(
 // PermissionsForSubjectsAndResourceMinLevel lists permissions of at least the minimum level that have been granted
 // to zero or more subjects for a specific resource.
 func PermissionsForSubjectsAndResourceMinLevel(
-	tx *sql.Tx, subjectIds []string, resourceTypeName, resourceName, minLevel string,
-) ([]*models.Permission, error) {
+	ctx context.Context, tx *sql.Tx, subjectIds []string, resourceTypeName, resourceName, minLevel string,
+) (_ []*models.Permission, err error) {
+	ctx, span := startSpan(ctx, "PermissionsForSubjectsAndResourceMinLevel")
+	defer endSpan(span, &err)
+
 	sa := StringArray(subjectIds)
 
 	// Query the database.
@@ -359,7 +332,7 @@ func PermissionsForSubjectsAndResourceMinLevel(
             AND pl.precedence <= (SELECT precedence FROM permission_levels WHERE name = $4)
 	          WINDOW w AS (PARTITION BY r.id ORDER BY pl.precedence)
             ORDER BY r.id`
-	rows, err := tx.Query(query, &sa, resourceTypeName, resourceName, minLevel)
+	rows, err := tx.QueryContext(ctx, query, &sa, resourceTypeName, resourceName, minLevel)
 	if err != nil {
 		return nil, err
 	}
@@ -369,7 +342,9 @@ func PermissionsForSubjectsAndResourceMinLevel(
 }
 
 // GetPermissionByID obtains information about a specific permission.
-func GetPermissionByID(tx *sql.Tx, permissionID string) (*models.Permission, error) {
+func GetPermissionByID(ctx context.Context, tx *sql.Tx, permissionID string) (_ *models.Permission, err error) {
+	ctx, span := startSpan(ctx, "GetPermissionByID")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := `SELECT p.id AS id,
@@ -386,7 +361,7 @@ func GetPermissionByID(tx *sql.Tx, permissionID string) (*models.Permission, err
 	          JOIN resources r ON p.resource_id = r.id
 	          JOIN resource_types rt ON r.resource_type_id = rt.id
 	          WHERE p.id = $1`
-	rows, err := tx.Query(query, &permissionID)
+	rows, err := tx.QueryContext(ctx, query, &permissionID)
 	if err != nil {
 		return nil, err
 	}
@@ -411,11 +386,13 @@ func GetPermissionByID(tx *sql.Tx, permissionID string) (*models.Permission, err
 }
 
 // GetPermissionLevelIDByName returns the identifier for the permission level with the given name.
-func GetPermissionLevelIDByName(tx *sql.Tx, level models.PermissionLevel) (*string, error) {
+func GetPermissionLevelIDByName(ctx context.Context, tx *sql.Tx, level models.PermissionLevel) (_ *string, err error) {
+	ctx, span := startSpan(ctx, "GetPermissionLevelIDByName")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := "SELECT id FROM permission_levels WHERE name = $1"
-	rows, err := tx.Query(query, string(level))
+	rows, err := tx.QueryContext(ctx, query, string(level))
 	if err != nil {
 		return nil, err
 	}
@@ -446,18 +423,20 @@ func GetPermissionLevelIDByName(tx *sql.Tx, level models.PermissionLevel) (*stri
 
 // UpsertPermission updates a permission or inserts it if it doesn't exist.
 func UpsertPermission(
-	tx *sql.Tx,
+	ctx context.Context, tx *sql.Tx,
 	subjectID models.InternalSubjectID,
 	resourceID string,
 	permissionLevelID string,
-) (*models.Permission, error) {
+) (_ *models.Permission, err error) {
+	ctx, span := startSpan(ctx, "UpsertPermission")
+	defer endSpan(span, &err)
 
 	// Update the database.
 	stmt := `INSERT INTO permissions (subject_id, resource_id, permission_level_id) VALUES ($1, $2, $3)
 	         ON CONFLICT (subject_id, resource_id) DO UPDATE
 	         SET permission_level_id = EXCLUDED.permission_level_id
 	         RETURNING id`
-	row := tx.QueryRow(stmt, string(subjectID), resourceID, permissionLevelID)
+	row := tx.QueryRowContext(ctx, stmt, string(subjectID), resourceID, permissionLevelID)
 
 	// Extract the permission ID.
 	var permissionID string
@@ -466,7 +445,7 @@ func UpsertPermission(
 	}
 
 	// Look up the permission.
-	permission, err := GetPermissionByID(tx, permissionID)
+	permission, err := GetPermissionByID(ctx, tx, permissionID)
 	if err != nil {
 		return nil, err
 	} else if permission == nil {
@@ -477,10 +456,12 @@ func UpsertPermission(
 
 // GetPermission gets a subject's permission to a specific resource if it exists.
 func GetPermission(
-	tx *sql.Tx,
+	ctx context.Context, tx *sql.Tx,
 	subjectID models.InternalSubjectID,
 	resourceID string,
-) (*models.Permission, error) {
+) (_ *models.Permission, err error) {
+	ctx, span := startSpan(ctx, "GetPermission")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := `SELECT p.id AS id,
@@ -498,7 +479,7 @@ func GetPermission(
 	          JOIN resource_types rt ON r.resource_type_id = rt.id
 	          WHERE p.subject_id = $1
 	          AND p.resource_id = $2`
-	rows, err := tx.Query(query, string(subjectID), resourceID)
+	rows, err := tx.QueryContext(ctx, query, string(subjectID), resourceID)
 	if err != nil {
 		return nil, err
 	}
@@ -523,11 +504,13 @@ func GetPermission(
 }
 
 // DeletePermission removes a permission from the database.
-func DeletePermission(tx *sql.Tx, id models.PermissionID) error {
+func DeletePermission(ctx context.Context, tx *sql.Tx, id models.PermissionID) (err error) {
+	ctx, span := startSpan(ctx, "DeletePermission")
+	defer endSpan(span, &err)
 
 	// Update the database.
 	stmt := "DELETE FROM permissions WHERE id = $1"
-	result, err := tx.Exec(stmt, string(id))
+	result, err := tx.ExecContext(ctx, stmt, string(id))
 	if err != nil {
 		return err
 	}
@@ -548,7 +531,9 @@ func DeletePermission(tx *sql.Tx, id models.PermissionID) error {
 }
 
 // CopyPermissions copies permissions from one subject to another.
-func CopyPermissions(tx *sql.Tx, source, dest *models.SubjectOut) error {
+func CopyPermissions(ctx context.Context, tx *sql.Tx, source, dest *models.SubjectOut) (err error) {
+	ctx, span := startSpan(ctx, "CopyPermissions")
+	defer endSpan(span, &err)
 
 	// Copy or update permissions.
 	stmt := `INSERT INTO permissions AS d (subject_id, resource_id, permission_level_id)
@@ -559,7 +544,265 @@ func CopyPermissions(tx *sql.Tx, source, dest *models.SubjectOut) error {
                WHERE id IN (d.permission_level_id, EXCLUDED.permission_level_id)
                ORDER BY precedence LIMIT 1
            )`
-	_, err := tx.Exec(stmt, &source.ID, &dest.ID)
+	_, err = tx.ExecContext(ctx, stmt, &source.ID, &dest.ID)
 
 	return err
 }
+
+// ListSubjectPermissions lists permissions granted directly to the subject with the given internal ID.
+func ListSubjectPermissions(
+	ctx context.Context, tx *sql.Tx, id models.InternalSubjectID,
+) (_ []*models.Permission, err error) {
+	ctx, span := startSpan(ctx, "ListSubjectPermissions")
+	defer endSpan(span, &err)
+
+	// Query the database.
+	query := `SELECT p.id AS id,
+	                 s.id AS internal_subject_id,
+	                 s.subject_id AS subject_id,
+	                 s.subject_type AS subject_type,
+	                 r.id AS resource_id,
+	                 r.name AS resource_name,
+	                 rt.name AS resource_type,
+	                 pl.name AS permission_level
+	          FROM permissions p
+	          JOIN permission_levels pl ON p.permission_level_id = pl.id
+	          JOIN subjects s ON p.subject_id = s.id
+	          JOIN resources r ON p.resource_id = r.id
+	          JOIN resource_types rt ON r.resource_type_id = rt.id
+	          WHERE s.id = $1
+	          ORDER BY rt.name, r.name`
+	rows, err := tx.QueryContext(ctx, query, string(id))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return rowsToPermissionList(rows)
+}
+
+// ListPermissionConflicts lists the resources to which both the source and destination subjects have been granted
+// permissions. The resolved permission level for each conflict is the level that CopyPermissions would retain.
+func ListPermissionConflicts(
+	ctx context.Context, tx *sql.Tx, source, dest *models.SubjectOut,
+) (_ []*models.PermissionConflict, err error) {
+	ctx, span := startSpan(ctx, "ListPermissionConflicts")
+	defer endSpan(span, &err)
+
+	// Query the database.
+	query := `SELECT r.id AS resource_id,
+	                 r.name AS resource_name,
+	                 rt.name AS resource_type,
+	                 spl.name AS source_level,
+	                 dpl.name AS target_level,
+	                 CASE WHEN spl.precedence <= dpl.precedence THEN spl.name ELSE dpl.name END AS resolved_level
+	          FROM permissions sp
+	          JOIN permissions dp ON sp.resource_id = dp.resource_id
+	          JOIN permission_levels spl ON sp.permission_level_id = spl.id
+	          JOIN permission_levels dpl ON dp.permission_level_id = dpl.id
+	          JOIN resources r ON sp.resource_id = r.id
+	          JOIN resource_types rt ON r.resource_type_id = rt.id
+	          WHERE sp.subject_id = $1 AND dp.subject_id = $2
+	          ORDER BY rt.name, r.name`
+	rows, err := tx.QueryContext(ctx, query, string(*source.ID), string(*dest.ID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Build the list of conflicts.
+	conflicts := make([]*models.PermissionConflict, 0)
+	for rows.Next() {
+		var resource models.ResourceOut
+		var conflict models.PermissionConflict
+		err := rows.Scan(
+			&resource.ID, &resource.Name, &resource.ResourceType, &conflict.SourceLevel, &conflict.TargetLevel,
+			&conflict.ResolvedLevel,
+		)
+		if err != nil {
+			return nil, err
+		}
+		conflict.Resource = &resource
+		conflicts = append(conflicts, &conflict)
+	}
+
+	return conflicts, nil
+}
+
+// Merge policies that can be used when copying permissions between resources.
+const (
+	MergePolicyKeepHigher   = "keep-higher"
+	MergePolicyOverwrite    = "overwrite"
+	MergePolicySkipExisting = "skip-existing"
+)
+
+// CopyResourcePermissions copies permissions from one resource to another. The merge policy determines how conflicts
+// with existing permissions for the destination resource are resolved.
+func CopyResourcePermissions(
+	ctx context.Context, tx *sql.Tx, source, dest *models.ResourceOut, mergePolicy string,
+) (err error) {
+	ctx, span := startSpan(ctx, "CopyResourcePermissions")
+	defer endSpan(span, &err)
+
+	// Determine how to handle conflicts.
+	var onConflict string
+	switch mergePolicy {
+	case MergePolicyKeepHigher:
+		onConflict = `DO UPDATE SET permission_level_id = (
+               SELECT id FROM permission_levels
+               WHERE id IN (d.permission_level_id, EXCLUDED.permission_level_id)
+               ORDER BY precedence LIMIT 1
+           )`
+	case MergePolicyOverwrite:
+		onConflict = "DO UPDATE SET permission_level_id = EXCLUDED.permission_level_id"
+	case MergePolicySkipExisting:
+		onConflict = "DO NOTHING"
+	default:
+		return fmt.Errorf("unrecognized merge policy: %s", mergePolicy)
+	}
+
+	// Copy or update permissions.
+	stmt := `INSERT INTO permissions AS d (subject_id, resource_id, permission_level_id)
+           SELECT subject_id, $2, permission_level_id
+           FROM permissions WHERE resource_id = $1
+           ON CONFLICT (subject_id, resource_id) ` + onConflict
+	_, err = tx.ExecContext(ctx, stmt, source.ID, dest.ID)
+
+	return err
+}
+
+// permissionListBuilder returns a query builder that selects permissions in the format expected by
+// rowsToPermissionList.
+func permissionListBuilder() sq.SelectBuilder {
+	return psql.Select(
+		"p.id AS id",
+		"s.id AS internal_subject_id",
+		"s.subject_id AS subject_id",
+		"s.subject_type AS subject_type",
+		"r.id AS resource_id",
+		"r.name AS resource_name",
+		"rt.name AS resource_type",
+		"pl.name AS permission_level",
+	).
+		From("permissions p").
+		Join("permission_levels pl ON p.permission_level_id = pl.id").
+		Join("subjects s ON p.subject_id = s.id").
+		Join("resources r ON p.resource_id = r.id").
+		Join("resource_types rt ON r.resource_type_id = rt.id")
+}
+
+// mostPermissiveBuilder returns a query builder that selects the most permissive permission for each resource in the
+// format expected by rowsToPermissionList, sorted by resource ID. The window and the ORDER BY clause are added as a
+// suffix because Squirrel doesn't have explicit support for the WINDOW clause, so conditions can still be added.
+func mostPermissiveBuilder() sq.SelectBuilder {
+	return psql.Select(
+		"first_value(p.id) OVER w AS id",
+		"first_value(s.id) OVER w AS internal_subject_id",
+		"first_value(s.subject_id) OVER w AS subject_id",
+		"first_value(s.subject_type) OVER w AS subject_type",
+		"r.id AS resource_id",
+		"first_value(r.name) OVER w AS resource_name",
+		"first_value(rt.name) OVER w AS resource_type",
+		"first_value(pl.name) OVER w AS permission_level",
+	).Distinct().Options("ON (r.id)").
+		From("permissions p").
+		Join("permission_levels pl ON p.permission_level_id = pl.id").
+		Join("subjects s ON p.subject_id = s.id").
+		Join("resources r ON p.resource_id = r.id").
+		Join("resource_types rt ON r.resource_type_id = rt.id").
+		Suffix("WINDOW w AS (PARTITION BY r.id ORDER BY pl.precedence) ORDER BY r.id")
+}
+
+// queryPermissionList executes a query built by permissionListBuilder or mostPermissiveBuilder and returns the
+// resulting permissions.
+func queryPermissionList(ctx context.Context, tx *sql.Tx, builder sq.SelectBuilder) ([]*models.Permission, error) {
+
+	// Generate the query.
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute the query.
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return rowsToPermissionList(rows)
+}
+
+// FindSubjectPermissions lists permissions granted directly to the subject with the given internal ID, optionally
+// limiting the results to a single resource type or to a minimum permission level.
+func FindSubjectPermissions(
+	ctx context.Context, tx *sql.Tx, id models.InternalSubjectID, resourceTypeName, minLevel *string,
+) (_ []*models.Permission, err error) {
+	ctx, span := startSpan(ctx, "FindSubjectPermissions")
+	defer endSpan(span, &err)
+
+	// Begin building the query.
+	builder := permissionListBuilder().Where(sq.Eq{"s.id": string(id)})
+
+	// Add the resource type filter if a resource type was specified.
+	if resourceTypeName != nil {
+		builder = builder.Where(sq.Eq{"rt.name": *resourceTypeName})
+	}
+
+	// Add the permission level expression if a minimum level was specified.
+	if minLevel != nil {
+		builder = builder.Where(permissionLevelPrecedenceExpression("pl.precedence <=", *minLevel))
+	}
+
+	return queryPermissionList(ctx, tx, builder.OrderBy("rt.name", "r.name"))
+}
+
+// FindResourcePermissions lists permissions granted for the resource with the given ID, optionally excluding
+// permissions granted at the given permission level.
+func FindResourcePermissions(
+	ctx context.Context, tx *sql.Tx, resourceID string, excludedLevel *models.PermissionLevel,
+) (_ []*models.Permission, err error) {
+	ctx, span := startSpan(ctx, "FindResourcePermissions")
+	defer endSpan(span, &err)
+
+	// Begin building the query.
+	builder := permissionListBuilder().Where(sq.Eq{"r.id": resourceID})
+
+	// Exclude the permission level if one was specified.
+	if excludedLevel != nil {
+		builder = builder.Where(sq.NotEq{"pl.name": string(*excludedLevel)})
+	}
+
+	return queryPermissionList(ctx, tx, builder.OrderBy("s.subject_id"))
+}
+
+// DeletePermissions removes multiple permissions from the database.
+func DeletePermissions(ctx context.Context, tx *sql.Tx, permissions []*models.Permission) (err error) {
+	ctx, span := startSpan(ctx, "DeletePermissions")
+	defer endSpan(span, &err)
+
+	// Extract the permission IDs.
+	ids := make([]string, len(permissions))
+	for i, permission := range permissions {
+		ids[i] = string(*permission.ID)
+	}
+	sa := StringArray(ids)
+
+	// Update the database.
+	stmt := "DELETE FROM permissions WHERE id = any($1)"
+	result, err := tx.ExecContext(ctx, stmt, &sa)
+	if err != nil {
+		return err
+	}
+
+	// Verify that the expected number of rows was deleted.
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != int64(len(ids)) {
+		return fmt.Errorf("expected to delete %d permissions but %d were deleted", len(ids), count)
+	}
+
+	return nil
+}
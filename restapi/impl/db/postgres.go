@@ -0,0 +1,465 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+)
+
+// postgresStore is a Store backed by a PostgreSQL database. Read-only transactions are sent to the read replica if
+// one is configured.
+type postgresStore struct {
+	db             *sql.DB
+	replica        *sql.DB
+	replicaTimeout time.Duration
+	schema         string
+}
+
+// NewPostgresStore returns a Store that uses the tables in the given schema of a PostgreSQL database.
+func NewPostgresStore(db *sql.DB, schema string) Store {
+	return &postgresStore{db: db, schema: schema}
+}
+
+// NewReplicatedPostgresStore returns a Store that uses the tables in the given schema of a PostgreSQL database, sending
+// read-only transactions to a read replica. Read-only transactions fall back to the primary database if a transaction
+// can't be started on the replica within replicaTimeout. A replicaTimeout of zero disables the timeout, leaving only
+// the deadline of the request context.
+func NewReplicatedPostgresStore(db, replica *sql.DB, schema string, replicaTimeout time.Duration) Store {
+	return &postgresStore{db: db, replica: replica, replicaTimeout: replicaTimeout, schema: schema}
+}
+
+// beginner is implemented by both *sql.DB and *sql.Conn.
+type beginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// begin starts a new transaction and sets the search path so that the permissions schema is used.
+func (s *postgresStore) begin(ctx context.Context, b beginner, opts *sql.TxOptions) (*sql.Tx, error) {
+	tx, err := b.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("SET search_path TO %s", s.schema))
+	if err != nil {
+		tx.Rollback() // nolint:errcheck
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// beginPrimary starts a new transaction in the primary database.
+func (s *postgresStore) beginPrimary(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	tx, err := s.begin(ctx, s.db, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &postgresTx{ctx: ctx, tx: tx}, nil
+}
+
+// beginReplica starts a new transaction in the read replica. Connecting to the replica may take at most replicaTimeout,
+// so that an unreachable replica doesn't use up the time available to the request before the primary database can be
+// tried. The connection is returned to the pool when the transaction completes.
+func (s *postgresStore) beginReplica(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	connCtx := ctx
+	if s.replicaTimeout > 0 {
+		var cancel context.CancelFunc
+		connCtx, cancel = context.WithTimeout(ctx, s.replicaTimeout)
+		defer cancel()
+	}
+
+	conn, err := s.replica.Conn(connCtx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.begin(ctx, conn, opts)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &postgresTx{ctx: ctx, tx: tx, conn: conn}, nil
+}
+
+// Begin starts a new transaction in the primary database.
+func (s *postgresStore) Begin(ctx context.Context) (Tx, error) {
+	return s.beginPrimary(ctx, nil)
+}
+
+// BeginReadOnly starts a new read-only transaction in the read replica if one is configured, falling back to the
+// primary database if the replica is unavailable. Only failures to start the transaction cause a fallback; callers
+// that need to retry queries that fail after the transaction has been started can use UsesReplica to find out whether
+// the transaction was started in the replica.
+func (s *postgresStore) BeginReadOnly(ctx context.Context) (Tx, error) {
+	opts := &sql.TxOptions{ReadOnly: true}
+	if s.replica != nil {
+		tx, err := s.beginReplica(ctx, opts)
+		if err == nil {
+			return tx, nil
+		}
+		logger.FromContext(ctx).Warnf("unable to use the read replica, falling back to the primary database: %s", err)
+	}
+	return s.beginPrimary(ctx, opts)
+}
+
+// BeginTx starts a new transaction in the primary database using the given options.
+func (s *postgresStore) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	return s.beginPrimary(ctx, opts)
+}
+
+// UsesReplica returns true if a transaction was started in a read replica. Transactions that can be started in a read
+// replica report it by implementing an InReplica method.
+func UsesReplica(tx Tx) bool {
+	r, ok := tx.(interface{ InReplica() bool })
+	return ok && r.InReplica()
+}
+
+// CheckSchema returns an error if the given schema doesn't exist in a PostgreSQL database.
+func CheckSchema(ctx context.Context, db *sql.DB, schema string) error {
+	var count int
+	query := "SELECT count(*) FROM information_schema.schemata WHERE schema_name = $1"
+	if err := db.QueryRowContext(ctx, query, schema).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("schema %s not found", schema)
+	}
+	return nil
+}
+
+// postgresTx is a Tx that delegates to the query functions in this package. Transactions in the read replica hold on
+// to their connection, which is released when the transaction completes.
+type postgresTx struct {
+	ctx  context.Context
+	tx   *sql.Tx
+	conn *sql.Conn
+}
+
+func (t *postgresTx) Commit() error {
+	defer t.releaseConn()
+	return t.tx.Commit()
+}
+
+func (t *postgresTx) Rollback() error {
+	defer t.releaseConn()
+	return t.tx.Rollback()
+}
+
+// InReplica returns true if the transaction was started in the read replica.
+func (t *postgresTx) InReplica() bool {
+	return t.conn != nil
+}
+
+// releaseConn returns the connection used by a read replica transaction to the pool. Closing the connection more than
+// once has no effect.
+func (t *postgresTx) releaseConn() {
+	if t.conn != nil {
+		t.conn.Close()
+	}
+}
+
+func (t *postgresTx) TryLock(key int64) (bool, error) {
+	return TryLock(t.ctx, t.tx, key)
+}
+
+func (t *postgresTx) ListResourceTypes(resourceTypeName *string) ([]*models.ResourceTypeOut, error) {
+	return ListResourceTypes(t.ctx, t.tx, resourceTypeName)
+}
+
+func (t *postgresTx) GetResourceTypeByName(name *string) (*models.ResourceTypeOut, error) {
+	return GetResourceTypeByName(t.ctx, t.tx, name)
+}
+
+func (t *postgresTx) GetDuplicateResourceTypeByName(id *string, name *string) (*models.ResourceTypeOut, error) {
+	return GetDuplicateResourceTypeByName(t.ctx, t.tx, id, name)
+}
+
+func (t *postgresTx) GetResourceType(id *string) (*models.ResourceTypeOut, error) {
+	return GetResourceType(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) ResourceTypeExists(id *string) (bool, error) {
+	return ResourceTypeExists(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) AddNewResourceType(resourceTypeIn *models.ResourceTypeIn) (*models.ResourceTypeOut, error) {
+	return AddNewResourceType(t.ctx, t.tx, resourceTypeIn)
+}
+
+func (t *postgresTx) UpdateResourceType(
+	id *string, resourceTypeIn *models.ResourceTypeIn,
+) (*models.ResourceTypeOut, error) {
+	return UpdateResourceType(t.ctx, t.tx, id, resourceTypeIn)
+}
+
+func (t *postgresTx) DeleteResourceType(id *string) error {
+	return DeleteResourceType(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) GetResourceTypeVersion(id *string) (*int64, error) {
+	return GetResourceTypeVersion(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) LockResourceTypeVersion(id *string) (*int64, error) {
+	return LockResourceTypeVersion(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) CountResourcesOfType(resourceTypeID *string) (int64, error) {
+	return CountResourcesOfType(t.ctx, t.tx, resourceTypeID)
+}
+
+func (t *postgresTx) ResourceExists(id *string) (bool, error) {
+	return ResourceExists(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) GetResource(id *string) (*models.ResourceOut, error) {
+	return GetResource(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) GetResourceByName(name *string, resourceTypeID *string) (*models.ResourceOut, error) {
+	return GetResourceByName(t.ctx, t.tx, name, resourceTypeID)
+}
+
+func (t *postgresTx) GetResourceByNameAndType(name, resourceTypeName string) (*models.ResourceOut, error) {
+	return GetResourceByNameAndType(t.ctx, t.tx, name, resourceTypeName)
+}
+
+func (t *postgresTx) GetDuplicateResourceByName(id *string, name *string) (*models.ResourceOut, error) {
+	return GetDuplicateResourceByName(t.ctx, t.tx, id, name)
+}
+
+func (t *postgresTx) AddResource(name *string, resourceTypeID *string) (*models.ResourceOut, error) {
+	return AddResource(t.ctx, t.tx, name, resourceTypeID)
+}
+
+func (t *postgresTx) UpdateResource(id *string, name *string) (*models.ResourceOut, error) {
+	return UpdateResource(t.ctx, t.tx, id, name)
+}
+
+func (t *postgresTx) ListResources(
+	resourceTypeName, resourceName *string, selector LabelSelector,
+) ([]*models.ResourceOut, error) {
+	return ListResources(t.ctx, t.tx, resourceTypeName, resourceName, selector)
+}
+
+func (t *postgresTx) SetResourceLabels(id *string, labels map[string]string) error {
+	return SetResourceLabels(t.ctx, t.tx, id, labels)
+}
+
+func (t *postgresTx) DeleteResource(id *string) error {
+	return DeleteResource(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) LockResource(id *string) error {
+	return LockResource(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) GetResourceVersion(id *string) (*int64, error) {
+	return GetResourceVersion(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) LockResourceVersion(id *string) (*int64, error) {
+	return LockResourceVersion(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) AddSubject(
+	subjectID models.ExternalSubjectID, subjectType models.SubjectType,
+) (*models.SubjectOut, error) {
+	return AddSubject(t.ctx, t.tx, subjectID, subjectType)
+}
+
+func (t *postgresTx) UpdateSubject(
+	id models.InternalSubjectID, subjectID models.ExternalSubjectID, subjectType models.SubjectType,
+) (*models.SubjectOut, error) {
+	return UpdateSubject(t.ctx, t.tx, id, subjectID, subjectType)
+}
+
+func (t *postgresTx) SubjectIDExists(subjectID models.ExternalSubjectID) (bool, error) {
+	return SubjectIDExists(t.ctx, t.tx, subjectID)
+}
+
+func (t *postgresTx) SubjectExists(id models.InternalSubjectID) (bool, error) {
+	return SubjectExists(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) DuplicateSubjectExists(
+	id models.InternalSubjectID, subjectID models.ExternalSubjectID,
+) (bool, error) {
+	return DuplicateSubjectExists(t.ctx, t.tx, id, subjectID)
+}
+
+func (t *postgresTx) ListSubjects(subjectType, subjectID *string) ([]*models.SubjectOut, error) {
+	return ListSubjects(t.ctx, t.tx, subjectType, subjectID)
+}
+
+func (t *postgresTx) DeleteSubject(id models.InternalSubjectID) error {
+	return DeleteSubject(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) GetSubject(
+	subjectID models.ExternalSubjectID, subjectType models.SubjectType,
+) (*models.SubjectOut, error) {
+	return GetSubject(t.ctx, t.tx, subjectID, subjectType)
+}
+
+func (t *postgresTx) GetSubjectByExternalID(subjectID models.ExternalSubjectID) (*models.SubjectOut, error) {
+	return GetSubjectByExternalID(t.ctx, t.tx, subjectID)
+}
+
+func (t *postgresTx) GetSubjectByID(id models.InternalSubjectID) (*models.SubjectOut, error) {
+	return GetSubjectByID(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) GetSubjectVersion(id models.InternalSubjectID) (*int64, error) {
+	return GetSubjectVersion(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) LockSubjectVersion(id models.InternalSubjectID) (*int64, error) {
+	return LockSubjectVersion(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) ListPermissions(selector LabelSelector) ([]*models.Permission, error) {
+	return ListPermissions(t.ctx, t.tx, selector)
+}
+
+func (t *postgresTx) ListResourcePermissions(resourceTypeName, resourceName string) ([]*models.Permission, error) {
+	return ListResourcePermissions(t.ctx, t.tx, resourceTypeName, resourceName)
+}
+
+func (t *postgresTx) ListSubjectPermissions(id models.InternalSubjectID) ([]*models.Permission, error) {
+	return ListSubjectPermissions(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) FindSubjectPermissions(
+	id models.InternalSubjectID, resourceTypeName, minLevel *string,
+) ([]*models.Permission, error) {
+	return FindSubjectPermissions(t.ctx, t.tx, id, resourceTypeName, minLevel)
+}
+
+func (t *postgresTx) FindResourcePermissions(
+	resourceID string, excludedLevel *models.PermissionLevel,
+) ([]*models.Permission, error) {
+	return FindResourcePermissions(t.ctx, t.tx, resourceID, excludedLevel)
+}
+
+func (t *postgresTx) GetPermissionByID(permissionID string) (*models.Permission, error) {
+	return GetPermissionByID(t.ctx, t.tx, permissionID)
+}
+
+func (t *postgresTx) GetPermission(subjectID models.InternalSubjectID, resourceID string) (*models.Permission, error) {
+	return GetPermission(t.ctx, t.tx, subjectID, resourceID)
+}
+
+func (t *postgresTx) GetPermissionLevelIDByName(level models.PermissionLevel) (*string, error) {
+	return GetPermissionLevelIDByName(t.ctx, t.tx, level)
+}
+
+func (t *postgresTx) GetPermissionVersion(subjectID models.InternalSubjectID, resourceID string) (*int64, error) {
+	return GetPermissionVersion(t.ctx, t.tx, subjectID, resourceID)
+}
+
+func (t *postgresTx) LockPermissionVersion(subjectID models.InternalSubjectID, resourceID string) (*int64, error) {
+	return LockPermissionVersion(t.ctx, t.tx, subjectID, resourceID)
+}
+
+func (t *postgresTx) UpsertPermission(
+	subjectID models.InternalSubjectID, resourceID string, permissionLevelID string,
+) (*models.Permission, error) {
+	return UpsertPermission(t.ctx, t.tx, subjectID, resourceID, permissionLevelID)
+}
+
+func (t *postgresTx) DeletePermission(id models.PermissionID) error {
+	return DeletePermission(t.ctx, t.tx, id)
+}
+
+func (t *postgresTx) DeletePermissions(permissions []*models.Permission) error {
+	return DeletePermissions(t.ctx, t.tx, permissions)
+}
+
+func (t *postgresTx) CopyPermissions(source, dest *models.SubjectOut) error {
+	return CopyPermissions(t.ctx, t.tx, source, dest)
+}
+
+func (t *postgresTx) CopyResourcePermissions(source, dest *models.ResourceOut, mergePolicy string) error {
+	return CopyResourcePermissions(t.ctx, t.tx, source, dest, mergePolicy)
+}
+
+func (t *postgresTx) ListPermissionConflicts(source, dest *models.SubjectOut) ([]*models.PermissionConflict, error) {
+	return ListPermissionConflicts(t.ctx, t.tx, source, dest)
+}
+
+func (t *postgresTx) PermissionsForSubjects(subjectIds []string) ([]*models.Permission, error) {
+	return PermissionsForSubjects(t.ctx, t.tx, subjectIds)
+}
+
+func (t *postgresTx) PermissionsForSubjectsMinLevel(
+	subjectIds []string, minLevel string,
+) ([]*models.Permission, error) {
+	return PermissionsForSubjectsMinLevel(t.ctx, t.tx, subjectIds, minLevel)
+}
+
+func (t *postgresTx) PermissionsForSubjectsAndResourceType(
+	subjectIds []string, resourceTypeName string, selector LabelSelector,
+) ([]*models.Permission, error) {
+	return PermissionsForSubjectsAndResourceType(t.ctx, t.tx, subjectIds, resourceTypeName, selector)
+}
+
+func (t *postgresTx) PermissionsForSubjectsAndResourceTypeMinLevel(
+	subjectIds []string, resourceTypeName, minLevel string, selector LabelSelector,
+) ([]*models.Permission, error) {
+	return PermissionsForSubjectsAndResourceTypeMinLevel(
+		t.ctx, t.tx, subjectIds, resourceTypeName, minLevel, selector,
+	)
+}
+
+func (t *postgresTx) PermissionsForSubjectsAndResource(
+	subjectIds []string, resourceTypeName, resourceName string,
+) ([]*models.Permission, error) {
+	return PermissionsForSubjectsAndResource(t.ctx, t.tx, subjectIds, resourceTypeName, resourceName)
+}
+
+func (t *postgresTx) PermissionsForSubjectsAndResourceMinLevel(
+	subjectIds []string, resourceTypeName, resourceName, minLevel string,
+) ([]*models.Permission, error) {
+	return PermissionsForSubjectsAndResourceMinLevel(t.ctx, t.tx, subjectIds, resourceTypeName, resourceName, minLevel)
+}
+
+func (t *postgresTx) AbbreviatedPermissionsForSubjectAndResourceType(
+	subjectIDs []string, resourceTypeName string, minLevel *string, selector LabelSelector,
+) ([]*models.AbbreviatedPermission, error) {
+	return AbbreviatedPermissionsForSubjectAndResourceType(
+		t.ctx, t.tx, subjectIDs, resourceTypeName, minLevel, selector,
+	)
+}
+
+func (t *postgresTx) EachResourceType(f func(*models.ResourceTypeOut) error) error {
+	return EachResourceType(t.ctx, t.tx, f)
+}
+
+func (t *postgresTx) EachSubject(f func(*models.SubjectOut) error) error {
+	return EachSubject(t.ctx, t.tx, f)
+}
+
+func (t *postgresTx) EachResource(f func(*models.ResourceOut) error) error {
+	return EachResource(t.ctx, t.tx, f)
+}
+
+func (t *postgresTx) EachPermission(f func(*models.Permission) error) error {
+	return EachPermission(t.ctx, t.tx, f)
+}
+
+func (t *postgresTx) LatestChangeSeq() (int64, error) {
+	return LatestChangeSeq(t.ctx, t.tx)
+}
+
+func (t *postgresTx) ListChanges(since int64, limit int) ([]*Change, error) {
+	return ListChanges(t.ctx, t.tx, since, limit)
+}
+
+func (t *postgresTx) PruneChanges(before time.Time) (int64, error) {
+	return PruneChanges(t.ctx, t.tx, before)
+}
@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDriver is a database driver that accepts transactions and statements without doing anything, so that the
+// database selection logic in postgresStore can be tested without a database. Connections to the data source named
+// "unavailable" fail, connections to the data source named "hanging" don't complete until the context is canceled,
+// and the number of transactions started in each data source is recorded.
+type fakeDriver struct {
+	mu     sync.Mutex
+	begins map[string]int
+}
+
+var testDriver = &fakeDriver{begins: make(map[string]int)}
+
+func init() {
+	sql.Register("permissions-fake", testDriver)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	if name == "unavailable" {
+		return nil, errors.New("connection refused")
+	}
+	return &fakeConn{driver: d, name: name}, nil
+}
+
+func (d *fakeDriver) OpenConnector(name string) (driver.Connector, error) {
+	return &fakeConnector{driver: d, name: name}, nil
+}
+
+func (d *fakeDriver) beginCount(name string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.begins[name]
+}
+
+type fakeConnector struct {
+	driver *fakeDriver
+	name   string
+}
+
+func (c *fakeConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if c.name == "hanging" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return c.driver.Open(c.name)
+}
+
+func (c *fakeConnector) Driver() driver.Driver { return c.driver }
+
+type fakeConn struct {
+	driver *fakeDriver
+	name   string
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	c.driver.begins[c.name]++
+	return fakeTx{}, nil
+}
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return driver.RowsAffected(0), nil }
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return nil, errors.New("not supported") }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+func openFakeDB(t *testing.T, name string) *sql.DB {
+	db, err := sql.Open("permissions-fake", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestBeginReadOnlyUsesReplica(t *testing.T) {
+	store := NewReplicatedPostgresStore(openFakeDB(t, "primary1"), openFakeDB(t, "replica1"), "permissions", time.Second)
+
+	tx, err := store.BeginReadOnly(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	if testDriver.beginCount("replica1") != 1 || testDriver.beginCount("primary1") != 0 {
+		t.Error("the read-only transaction wasn't started in the replica")
+	}
+	if !UsesReplica(tx) {
+		t.Error("the transaction wasn't reported as using the replica")
+	}
+}
+
+func TestBeginReadOnlyFallsBackToPrimary(t *testing.T) {
+	store := NewReplicatedPostgresStore(
+		openFakeDB(t, "primary2"), openFakeDB(t, "unavailable"), "permissions", time.Second,
+	)
+
+	tx, err := store.BeginReadOnly(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	if testDriver.beginCount("primary2") != 1 {
+		t.Error("the read-only transaction wasn't started in the primary database")
+	}
+	if UsesReplica(tx) {
+		t.Error("the transaction was reported as using the replica")
+	}
+}
+
+func TestBeginReadOnlyReplicaTimeout(t *testing.T) {
+	store := NewReplicatedPostgresStore(
+		openFakeDB(t, "primary4"), openFakeDB(t, "hanging"), "permissions", 50*time.Millisecond,
+	)
+
+	// The request context has a much longer deadline than the replica timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	start := time.Now()
+	tx, err := store.BeginReadOnly(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("falling back to the primary database took too long: %s", elapsed)
+	}
+	if testDriver.beginCount("primary4") != 1 {
+		t.Error("the read-only transaction wasn't started in the primary database")
+	}
+}
+
+func TestBeginUsesPrimary(t *testing.T) {
+	store := NewReplicatedPostgresStore(openFakeDB(t, "primary3"), openFakeDB(t, "replica3"), "permissions", time.Second)
+
+	tx, err := store.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	if testDriver.beginCount("primary3") != 1 || testDriver.beginCount("replica3") != 0 {
+		t.Error("the transaction wasn't started in the primary database")
+	}
+}
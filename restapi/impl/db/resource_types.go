@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -8,17 +9,20 @@ import (
 )
 
 // ListResourceTypes lists all defined resource types.
-func ListResourceTypes(tx *sql.Tx, resourceTypeName *string) ([]*models.ResourceTypeOut, error) {
+func ListResourceTypes(
+	ctx context.Context, tx *sql.Tx, resourceTypeName *string,
+) (_ []*models.ResourceTypeOut, err error) {
+	ctx, span := startSpan(ctx, "ListResourceTypes")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	var rows *sql.Rows
-	var err error
 	if resourceTypeName == nil {
 		query := "SELECT id, name, description FROM resource_types"
-		rows, err = tx.Query(query)
+		rows, err = tx.QueryContext(ctx, query)
 	} else {
 		query := "SELECT id, name, description FROM resource_types WHERE name = $1"
-		rows, err = tx.Query(query, *resourceTypeName)
+		rows, err = tx.QueryContext(ctx, query, *resourceTypeName)
 	}
 	if err != nil {
 		return nil, err
@@ -44,13 +48,15 @@ func ListResourceTypes(tx *sql.Tx, resourceTypeName *string) ([]*models.Resource
 }
 
 // GetResourceTypeByName gets information about the resource type with the given name.
-func GetResourceTypeByName(tx *sql.Tx, name *string) (*models.ResourceTypeOut, error) {
+func GetResourceTypeByName(ctx context.Context, tx *sql.Tx, name *string) (_ *models.ResourceTypeOut, err error) {
+	ctx, span := startSpan(ctx, "GetResourceTypeByName")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := `SELECT id, name, description FROM resource_types
 	          WHERE lower(trim(regexp_replace(name, '\s+', ' ', 'g')))
 	              = lower(trim(regexp_replace($1, '\s+', ' ', 'g')))`
-	rows, err := tx.Query(query, name)
+	rows, err := tx.QueryContext(ctx, query, name)
 	if err != nil {
 		return nil, err
 	}
@@ -80,14 +86,18 @@ func GetResourceTypeByName(tx *sql.Tx, name *string) (*models.ResourceTypeOut, e
 
 // GetDuplicateResourceTypeByName returns information about the resource type with the given name, and checks for
 // duplicates
-func GetDuplicateResourceTypeByName(tx *sql.Tx, id *string, name *string) (*models.ResourceTypeOut, error) {
+func GetDuplicateResourceTypeByName(
+	ctx context.Context, tx *sql.Tx, id *string, name *string,
+) (_ *models.ResourceTypeOut, err error) {
+	ctx, span := startSpan(ctx, "GetDuplicateResourceTypeByName")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := `SELECT id, name, description FROM resource_types
 	          WHERE id != $1
 	          AND lower(trim(regexp_replace(name, '\s+', ' ', 'g')))
 	            = lower(trim(regexp_replace($2, '\s+', ' ', 'g')))`
-	rows, err := tx.Query(query, id, name)
+	rows, err := tx.QueryContext(ctx, query, id, name)
 	if err != nil {
 		return nil, err
 	}
@@ -115,12 +125,49 @@ func GetDuplicateResourceTypeByName(tx *sql.Tx, id *string, name *string) (*mode
 	return resourceTypes[0], nil
 }
 
+// GetResourceType gets information about the resource type with the given ID.
+func GetResourceType(ctx context.Context, tx *sql.Tx, id *string) (_ *models.ResourceTypeOut, err error) {
+	ctx, span := startSpan(ctx, "GetResourceType")
+	defer endSpan(span, &err)
+
+	// Query the database.
+	query := "SELECT id, name, description FROM resource_types WHERE id = $1"
+	rows, err := tx.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Get the resource type.
+	resourceTypes := make([]*models.ResourceTypeOut, 0)
+	for rows.Next() {
+		var resourceType models.ResourceTypeOut
+		if err := rows.Scan(&resourceType.ID, &resourceType.Name, &resourceType.Description); err != nil {
+			return nil, err
+		}
+		resourceTypes = append(resourceTypes, &resourceType)
+	}
+
+	// Check for duplicates. The ID is the primary key so this shouldn't happen.
+	if len(resourceTypes) > 1 {
+		return nil, fmt.Errorf("found multiple resource types with the ID: %s", *id)
+	}
+
+	// Return the result.
+	if len(resourceTypes) < 1 {
+		return nil, nil
+	}
+	return resourceTypes[0], nil
+}
+
 // ResourceTypeExists determines whether or not the resource type with the given ID exists.
-func ResourceTypeExists(tx *sql.Tx, id *string) (bool, error) {
+func ResourceTypeExists(ctx context.Context, tx *sql.Tx, id *string) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "ResourceTypeExists")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := "SELECT count(*) FROM resource_types WHERE id = $1"
-	row := tx.QueryRow(query, id)
+	row := tx.QueryRowContext(ctx, query, id)
 
 	// Get the result.
 	var count uint32
@@ -131,13 +178,17 @@ func ResourceTypeExists(tx *sql.Tx, id *string) (bool, error) {
 }
 
 // AddNewResourceType adds a new resource type to the database.
-func AddNewResourceType(tx *sql.Tx, resourceTypeIn *models.ResourceTypeIn) (*models.ResourceTypeOut, error) {
+func AddNewResourceType(
+	ctx context.Context, tx *sql.Tx, resourceTypeIn *models.ResourceTypeIn,
+) (_ *models.ResourceTypeOut, err error) {
+	ctx, span := startSpan(ctx, "AddNewResourceType")
+	defer endSpan(span, &err)
 
 	// Insert the resource type.
 	query := `INSERT INTO resource_types (name, description)
 	          VALUES (trim(regexp_replace($1, '\s+', ' ', 'g')), $2)
 	          RETURNING id, name, description`
-	row := tx.QueryRow(query, resourceTypeIn.Name, resourceTypeIn.Description)
+	row := tx.QueryRowContext(ctx, query, resourceTypeIn.Name, resourceTypeIn.Description)
 
 	// Get the newly created resource type.
 	var resourceTypeOut models.ResourceTypeOut
@@ -149,10 +200,12 @@ func AddNewResourceType(tx *sql.Tx, resourceTypeIn *models.ResourceTypeIn) (*mod
 
 // UpdateResourceType updates a resource type in the database.
 func UpdateResourceType(
-	tx *sql.Tx,
+	ctx context.Context, tx *sql.Tx,
 	id *string,
 	resourceTypeIn *models.ResourceTypeIn,
-) (*models.ResourceTypeOut, error) {
+) (_ *models.ResourceTypeOut, err error) {
+	ctx, span := startSpan(ctx, "UpdateResourceType")
+	defer endSpan(span, &err)
 
 	// Update the databse.
 	statement := `UPDATE resource_types
@@ -160,7 +213,7 @@ func UpdateResourceType(
 	                  description = $2
 	              WHERE id = $3
 	              RETURNING id, name, description`
-	row := tx.QueryRow(statement, resourceTypeIn.Name, resourceTypeIn.Description, id)
+	row := tx.QueryRowContext(ctx, statement, resourceTypeIn.Name, resourceTypeIn.Description, id)
 
 	// Get the newly updated resource type.
 	var resourceTypeOut models.ResourceTypeOut
@@ -172,11 +225,13 @@ func UpdateResourceType(
 }
 
 // DeleteResourceType removes a resource type from the database.
-func DeleteResourceType(tx *sql.Tx, id *string) error {
+func DeleteResourceType(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	ctx, span := startSpan(ctx, "DeleteResourceType")
+	defer endSpan(span, &err)
 
 	// Update the database.
 	statement := "DELETE FROM resource_types WHERE id = $1"
-	result, err := tx.Exec(statement, id)
+	result, err := tx.ExecContext(ctx, statement, id)
 	if err != nil {
 		return err
 	}
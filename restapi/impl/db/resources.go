@@ -1,9 +1,11 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/cyverse-de/permissions/models"
 )
 
@@ -19,13 +21,49 @@ func rowsToResourceList(rows *sql.Rows) ([]*models.ResourceOut, error) {
 		resources = append(resources, &resource)
 	}
 
+	return resources, rows.Err()
+}
+
+func rowToResource(row *sql.Row) (*models.ResourceOut, error) {
+	var resource models.ResourceOut
+	if err := row.Scan(&resource.ID, &resource.Name, &resource.ResourceType); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// queryResources executes a query that selects the ID, name and type name of zero or more resources, and loads the
+// labels of each resource.
+func queryResources(
+	ctx context.Context, tx *sql.Tx, query string, args ...interface{},
+) ([]*models.ResourceOut, error) {
+
+	// Query the database.
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := rowsToResourceList(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the labels.
+	if err := loadResourceLabels(ctx, tx, resources); err != nil {
+		return nil, err
+	}
 	return resources, nil
 }
 
-func rowsToResource(rows *sql.Rows, duplicateErr error) (*models.ResourceOut, error) {
+// queryResource executes a query that selects the ID, name and type name of at most one resource, and loads the
+// labels of the resource.
+func queryResource(
+	ctx context.Context, tx *sql.Tx, duplicateErr error, query string, args ...interface{},
+) (*models.ResourceOut, error) {
 
 	// Get the resources.
-	resources, err := rowsToResourceList(rows)
+	resources, err := queryResources(ctx, tx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -42,20 +80,54 @@ func rowsToResource(rows *sql.Rows, duplicateErr error) (*models.ResourceOut, er
 	return resources[0], nil
 }
 
-func rowToResource(row *sql.Row) (*models.ResourceOut, error) {
-	var resource models.ResourceOut
-	if err := row.Scan(&resource.ID, &resource.Name, &resource.ResourceType); err != nil {
-		return nil, err
+// loadResourceLabels looks up the labels of the given resources. The labels of resources without any labels are left
+// nil.
+func loadResourceLabels(ctx context.Context, tx *sql.Tx, resources []*models.ResourceOut) error {
+	if len(resources) == 0 {
+		return nil
 	}
-	return &resource, nil
+
+	// Index the resources by ID.
+	ids := make([]string, len(resources))
+	resourceByID := make(map[string]*models.ResourceOut, len(resources))
+	for i, resource := range resources {
+		ids[i] = *resource.ID
+		resourceByID[*resource.ID] = resource
+	}
+	sa := StringArray(ids)
+
+	// Query the database.
+	query := "SELECT resource_id, key, value FROM resource_labels WHERE resource_id = any($1)"
+	rows, err := tx.QueryContext(ctx, query, &sa)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// Add the labels to the resources.
+	for rows.Next() {
+		var resourceID, key, value string
+		if err := rows.Scan(&resourceID, &key, &value); err != nil {
+			return err
+		}
+		resource := resourceByID[resourceID]
+		if resource.Labels == nil {
+			resource.Labels = make(map[string]string)
+		}
+		resource.Labels[key] = value
+	}
+
+	return rows.Err()
 }
 
 // CountResourcesOfType counts the number of resources of the given type.
-func CountResourcesOfType(tx *sql.Tx, resourceTypeID *string) (int64, error) {
+func CountResourcesOfType(ctx context.Context, tx *sql.Tx, resourceTypeID *string) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "CountResourcesOfType")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := "SELECT count(*) FROM resources WHERE resource_type_id = $1"
-	row := tx.QueryRow(query, resourceTypeID)
+	row := tx.QueryRowContext(ctx, query, resourceTypeID)
 
 	// Return the result.
 	var count int64
@@ -66,11 +138,13 @@ func CountResourcesOfType(tx *sql.Tx, resourceTypeID *string) (int64, error) {
 }
 
 // ResourceExists determines whether or not the resource with the given ID exists.
-func ResourceExists(tx *sql.Tx, id *string) (bool, error) {
+func ResourceExists(ctx context.Context, tx *sql.Tx, id *string) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "ResourceExists")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := "SELECT count(*) FROM resources WHERE id = $1"
-	row := tx.QueryRow(query, id)
+	row := tx.QueryRowContext(ctx, query, id)
 
 	// Get the result.
 	var count uint32
@@ -80,44 +154,56 @@ func ResourceExists(tx *sql.Tx, id *string) (bool, error) {
 	return count > 0, nil
 }
 
+// GetResource returns information about the resource with the given ID.
+func GetResource(ctx context.Context, tx *sql.Tx, id *string) (_ *models.ResourceOut, err error) {
+	ctx, span := startSpan(ctx, "GetResource")
+	defer endSpan(span, &err)
+
+	// Query the database.
+	query := `SELECT r.id, r.name, t.name AS resource_type
+            FROM resources r JOIN resource_types t ON r.resource_type_id = t.id
+            WHERE r.id = $1`
+	duplicateErr := fmt.Errorf("found multiple resources with the ID, '%s'", *id)
+	return queryResource(ctx, tx, duplicateErr, query, id)
+}
+
 // GetResourceByName obtains information about all resources with the given name. Multiple resources may have the same
 // name as long as the types are different.
-func GetResourceByName(tx *sql.Tx, name *string, resourceTypeID *string) (*models.ResourceOut, error) {
+func GetResourceByName(
+	ctx context.Context, tx *sql.Tx, name *string, resourceTypeID *string,
+) (_ *models.ResourceOut, err error) {
+	ctx, span := startSpan(ctx, "GetResourceByName")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := `SELECT r.id, r.name, t.name AS resource_type
             FROM resources r JOIN resource_types t ON r.resource_type_id = t.id
             WHERE t.id = $1 and r.name = $2`
-	rows, err := tx.Query(query, resourceTypeID, name)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	// Get the resource.
-	return rowsToResource(rows, fmt.Errorf("found multiple resources of the same type named, '%s'", *name))
+	duplicateErr := fmt.Errorf("found multiple resources of the same type named, '%s'", *name)
+	return queryResource(ctx, tx, duplicateErr, query, resourceTypeID, name)
 }
 
 // GetResourceByNameAndType obtains information about the resource with the given name and type.
-func GetResourceByNameAndType(tx *sql.Tx, name, resourceTypeName string) (*models.ResourceOut, error) {
+func GetResourceByNameAndType(
+	ctx context.Context, tx *sql.Tx, name, resourceTypeName string,
+) (_ *models.ResourceOut, err error) {
+	ctx, span := startSpan(ctx, "GetResourceByNameAndType")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := `SELECT r.id, r.name, t.name AS resource_type
             FROM resources r JOIN resource_types t ON r.resource_type_id = t.id
             WHERE t.name = $1 and r.name = $2`
-	rows, err := tx.Query(query, resourceTypeName, name)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	// Get the resource.
 	duplicateErr := fmt.Errorf("found multiple resources with the same type and name: %s:%s", resourceTypeName, name)
-	return rowsToResource(rows, duplicateErr)
+	return queryResource(ctx, tx, duplicateErr, query, resourceTypeName, name)
 }
 
 // GetDuplicateResourceByName obtains information about duplicate resources in the database.
-func GetDuplicateResourceByName(tx *sql.Tx, id *string, name *string) (*models.ResourceOut, error) {
+func GetDuplicateResourceByName(
+	ctx context.Context, tx *sql.Tx, id *string, name *string,
+) (_ *models.ResourceOut, err error) {
+	ctx, span := startSpan(ctx, "GetDuplicateResourceByName")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := `SELECT r.id, r.name, t.name AS resource_type
@@ -125,81 +211,84 @@ func GetDuplicateResourceByName(tx *sql.Tx, id *string, name *string) (*models.R
             WHERE r.id != $1
             AND r.name = $2
             AND r.resource_type_id = (SELECT resource_type_id FROM resources WHERE id = $1)`
-	rows, err := tx.Query(query, id, name)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	// Get the resource.
-	return rowsToResource(rows, fmt.Errorf("found multiple resources of the same type named, '%s'", *name))
+	duplicateErr := fmt.Errorf("found multiple resources of the same type named, '%s'", *name)
+	return queryResource(ctx, tx, duplicateErr, query, id, name)
 }
 
 // AddResource adds a resource to the database.
-func AddResource(tx *sql.Tx, name *string, resourceTypeID *string) (*models.ResourceOut, error) {
+func AddResource(
+	ctx context.Context, tx *sql.Tx, name *string, resourceTypeID *string,
+) (_ *models.ResourceOut, err error) {
+	ctx, span := startSpan(ctx, "AddResource")
+	defer endSpan(span, &err)
 
 	// Update the database.
 	query := `INSERT INTO resources (name, resource_type_id) VALUES ($1, $2)
             RETURNING id, name, (SELECT name FROM resource_types t WHERE t.id = resource_type_id)`
-	row := tx.QueryRow(query, name, resourceTypeID)
+	row := tx.QueryRowContext(ctx, query, name, resourceTypeID)
 
 	// Return the result.
 	return rowToResource(row)
 }
 
 // UpdateResource updates a resource in the database.
-func UpdateResource(tx *sql.Tx, id *string, name *string) (*models.ResourceOut, error) {
+func UpdateResource(ctx context.Context, tx *sql.Tx, id *string, name *string) (_ *models.ResourceOut, err error) {
+	ctx, span := startSpan(ctx, "UpdateResource")
+	defer endSpan(span, &err)
 
 	// Update the database.
 	query := `UPDATE resources SET name = $1 WHERE id = $2
             RETURNING id, name, (SELECT name FROM resource_types t WHERE t.id = resource_type_id)`
-	row := tx.QueryRow(query, name, id)
+	row := tx.QueryRowContext(ctx, query, name, id)
 
-	// Return the result.
-	return rowToResource(row)
+	// Load the labels and return the result.
+	resource, err := rowToResource(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadResourceLabels(ctx, tx, []*models.ResourceOut{resource}); err != nil {
+		return nil, err
+	}
+	return resource, nil
 }
 
-// ListResources lists resources in the database, optionally filtering by resource type and resource name.
-func ListResources(tx *sql.Tx, resourceTypeName, resourceName *string) ([]*models.ResourceOut, error) {
-
-	// Query the database.
-	var rows *sql.Rows
-	var err error
-	if resourceTypeName != nil && resourceName != nil {
-		query := `SELECT r.id, r.name, t.name AS resource_type
-              FROM resources r JOIN resource_types t ON r.resource_type_id = t.id
-              WHERE t.name = $1 AND r.name = $2`
-		rows, err = tx.Query(query, *resourceTypeName, *resourceName)
-	} else if resourceTypeName != nil {
-		query := `SELECT r.id, r.name, t.name AS resource_type
-              FROM resources r JOIN resource_types t ON r.resource_type_id = t.id
-              WHERE t.name = $1`
-		rows, err = tx.Query(query, *resourceTypeName)
-	} else if resourceName != nil {
-		query := `SELECT r.id, r.name, t.name AS resource_type
-              FROM resources r JOIN resource_types t ON r.resource_type_id = t.id
-              WHERE r.name = $1`
-		rows, err = tx.Query(query, *resourceName)
-	} else {
-		query := `SELECT r.id, r.name, t.name AS resource_type
-            FROM resources r JOIN resource_types t ON r.resource_type_id = t.id`
-		rows, err = tx.Query(query)
+// ListResources lists resources in the database, optionally filtering by resource type, resource name and labels.
+func ListResources(
+	ctx context.Context, tx *sql.Tx, resourceTypeName, resourceName *string, selector LabelSelector,
+) (_ []*models.ResourceOut, err error) {
+	ctx, span := startSpan(ctx, "ListResources")
+	defer endSpan(span, &err)
+
+	// Build the query.
+	builder := psql.Select("r.id", "r.name", "t.name AS resource_type").
+		From("resources r").
+		Join("resource_types t ON r.resource_type_id = t.id")
+	if resourceTypeName != nil {
+		builder = builder.Where(sq.Eq{"t.name": *resourceTypeName})
 	}
+	if resourceName != nil {
+		builder = builder.Where(sq.Eq{"r.name": *resourceName})
+	}
+	builder = builder.Where(selector.Condition("r.id"))
+
+	// Generate the query.
+	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
 	// Build the list of resources.
-	return rowsToResourceList(rows)
+	return queryResources(ctx, tx, query, args...)
 }
 
 // DeleteResource removes a resource from the database.
-func DeleteResource(tx *sql.Tx, id *string) error {
+func DeleteResource(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	ctx, span := startSpan(ctx, "DeleteResource")
+	defer endSpan(span, &err)
 
 	// Update the database.
 	stmt := "DELETE FROM resources WHERE id = $1"
-	result, err := tx.Exec(stmt, id)
+	result, err := tx.ExecContext(ctx, stmt, id)
 	if err != nil {
 		return err
 	}
@@ -218,3 +307,63 @@ func DeleteResource(tx *sql.Tx, id *string) error {
 
 	return nil
 }
+
+// LockResource obtains a row-level lock on the resource with the given ID for the remainder of the transaction.
+func LockResource(ctx context.Context, tx *sql.Tx, id *string) (err error) {
+	ctx, span := startSpan(ctx, "LockResource")
+	defer endSpan(span, &err)
+
+	_, err = tx.ExecContext(ctx, "SELECT id FROM resources WHERE id = $1 FOR UPDATE", id)
+	return err
+}
+
+// TryLock attempts to obtain the transaction-level advisory lock with the given key, returning false if another
+// transaction holds it.
+func TryLock(ctx context.Context, tx *sql.Tx, key int64) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "TryLock")
+	defer endSpan(span, &err)
+
+	var locked bool
+	err = tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", key).Scan(&locked)
+	return locked, err
+}
+
+// SetResourceLabels replaces the labels of the resource with the given ID. The version of the resource is incremented
+// if the labels change.
+func SetResourceLabels(ctx context.Context, tx *sql.Tx, id *string, labels map[string]string) (err error) {
+	ctx, span := startSpan(ctx, "SetResourceLabels")
+	defer endSpan(span, &err)
+
+	// Load the current labels.
+	resource := &models.ResourceOut{ID: id}
+	if err := loadResourceLabels(ctx, tx, []*models.ResourceOut{resource}); err != nil {
+		return err
+	}
+	if SameLabels(resource.Labels, labels) {
+		return nil
+	}
+
+	// Remove the existing labels.
+	if _, err := tx.ExecContext(ctx, "DELETE FROM resource_labels WHERE resource_id = $1", id); err != nil {
+		return err
+	}
+
+	// Add the new labels.
+	if len(labels) > 0 {
+		builder := psql.Insert("resource_labels").Columns("resource_id", "key", "value")
+		for key, value := range labels {
+			builder = builder.Values(*id, key, value)
+		}
+		stmt, args, err := builder.ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, stmt, args...); err != nil {
+			return err
+		}
+	}
+
+	// Increment the version of the resource.
+	_, err = tx.ExecContext(ctx, "UPDATE resources SET version = version + 1 WHERE id = $1", id)
+	return err
+}
@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// timestampFormat is the format of the timestamps stored in the permission_changes table. The timestamps are stored
+// in UTC with a fixed number of digits so that they sort correctly as text.
+const timestampFormat = "2006-01-02T15:04:05.000Z"
+
+// LatestChangeSeq returns the sequence number of the most recently committed permission change, or zero if no changes
+// have been committed. SQLite keeps track of the largest sequence number ever assigned, even if the change has since
+// been pruned.
+func (t *tx) LatestChangeSeq() (int64, error) {
+	var seq int64
+	query := "SELECT seq FROM sqlite_sequence WHERE name = 'permission_changes'"
+	err := t.tx.QueryRowContext(t.ctx, query).Scan(&seq)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return seq, err
+}
+
+// ListChanges lists up to limit permission changes with sequence numbers greater than since, in sequence order.
+func (t *tx) ListChanges(since int64, limit int) ([]*permsdb.Change, error) {
+	query := `SELECT seq, change_type, permission_id, subject_id, subject_type, resource_type, resource_name,
+	                 permission_level, changed_at
+	          FROM permission_changes
+	          WHERE seq > ?
+	          ORDER BY seq
+	          LIMIT ?`
+
+	changes := make([]*permsdb.Change, 0)
+	err := t.eachRow(query, []interface{}{since, limit}, func(rows *sql.Rows) error {
+		var c permsdb.Change
+		var changedAt string
+		err := rows.Scan(
+			&c.Seq, &c.ChangeType, &c.PermissionID, &c.SubjectID, &c.SubjectType, &c.ResourceType, &c.ResourceName,
+			&c.PermissionLevel, &changedAt,
+		)
+		if err != nil {
+			return err
+		}
+		if c.ChangedAt, err = time.Parse(time.RFC3339Nano, changedAt); err != nil {
+			return err
+		}
+		changes = append(changes, &c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// PruneChanges removes every permission change up to the last one made before the given time and returns the number
+// of changes removed.
+func (t *tx) PruneChanges(before time.Time) (int64, error) {
+	stmt := `DELETE FROM permission_changes
+	         WHERE seq <= (SELECT max(seq) FROM permission_changes WHERE changed_at < ?)`
+	result, err := t.tx.ExecContext(t.ctx, stmt, before.UTC().Format(timestampFormat))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// eachRow executes a query and calls a function for each row in the result set.
+func (t *tx) eachRow(query string, args []interface{}, f func(*sql.Rows) error) error {
+	rows, err := t.tx.QueryContext(t.ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := f(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// EachResourceType calls a function for every resource type, sorted by name.
+func (t *tx) EachResourceType(f func(*models.ResourceTypeOut) error) error {
+	query := "SELECT id, name, description FROM resource_types ORDER BY name"
+	return t.eachRow(query, nil, func(rows *sql.Rows) error {
+		var resourceType models.ResourceTypeOut
+		if err := rows.Scan(&resourceType.ID, &resourceType.Name, &resourceType.Description); err != nil {
+			return err
+		}
+		return f(&resourceType)
+	})
+}
+
+// EachSubject calls a function for every subject, sorted by subject type and external subject ID.
+func (t *tx) EachSubject(f func(*models.SubjectOut) error) error {
+	return t.eachRow(subjectQuery+subjectOrder, nil, func(rows *sql.Rows) error {
+		var subjectDto permsdb.SubjectDTO
+		if err := rows.Scan(&subjectDto.ID, &subjectDto.SubjectID, &subjectDto.SubjectType); err != nil {
+			return err
+		}
+		return f(subjectDto.ToSubjectOut())
+	})
+}
+
+// EachResource calls a function for every resource, sorted by resource type name and resource name. The labels of
+// each resource are included.
+func (t *tx) EachResource(f func(*models.ResourceOut) error) error {
+	query := `SELECT r.id, r.name, t.name AS resource_type, l.key, l.value
+	          FROM resources r JOIN resource_types t ON r.resource_type_id = t.id
+	          LEFT JOIN resource_labels l ON r.id = l.resource_id
+	          ORDER BY t.name, r.name, l.key`
+	scanner := permsdb.NewLabeledResourceScanner(f)
+	if err := t.eachRow(query, nil, scanner.Scan); err != nil {
+		return err
+	}
+	return scanner.Flush()
+}
+
+// EachPermission calls a function for every permission granted directly to a subject, sorted by resource type name,
+// resource name, subject type and external subject ID.
+func (t *tx) EachPermission(f func(*models.Permission) error) error {
+	query, args, err := permissionListBuilder().
+		OrderBy("rt.name", "r.name", "CASE s.subject_type WHEN 'user' THEN 0 ELSE 1 END", "s.subject_id").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return t.eachRow(query, args, func(rows *sql.Rows) error {
+		var dto permsdb.PermissionDTO
+		err := rows.Scan(
+			&dto.ID, &dto.InternalSubjectID, &dto.SubjectID, &dto.SubjectType, &dto.ResourceID,
+			&dto.ResourceName, &dto.ResourceType, &dto.PermissionLevel,
+		)
+		if err != nil {
+			return err
+		}
+		return f(dto.ToPermission())
+	})
+}
@@ -0,0 +1,503 @@
+package sqlite
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// permissionColumns are the columns selected by permission queries, in the order expected by queryPermissionList.
+var permissionColumns = []string{
+	"id", "internal_subject_id", "subject_id", "subject_type", "resource_id", "resource_name", "resource_type",
+	"permission_level",
+}
+
+// permissionListBuilder returns a query builder that selects permissions in the format expected by
+// queryPermissionList.
+func permissionListBuilder() sq.SelectBuilder {
+	return sqlBuilder.Select(
+		"p.id AS id",
+		"s.id AS internal_subject_id",
+		"s.subject_id AS subject_id",
+		"s.subject_type AS subject_type",
+		"r.id AS resource_id",
+		"r.name AS resource_name",
+		"rt.name AS resource_type",
+		"pl.name AS permission_level",
+	).
+		From("permissions p").
+		Join("permission_levels pl ON p.permission_level_id = pl.id").
+		Join("subjects s ON p.subject_id = s.id").
+		Join("resources r ON p.resource_id = r.id").
+		Join("resource_types rt ON r.resource_type_id = rt.id")
+}
+
+// queryPermissionList executes a query that selects permissions and returns the resulting permissions.
+func (t *tx) queryPermissionList(builder sq.SelectBuilder) ([]*models.Permission, error) {
+
+	// Generate the query.
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute the query.
+	rows, err := t.tx.QueryContext(t.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Build the list of permissions.
+	permissions := make([]*models.Permission, 0)
+	for rows.Next() {
+		var dto permsdb.PermissionDTO
+		err := rows.Scan(
+			&dto.ID, &dto.InternalSubjectID, &dto.SubjectID, &dto.SubjectType, &dto.ResourceID,
+			&dto.ResourceName, &dto.ResourceType, &dto.PermissionLevel,
+		)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, dto.ToPermission())
+	}
+
+	return permissions, rows.Err()
+}
+
+// queryPermission executes a query that selects at most one permission.
+func (t *tx) queryPermission(builder sq.SelectBuilder, duplicateErr error) (*models.Permission, error) {
+
+	// Query the database.
+	permissions, err := t.queryPermissionList(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for duplicates. This shouldn't happen because of the uniqueness constraints.
+	if len(permissions) > 1 {
+		return nil, duplicateErr
+	}
+
+	// Return the result.
+	if len(permissions) < 1 {
+		return nil, nil
+	}
+	return permissions[0], nil
+}
+
+// atLeast returns a condition that limits permissions to those at or above the given permission level.
+func atLeast(minLevel string) sq.Sqlizer {
+	return sq.Expr("pl.precedence <= (SELECT precedence FROM permission_levels WHERE name = ?)", minLevel)
+}
+
+// rankedPermissions returns a query builder that selects the permissions that match the given conditions, ranking the
+// permissions for each resource from most permissive to least permissive. SQLite doesn't support DISTINCT ON, so the
+// most permissive permission for each resource is selected by filtering on the rank instead.
+func rankedPermissions(conditions ...sq.Sqlizer) sq.SelectBuilder {
+	builder := permissionListBuilder().
+		Column("row_number() OVER (PARTITION BY r.id ORDER BY pl.precedence) AS permission_rank")
+	for _, condition := range conditions {
+		builder = builder.Where(condition)
+	}
+	return builder
+}
+
+// mostPermissive lists the most permissive permission for each resource among the permissions that match the given
+// conditions, sorted by resource ID.
+func (t *tx) mostPermissive(conditions ...sq.Sqlizer) ([]*models.Permission, error) {
+	builder := sqlBuilder.Select(permissionColumns...).
+		FromSelect(rankedPermissions(conditions...), "ranked").
+		Where("permission_rank = 1").
+		OrderBy("resource_id")
+	return t.queryPermissionList(builder)
+}
+
+// ListPermissions lists all existing permissions for resources that match the label selector.
+func (t *tx) ListPermissions(selector permsdb.LabelSelector) ([]*models.Permission, error) {
+	builder := permissionListBuilder().
+		Where(selector.Condition("r.id")).
+		OrderBy("s.subject_id", "r.name", "pl.precedence")
+	return t.queryPermissionList(builder)
+}
+
+// ListResourcePermissions lists permissions associated with a specific resource.
+func (t *tx) ListResourcePermissions(resourceTypeName, resourceName string) ([]*models.Permission, error) {
+	builder := permissionListBuilder().
+		Where(sq.Eq{"rt.name": resourceTypeName, "r.name": resourceName}).
+		OrderBy("s.subject_id")
+	return t.queryPermissionList(builder)
+}
+
+// ListSubjectPermissions lists permissions granted directly to the subject with the given internal ID.
+func (t *tx) ListSubjectPermissions(id models.InternalSubjectID) ([]*models.Permission, error) {
+	builder := permissionListBuilder().
+		Where("s.id = uuid(?)", string(id)).
+		OrderBy("rt.name", "r.name")
+	return t.queryPermissionList(builder)
+}
+
+// FindSubjectPermissions lists permissions granted directly to the subject with the given internal ID, optionally
+// limiting the results to a single resource type or to a minimum permission level.
+func (t *tx) FindSubjectPermissions(
+	id models.InternalSubjectID, resourceTypeName, minLevel *string,
+) ([]*models.Permission, error) {
+
+	// Begin building the query.
+	builder := permissionListBuilder().Where("s.id = uuid(?)", string(id))
+
+	// Add the resource type filter if a resource type was specified.
+	if resourceTypeName != nil {
+		builder = builder.Where(sq.Eq{"rt.name": *resourceTypeName})
+	}
+
+	// Add the permission level filter if a minimum level was specified.
+	if minLevel != nil {
+		builder = builder.Where(atLeast(*minLevel))
+	}
+
+	return t.queryPermissionList(builder.OrderBy("rt.name", "r.name"))
+}
+
+// FindResourcePermissions lists permissions granted for the resource with the given ID, optionally excluding
+// permissions granted at the given permission level.
+func (t *tx) FindResourcePermissions(
+	resourceID string, excludedLevel *models.PermissionLevel,
+) ([]*models.Permission, error) {
+
+	// Begin building the query.
+	builder := permissionListBuilder().Where("r.id = uuid(?)", resourceID)
+
+	// Exclude the permission level if one was specified.
+	if excludedLevel != nil {
+		builder = builder.Where(sq.NotEq{"pl.name": string(*excludedLevel)})
+	}
+
+	return t.queryPermissionList(builder.OrderBy("s.subject_id"))
+}
+
+// GetPermissionByID obtains information about a specific permission.
+func (t *tx) GetPermissionByID(permissionID string) (*models.Permission, error) {
+	builder := permissionListBuilder().Where("p.id = uuid(?)", permissionID)
+	return t.queryPermission(builder, fmt.Errorf("multiple permissions found for ID: %s", permissionID))
+}
+
+// GetPermission gets a subject's permission to a specific resource if it exists.
+func (t *tx) GetPermission(subjectID models.InternalSubjectID, resourceID string) (*models.Permission, error) {
+	builder := permissionListBuilder().
+		Where("p.subject_id = uuid(?)", string(subjectID)).
+		Where("p.resource_id = uuid(?)", resourceID)
+	duplicateErr := fmt.Errorf("multiple permissions found for subject/resource: %s/%s", subjectID, resourceID)
+	return t.queryPermission(builder, duplicateErr)
+}
+
+// GetPermissionLevelIDByName returns the identifier for the permission level with the given name.
+func (t *tx) GetPermissionLevelIDByName(level models.PermissionLevel) (*string, error) {
+
+	// Query the database.
+	rows, err := t.tx.QueryContext(t.ctx, "SELECT id FROM permission_levels WHERE name = ?", string(level))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Build the list of permission level IDs.
+	ids := make([]*string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, &id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Check for duplicate results. This shouldn't happen because there's a uniqueness constraint.
+	if len(ids) > 1 {
+		return nil, fmt.Errorf("duplicate permission levels found: %s", string(level))
+	}
+
+	// Return the result.
+	if len(ids) < 1 {
+		return nil, nil
+	}
+	return ids[0], nil
+}
+
+// GetPermissionVersion returns the current version of a subject's permission to a resource.
+func (t *tx) GetPermissionVersion(subjectID models.InternalSubjectID, resourceID string) (*int64, error) {
+	query := "SELECT version FROM permissions WHERE subject_id = uuid(?) AND resource_id = uuid(?)"
+	return t.queryVersion(query, string(subjectID), resourceID)
+}
+
+// LockPermissionVersion returns the current version of a subject's permission to a resource.
+// Locking isn't required because transactions are serialized.
+func (t *tx) LockPermissionVersion(subjectID models.InternalSubjectID, resourceID string) (*int64, error) {
+	return t.GetPermissionVersion(subjectID, resourceID)
+}
+
+// UpsertPermission updates a permission or inserts it if it doesn't exist.
+func (t *tx) UpsertPermission(
+	subjectID models.InternalSubjectID,
+	resourceID string,
+	permissionLevelID string,
+) (*models.Permission, error) {
+
+	// Update the database.
+	stmt := `INSERT INTO permissions (id, subject_id, resource_id, permission_level_id)
+	         VALUES (new_id(), uuid(?), uuid(?), uuid(?))
+	         ON CONFLICT (subject_id, resource_id) DO UPDATE
+	         SET permission_level_id = excluded.permission_level_id
+	         RETURNING id`
+	row := t.tx.QueryRowContext(t.ctx, stmt, string(subjectID), resourceID, permissionLevelID)
+
+	// Extract the permission ID.
+	var permissionID string
+	if err := row.Scan(&permissionID); err != nil {
+		return nil, err
+	}
+
+	// Look up the permission.
+	permission, err := t.GetPermissionByID(permissionID)
+	if err != nil {
+		return nil, err
+	} else if permission == nil {
+		return nil, fmt.Errorf("unable to look up permission after upsert: %s", permissionID)
+	}
+	return permission, nil
+}
+
+// DeletePermission removes a permission.
+func (t *tx) DeletePermission(id models.PermissionID) error {
+	result, err := t.tx.ExecContext(t.ctx, "DELETE FROM permissions WHERE id = uuid(?)", string(id))
+	if err != nil {
+		return err
+	}
+	return checkDeleted(result, "permissions", string(id))
+}
+
+// DeletePermissions removes multiple permissions.
+func (t *tx) DeletePermissions(permissions []*models.Permission) error {
+
+	// Extract the permission IDs.
+	ids := make([]string, len(permissions))
+	for i, permission := range permissions {
+		ids[i] = string(*permission.ID)
+	}
+
+	// Update the database.
+	stmt, args, err := sqlBuilder.Delete("permissions").Where(sq.Eq{"id": ids}).ToSql()
+	if err != nil {
+		return err
+	}
+	result, err := t.tx.ExecContext(t.ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	// Verify that the expected number of rows was deleted.
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != int64(len(ids)) {
+		return fmt.Errorf("expected to delete %d permissions but %d were deleted", len(ids), count)
+	}
+
+	return nil
+}
+
+// keepHigherLevel is the conflict clause used to retain the more permissive of two permission levels when permissions
+// are copied.
+const keepHigherLevel = `DO UPDATE SET permission_level_id = (
+                             SELECT id FROM permission_levels
+                             WHERE id IN (d.permission_level_id, excluded.permission_level_id)
+                             ORDER BY precedence LIMIT 1
+                         )`
+
+// CopyPermissions copies permissions from one subject to another.
+func (t *tx) CopyPermissions(source, dest *models.SubjectOut) error {
+
+	// The WHERE clause is required to keep SQLite from parsing ON CONFLICT as a join constraint.
+	stmt := `INSERT INTO permissions AS d (id, subject_id, resource_id, permission_level_id)
+	         SELECT new_id(), uuid(?), resource_id, permission_level_id
+	         FROM permissions WHERE subject_id = uuid(?)
+	         ON CONFLICT (subject_id, resource_id) ` + keepHigherLevel
+	_, err := t.tx.ExecContext(t.ctx, stmt, string(*dest.ID), string(*source.ID))
+
+	return err
+}
+
+// CopyResourcePermissions copies permissions from one resource to another. The merge policy determines how conflicts
+// with existing permissions for the destination resource are resolved.
+func (t *tx) CopyResourcePermissions(source, dest *models.ResourceOut, mergePolicy string) error {
+
+	// Determine how to handle conflicts.
+	var onConflict string
+	switch mergePolicy {
+	case permsdb.MergePolicyKeepHigher:
+		onConflict = keepHigherLevel
+	case permsdb.MergePolicyOverwrite:
+		onConflict = "DO UPDATE SET permission_level_id = excluded.permission_level_id"
+	case permsdb.MergePolicySkipExisting:
+		onConflict = "DO NOTHING"
+	default:
+		return fmt.Errorf("unrecognized merge policy: %s", mergePolicy)
+	}
+
+	// Copy or update permissions.
+	stmt := `INSERT INTO permissions AS d (id, subject_id, resource_id, permission_level_id)
+	         SELECT new_id(), subject_id, uuid(?), permission_level_id
+	         FROM permissions WHERE resource_id = uuid(?)
+	         ON CONFLICT (subject_id, resource_id) ` + onConflict
+	_, err := t.tx.ExecContext(t.ctx, stmt, *dest.ID, *source.ID)
+
+	return err
+}
+
+// ListPermissionConflicts lists the resources to which both the source and destination subjects have been granted
+// permissions. The resolved permission level for each conflict is the level that CopyPermissions would retain.
+func (t *tx) ListPermissionConflicts(source, dest *models.SubjectOut) ([]*models.PermissionConflict, error) {
+
+	// Query the database.
+	query := `SELECT r.id AS resource_id,
+	                 r.name AS resource_name,
+	                 rt.name AS resource_type,
+	                 spl.name AS source_level,
+	                 dpl.name AS target_level,
+	                 CASE WHEN spl.precedence <= dpl.precedence THEN spl.name ELSE dpl.name END AS resolved_level
+	          FROM permissions sp
+	          JOIN permissions dp ON sp.resource_id = dp.resource_id
+	          JOIN permission_levels spl ON sp.permission_level_id = spl.id
+	          JOIN permission_levels dpl ON dp.permission_level_id = dpl.id
+	          JOIN resources r ON sp.resource_id = r.id
+	          JOIN resource_types rt ON r.resource_type_id = rt.id
+	          WHERE sp.subject_id = uuid(?) AND dp.subject_id = uuid(?)
+	          ORDER BY rt.name, r.name`
+	rows, err := t.tx.QueryContext(t.ctx, query, string(*source.ID), string(*dest.ID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Build the list of conflicts.
+	conflicts := make([]*models.PermissionConflict, 0)
+	for rows.Next() {
+		var resource models.ResourceOut
+		var conflict models.PermissionConflict
+		err := rows.Scan(
+			&resource.ID, &resource.Name, &resource.ResourceType, &conflict.SourceLevel, &conflict.TargetLevel,
+			&conflict.ResolvedLevel,
+		)
+		if err != nil {
+			return nil, err
+		}
+		conflict.Resource = &resource
+		conflicts = append(conflicts, &conflict)
+	}
+
+	return conflicts, rows.Err()
+}
+
+// PermissionsForSubjects lists permissions granted to zero or more subjects.
+func (t *tx) PermissionsForSubjects(subjectIds []string) ([]*models.Permission, error) {
+	return t.mostPermissive(sq.Eq{"s.subject_id": subjectIds})
+}
+
+// PermissionsForSubjectsMinLevel lists permissions of at least the given level granted to zero or more subjects.
+func (t *tx) PermissionsForSubjectsMinLevel(subjectIds []string, minLevel string) ([]*models.Permission, error) {
+	return t.mostPermissive(sq.Eq{"s.subject_id": subjectIds}, atLeast(minLevel))
+}
+
+// PermissionsForSubjectsAndResourceType lists permissions that have been granted to zero or more subjects for the
+// specified type of resource, limited to resources that match the label selector.
+func (t *tx) PermissionsForSubjectsAndResourceType(
+	subjectIds []string, resourceTypeName string, selector permsdb.LabelSelector,
+) ([]*models.Permission, error) {
+	return t.mostPermissive(
+		sq.Eq{"s.subject_id": subjectIds, "rt.name": resourceTypeName},
+		selector.Condition("r.id"),
+	)
+}
+
+// PermissionsForSubjectsAndResourceTypeMinLevel lists permissions of at least the minimum level that have been
+// granted to zero or more subjects for the specified type of resource, limited to resources that match the label
+// selector.
+func (t *tx) PermissionsForSubjectsAndResourceTypeMinLevel(
+	subjectIds []string, resourceTypeName, minLevel string, selector permsdb.LabelSelector,
+) ([]*models.Permission, error) {
+	return t.mostPermissive(
+		sq.Eq{"s.subject_id": subjectIds, "rt.name": resourceTypeName},
+		atLeast(minLevel),
+		selector.Condition("r.id"),
+	)
+}
+
+// PermissionsForSubjectsAndResource lists permissions granted to zero or more subjects for a specific resource.
+func (t *tx) PermissionsForSubjectsAndResource(
+	subjectIds []string, resourceTypeName, resourceName string,
+) ([]*models.Permission, error) {
+	return t.mostPermissive(sq.Eq{"s.subject_id": subjectIds, "rt.name": resourceTypeName, "r.name": resourceName})
+}
+
+// PermissionsForSubjectsAndResourceMinLevel lists permissions of at least the minimum level that have been granted
+// to zero or more subjects for a specific resource.
+func (t *tx) PermissionsForSubjectsAndResourceMinLevel(
+	subjectIds []string, resourceTypeName, resourceName, minLevel string,
+) ([]*models.Permission, error) {
+	return t.mostPermissive(
+		sq.Eq{"s.subject_id": subjectIds, "rt.name": resourceTypeName, "r.name": resourceName},
+		atLeast(minLevel),
+	)
+}
+
+// AbbreviatedPermissionsForSubjectAndResourceType lists permissions for a subject and resource type. If the
+// minLevel parameter is specified, permissions that don't meet or exceed the minimum level will be omitted
+// from the results. Only permissions for resources that match the label selector are listed.
+func (t *tx) AbbreviatedPermissionsForSubjectAndResourceType(
+	subjectIDs []string, resourceTypeName string, minLevel *string, selector permsdb.LabelSelector,
+) ([]*models.AbbreviatedPermission, error) {
+
+	// Build the query.
+	conditions := []sq.Sqlizer{
+		sq.Eq{"rt.name": resourceTypeName, "s.subject_id": subjectIDs},
+		selector.Condition("r.id"),
+	}
+	if minLevel != nil {
+		conditions = append(conditions, atLeast(*minLevel))
+	}
+	query, args, err := sqlBuilder.Select("id", "resource_name", "resource_type", "permission_level").
+		FromSelect(rankedPermissions(conditions...), "ranked").
+		Where("permission_rank = 1").
+		OrderBy("resource_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute the query.
+	rows, err := t.tx.QueryContext(t.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Build the list of abbreviated permissions.
+	permissions := make([]*models.AbbreviatedPermission, 0)
+	for rows.Next() {
+		var permission models.AbbreviatedPermission
+		err := rows.Scan(
+			&permission.ID, &permission.ResourceName, &permission.ResourceType, &permission.PermissionLevel,
+		)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, &permission)
+	}
+
+	return permissions, rows.Err()
+}
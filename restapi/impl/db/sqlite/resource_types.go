@@ -0,0 +1,148 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// queryResourceTypes executes a query that selects the ID, name and description of zero or more resource types.
+func (t *tx) queryResourceTypes(query string, args ...interface{}) ([]*models.ResourceTypeOut, error) {
+
+	// Query the database.
+	rows, err := t.tx.QueryContext(t.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Build the list of resource types.
+	resourceTypes := make([]*models.ResourceTypeOut, 0)
+	for rows.Next() {
+		var resourceType models.ResourceTypeOut
+		if err := rows.Scan(&resourceType.ID, &resourceType.Name, &resourceType.Description); err != nil {
+			return nil, err
+		}
+		resourceTypes = append(resourceTypes, &resourceType)
+	}
+
+	return resourceTypes, rows.Err()
+}
+
+// queryResourceType executes a query that selects the ID, name and description of at most one resource type.
+func (t *tx) queryResourceType(
+	duplicateErr error, query string, args ...interface{},
+) (*models.ResourceTypeOut, error) {
+
+	// Query the database.
+	resourceTypes, err := t.queryResourceTypes(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for duplicates. There are uniqueness constraints on the table so this shouldn't happen.
+	if len(resourceTypes) > 1 {
+		return nil, duplicateErr
+	}
+
+	// Return the result.
+	if len(resourceTypes) < 1 {
+		return nil, nil
+	}
+	return resourceTypes[0], nil
+}
+
+// ListResourceTypes lists all defined resource types.
+func (t *tx) ListResourceTypes(resourceTypeName *string) ([]*models.ResourceTypeOut, error) {
+	if resourceTypeName == nil {
+		return t.queryResourceTypes("SELECT id, name, description FROM resource_types")
+	}
+	return t.queryResourceTypes("SELECT id, name, description FROM resource_types WHERE name = ?", *resourceTypeName)
+}
+
+// GetResourceTypeByName gets information about the resource type with the given name. Names are compared without
+// regard to case or whitespace.
+func (t *tx) GetResourceTypeByName(name *string) (*models.ResourceTypeOut, error) {
+	query := `SELECT id, name, description FROM resource_types
+	          WHERE resource_type_name_key(name) = resource_type_name_key(?)`
+	duplicateErr := fmt.Errorf("found multiple resource types with the name: %s", *name)
+	return t.queryResourceType(duplicateErr, query, *name)
+}
+
+// GetDuplicateResourceTypeByName returns information about a resource type other than the one with the given ID that
+// has the given name.
+func (t *tx) GetDuplicateResourceTypeByName(id *string, name *string) (*models.ResourceTypeOut, error) {
+	query := `SELECT id, name, description FROM resource_types
+	          WHERE id != uuid(?)
+	          AND resource_type_name_key(name) = resource_type_name_key(?)`
+	duplicateErr := fmt.Errorf("found multiple duplicate resource types with the name: %s", *name)
+	return t.queryResourceType(duplicateErr, query, *id, *name)
+}
+
+// GetResourceType gets information about the resource type with the given ID.
+func (t *tx) GetResourceType(id *string) (*models.ResourceTypeOut, error) {
+	query := "SELECT id, name, description FROM resource_types WHERE id = uuid(?)"
+	duplicateErr := fmt.Errorf("found multiple resource types with the ID: %s", *id)
+	return t.queryResourceType(duplicateErr, query, *id)
+}
+
+// ResourceTypeExists determines whether or not the resource type with the given ID exists.
+func (t *tx) ResourceTypeExists(id *string) (bool, error) {
+	return t.exists("SELECT count(*) FROM resource_types WHERE id = uuid(?)", *id)
+}
+
+// AddNewResourceType adds a new resource type.
+func (t *tx) AddNewResourceType(resourceTypeIn *models.ResourceTypeIn) (*models.ResourceTypeOut, error) {
+
+	// Insert the resource type.
+	query := `INSERT INTO resource_types (id, name, description)
+	          VALUES (new_id(), collapse_whitespace(?), ?)
+	          RETURNING id, name, description`
+	row := t.tx.QueryRowContext(t.ctx, query, *resourceTypeIn.Name, resourceTypeIn.Description)
+
+	// Get the newly created resource type.
+	var resourceTypeOut models.ResourceTypeOut
+	if err := row.Scan(&resourceTypeOut.ID, &resourceTypeOut.Name, &resourceTypeOut.Description); err != nil {
+		return nil, err
+	}
+	return &resourceTypeOut, nil
+}
+
+// UpdateResourceType updates a resource type.
+func (t *tx) UpdateResourceType(id *string, resourceTypeIn *models.ResourceTypeIn) (*models.ResourceTypeOut, error) {
+
+	// Update the database.
+	statement := `UPDATE resource_types
+	              SET name = collapse_whitespace(?), description = ?
+	              WHERE id = uuid(?)
+	              RETURNING id, name, description`
+	row := t.tx.QueryRowContext(t.ctx, statement, *resourceTypeIn.Name, resourceTypeIn.Description, *id)
+
+	// Get the newly updated resource type.
+	var resourceTypeOut models.ResourceTypeOut
+	if err := row.Scan(&resourceTypeOut.ID, &resourceTypeOut.Name, &resourceTypeOut.Description); err != nil {
+		return nil, err
+	}
+	return &resourceTypeOut, nil
+}
+
+// DeleteResourceType removes a resource type.
+func (t *tx) DeleteResourceType(id *string) error {
+	result, err := t.tx.ExecContext(t.ctx, "DELETE FROM resource_types WHERE id = uuid(?)", *id)
+	if err != nil {
+		return err
+	}
+	return checkDeleted(result, "resource types", *id)
+}
+
+// GetResourceTypeVersion returns the current version of the resource type with the given ID. Locking isn't required
+// because transactions are serialized.
+func (t *tx) GetResourceTypeVersion(id *string) (*int64, error) {
+	return t.queryVersion("SELECT version FROM resource_types WHERE id = uuid(?)", *id)
+}
+
+// LockResourceTypeVersion returns the current version of the resource type with the given ID.
+// Locking isn't required because transactions are serialized.
+func (t *tx) LockResourceTypeVersion(id *string) (*int64, error) {
+	return t.GetResourceTypeVersion(id)
+}
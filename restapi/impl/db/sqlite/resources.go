@@ -0,0 +1,286 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// resourceQuery is the base query used to look up resources.
+const resourceQuery = `SELECT r.id, r.name, t.name AS resource_type
+                       FROM resources r JOIN resource_types t ON r.resource_type_id = t.id`
+
+// scanResources builds a list of resources from rows containing the ID, name and type name of each resource.
+func scanResources(rows *sql.Rows) ([]*models.ResourceOut, error) {
+	defer rows.Close()
+
+	resources := make([]*models.ResourceOut, 0)
+	for rows.Next() {
+		var resource models.ResourceOut
+		if err := rows.Scan(&resource.ID, &resource.Name, &resource.ResourceType); err != nil {
+			return nil, err
+		}
+		resources = append(resources, &resource)
+	}
+
+	return resources, rows.Err()
+}
+
+// queryResources executes a query that selects the ID, name and type name of zero or more resources, and loads the
+// labels of each resource.
+func (t *tx) queryResources(query string, args ...interface{}) ([]*models.ResourceOut, error) {
+
+	// Query the database.
+	rows, err := t.tx.QueryContext(t.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := scanResources(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the labels.
+	if err := t.loadResourceLabels(resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// loadResourceLabels looks up the labels of the given resources. The labels of resources without any labels are left
+// nil.
+func (t *tx) loadResourceLabels(resources []*models.ResourceOut) error {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	// Index the resources by ID.
+	ids := make([]string, len(resources))
+	resourceByID := make(map[string]*models.ResourceOut, len(resources))
+	for i, resource := range resources {
+		ids[i] = *resource.ID
+		resourceByID[*resource.ID] = resource
+	}
+
+	// Query the database.
+	query, args, err := sqlBuilder.Select("resource_id", "key", "value").
+		From("resource_labels").
+		Where(sq.Eq{"resource_id": ids}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	rows, err := t.tx.QueryContext(t.ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// Add the labels to the resources.
+	for rows.Next() {
+		var resourceID, key, value string
+		if err := rows.Scan(&resourceID, &key, &value); err != nil {
+			return err
+		}
+		resource := resourceByID[resourceID]
+		if resource.Labels == nil {
+			resource.Labels = make(map[string]string)
+		}
+		resource.Labels[key] = value
+	}
+
+	return rows.Err()
+}
+
+// queryResource executes a query that selects the ID, name and type name of at most one resource, and loads the
+// labels of the resource.
+func (t *tx) queryResource(duplicateErr error, query string, args ...interface{}) (*models.ResourceOut, error) {
+
+	// Query the database.
+	resources, err := t.queryResources(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for duplicates. This shouldn't happen unless there's a bug in the query.
+	if len(resources) > 1 {
+		return nil, duplicateErr
+	}
+
+	// Return the result.
+	if len(resources) < 1 {
+		return nil, nil
+	}
+	return resources[0], nil
+}
+
+// CountResourcesOfType counts the number of resources of the given type.
+func (t *tx) CountResourcesOfType(resourceTypeID *string) (int64, error) {
+	var count int64
+	query := "SELECT count(*) FROM resources WHERE resource_type_id = uuid(?)"
+	row := t.tx.QueryRowContext(t.ctx, query, *resourceTypeID)
+	if err := row.Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ResourceExists determines whether or not the resource with the given ID exists.
+func (t *tx) ResourceExists(id *string) (bool, error) {
+	return t.exists("SELECT count(*) FROM resources WHERE id = uuid(?)", *id)
+}
+
+// GetResource returns information about the resource with the given ID.
+func (t *tx) GetResource(id *string) (*models.ResourceOut, error) {
+	duplicateErr := fmt.Errorf("found multiple resources with the ID, '%s'", *id)
+	return t.queryResource(duplicateErr, resourceQuery+" WHERE r.id = uuid(?)", *id)
+}
+
+// GetResourceByName obtains information about the resource with the given name and resource type ID.
+func (t *tx) GetResourceByName(name *string, resourceTypeID *string) (*models.ResourceOut, error) {
+	query := resourceQuery + " WHERE t.id = uuid(?) AND r.name = ?"
+	duplicateErr := fmt.Errorf("found multiple resources of the same type named, '%s'", *name)
+	return t.queryResource(duplicateErr, query, *resourceTypeID, *name)
+}
+
+// GetResourceByNameAndType obtains information about the resource with the given name and type.
+func (t *tx) GetResourceByNameAndType(name, resourceTypeName string) (*models.ResourceOut, error) {
+	query := resourceQuery + " WHERE t.name = ? AND r.name = ?"
+	duplicateErr := fmt.Errorf("found multiple resources with the same type and name: %s:%s", resourceTypeName, name)
+	return t.queryResource(duplicateErr, query, resourceTypeName, name)
+}
+
+// GetDuplicateResourceByName obtains information about a resource other than the one with the given ID that has the
+// same type as that resource and the given name.
+func (t *tx) GetDuplicateResourceByName(id *string, name *string) (*models.ResourceOut, error) {
+	query := resourceQuery + ` WHERE r.id != uuid(?1)
+	                           AND r.name = ?2
+	                           AND r.resource_type_id = (SELECT resource_type_id FROM resources WHERE id = uuid(?1))`
+	duplicateErr := fmt.Errorf("found multiple resources of the same type named, '%s'", *name)
+	return t.queryResource(duplicateErr, query, *id, *name)
+}
+
+// AddResource adds a resource.
+func (t *tx) AddResource(name *string, resourceTypeID *string) (*models.ResourceOut, error) {
+
+	// Update the database.
+	var id string
+	query := "INSERT INTO resources (id, name, resource_type_id) VALUES (new_id(), ?, uuid(?)) RETURNING id"
+	if err := t.tx.QueryRowContext(t.ctx, query, *name, *resourceTypeID).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	// Return the result.
+	return t.GetResource(&id)
+}
+
+// UpdateResource updates a resource.
+func (t *tx) UpdateResource(id *string, name *string) (*models.ResourceOut, error) {
+
+	// Update the database.
+	var resourceID string
+	query := "UPDATE resources SET name = ? WHERE id = uuid(?) RETURNING id"
+	if err := t.tx.QueryRowContext(t.ctx, query, *name, *id).Scan(&resourceID); err != nil {
+		return nil, err
+	}
+
+	// Return the result.
+	resource, err := t.GetResource(&resourceID)
+	if err == nil && resource == nil {
+		err = sql.ErrNoRows
+	}
+	return resource, err
+}
+
+// ListResources lists resources, optionally filtering by resource type, resource name and labels.
+func (t *tx) ListResources(
+	resourceTypeName, resourceName *string, selector permsdb.LabelSelector,
+) ([]*models.ResourceOut, error) {
+
+	// Build the query.
+	builder := sqlBuilder.Select("r.id", "r.name", "t.name AS resource_type").
+		From("resources r").
+		Join("resource_types t ON r.resource_type_id = t.id")
+	if resourceTypeName != nil {
+		builder = builder.Where(sq.Eq{"t.name": *resourceTypeName})
+	}
+	if resourceName != nil {
+		builder = builder.Where(sq.Eq{"r.name": *resourceName})
+	}
+	query, args, err := builder.Where(selector.Condition("r.id")).OrderBy("r.rowid").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return t.queryResources(query, args...)
+}
+
+// SetResourceLabels replaces the labels of the resource with the given ID. The version of the resource is incremented
+// if the labels change.
+func (t *tx) SetResourceLabels(id *string, labels map[string]string) error {
+
+	// Load the current labels.
+	resource, err := t.GetResource(id)
+	if err != nil {
+		return err
+	}
+	if resource == nil {
+		return sql.ErrNoRows
+	}
+	if permsdb.SameLabels(resource.Labels, labels) {
+		return nil
+	}
+
+	// Remove the existing labels.
+	if _, err := t.tx.ExecContext(t.ctx, "DELETE FROM resource_labels WHERE resource_id = ?", *resource.ID); err != nil {
+		return err
+	}
+
+	// Add the new labels.
+	if len(labels) > 0 {
+		builder := sqlBuilder.Insert("resource_labels").Columns("resource_id", "key", "value")
+		for key, value := range labels {
+			builder = builder.Values(*resource.ID, key, value)
+		}
+		stmt, args, err := builder.ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := t.tx.ExecContext(t.ctx, stmt, args...); err != nil {
+			return err
+		}
+	}
+
+	// Increment the version of the resource.
+	_, err = t.tx.ExecContext(t.ctx, "UPDATE resources SET version = version + 1 WHERE id = ?", *resource.ID)
+	return err
+}
+
+// DeleteResource removes a resource along with any permissions that have been granted for it.
+func (t *tx) DeleteResource(id *string) error {
+	result, err := t.tx.ExecContext(t.ctx, "DELETE FROM resources WHERE id = uuid(?)", *id)
+	if err != nil {
+		return err
+	}
+	return checkDeleted(result, "resources", *id)
+}
+
+// LockResource validates the resource ID. Locking isn't required because transactions are serialized.
+func (t *tx) LockResource(id *string) error {
+	_, err := t.tx.ExecContext(t.ctx, "SELECT id FROM resources WHERE id = uuid(?)", *id)
+	return err
+}
+
+// GetResourceVersion returns the current version of the resource with the given ID.
+func (t *tx) GetResourceVersion(id *string) (*int64, error) {
+	return t.queryVersion("SELECT version FROM resources WHERE id = uuid(?)", *id)
+}
+
+// LockResourceVersion returns the current version of the resource with the given ID.
+// Locking isn't required because transactions are serialized.
+func (t *tx) LockResourceVersion(id *string) (*int64, error) {
+	return t.GetResourceVersion(id)
+}
@@ -0,0 +1,241 @@
+--
+-- The SQLite version of the permissions schema. Identifiers are stored as lower-case text, and the version number of
+-- each row is incremented by a trigger whenever the row changes.
+--
+CREATE TABLE IF NOT EXISTS resource_types (
+    id text NOT NULL PRIMARY KEY,
+    name text NOT NULL,
+    description text,
+    version integer NOT NULL DEFAULT 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS resource_types_name_unique
+    ON resource_types (resource_type_name_key(name));
+
+CREATE TABLE IF NOT EXISTS resources (
+    id text NOT NULL PRIMARY KEY,
+    name text NOT NULL,
+    resource_type_id text NOT NULL REFERENCES resource_types (id),
+    version integer NOT NULL DEFAULT 1,
+    UNIQUE (resource_type_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS subjects (
+    id text NOT NULL PRIMARY KEY,
+    subject_id text NOT NULL UNIQUE,
+    subject_type text NOT NULL CHECK (subject_type IN ('user', 'group')),
+    version integer NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS permission_levels (
+    id text NOT NULL PRIMARY KEY,
+    name text NOT NULL UNIQUE,
+    description text,
+    precedence integer NOT NULL UNIQUE
+);
+
+--
+-- Permission levels with lower precedence values are more permissive.
+--
+INSERT INTO permission_levels (id, name, description, precedence) VALUES
+    ('7b1bd5e4-2e3b-4b8b-9c7e-4f0d6f1c0a01', 'own', 'Owner', 0),
+    ('7b1bd5e4-2e3b-4b8b-9c7e-4f0d6f1c0a02', 'admin', 'Administrator', 1),
+    ('7b1bd5e4-2e3b-4b8b-9c7e-4f0d6f1c0a03', 'write', 'Write', 2),
+    ('7b1bd5e4-2e3b-4b8b-9c7e-4f0d6f1c0a04', 'read', 'Read', 3)
+ON CONFLICT (name) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id text NOT NULL PRIMARY KEY,
+    subject_id text NOT NULL REFERENCES subjects (id) ON DELETE CASCADE,
+    resource_id text NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    permission_level_id text NOT NULL REFERENCES permission_levels (id),
+    version integer NOT NULL DEFAULT 1,
+    UNIQUE (subject_id, resource_id)
+);
+
+CREATE TABLE IF NOT EXISTS resource_labels (
+    resource_id text NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    key text NOT NULL,
+    value text NOT NULL,
+    PRIMARY KEY (resource_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS resource_labels_key_value_index
+    ON resource_labels (key, value);
+
+CREATE TRIGGER IF NOT EXISTS resource_types_version
+    AFTER UPDATE OF name, description ON resource_types
+    WHEN NEW.name IS NOT OLD.name OR NEW.description IS NOT OLD.description
+BEGIN
+    UPDATE resource_types SET version = OLD.version + 1 WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS resources_version
+    AFTER UPDATE OF name, resource_type_id ON resources
+    WHEN NEW.name IS NOT OLD.name OR NEW.resource_type_id IS NOT OLD.resource_type_id
+BEGIN
+    UPDATE resources SET version = OLD.version + 1 WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS subjects_version
+    AFTER UPDATE OF subject_id, subject_type ON subjects
+    WHEN NEW.subject_id IS NOT OLD.subject_id OR NEW.subject_type IS NOT OLD.subject_type
+BEGIN
+    UPDATE subjects SET version = OLD.version + 1 WHERE id = NEW.id;
+END;
+
+--
+-- Permission version numbers are drawn from a single counter so that they're never reused, even if a permission is
+-- revoked and granted again. The counter starts after the highest version number that's already in use.
+--
+CREATE TABLE IF NOT EXISTS permission_version_counter (
+    id integer NOT NULL PRIMARY KEY CHECK (id = 1),
+    last_version integer NOT NULL
+);
+
+INSERT INTO permission_version_counter (id, last_version)
+    SELECT 1, coalesce(max(version), 0) FROM permissions WHERE true
+ON CONFLICT (id) DO NOTHING;
+
+CREATE TRIGGER IF NOT EXISTS permissions_initial_version AFTER INSERT ON permissions
+BEGIN
+    UPDATE permission_version_counter SET last_version = last_version + 1;
+    UPDATE permissions SET version = (SELECT last_version FROM permission_version_counter) WHERE id = NEW.id;
+END;
+
+DROP TRIGGER IF EXISTS permissions_version;
+CREATE TRIGGER permissions_version
+    AFTER UPDATE OF permission_level_id ON permissions
+    WHEN NEW.permission_level_id IS NOT OLD.permission_level_id
+BEGIN
+    UPDATE permission_version_counter SET last_version = last_version + 1;
+    UPDATE permissions SET version = (SELECT last_version FROM permission_version_counter) WHERE id = NEW.id;
+END;
+
+--
+-- Every change to a permission is recorded in permission_changes by the triggers below. Renaming a subject, resource or
+-- resource type is recorded as the revocation of each affected permission under the old name followed by a grant
+-- under the new name. SQLite only allows one writer at a time, so the sequence numbers reflect the order in which the
+-- changes were committed.
+--
+CREATE TABLE IF NOT EXISTS permission_changes (
+    seq integer PRIMARY KEY AUTOINCREMENT,
+    change_type text NOT NULL CHECK (change_type IN ('granted', 'updated', 'revoked')),
+    permission_id text NOT NULL,
+    subject_id text NOT NULL,
+    subject_type text NOT NULL,
+    resource_type text NOT NULL,
+    resource_name text NOT NULL,
+    permission_level text NOT NULL,
+    changed_at text NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE TRIGGER IF NOT EXISTS permissions_granted AFTER INSERT ON permissions
+BEGIN
+    INSERT INTO permission_changes (
+        change_type, permission_id, subject_id, subject_type, resource_type, resource_name, permission_level
+    )
+    SELECT 'granted', NEW.id, s.subject_id, s.subject_type, rt.name, r.name, pl.name
+    FROM subjects s, resources r, resource_types rt, permission_levels pl
+    WHERE s.id = NEW.subject_id AND r.id = NEW.resource_id AND rt.id = r.resource_type_id
+    AND pl.id = NEW.permission_level_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS permissions_updated
+    AFTER UPDATE OF permission_level_id ON permissions
+    WHEN NEW.permission_level_id IS NOT OLD.permission_level_id
+BEGIN
+    INSERT INTO permission_changes (
+        change_type, permission_id, subject_id, subject_type, resource_type, resource_name, permission_level
+    )
+    SELECT 'updated', NEW.id, s.subject_id, s.subject_type, rt.name, r.name, pl.name
+    FROM subjects s, resources r, resource_types rt, permission_levels pl
+    WHERE s.id = NEW.subject_id AND r.id = NEW.resource_id AND rt.id = r.resource_type_id
+    AND pl.id = NEW.permission_level_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS permissions_revoked AFTER DELETE ON permissions
+BEGIN
+    INSERT INTO permission_changes (
+        change_type, permission_id, subject_id, subject_type, resource_type, resource_name, permission_level
+    )
+    SELECT 'revoked', OLD.id, s.subject_id, s.subject_type, rt.name, r.name, pl.name
+    FROM subjects s, resources r, resource_types rt, permission_levels pl
+    WHERE s.id = OLD.subject_id AND r.id = OLD.resource_id AND rt.id = r.resource_type_id
+    AND pl.id = OLD.permission_level_id;
+END;
+
+--
+-- Permissions are deleted explicitly before their subjects and resources so that the revocations are recorded while
+-- the subject and resource can still be looked up.
+--
+CREATE TRIGGER IF NOT EXISTS subjects_delete_permissions BEFORE DELETE ON subjects
+BEGIN
+    DELETE FROM permissions WHERE subject_id = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS resources_delete_permissions BEFORE DELETE ON resources
+BEGIN
+    DELETE FROM permissions WHERE resource_id = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS subjects_rename
+    AFTER UPDATE OF subject_id, subject_type ON subjects
+    WHEN NEW.subject_id IS NOT OLD.subject_id OR NEW.subject_type IS NOT OLD.subject_type
+BEGIN
+    INSERT INTO permission_changes (
+        change_type, permission_id, subject_id, subject_type, resource_type, resource_name, permission_level
+    )
+    SELECT c.change_type, p.id,
+           CASE c.change_type WHEN 'revoked' THEN OLD.subject_id ELSE NEW.subject_id END,
+           CASE c.change_type WHEN 'revoked' THEN OLD.subject_type ELSE NEW.subject_type END,
+           rt.name, r.name, pl.name
+    FROM (SELECT 1 AS ord, 'revoked' AS change_type UNION ALL SELECT 2, 'granted') AS c
+    CROSS JOIN permissions p
+    JOIN resources r ON p.resource_id = r.id
+    JOIN resource_types rt ON r.resource_type_id = rt.id
+    JOIN permission_levels pl ON p.permission_level_id = pl.id
+    WHERE p.subject_id = NEW.id
+    ORDER BY c.ord, p.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS resources_rename
+    AFTER UPDATE OF name, resource_type_id ON resources
+    WHEN NEW.name IS NOT OLD.name OR NEW.resource_type_id IS NOT OLD.resource_type_id
+BEGIN
+    INSERT INTO permission_changes (
+        change_type, permission_id, subject_id, subject_type, resource_type, resource_name, permission_level
+    )
+    SELECT c.change_type, p.id, s.subject_id, s.subject_type, rt.name,
+           CASE c.change_type WHEN 'revoked' THEN OLD.name ELSE NEW.name END,
+           pl.name
+    FROM (
+        SELECT 1 AS ord, 'revoked' AS change_type, OLD.resource_type_id AS resource_type_id
+        UNION ALL SELECT 2, 'granted', NEW.resource_type_id
+    ) AS c
+    JOIN resource_types rt ON c.resource_type_id = rt.id
+    CROSS JOIN permissions p
+    JOIN subjects s ON p.subject_id = s.id
+    JOIN permission_levels pl ON p.permission_level_id = pl.id
+    WHERE p.resource_id = NEW.id
+    ORDER BY c.ord, p.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS resource_types_rename
+    AFTER UPDATE OF name ON resource_types
+    WHEN NEW.name IS NOT OLD.name
+BEGIN
+    INSERT INTO permission_changes (
+        change_type, permission_id, subject_id, subject_type, resource_type, resource_name, permission_level
+    )
+    SELECT c.change_type, p.id, s.subject_id, s.subject_type,
+           CASE c.change_type WHEN 'revoked' THEN OLD.name ELSE NEW.name END,
+           r.name, pl.name
+    FROM (SELECT 1 AS ord, 'revoked' AS change_type UNION ALL SELECT 2, 'granted') AS c
+    CROSS JOIN resources r
+    JOIN permissions p ON p.resource_id = r.id
+    JOIN subjects s ON p.subject_id = s.id
+    JOIN permission_levels pl ON p.permission_level_id = pl.id
+    WHERE r.resource_type_id = NEW.id
+    ORDER BY c.ord, p.id;
+END;
@@ -0,0 +1,178 @@
+// Package sqlite provides an implementation of the permissions database interface that stores its data in a SQLite
+// database. It's intended for small deployments and local development, where running PostgreSQL would be overkill.
+//
+// The schema is created automatically when a store is created. SQLite doesn't support everything that the PostgreSQL
+// schema relies on, so a few SQL functions are registered by the driver used to open the database:
+//
+//	uuid(text)                     validates an identifier and converts it to lower case
+//	new_id()                       generates a new identifier
+//	collapse_whitespace(text)      replaces runs of whitespace with single spaces and trims the result
+//	resource_type_name_key(text)   the value used to compare resource type names
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	_ "embed" // Required for the embedded schema.
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/mattn/go-sqlite3"
+)
+
+// DriverName is the name of the database driver that must be used to open SQLite databases used by this package.
+const DriverName = "sqlite3_permissions"
+
+// uriPrefix is the prefix used to identify SQLite database URIs.
+const uriPrefix = "sqlite:"
+
+//go:embed schema.sql
+var schema string
+
+func init() {
+	sql.Register(DriverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			functions := []struct {
+				name string
+				impl interface{}
+				pure bool
+			}{
+				{"uuid", permsdb.ParseID, true},
+				{"new_id", permsdb.NewID, false},
+				{"collapse_whitespace", collapseWhitespace, true},
+				{"resource_type_name_key", resourceTypeNameKey, true},
+			}
+			for _, f := range functions {
+				if err := conn.RegisterFunc(f.name, f.impl, f.pure); err != nil {
+					return err
+				}
+			}
+
+			_, err := conn.Exec("PRAGMA foreign_keys = ON", nil)
+			return err
+		},
+	})
+}
+
+// collapseWhitespace replaces runs of whitespace in a string with single spaces and removes leading and trailing
+// whitespace.
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// resourceTypeNameKey returns the key used to determine whether or not two resource type names are equivalent.
+func resourceTypeNameKey(name string) string {
+	return strings.ToLower(collapseWhitespace(name))
+}
+
+// ParseURI determines whether or not a database URI refers to a SQLite database. If it does, the data source name that
+// should be passed to sql.Open is returned along with a flag set to true. Supported URIs look like
+// sqlite:///var/lib/permissions.db, sqlite://permissions.db and sqlite::memory:. Any query parameters are passed along
+// to the SQLite driver.
+func ParseURI(uri string) (string, bool) {
+	if !strings.HasPrefix(uri, uriPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(uri, uriPrefix), "//"), true
+}
+
+// sqlBuilder is the statement builder used to build queries dynamically.
+var sqlBuilder = sq.StatementBuilder.PlaceholderFormat(sq.Question)
+
+// Store is a permsdb.Store backed by a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore creates the permissions schema in a SQLite database if it doesn't exist already and returns a store that
+// uses the database. The database must have been opened using DriverName. SQLite only supports one writer at a time,
+// so the database connection pool is limited to a single connection, which serializes transactions.
+func NewStore(db *sql.DB) (*Store, error) {
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Begin starts a new transaction.
+func (s *Store) Begin(ctx context.Context) (permsdb.Tx, error) {
+	sqlTx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &tx{ctx: ctx, tx: sqlTx}, nil
+}
+
+// BeginReadOnly starts a new transaction. There are no read replicas, so this is the same as Begin.
+func (s *Store) BeginReadOnly(ctx context.Context) (permsdb.Tx, error) {
+	return s.Begin(ctx)
+}
+
+// BeginTx starts a new transaction. Transactions are serialized, so the options are ignored.
+func (s *Store) BeginTx(ctx context.Context, _ *sql.TxOptions) (permsdb.Tx, error) {
+	return s.Begin(ctx)
+}
+
+// tx is a transaction in a SQLite store.
+type tx struct {
+	ctx context.Context
+	tx  *sql.Tx
+}
+
+func (t *tx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *tx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// TryLock always obtains the lock because transactions are serialized.
+func (t *tx) TryLock(key int64) (bool, error) {
+	return true, nil
+}
+
+// exists executes a query that counts rows and returns true if the count is greater than zero.
+func (t *tx) exists(query string, args ...interface{}) (bool, error) {
+	var count int64
+	if err := t.tx.QueryRowContext(t.ctx, query, args...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// queryVersion executes a query that returns at most one version number. A nil version is returned if no rows match.
+func (t *tx) queryVersion(query string, args ...interface{}) (*int64, error) {
+	var version int64
+	err := t.tx.QueryRowContext(t.ctx, query, args...).Scan(&version)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// checkDeleted verifies that a statement deleted exactly one row.
+func checkDeleted(result sql.Result, description, id string) error {
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("no %s deleted for id %s", description, id)
+	}
+	if count > 1 {
+		return fmt.Errorf("multiple %s deleted for id %s", description, id)
+	}
+	return nil
+}
+
+// Verify that the SQLite store implements the database interface.
+var _ permsdb.Store = (*Store)(nil)
@@ -0,0 +1,174 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// subjectQuery is the base query used to look up subjects.
+const subjectQuery = "SELECT id, subject_id, subject_type FROM subjects"
+
+// subjectOrder sorts subjects by type and then by external ID. Users are listed before groups, which matches the
+// order of the subject type enumeration in the PostgreSQL schema.
+const subjectOrder = " ORDER BY CASE subject_type WHEN 'user' THEN 0 ELSE 1 END, subject_id"
+
+// querySubjects executes a query that selects the internal ID, external ID and type of zero or more subjects.
+func (t *tx) querySubjects(query string, args ...interface{}) ([]*models.SubjectOut, error) {
+
+	// Query the database.
+	rows, err := t.tx.QueryContext(t.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Build the list of subjects.
+	subjects := make([]*models.SubjectOut, 0)
+	for rows.Next() {
+		var subjectDto permsdb.SubjectDTO
+		if err := rows.Scan(&subjectDto.ID, &subjectDto.SubjectID, &subjectDto.SubjectType); err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, subjectDto.ToSubjectOut())
+	}
+
+	return subjects, rows.Err()
+}
+
+// querySubject executes a query that selects the internal ID, external ID and type of at most one subject.
+func (t *tx) querySubject(duplicateErr error, query string, args ...interface{}) (*models.SubjectOut, error) {
+
+	// Query the database.
+	subjects, err := t.querySubjects(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for duplicates. This shouldn't happen unless there's a bug in the query.
+	if len(subjects) > 1 {
+		return nil, duplicateErr
+	}
+
+	// Return the result.
+	if len(subjects) < 1 {
+		return nil, nil
+	}
+	return subjects[0], nil
+}
+
+// AddSubject adds a subject.
+func (t *tx) AddSubject(
+	subjectID models.ExternalSubjectID,
+	subjectType models.SubjectType,
+) (*models.SubjectOut, error) {
+
+	// Update the database.
+	query := `INSERT INTO subjects (id, subject_id, subject_type) VALUES (new_id(), ?, ?)
+	          RETURNING id, subject_id, subject_type`
+	row := t.tx.QueryRowContext(t.ctx, query, string(subjectID), string(subjectType))
+
+	// Return the subject information.
+	var subjectDto permsdb.SubjectDTO
+	if err := row.Scan(&subjectDto.ID, &subjectDto.SubjectID, &subjectDto.SubjectType); err != nil {
+		return nil, err
+	}
+	return subjectDto.ToSubjectOut(), nil
+}
+
+// UpdateSubject updates a subject.
+func (t *tx) UpdateSubject(
+	id models.InternalSubjectID,
+	subjectID models.ExternalSubjectID,
+	subjectType models.SubjectType,
+) (*models.SubjectOut, error) {
+
+	// Update the database.
+	query := `UPDATE subjects SET subject_id = ?, subject_type = ?
+	          WHERE id = uuid(?)
+	          RETURNING id, subject_id, subject_type`
+	row := t.tx.QueryRowContext(t.ctx, query, string(subjectID), string(subjectType), string(id))
+
+	// Return the subject information.
+	var subjectDto permsdb.SubjectDTO
+	if err := row.Scan(&subjectDto.ID, &subjectDto.SubjectID, &subjectDto.SubjectType); err != nil {
+		return nil, err
+	}
+	return subjectDto.ToSubjectOut(), nil
+}
+
+// SubjectIDExists determines whether or not the subject with the given external ID exists.
+func (t *tx) SubjectIDExists(subjectID models.ExternalSubjectID) (bool, error) {
+	return t.exists("SELECT count(*) FROM subjects WHERE subject_id = ?", string(subjectID))
+}
+
+// SubjectExists determines whether or not the subject with the given internal ID exists.
+func (t *tx) SubjectExists(id models.InternalSubjectID) (bool, error) {
+	return t.exists("SELECT count(*) FROM subjects WHERE id = uuid(?)", string(id))
+}
+
+// DuplicateSubjectExists determines whether or not a subject with the same external subject ID and a different
+// internal subject ID exists.
+func (t *tx) DuplicateSubjectExists(id models.InternalSubjectID, subjectID models.ExternalSubjectID) (bool, error) {
+	query := "SELECT count(*) FROM subjects WHERE id != uuid(?) AND subject_id = ?"
+	return t.exists(query, string(id), string(subjectID))
+}
+
+// ListSubjects lists subjects, optionally filtering by subject type and external subject ID.
+func (t *tx) ListSubjects(subjectType, subjectID *string) ([]*models.SubjectOut, error) {
+
+	// PostgreSQL rejects unrecognized subject types, so we do too.
+	if subjectType != nil && *subjectType != string(models.SubjectTypeUser) &&
+		*subjectType != string(models.SubjectTypeGroup) {
+		return nil, fmt.Errorf("invalid input value for enum subject_type: \"%s\"", *subjectType)
+	}
+
+	query := subjectQuery + " WHERE (?1 IS NULL OR subject_type = ?1) AND (?2 IS NULL OR subject_id = ?2)" +
+		subjectOrder
+	return t.querySubjects(query, subjectType, subjectID)
+}
+
+// DeleteSubject removes a subject along with any permissions that have been granted to it.
+func (t *tx) DeleteSubject(id models.InternalSubjectID) error {
+	result, err := t.tx.ExecContext(t.ctx, "DELETE FROM subjects WHERE id = uuid(?)", string(id))
+	if err != nil {
+		return err
+	}
+	return checkDeleted(result, "subjects", string(id))
+}
+
+// GetSubject obtains information about the subject with the given external ID and subject type.
+func (t *tx) GetSubject(
+	subjectID models.ExternalSubjectID,
+	subjectType models.SubjectType,
+) (*models.SubjectOut, error) {
+	duplicateErr := fmt.Errorf(
+		"found multiple subjects with ID, %s, and type, %s", string(subjectID), string(subjectType),
+	)
+	query := subjectQuery + " WHERE subject_id = ? AND subject_type = ?"
+	return t.querySubject(duplicateErr, query, string(subjectID), string(subjectType))
+}
+
+// GetSubjectByExternalID returns information about the subject with the given external ID.
+func (t *tx) GetSubjectByExternalID(subjectID models.ExternalSubjectID) (*models.SubjectOut, error) {
+	duplicateErr := fmt.Errorf("found multiple subjects with ID, %s", string(subjectID))
+	return t.querySubject(duplicateErr, subjectQuery+" WHERE subject_id = ?", string(subjectID))
+}
+
+// GetSubjectByID returns information about the subject with the given internal ID.
+func (t *tx) GetSubjectByID(id models.InternalSubjectID) (*models.SubjectOut, error) {
+	duplicateErr := fmt.Errorf("found multiple subjects with internal ID, %s", string(id))
+	return t.querySubject(duplicateErr, subjectQuery+" WHERE id = uuid(?)", string(id))
+}
+
+// GetSubjectVersion returns the current version of the subject with the given internal ID.
+func (t *tx) GetSubjectVersion(id models.InternalSubjectID) (*int64, error) {
+	return t.queryVersion("SELECT version FROM subjects WHERE id = uuid(?)", string(id))
+}
+
+// LockSubjectVersion returns the current version of the subject with the given internal ID.
+// Locking isn't required because transactions are serialized.
+func (t *tx) LockSubjectVersion(id models.InternalSubjectID) (*int64, error) {
+	return t.GetSubjectVersion(id)
+}
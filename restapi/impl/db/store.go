@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// Store provides transactional access to the subjects, resources, resource types and permissions managed by the
+// permissions service.
+type Store interface {
+
+	// Begin starts a new transaction. Every operation performed within the transaction is canceled if the context is
+	// canceled or its deadline passes before the transaction completes.
+	Begin(ctx context.Context) (Tx, error)
+
+	// BeginReadOnly starts a new transaction that will only be used to read data. Read-only transactions may be
+	// served by a read replica, so they might not reflect changes that were committed very recently.
+	BeginReadOnly(ctx context.Context) (Tx, error)
+
+	// BeginTx starts a new transaction in the primary database using the given options, which may be nil. Stores that
+	// serialize their transactions may ignore the options, because their transactions are already isolated from each
+	// other.
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error)
+}
+
+// Tx represents a single transaction in a Store. Every operation performed within the transaction is either applied
+// when the transaction is committed or discarded when the transaction is rolled back. Calling Rollback after a
+// transaction has been committed has no effect other than returning an error.
+//
+// Each Get*Version method returns the current version number of an entity without locking it. The corresponding
+// Lock*Version method also locks the entity for the remainder of the transaction so that its version can't change
+// before the transaction completes, and is used to check If-Match preconditions before an update. Version numbers
+// are never reused for the same entity, even if a permission is revoked and granted again.
+type Tx interface {
+	Commit() error
+	Rollback() error
+
+	// TryLock attempts to obtain the exclusive lock identified by the given key, returning false without waiting if
+	// another transaction holds it. The lock is released when the transaction completes. Stores that serialize
+	// transactions always obtain the lock.
+	TryLock(key int64) (bool, error)
+
+	// Resource types.
+	ListResourceTypes(resourceTypeName *string) ([]*models.ResourceTypeOut, error)
+	GetResourceTypeByName(name *string) (*models.ResourceTypeOut, error)
+	GetDuplicateResourceTypeByName(id *string, name *string) (*models.ResourceTypeOut, error)
+	GetResourceType(id *string) (*models.ResourceTypeOut, error)
+	ResourceTypeExists(id *string) (bool, error)
+	AddNewResourceType(resourceTypeIn *models.ResourceTypeIn) (*models.ResourceTypeOut, error)
+	UpdateResourceType(id *string, resourceTypeIn *models.ResourceTypeIn) (*models.ResourceTypeOut, error)
+	DeleteResourceType(id *string) error
+	GetResourceTypeVersion(id *string) (*int64, error)
+	LockResourceTypeVersion(id *string) (*int64, error)
+
+	// Resources. The resources returned by these methods include their labels. Changing the labels of a resource
+	// increments its version.
+	CountResourcesOfType(resourceTypeID *string) (int64, error)
+	ResourceExists(id *string) (bool, error)
+	GetResource(id *string) (*models.ResourceOut, error)
+	GetResourceByName(name *string, resourceTypeID *string) (*models.ResourceOut, error)
+	GetResourceByNameAndType(name, resourceTypeName string) (*models.ResourceOut, error)
+	GetDuplicateResourceByName(id *string, name *string) (*models.ResourceOut, error)
+	AddResource(name *string, resourceTypeID *string) (*models.ResourceOut, error)
+	UpdateResource(id *string, name *string) (*models.ResourceOut, error)
+	ListResources(resourceTypeName, resourceName *string, selector LabelSelector) ([]*models.ResourceOut, error)
+	SetResourceLabels(id *string, labels map[string]string) error
+	DeleteResource(id *string) error
+	LockResource(id *string) error
+	GetResourceVersion(id *string) (*int64, error)
+	LockResourceVersion(id *string) (*int64, error)
+
+	// Subjects.
+	AddSubject(subjectID models.ExternalSubjectID, subjectType models.SubjectType) (*models.SubjectOut, error)
+	UpdateSubject(
+		id models.InternalSubjectID, subjectID models.ExternalSubjectID, subjectType models.SubjectType,
+	) (*models.SubjectOut, error)
+	SubjectIDExists(subjectID models.ExternalSubjectID) (bool, error)
+	SubjectExists(id models.InternalSubjectID) (bool, error)
+	DuplicateSubjectExists(id models.InternalSubjectID, subjectID models.ExternalSubjectID) (bool, error)
+	ListSubjects(subjectType, subjectID *string) ([]*models.SubjectOut, error)
+	DeleteSubject(id models.InternalSubjectID) error
+	GetSubject(subjectID models.ExternalSubjectID, subjectType models.SubjectType) (*models.SubjectOut, error)
+	GetSubjectByExternalID(subjectID models.ExternalSubjectID) (*models.SubjectOut, error)
+	GetSubjectByID(id models.InternalSubjectID) (*models.SubjectOut, error)
+	GetSubjectVersion(id models.InternalSubjectID) (*int64, error)
+	LockSubjectVersion(id models.InternalSubjectID) (*int64, error)
+
+	// Permissions granted directly to subjects.
+	ListPermissions(selector LabelSelector) ([]*models.Permission, error)
+	ListResourcePermissions(resourceTypeName, resourceName string) ([]*models.Permission, error)
+	ListSubjectPermissions(id models.InternalSubjectID) ([]*models.Permission, error)
+	FindSubjectPermissions(
+		id models.InternalSubjectID, resourceTypeName, minLevel *string,
+	) ([]*models.Permission, error)
+	FindResourcePermissions(resourceID string, excludedLevel *models.PermissionLevel) ([]*models.Permission, error)
+	GetPermissionByID(permissionID string) (*models.Permission, error)
+	GetPermission(subjectID models.InternalSubjectID, resourceID string) (*models.Permission, error)
+	GetPermissionLevelIDByName(level models.PermissionLevel) (*string, error)
+	GetPermissionVersion(subjectID models.InternalSubjectID, resourceID string) (*int64, error)
+	LockPermissionVersion(subjectID models.InternalSubjectID, resourceID string) (*int64, error)
+	UpsertPermission(
+		subjectID models.InternalSubjectID, resourceID string, permissionLevelID string,
+	) (*models.Permission, error)
+	DeletePermission(id models.PermissionID) error
+	DeletePermissions(permissions []*models.Permission) error
+	CopyPermissions(source, dest *models.SubjectOut) error
+	CopyResourcePermissions(source, dest *models.ResourceOut, mergePolicy string) error
+	ListPermissionConflicts(source, dest *models.SubjectOut) ([]*models.PermissionConflict, error)
+
+	// Effective permissions. Each of these lookups returns at most one permission per resource: the most permissive
+	// permission granted to any of the given subjects, with the results sorted by resource ID.
+	PermissionsForSubjects(subjectIds []string) ([]*models.Permission, error)
+	PermissionsForSubjectsMinLevel(subjectIds []string, minLevel string) ([]*models.Permission, error)
+	PermissionsForSubjectsAndResourceType(
+		subjectIds []string, resourceTypeName string, selector LabelSelector,
+	) ([]*models.Permission, error)
+	PermissionsForSubjectsAndResourceTypeMinLevel(
+		subjectIds []string, resourceTypeName, minLevel string, selector LabelSelector,
+	) ([]*models.Permission, error)
+	PermissionsForSubjectsAndResource(
+		subjectIds []string, resourceTypeName, resourceName string,
+	) ([]*models.Permission, error)
+	PermissionsForSubjectsAndResourceMinLevel(
+		subjectIds []string, resourceTypeName, resourceName, minLevel string,
+	) ([]*models.Permission, error)
+	AbbreviatedPermissionsForSubjectAndResourceType(
+		subjectIDs []string, resourceTypeName string, minLevel *string, selector LabelSelector,
+	) ([]*models.AbbreviatedPermission, error)
+
+	// Bulk export. Each of these calls a function once for every entity of the given kind without loading all of the
+	// entities into memory, stopping at the first error returned by the function. The function must not use the
+	// transaction.
+	EachResourceType(f func(*models.ResourceTypeOut) error) error
+	EachSubject(f func(*models.SubjectOut) error) error
+	EachResource(f func(*models.ResourceOut) error) error
+	EachPermission(f func(*models.Permission) error) error
+
+	// Permission changes. Every change to a permission is recorded automatically, including permissions that are
+	// removed along with their subjects or resources.
+	LatestChangeSeq() (int64, error)
+	ListChanges(since int64, limit int) ([]*Change, error)
+	PruneChanges(before time.Time) (int64, error)
+}
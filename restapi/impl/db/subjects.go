@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -44,15 +45,17 @@ func rowsToSubject(rows *sql.Rows, duplicateErr error) (*models.SubjectOut, erro
 
 // AddSubject adds a subject to the database.
 func AddSubject(
-	tx *sql.Tx,
+	ctx context.Context, tx *sql.Tx,
 	subjectID models.ExternalSubjectID,
 	subjectType models.SubjectType,
-) (*models.SubjectOut, error) {
+) (_ *models.SubjectOut, err error) {
+	ctx, span := startSpan(ctx, "AddSubject")
+	defer endSpan(span, &err)
 
 	// Update the database.
 	query := `INSERT INTO subjects (subject_id, subject_type) VALUES ($1, $2)
             RETURNING id, subject_id, subject_type`
-	row := tx.QueryRow(query, string(subjectID), string(subjectType))
+	row := tx.QueryRowContext(ctx, query, string(subjectID), string(subjectType))
 
 	// Return the subject information.
 	var subjectDto SubjectDTO
@@ -64,17 +67,19 @@ func AddSubject(
 
 // UpdateSubject updates a subject in the database.
 func UpdateSubject(
-	tx *sql.Tx,
+	ctx context.Context, tx *sql.Tx,
 	id models.InternalSubjectID,
 	subjectID models.ExternalSubjectID,
 	subjectType models.SubjectType,
-) (*models.SubjectOut, error) {
+) (_ *models.SubjectOut, err error) {
+	ctx, span := startSpan(ctx, "UpdateSubject")
+	defer endSpan(span, &err)
 
 	// Update the database.
 	query := `UPDATE subjects SET subject_id = $1, subject_type = $2
             WHERE id = $3
             RETURNING id, subject_id, subject_type`
-	row := tx.QueryRow(query, string(subjectID), string(subjectType), string(id))
+	row := tx.QueryRowContext(ctx, query, string(subjectID), string(subjectType), string(id))
 
 	// Return the subject information.
 	var subjectDto SubjectDTO
@@ -85,11 +90,13 @@ func UpdateSubject(
 }
 
 // SubjectIDExists determines whether or not the subject with the given external ID exists in the database.
-func SubjectIDExists(tx *sql.Tx, subjectID models.ExternalSubjectID) (bool, error) {
+func SubjectIDExists(ctx context.Context, tx *sql.Tx, subjectID models.ExternalSubjectID) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "SubjectIDExists")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := "SELECT count(*) FROM subjects WHERE subject_id = $1"
-	row := tx.QueryRow(query, string(subjectID))
+	row := tx.QueryRowContext(ctx, query, string(subjectID))
 
 	// Get the result.
 	var count uint32
@@ -100,11 +107,13 @@ func SubjectIDExists(tx *sql.Tx, subjectID models.ExternalSubjectID) (bool, erro
 }
 
 // SubjectExists determines whether or not the subject with the given internal ID exists in the database.
-func SubjectExists(tx *sql.Tx, id models.InternalSubjectID) (bool, error) {
+func SubjectExists(ctx context.Context, tx *sql.Tx, id models.InternalSubjectID) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "SubjectExists")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := "SELECT count(*) FROM subjects WHERE id = $1"
-	row := tx.QueryRow(query, string(id))
+	row := tx.QueryRowContext(ctx, query, string(id))
 
 	// Get the result.
 	var count uint32
@@ -117,14 +126,16 @@ func SubjectExists(tx *sql.Tx, id models.InternalSubjectID) (bool, error) {
 // DuplicateSubjectExists determines whether or not a subject with the same external subject ID and a different
 // internal subject ID exists.
 func DuplicateSubjectExists(
-	tx *sql.Tx,
+	ctx context.Context, tx *sql.Tx,
 	id models.InternalSubjectID,
 	subjectID models.ExternalSubjectID,
-) (bool, error) {
+) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "DuplicateSubjectExists")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	query := "SELECT count(*) FROM subjects WHERE id != $1 and subject_id = $2"
-	row := tx.QueryRow(query, string(id), string(subjectID))
+	row := tx.QueryRowContext(ctx, query, string(id), string(subjectID))
 
 	// Get the result.
 	var count uint32
@@ -135,30 +146,31 @@ func DuplicateSubjectExists(
 }
 
 // ListSubjects lists subjects in the database, optionally filtering by subject type and external subject ID
-func ListSubjects(tx *sql.Tx, subjectType, subjectID *string) ([]*models.SubjectOut, error) {
+func ListSubjects(ctx context.Context, tx *sql.Tx, subjectType, subjectID *string) (_ []*models.SubjectOut, err error) {
+	ctx, span := startSpan(ctx, "ListSubjects")
+	defer endSpan(span, &err)
 
 	// Query the database.
 	var rows *sql.Rows
-	var err error
 	if subjectType != nil && subjectID != nil {
 		query := `SELECT id, subject_id, subject_type FROM subjects
 		          WHERE subject_type = $1 AND subject_id = $2
 		          ORDER BY subject_type, subject_id`
-		rows, err = tx.Query(query, *subjectType, *subjectID)
+		rows, err = tx.QueryContext(ctx, query, *subjectType, *subjectID)
 	} else if subjectType != nil {
 		query := `SELECT id, subject_id, subject_type FROM subjects
 		          WHERE subject_type = $1
 		          ORDER BY subject_type, subject_id`
-		rows, err = tx.Query(query, *subjectType)
+		rows, err = tx.QueryContext(ctx, query, *subjectType)
 	} else if subjectID != nil {
 		query := `SELECT id, subject_id, subject_type FROM subjects
 		          WHERE subject_id = $1
 		          ORDER BY subject_type, subject_id`
-		rows, err = tx.Query(query, *subjectID)
+		rows, err = tx.QueryContext(ctx, query, *subjectID)
 	} else {
 		query := `SELECT id, subject_id, subject_type FROM subjects
 		          ORDER BY subject_type, subject_id`
-		rows, err = tx.Query(query)
+		rows, err = tx.QueryContext(ctx, query)
 	}
 	if err != nil {
 		return nil, err
@@ -170,11 +182,13 @@ func ListSubjects(tx *sql.Tx, subjectType, subjectID *string) ([]*models.Subject
 }
 
 // DeleteSubject removes a subject from the database.
-func DeleteSubject(tx *sql.Tx, id models.InternalSubjectID) error {
+func DeleteSubject(ctx context.Context, tx *sql.Tx, id models.InternalSubjectID) (err error) {
+	ctx, span := startSpan(ctx, "DeleteSubject")
+	defer endSpan(span, &err)
 
 	// Update the database.
 	stmt := "DELETE FROM subjects WHERE id = $1"
-	result, err := tx.Exec(stmt, string(id))
+	result, err := tx.ExecContext(ctx, stmt, string(id))
 	if err != nil {
 		return err
 	}
@@ -196,15 +210,17 @@ func DeleteSubject(tx *sql.Tx, id models.InternalSubjectID) error {
 
 // GetSubject obtains information about the subject with the given external ID and subject type.
 func GetSubject(
-	tx *sql.Tx,
+	ctx context.Context, tx *sql.Tx,
 	subjectID models.ExternalSubjectID,
 	subjectType models.SubjectType,
-) (*models.SubjectOut, error) {
+) (_ *models.SubjectOut, err error) {
+	ctx, span := startSpan(ctx, "GetSubject")
+	defer endSpan(span, &err)
 
 	// Get the subject information from the database.
 	query := `SELECT id, subject_id, subject_type FROM subjects
             WHERE subject_id = $1 AND subject_type = $2`
-	rows, err := tx.Query(query, string(subjectID), string(subjectType))
+	rows, err := tx.QueryContext(ctx, query, string(subjectID), string(subjectType))
 	if err != nil {
 		return nil, err
 	}
@@ -218,11 +234,15 @@ func GetSubject(
 }
 
 // GetSubjectByExternalID returns information about the subjects with the given external ID.
-func GetSubjectByExternalID(tx *sql.Tx, subjectID models.ExternalSubjectID) (*models.SubjectOut, error) {
+func GetSubjectByExternalID(
+	ctx context.Context, tx *sql.Tx, subjectID models.ExternalSubjectID,
+) (_ *models.SubjectOut, err error) {
+	ctx, span := startSpan(ctx, "GetSubjectByExternalID")
+	defer endSpan(span, &err)
 
 	// Get the subject information from the database.
 	query := "SELECT id, subject_id, subject_type FROM subjects WHERE subject_id = $1"
-	rows, err := tx.Query(query, string(subjectID))
+	rows, err := tx.QueryContext(ctx, query, string(subjectID))
 	if err != nil {
 		return nil, err
 	}
@@ -232,3 +252,21 @@ func GetSubjectByExternalID(tx *sql.Tx, subjectID models.ExternalSubjectID) (*mo
 	duplicateErr := fmt.Errorf("found multiple subjects with ID, %s", string(subjectID))
 	return rowsToSubject(rows, duplicateErr)
 }
+
+// GetSubjectByID returns information about the subject with the given internal ID.
+func GetSubjectByID(ctx context.Context, tx *sql.Tx, id models.InternalSubjectID) (_ *models.SubjectOut, err error) {
+	ctx, span := startSpan(ctx, "GetSubjectByID")
+	defer endSpan(span, &err)
+
+	// Get the subject information from the database.
+	query := "SELECT id, subject_id, subject_type FROM subjects WHERE id = $1"
+	rows, err := tx.QueryContext(ctx, query, string(id))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Get the subject.
+	duplicateErr := fmt.Errorf("found multiple subjects with internal ID, %s", string(id))
+	return rowsToSubject(rows, duplicateErr)
+}
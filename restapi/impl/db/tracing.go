@@ -0,0 +1,24 @@
+package db
+
+import (
+	"context"
+
+	"github.com/cyverse-de/permissions/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan starts a span for one of the functions in this package. The caller must end the span using endSpan.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.StartSpan(
+		ctx, "db."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+// endSpan ends a span started by startSpan, recording the error returned by the traced function if there was one.
+// Callers should defer it with a pointer to a named error result so that every return path is covered.
+func endSpan(span trace.Span, err *error) {
+	tracing.EndSpan(span, *err) // nolint:errcheck
+}
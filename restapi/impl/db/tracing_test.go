@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpansRecordErrors(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	tx, err := openFakeDB(t, "tracing").Begin()
+	if err != nil {
+		t.Fatalf("unable to start a transaction: %s", err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	// The fake driver doesn't support queries, so the lookup should fail.
+	if _, err := ListResourceTypes(context.Background(), tx, nil); err == nil {
+		t.Fatal("expected the lookup to fail")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("unexpected number of spans: %d", len(spans))
+	}
+	if spans[0].Name() != "db.ListResourceTypes" {
+		t.Errorf("unexpected span name: %s", spans[0].Name())
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("unexpected span status: %v", spans[0].Status())
+	}
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// queryVersion executes a query that returns at most one version number. A nil version is returned if no rows match.
+func queryVersion(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (*int64, error) {
+
+	// Query the database.
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Extract the version numbers.
+	versions := make([]int64, 0)
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Return the result.
+	if len(versions) > 1 {
+		return nil, fmt.Errorf("expected at most one version number but found %d", len(versions))
+	}
+	if len(versions) < 1 {
+		return nil, nil
+	}
+	return &versions[0], nil
+}
+
+// forUpdate appends a locking clause to a version query if the selected row should be locked for the remainder of the
+// transaction.
+func forUpdate(query string, lock bool) string {
+	if lock {
+		return query + " FOR UPDATE"
+	}
+	return query
+}
+
+// GetResourceTypeVersion returns the current version of the resource type with the given ID.
+func GetResourceTypeVersion(ctx context.Context, tx *sql.Tx, id *string) (_ *int64, err error) {
+	ctx, span := startSpan(ctx, "GetResourceTypeVersion")
+	defer endSpan(span, &err)
+
+	return resourceTypeVersion(ctx, tx, id, false)
+}
+
+// LockResourceTypeVersion returns the current version of the resource type with the given ID and locks the resource
+// type for the remainder of the transaction.
+func LockResourceTypeVersion(ctx context.Context, tx *sql.Tx, id *string) (_ *int64, err error) {
+	ctx, span := startSpan(ctx, "LockResourceTypeVersion")
+	defer endSpan(span, &err)
+
+	return resourceTypeVersion(ctx, tx, id, true)
+}
+
+func resourceTypeVersion(ctx context.Context, tx *sql.Tx, id *string, lock bool) (*int64, error) {
+	return queryVersion(ctx, tx, forUpdate("SELECT version FROM resource_types WHERE id = $1", lock), id)
+}
+
+// GetResourceVersion returns the current version of the resource with the given ID.
+func GetResourceVersion(ctx context.Context, tx *sql.Tx, id *string) (_ *int64, err error) {
+	ctx, span := startSpan(ctx, "GetResourceVersion")
+	defer endSpan(span, &err)
+
+	return resourceVersion(ctx, tx, id, false)
+}
+
+// LockResourceVersion returns the current version of the resource with the given ID and locks the resource for the
+// remainder of the transaction.
+func LockResourceVersion(ctx context.Context, tx *sql.Tx, id *string) (_ *int64, err error) {
+	ctx, span := startSpan(ctx, "LockResourceVersion")
+	defer endSpan(span, &err)
+
+	return resourceVersion(ctx, tx, id, true)
+}
+
+func resourceVersion(ctx context.Context, tx *sql.Tx, id *string, lock bool) (*int64, error) {
+	return queryVersion(ctx, tx, forUpdate("SELECT version FROM resources WHERE id = $1", lock), id)
+}
+
+// GetSubjectVersion returns the current version of the subject with the given internal ID.
+func GetSubjectVersion(ctx context.Context, tx *sql.Tx, id models.InternalSubjectID) (_ *int64, err error) {
+	ctx, span := startSpan(ctx, "GetSubjectVersion")
+	defer endSpan(span, &err)
+
+	return subjectVersion(ctx, tx, id, false)
+}
+
+// LockSubjectVersion returns the current version of the subject with the given internal ID and locks the subject for
+// the remainder of the transaction.
+func LockSubjectVersion(ctx context.Context, tx *sql.Tx, id models.InternalSubjectID) (_ *int64, err error) {
+	ctx, span := startSpan(ctx, "LockSubjectVersion")
+	defer endSpan(span, &err)
+
+	return subjectVersion(ctx, tx, id, true)
+}
+
+func subjectVersion(ctx context.Context, tx *sql.Tx, id models.InternalSubjectID, lock bool) (*int64, error) {
+	return queryVersion(ctx, tx, forUpdate("SELECT version FROM subjects WHERE id = $1", lock), string(id))
+}
+
+// GetPermissionVersion returns the current version of a subject's permission to a resource.
+func GetPermissionVersion(
+	ctx context.Context, tx *sql.Tx, subjectID models.InternalSubjectID, resourceID string,
+) (_ *int64, err error) {
+	ctx, span := startSpan(ctx, "GetPermissionVersion")
+	defer endSpan(span, &err)
+
+	return permissionVersion(ctx, tx, subjectID, resourceID, false)
+}
+
+// LockPermissionVersion returns the current version of a subject's permission to a resource and locks the permission
+// for the remainder of the transaction.
+func LockPermissionVersion(
+	ctx context.Context, tx *sql.Tx, subjectID models.InternalSubjectID, resourceID string,
+) (_ *int64, err error) {
+	ctx, span := startSpan(ctx, "LockPermissionVersion")
+	defer endSpan(span, &err)
+
+	return permissionVersion(ctx, tx, subjectID, resourceID, true)
+}
+
+func permissionVersion(
+	ctx context.Context, tx *sql.Tx, subjectID models.InternalSubjectID, resourceID string, lock bool,
+) (*int64, error) {
+	query := forUpdate("SELECT version FROM permissions WHERE subject_id = $1 AND resource_id = $2", lock)
+	return queryVersion(ctx, tx, query, string(subjectID), resourceID)
+}
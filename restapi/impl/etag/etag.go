@@ -0,0 +1,36 @@
+// Package etag contains functions for working with the entity tags used for optimistic concurrency control.
+package etag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FromVersion formats a version number as an entity tag.
+func FromVersion(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// Matches returns true if the value of an If-Match header matches an entity tag. A missing header always matches.
+// Otherwise, the header may contain a wildcard or a comma-separated list of entity tags.
+func Matches(ifMatch *string, tag string) bool {
+	if ifMatch == nil {
+		return true
+	}
+	for _, candidate := range strings.Split(*ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesVersion returns true if the value of an If-Match header matches the entity tag for a version number. A nil
+// version indicates that the entity doesn't exist, in which case only a missing header matches.
+func MatchesVersion(ifMatch *string, version *int64) bool {
+	if version == nil {
+		return ifMatch == nil
+	}
+	return Matches(ifMatch, FromVersion(*version))
+}
@@ -0,0 +1,50 @@
+package etag
+
+import "testing"
+
+func TestFromVersion(t *testing.T) {
+	if tag := FromVersion(42); tag != `"42"` {
+		t.Errorf("unexpected entity tag: %s", tag)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	header := func(s string) *string { return &s }
+
+	tests := []struct {
+		ifMatch  *string
+		tag      string
+		expected bool
+	}{
+		{nil, `"1"`, true},
+		{header("*"), `"1"`, true},
+		{header(`"1"`), `"1"`, true},
+		{header(`"2"`), `"1"`, false},
+		{header(`"2", "1"`), `"1"`, true},
+		{header("1"), `"1"`, false},
+	}
+
+	for _, test := range tests {
+		if actual := Matches(test.ifMatch, test.tag); actual != test.expected {
+			t.Errorf("Matches(%v, %s) returned %t", test.ifMatch, test.tag, actual)
+		}
+	}
+}
+
+func TestMatchesVersion(t *testing.T) {
+	header := func(s string) *string { return &s }
+	version := int64(3)
+
+	if !MatchesVersion(nil, nil) {
+		t.Error("a missing header should match a missing entity")
+	}
+	if MatchesVersion(header("*"), nil) {
+		t.Error("a wildcard should not match a missing entity")
+	}
+	if !MatchesVersion(header(`"3"`), &version) {
+		t.Error("the current version should match")
+	}
+	if MatchesVersion(header(`"2"`), &version) {
+		t.Error("a stale version should not match")
+	}
+}
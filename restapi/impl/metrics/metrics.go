@@ -0,0 +1,120 @@
+// Package metrics contains the Prometheus metrics reported by the permissions service.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is the path of the endpoint used to report metrics.
+const Path = "/metrics"
+
+var (
+	requestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "permissions_http_requests_total",
+			Help: "The number of HTTP requests handled, by swagger operation ID and status code.",
+		},
+		[]string{"operation", "code"},
+	)
+
+	requestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "permissions_http_request_duration_seconds",
+			Help:    "The amount of time spent handling HTTP requests, by swagger operation ID.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"operation"},
+	)
+
+	grouperLookupDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "permissions_grouper_lookup_duration_seconds",
+			Help:    "The amount of time spent looking up the groups that a subject belongs to.",
+			Buckets: prometheus.DefBuckets,
+		},
+	)
+
+	grouperGroupCount = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "permissions_grouper_group_count",
+			Help:    "The number of groups that subjects belong to, as reported by group lookups.",
+			Buckets: []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		},
+	)
+
+	transactionRollbacks = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "permissions_transaction_rollbacks_total",
+			Help: "The number of read-write database transactions that were rolled back.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		requestsTotal, requestDuration, grouperLookupDuration, grouperGroupCount, transactionRollbacks,
+	)
+}
+
+// RegisterDBStats registers a collector for the connection pool statistics of a database. The name is used to
+// distinguish between the databases used by the service.
+func RegisterDBStats(db *sql.DB, name string) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveGrouperLookup records the duration and result size of a lookup of the groups that a subject belongs to.
+func ObserveGrouperLookup(start time.Time, groupCount int) {
+	grouperLookupDuration.Observe(time.Since(start).Seconds())
+	grouperGroupCount.Observe(float64(groupCount))
+}
+
+// statusRecorder is a response writer that records the response status code.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+// WriteHeader records the status code before writing it.
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// InstrumentOperations returns a handler that records request counts and latencies for each swagger operation. It
+// must be installed after the request has been routed, so that the matched route is available.
+func InstrumentOperations(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		operation := "unknown"
+		if route := middleware.MatchedRouteFrom(r); route != nil && route.Operation != nil {
+			operation = route.Operation.ID
+		}
+
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		handler.ServeHTTP(recorder, r)
+
+		requestsTotal.WithLabelValues(operation, strconv.Itoa(recorder.code)).Inc()
+		requestDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	})
+}
+
+// WithEndpoint returns a handler that serves metrics at the metrics path and passes all other requests on to the
+// given handler.
+func WithEndpoint(handler http.Handler) http.Handler {
+	metricsHandler := promhttp.Handler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == Path {
+			metricsHandler.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
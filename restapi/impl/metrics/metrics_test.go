@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cyverse-de/permissions/restapi/impl/db/memory"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestInstrumentOperations(t *testing.T) {
+	handler := InstrumentOperations(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	before := testutil.ToFloat64(requestsTotal.WithLabelValues("unknown", "418"))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	after := testutil.ToFloat64(requestsTotal.WithLabelValues("unknown", "418"))
+	if after-before != 1 {
+		t.Errorf("unexpected request count change: %v", after-before)
+	}
+}
+
+func TestWithEndpoint(t *testing.T) {
+	handler := WithEndpoint(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("unexpected status code for the metrics endpoint: %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "permissions_transaction_rollbacks_total") {
+		t.Error("rollback count not found in metrics")
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/subjects", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("unexpected status code for other endpoints: %d", rec.Code)
+	}
+}
+
+func TestInstrumentStore(t *testing.T) {
+	store := InstrumentStore(memory.NewStore())
+
+	before := testutil.ToFloat64(transactionRollbacks)
+	tx, err := store.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	tx, err = store.BeginReadOnly(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if after := testutil.ToFloat64(transactionRollbacks); after-before != 1 {
+		t.Errorf("unexpected rollback count change: %v", after-before)
+	}
+}
+
+func TestInstrumentStoreRollbackAfterCommit(t *testing.T) {
+	store := InstrumentStore(memory.NewStore())
+
+	// A deferred rollback after a successful commit shouldn't be counted.
+	before := testutil.ToFloat64(transactionRollbacks)
+	tx, err := store.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err == nil {
+		t.Error("expected the rollback of a committed transaction to fail")
+	}
+	if after := testutil.ToFloat64(transactionRollbacks); after != before {
+		t.Errorf("unexpected rollback count change: %v", after-before)
+	}
+}
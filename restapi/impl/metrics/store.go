@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// instrumentedStore is a permsdb.Store that counts transaction rollbacks. Only read-write transactions are counted,
+// because read-only transactions are always rolled back once the data has been read.
+type instrumentedStore struct {
+	permsdb.Store
+}
+
+// InstrumentStore wraps a store so that transaction rollbacks are counted.
+func InstrumentStore(store permsdb.Store) permsdb.Store {
+	return &instrumentedStore{Store: store}
+}
+
+// Begin starts a transaction.
+func (s *instrumentedStore) Begin(ctx context.Context) (permsdb.Tx, error) {
+	return instrumentTx(s.Store.Begin(ctx))
+}
+
+// BeginReadOnly starts a read-only transaction. Rollbacks of read-only transactions aren't counted.
+func (s *instrumentedStore) BeginReadOnly(ctx context.Context) (permsdb.Tx, error) {
+	return s.Store.BeginReadOnly(ctx)
+}
+
+// BeginTx starts a transaction with the given options. Rollbacks are only counted if the transaction isn't read-only.
+func (s *instrumentedStore) BeginTx(ctx context.Context, opts *sql.TxOptions) (permsdb.Tx, error) {
+	if opts != nil && opts.ReadOnly {
+		return s.Store.BeginTx(ctx, opts)
+	}
+	return instrumentTx(s.Store.BeginTx(ctx, opts))
+}
+
+// instrumentedTx is a permsdb.Tx that counts rollbacks.
+type instrumentedTx struct {
+	permsdb.Tx
+}
+
+func instrumentTx(tx permsdb.Tx, err error) (permsdb.Tx, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedTx{Tx: tx}, nil
+}
+
+// Rollback rolls back the transaction. The rollback is only counted if the transaction was still in progress, so
+// calling Rollback after the transaction has been committed has no effect on the count.
+func (t *instrumentedTx) Rollback() error {
+	err := t.Tx.Rollback()
+	if err == nil {
+		transactionRollbacks.Inc()
+	}
+	return err
+}
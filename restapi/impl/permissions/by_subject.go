@@ -1,16 +1,17 @@
 package permissions
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func bySubjectOk(perms []*models.Permission) middleware.Responder {
@@ -33,78 +34,61 @@ func bySubjectBadRequest(reason string) middleware.Responder {
 
 // BuildBySubjectHandler builds the request handler for the permissions by subject endpoint
 func BuildBySubjectHandler(
-	db *sql.DB, grouperClient grouper.Grouper, schema string,
+	db permsdb.Store, grouperClient grouper.Grouper,
 ) func(permissions.BySubjectParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params permissions.BySubjectParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type": params.SubjectType,
+			"subject_id":   params.SubjectID,
+		})
 		subjectType := params.SubjectType
 		subjectID := params.SubjectID
 		lookup := extractLookupFlag(params.Lookup)
 		minLevel := params.MinLevel
 
-		// Create a transaction for the request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return bySubjectInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
-		if err != nil {
-			logger.Log.Error(err)
-			return bySubjectInternalServerError(err.Error())
-		}
-
-		// Verify that the subject type is correct.
-		subject, err := permsdb.GetSubjectByExternalID(tx, models.ExternalSubjectID(subjectID))
-		if err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
-			return bySubjectInternalServerError(err.Error())
-		}
-		if subject != nil && string(*subject.SubjectType) != subjectType {
-			tx.Rollback() // nolint:errcheck
-			reason := fmt.Sprintf("incorrect type for subject, %s: %s", subjectID, subjectType)
-			return bySubjectBadRequest(reason)
-		}
-
-		// Get the list of subject IDs to use for the query.
-		subjectIds, err := buildSubjectIDList(grouperClient, subjectType, subjectID, lookup)
-		if err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
-			return bySubjectInternalServerError(err.Error())
-		}
-
 		// Perform the lookup.
 		var perms []*models.Permission
-		if minLevel == nil {
-			perms, err = permsdb.PermissionsForSubjects(tx, subjectIds)
+		responder, err := runLookup(ctx, db, params.XReadYourWrites, func(tx permsdb.Tx) (middleware.Responder, error) {
+
+			// Verify that the subject type is correct.
+			subject, err := tx.GetSubjectByExternalID(models.ExternalSubjectID(subjectID))
 			if err != nil {
-				tx.Rollback() // nolint:errcheck
-				logger.Log.Error(err)
-				return bySubjectInternalServerError(err.Error())
+				return nil, err
 			}
-		} else {
-			perms, err = permsdb.PermissionsForSubjectsMinLevel(tx, subjectIds, *minLevel)
+			if subject != nil && string(*subject.SubjectType) != subjectType {
+				reason := fmt.Sprintf("incorrect type for subject, %s: %s", subjectID, subjectType)
+				return bySubjectBadRequest(reason), nil
+			}
+
+			// Get the list of subject IDs to use for the query.
+			subjectIds, err := BuildSubjectIDList(ctx, grouperClient, subjectType, subjectID, lookup)
 			if err != nil {
-				tx.Rollback() // nolint:errcheck
-				logger.Log.Error(err)
-				return bySubjectInternalServerError(err.Error())
+				log.Error(err)
+				return bySubjectInternalServerError(err.Error()), nil
 			}
-		}
 
-		// Commit the transaction.
-		if err := tx.Commit(); err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			// Look up the permissions.
+			if minLevel == nil {
+				perms, err = tx.PermissionsForSubjects(subjectIds)
+			} else {
+				perms, err = tx.PermissionsForSubjectsMinLevel(subjectIds, *minLevel)
+			}
+			return nil, err
+		})
+		if err != nil {
+			log.Error(err)
 			return bySubjectInternalServerError(err.Error())
 		}
+		if responder != nil {
+			return responder
+		}
 
 		// Add the subject source ID to the response body.
-		if err := grouperClient.AddSourceIDToPermissions(perms); err != nil {
-			logger.Log.Error(err)
+		if err := grouperClient.AddSourceIDToPermissions(ctx, perms); err != nil {
+			log.Error(err)
 			return bySubjectInternalServerError(err.Error())
 		}
 
@@ -1,16 +1,17 @@
 package permissions
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func bySubjectAndResourceOk(perms []*models.Permission) middleware.Responder {
@@ -33,11 +34,18 @@ func bySubjectAndResourceBadRequest(reason string) middleware.Responder {
 
 // BuildBySubjectAndResourceHandler builds the request handler for the permissions by subject and resource endpoint.
 func BuildBySubjectAndResourceHandler(
-	db *sql.DB, grouperClient grouper.Grouper, schema string,
+	db permsdb.Store, grouperClient grouper.Grouper,
 ) func(permissions.BySubjectAndResourceParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params permissions.BySubjectAndResourceParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type":  params.SubjectType,
+			"subject_id":    params.SubjectID,
+			"resource_type": params.ResourceType,
+			"resource_name": params.ResourceName,
+		})
 		subjectType := params.SubjectType
 		subjectID := params.SubjectID
 		resourceTypeName := params.ResourceType
@@ -45,95 +53,66 @@ func BuildBySubjectAndResourceHandler(
 		lookup := extractLookupFlag(params.Lookup)
 		minLevel := params.MinLevel
 
-		// Start a transaction for the request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return bySubjectAndResourceInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
-		if err != nil {
-			logger.Log.Error(err)
-			return bySubjectAndResourceInternalServerError(err.Error())
-		}
-
-		// Verify that the subject type is correct.
-		subject, err := permsdb.GetSubjectByExternalID(tx, models.ExternalSubjectID(subjectID))
-		if err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
-			return bySubjectAndResourceInternalServerError(err.Error())
-		}
-		if subject != nil && string(*subject.SubjectType) != subjectType {
-			tx.Rollback() // nolint:errcheck
-			reason := fmt.Sprintf("incorrect type for subject, %s: %s", subjectID, subjectType)
-			return bySubjectAndResourceBadRequest(reason)
-		}
-
-		// Verify that the resource type exists.
-		resourceType, err := permsdb.GetResourceTypeByName(tx, &resourceTypeName)
-		if err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
-			return bySubjectAndResourceInternalServerError(err.Error())
-		}
-		if resourceType == nil {
-			tx.Rollback() // nolint:errcheck
-			return bySubjectAndResourceOk(make([]*models.Permission, 0))
-		}
+		// Perform the lookup.
+		var perms []*models.Permission
+		responder, err := runLookup(ctx, db, params.XReadYourWrites, func(tx permsdb.Tx) (middleware.Responder, error) {
 
-		// Verify that the resource exists.
-		resource, err := permsdb.GetResourceByName(tx, &resourceName, resourceType.ID)
-		if err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
-			return bySubjectAndResourceInternalServerError(err.Error())
-		}
-		if resource == nil {
-			tx.Rollback() // nolint:errcheck
-			return bySubjectAndResourceOk(make([]*models.Permission, 0))
-		}
+			// Verify that the subject type is correct.
+			subject, err := tx.GetSubjectByExternalID(models.ExternalSubjectID(subjectID))
+			if err != nil {
+				return nil, err
+			}
+			if subject != nil && string(*subject.SubjectType) != subjectType {
+				reason := fmt.Sprintf("incorrect type for subject, %s: %s", subjectID, subjectType)
+				return bySubjectAndResourceBadRequest(reason), nil
+			}
 
-		// Get the list of subject IDs to use for the query.
-		subjectIds, err := buildSubjectIDList(grouperClient, subjectType, subjectID, lookup)
-		if err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
-			return bySubjectInternalServerError(err.Error())
-		}
+			// Verify that the resource type exists.
+			resourceType, err := tx.GetResourceTypeByName(&resourceTypeName)
+			if err != nil {
+				return nil, err
+			}
+			if resourceType == nil {
+				return bySubjectAndResourceOk(make([]*models.Permission, 0)), nil
+			}
 
-		// Perform the lookup.
-		var perms []*models.Permission
-		if minLevel == nil {
-			perms, err = permsdb.PermissionsForSubjectsAndResource(tx, subjectIds, resourceTypeName, resourceName)
+			// Verify that the resource exists.
+			resource, err := tx.GetResourceByName(&resourceName, resourceType.ID)
 			if err != nil {
-				tx.Rollback() // nolint:errcheck
-				logger.Log.Error(err)
-				return bySubjectAndResourceInternalServerError(err.Error())
+				return nil, err
+			}
+			if resource == nil {
+				return bySubjectAndResourceOk(make([]*models.Permission, 0)), nil
 			}
-		} else {
-			perms, err = permsdb.PermissionsForSubjectsAndResourceMinLevel(
-				tx, subjectIds, resourceTypeName, resourceName, *minLevel,
-			)
+
+			// Get the list of subject IDs to use for the query.
+			subjectIds, err := BuildSubjectIDList(ctx, grouperClient, subjectType, subjectID, lookup)
 			if err != nil {
-				tx.Rollback() // nolint:errcheck
-				logger.Log.Error(err)
-				return bySubjectAndResourceInternalServerError(err.Error())
+				log.Error(err)
+				return bySubjectAndResourceInternalServerError(err.Error()), nil
 			}
-		}
 
-		// Commit the transaction.
-		err = tx.Commit()
+			// Look up the permissions.
+			if minLevel == nil {
+				perms, err = tx.PermissionsForSubjectsAndResource(subjectIds, resourceTypeName, resourceName)
+			} else {
+				perms, err = tx.PermissionsForSubjectsAndResourceMinLevel(
+					subjectIds, resourceTypeName, resourceName, *minLevel,
+				)
+			}
+			return nil, err
+		})
 		if err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return bySubjectAndResourceInternalServerError(err.Error())
 		}
+		if responder != nil {
+			return responder
+		}
 
 		// Add the subject source ID to the results.
-		if err := grouperClient.AddSourceIDToPermissions(perms); err != nil {
-			logger.Log.Error(err)
+		if err := grouperClient.AddSourceIDToPermissions(ctx, perms); err != nil {
+			log.Error(err)
 			return bySubjectAndResourceInternalServerError(err.Error())
 		}
 
@@ -1,16 +1,17 @@
 package permissions
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func bySubjectAndResourceTypeOk(perms []*models.Permission) middleware.Responder {
@@ -34,91 +35,83 @@ func bySubjectAndResourceTypeBadRequest(reason string) middleware.Responder {
 // BuildBySubjectAndResourceTypeHandler builds the request handler for the permissions by subject and resource type
 // endpoint.
 func BuildBySubjectAndResourceTypeHandler(
-	db *sql.DB, grouperClient grouper.Grouper, schema string,
+	db permsdb.Store, grouperClient grouper.Grouper,
 ) func(permissions.BySubjectAndResourceTypeParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params permissions.BySubjectAndResourceTypeParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type":  params.SubjectType,
+			"subject_id":    params.SubjectID,
+			"resource_type": params.ResourceType,
+		})
 		subjectType := params.SubjectType
 		subjectID := params.SubjectID
 		resourceTypeName := params.ResourceType
 		lookup := extractLookupFlag(params.Lookup)
 		minLevel := params.MinLevel
 
-		// Create a transaction for the request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return bySubjectAndResourceTypeInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
-		if err != nil {
-			logger.Log.Error(err)
-			return bySubjectAndResourceTypeInternalServerError(err.Error())
-		}
-
-		// Verify that the subject type is correct.
-		subject, err := permsdb.GetSubjectByExternalID(tx, models.ExternalSubjectID(subjectID))
-		if err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
-			return bySubjectAndResourceTypeInternalServerError(err.Error())
-		}
-		if subject != nil && string(*subject.SubjectType) != subjectType {
-			tx.Rollback() // nolint:errcheck
-			reason := fmt.Sprintf("incorrect type for subject, %s: %s", subjectID, subjectType)
-			return bySubjectAndResourceTypeBadRequest(reason)
-		}
-
-		// Verify that the resource type exists.
-		resourceType, err := permsdb.GetResourceTypeByName(tx, &resourceTypeName)
-		if err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
-			return bySubjectAndResourceTypeInternalServerError(err.Error())
-		}
-		if resourceType == nil {
-			tx.Rollback() // nolint:errcheck
-			return bySubjectAndResourceTypeOk(make([]*models.Permission, 0))
-		}
-
-		// Get the list of subject IDs to use for the query.
-		subjectIds, err := buildSubjectIDList(grouperClient, subjectType, subjectID, lookup)
-		if err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
-			return bySubjectInternalServerError(err.Error())
+		// Parse the label selector.
+		var selector permsdb.LabelSelector
+		if params.LabelSelector != nil {
+			var err error
+			if selector, err = permsdb.ParseLabelSelector(*params.LabelSelector); err != nil {
+				return bySubjectAndResourceTypeBadRequest(err.Error())
+			}
 		}
 
 		// Perform the lookup.
 		var perms []*models.Permission
-		if minLevel == nil {
-			perms, err = permsdb.PermissionsForSubjectsAndResourceType(tx, subjectIds, resourceTypeName)
+		responder, err := runLookup(ctx, db, params.XReadYourWrites, func(tx permsdb.Tx) (middleware.Responder, error) {
+
+			// Verify that the subject type is correct.
+			subject, err := tx.GetSubjectByExternalID(models.ExternalSubjectID(subjectID))
 			if err != nil {
-				tx.Rollback() // nolint:errcheck
-				logger.Log.Error(err)
-				return bySubjectAndResourceTypeInternalServerError(err.Error())
+				return nil, err
+			}
+			if subject != nil && string(*subject.SubjectType) != subjectType {
+				reason := fmt.Sprintf("incorrect type for subject, %s: %s", subjectID, subjectType)
+				return bySubjectAndResourceTypeBadRequest(reason), nil
 			}
-		} else {
-			perms, err = permsdb.PermissionsForSubjectsAndResourceTypeMinLevel(tx, subjectIds, resourceTypeName, *minLevel)
+
+			// Verify that the resource type exists.
+			resourceType, err := tx.GetResourceTypeByName(&resourceTypeName)
 			if err != nil {
-				tx.Rollback() // nolint:errcheck
-				logger.Log.Error(err)
-				return bySubjectAndResourceTypeInternalServerError(err.Error())
+				return nil, err
+			}
+			if resourceType == nil {
+				return bySubjectAndResourceTypeOk(make([]*models.Permission, 0)), nil
+			}
+
+			// Get the list of subject IDs to use for the query.
+			subjectIds, err := BuildSubjectIDList(ctx, grouperClient, subjectType, subjectID, lookup)
+			if err != nil {
+				log.Error(err)
+				return bySubjectAndResourceTypeInternalServerError(err.Error()), nil
 			}
-		}
 
-		// Commit the transaction.
-		if err := tx.Commit(); err != nil {
-			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			// Look up the permissions.
+			if minLevel == nil {
+				perms, err = tx.PermissionsForSubjectsAndResourceType(subjectIds, resourceTypeName, selector)
+			} else {
+				perms, err = tx.PermissionsForSubjectsAndResourceTypeMinLevel(
+					subjectIds, resourceTypeName, *minLevel, selector,
+				)
+			}
+			return nil, err
+		})
+		if err != nil {
+			log.Error(err)
 			return bySubjectAndResourceTypeInternalServerError(err.Error())
 		}
+		if responder != nil {
+			return responder
+		}
 
 		// Add the subject source ID to the response body.
-		if err := grouperClient.AddSourceIDToPermissions(perms); err != nil {
-			logger.Log.Error(err)
+		if err := grouperClient.AddSourceIDToPermissions(ctx, perms); err != nil {
+			log.Error(err)
 			return bySubjectAndResourceTypeInternalServerError(err.Error())
 		}
 
@@ -1,15 +1,16 @@
 package permissions
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func bySubjectAndResourceTypeAbbreviatedOk(perms []*models.AbbreviatedPermission) middleware.Responder {
@@ -31,72 +32,74 @@ func bySubjectAndResourceTypeAbbreviatedBadRequest(reason string) middleware.Res
 }
 
 func BuildBySubjectAndResourceTypeAbbreviatedHandler(
-	db *sql.DB, grouperClient grouper.Grouper, schema string,
+	db permsdb.Store, grouperClient grouper.Grouper,
 ) func(permissions.BySubjectAndResourceTypeAbbreviatedParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params permissions.BySubjectAndResourceTypeAbbreviatedParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type":  params.SubjectType,
+			"subject_id":    params.SubjectID,
+			"resource_type": params.ResourceType,
+		})
 		subjectType := params.SubjectType
 		subjectID := params.SubjectID
 		resourceTypeName := params.ResourceType
 		lookup := extractLookupFlag(params.Lookup)
 		minLevel := params.MinLevel
 
-		// Create a transaction for the request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return bySubjectAndResourceTypeAbbreviatedInternalServerError(err.Error())
+		// Parse the label selector.
+		var selector permsdb.LabelSelector
+		if params.LabelSelector != nil {
+			var err error
+			if selector, err = permsdb.ParseLabelSelector(*params.LabelSelector); err != nil {
+				return bySubjectAndResourceTypeAbbreviatedBadRequest(err.Error())
+			}
 		}
-		defer tx.Rollback() // nolint:errcheck
 
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
-		if err != nil {
-			logger.Log.Error(err)
-			return bySubjectAndResourceTypeAbbreviatedInternalServerError(err.Error())
-		}
+		// Perform the lookup.
+		var perms []*models.AbbreviatedPermission
+		responder, err := runLookup(ctx, db, params.XReadYourWrites, func(tx permsdb.Tx) (middleware.Responder, error) {
 
-		// Verify that the subject type is correct.
-		subject, err := permsdb.GetSubjectByExternalID(tx, models.ExternalSubjectID(subjectID))
-		if err != nil {
-			logger.Log.Error(err)
-			return bySubjectAndResourceTypeAbbreviatedInternalServerError(err.Error())
-		}
-		if subject != nil && string(*subject.SubjectType) != subjectType {
-			reason := fmt.Sprintf("incorrect type for subject, %s: %s", subjectID, subjectType)
-			return bySubjectAndResourceTypeAbbreviatedBadRequest(reason)
-		}
+			// Verify that the subject type is correct.
+			subject, err := tx.GetSubjectByExternalID(models.ExternalSubjectID(subjectID))
+			if err != nil {
+				return nil, err
+			}
+			if subject != nil && string(*subject.SubjectType) != subjectType {
+				reason := fmt.Sprintf("incorrect type for subject, %s: %s", subjectID, subjectType)
+				return bySubjectAndResourceTypeAbbreviatedBadRequest(reason), nil
+			}
 
-		// Verify that the resource type exists.
-		resourceType, err := permsdb.GetResourceTypeByName(tx, &resourceTypeName)
-		if err != nil {
-			logger.Log.Error(err)
-			return bySubjectAndResourceTypeAbbreviatedInternalServerError(err.Error())
-		}
-		if resourceType == nil {
-			return bySubjectAndResourceTypeAbbreviatedOk(make([]*models.AbbreviatedPermission, 0))
-		}
+			// Verify that the resource type exists.
+			resourceType, err := tx.GetResourceTypeByName(&resourceTypeName)
+			if err != nil {
+				return nil, err
+			}
+			if resourceType == nil {
+				return bySubjectAndResourceTypeAbbreviatedOk(make([]*models.AbbreviatedPermission, 0)), nil
+			}
 
-		// Get the list of subject IDs to use for the query.
-		subjectIDs, err := buildSubjectIDList(grouperClient, subjectType, subjectID, lookup)
-		if err != nil {
-			logger.Log.Error(err)
-			return bySubjectAndResourceTypeAbbreviatedInternalServerError(err.Error())
-		}
+			// Get the list of subject IDs to use for the query.
+			subjectIDs, err := BuildSubjectIDList(ctx, grouperClient, subjectType, subjectID, lookup)
+			if err != nil {
+				log.Error(err)
+				return bySubjectAndResourceTypeAbbreviatedInternalServerError(err.Error()), nil
+			}
 
-		// Perform the lookup.
-		perms, err := permsdb.AbbreviatedPermissionsForSubjectAndResourceType(
-			tx, subjectIDs, resourceTypeName, minLevel,
-		)
+			// Look up the permissions.
+			perms, err = tx.AbbreviatedPermissionsForSubjectAndResourceType(
+				subjectIDs, resourceTypeName, minLevel, selector,
+			)
+			return nil, err
+		})
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return bySubjectAndResourceTypeAbbreviatedInternalServerError(err.Error())
 		}
-
-		// Commit the transaction.
-		if err := tx.Commit(); err != nil {
-			logger.Log.Error(err)
-			return bySubjectAndResourceTypeAbbreviatedInternalServerError(err.Error())
+		if responder != nil {
+			return responder
 		}
 
 		return bySubjectAndResourceTypeAbbreviatedOk(perms)
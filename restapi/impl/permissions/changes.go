@@ -0,0 +1,68 @@
+package permissions
+
+import (
+	"context"
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/models"
+)
+
+func permissionKey(permission *models.Permission) string {
+	return string(*permission.Subject.ID) + "/" + *permission.Resource.ID
+}
+
+// recordPermissionChange records the difference between two versions of the same permission in a change set. Either
+// version may be nil if the permission didn't exist at the time.
+func recordPermissionChange(changes *models.ChangeSet, before, after *models.Permission) {
+	switch {
+	case before == nil && after == nil:
+		return
+	case before == nil:
+		changes.AddedPermissions = append(changes.AddedPermissions, after)
+	case after == nil:
+		changes.RemovedPermissions = append(changes.RemovedPermissions, before)
+	case *before.PermissionLevel != *after.PermissionLevel:
+		changes.UpdatedPermissions = append(changes.UpdatedPermissions, &models.PermissionUpdate{
+			Permission:    after,
+			PreviousLevel: before.PermissionLevel,
+		})
+	}
+}
+
+// recordPermissionListChanges records the differences between two listings of the same set of permissions in a
+// change set.
+func recordPermissionListChanges(changes *models.ChangeSet, before, after []*models.Permission) {
+
+	// Index the original permissions.
+	original := make(map[string]*models.Permission)
+	for _, permission := range before {
+		original[permissionKey(permission)] = permission
+	}
+
+	// Record added and updated permissions.
+	current := make(map[string]bool)
+	for _, permission := range after {
+		key := permissionKey(permission)
+		current[key] = true
+		recordPermissionChange(changes, original[key], permission)
+	}
+
+	// Record removed permissions.
+	for _, permission := range before {
+		if !current[permissionKey(permission)] {
+			recordPermissionChange(changes, permission, nil)
+		}
+	}
+}
+
+// addSourceIDToChangeSet adds subject source IDs to all permissions in a change set.
+func addSourceIDToChangeSet(ctx context.Context, grouperClient grouper.Grouper, changes *models.ChangeSet) error {
+	if err := grouperClient.AddSourceIDToPermissions(ctx, changes.AddedPermissions); err != nil {
+		return err
+	}
+	for _, update := range changes.UpdatedPermissions {
+		if err := grouperClient.AddSourceIDToPermission(ctx, update.Permission); err != nil {
+			return err
+		}
+	}
+	return grouperClient.AddSourceIDToPermissions(ctx, changes.RemovedPermissions)
+}
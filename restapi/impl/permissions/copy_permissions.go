@@ -1,20 +1,24 @@
 package permissions
 
 import (
-	"database/sql"
-	"fmt"
-
+	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func copyPermissionsOk() middleware.Responder {
 	return permissions.NewCopyPermissionsOK()
 }
 
+func copyPermissionsAccepted(changes *models.ChangeSet) middleware.Responder {
+	return permissions.NewCopyPermissionsAccepted().WithPayload(changes)
+}
+
 func copyPermissionsBadRequest(reason string) middleware.Responder {
 	return permissions.NewCopyPermissionsBadRequest().WithPayload(
 		&models.ErrorOut{Reason: &reason},
@@ -28,7 +32,9 @@ func copyPermissionsInternalServerError(reason string) middleware.Responder {
 }
 
 // BuildCopyPermissionsHandler builds the request handler for the copy permissions endpoint.
-func BuildCopyPermissionsHandler(db *sql.DB, schema string) func(permissions.CopyPermissionsParams) middleware.Responder {
+func BuildCopyPermissionsHandler(
+	db permsdb.Store, grouperClient grouper.Grouper,
+) func(permissions.CopyPermissionsParams) middleware.Responder {
 
 	erf := &ErrorResponseFns{
 		InternalServerError: copyPermissionsInternalServerError,
@@ -37,25 +43,28 @@ func BuildCopyPermissionsHandler(db *sql.DB, schema string) func(permissions.Cop
 
 	// Return the handler function.
 	return func(params permissions.CopyPermissionsParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type": params.SubjectType,
+			"subject_id":   params.SubjectID,
+		})
 		sourceType := models.SubjectType(params.SubjectType)
 		sourceID := models.ExternalSubjectID(params.SubjectID)
 		destSubjects := params.DestSubjects.Subjects
+		dryRun := params.DryRun != nil && *params.DryRun
+		changes := &models.ChangeSet{}
 
 		// Start a transaction for this request.
-		tx, err := db.Begin()
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
-			return copyPermissionsInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
-		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return copyPermissionsInternalServerError(err.Error())
 		}
 
 		// Either get or add the source subject.
-		source, errorResponse := getOrAddSubject(tx, &models.SubjectIn{SubjectType: &sourceType, SubjectID: &sourceID}, erf)
+		source, errorResponse := getOrAddSubject(
+			log, tx, &models.SubjectIn{SubjectType: &sourceType, SubjectID: &sourceID}, changes, erf,
+		)
 		if errorResponse != nil {
 			tx.Rollback() // nolint:errcheck
 			return errorResponse
@@ -65,24 +74,54 @@ func BuildCopyPermissionsHandler(db *sql.DB, schema string) func(permissions.Cop
 		for _, destIn := range destSubjects {
 
 			// Either get or add the subject.
-			dest, errorResponse := getOrAddSubject(tx, destIn, erf)
+			dest, errorResponse := getOrAddSubject(log, tx, destIn, changes, erf)
 			if errorResponse != nil {
 				tx.Rollback() // nolint:errcheck
 				return errorResponse
 			}
 
+			// Record the destination subject's permissions before the copy.
+			before, err := tx.ListSubjectPermissions(*dest.ID)
+			if err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return copyPermissionsInternalServerError(err.Error())
+			}
+
 			// Copy the permissions.
-			if err := permsdb.CopyPermissions(tx, source, dest); err != nil {
+			if err := tx.CopyPermissions(source, dest); err != nil {
 				tx.Rollback() // nolint:errcheck
-				logger.Log.Error(err)
+				log.Error(err)
+				return copyPermissionsInternalServerError(err.Error())
+			}
+
+			// Record the changes to the destination subject's permissions.
+			after, err := tx.ListSubjectPermissions(*dest.ID)
+			if err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return copyPermissionsInternalServerError(err.Error())
+			}
+			recordPermissionListChanges(changes, before, after)
+		}
+
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return copyPermissionsInternalServerError(err.Error())
+			}
+			if err := addSourceIDToChangeSet(ctx, grouperClient, changes); err != nil {
+				log.Error(err)
 				return copyPermissionsInternalServerError(err.Error())
 			}
+			return copyPermissionsAccepted(changes)
 		}
 
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return copyPermissionsInternalServerError(err.Error())
 		}
 
@@ -0,0 +1,154 @@
+package permissions
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
+	"github.com/cyverse-de/permissions/restapi/operations/permissions"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+func copyResourcePermissionsOk() middleware.Responder {
+	return permissions.NewCopyResourcePermissionsOK()
+}
+
+func copyResourcePermissionsAccepted(changes *models.ChangeSet) middleware.Responder {
+	return permissions.NewCopyResourcePermissionsAccepted().WithPayload(changes)
+}
+
+func copyResourcePermissionsBadRequest(reason string) middleware.Responder {
+	return permissions.NewCopyResourcePermissionsBadRequest().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func copyResourcePermissionsNotFound(reason string) middleware.Responder {
+	return permissions.NewCopyResourcePermissionsNotFound().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func copyResourcePermissionsInternalServerError(reason string) middleware.Responder {
+	return permissions.NewCopyResourcePermissionsInternalServerError().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+// BuildCopyResourcePermissionsHandler builds the request handler for the copy resource permissions endpoint.
+func BuildCopyResourcePermissionsHandler(
+	db permsdb.Store,
+) func(permissions.CopyResourcePermissionsParams) middleware.Responder {
+
+	erf := &ErrorResponseFns{
+		InternalServerError: copyResourcePermissionsInternalServerError,
+		BadRequest:          copyResourcePermissionsBadRequest,
+	}
+
+	// Return the handler function.
+	return func(params permissions.CopyResourcePermissionsParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"resource_type": params.ResourceType,
+			"resource_name": params.ResourceName,
+		})
+		destResources := params.DestResources.Resources
+		dryRun := params.DryRun != nil && *params.DryRun
+		changes := &models.ChangeSet{}
+
+		// Determine which merge policy to use.
+		mergePolicy := permsdb.MergePolicyKeepHigher
+		if params.MergePolicy != nil {
+			mergePolicy = *params.MergePolicy
+		}
+
+		// Start a transaction for this request.
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(err)
+			return copyResourcePermissionsInternalServerError(err.Error())
+		}
+
+		// Look up the source resource type.
+		resourceType, err := tx.GetResourceTypeByName(&params.ResourceType)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return copyResourcePermissionsInternalServerError(err.Error())
+		}
+		if resourceType == nil {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf("resource type not found: %s", params.ResourceType)
+			return copyResourcePermissionsNotFound(reason)
+		}
+
+		// Look up the source resource.
+		source, err := tx.GetResourceByName(&params.ResourceName, resourceType.ID)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return copyResourcePermissionsInternalServerError(err.Error())
+		}
+		if source == nil {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf("resource not found: %s/%s", params.ResourceType, params.ResourceName)
+			return copyResourcePermissionsNotFound(reason)
+		}
+
+		// Copy the source resource's permissions to each destination resource.
+		for _, destIn := range destResources {
+
+			// Either get or add the resource.
+			dest, errorResponse := getOrAddResource(log, tx, destIn, changes, erf)
+			if errorResponse != nil {
+				tx.Rollback() // nolint:errcheck
+				return errorResponse
+			}
+
+			// Record the destination resource's permissions before the copy.
+			before, err := tx.ListResourcePermissions(*dest.ResourceType, *dest.Name)
+			if err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return copyResourcePermissionsInternalServerError(err.Error())
+			}
+
+			// Copy the permissions.
+			if err := tx.CopyResourcePermissions(source, dest, mergePolicy); err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return copyResourcePermissionsInternalServerError(err.Error())
+			}
+
+			// Record the changes to the destination resource's permissions.
+			after, err := tx.ListResourcePermissions(*dest.ResourceType, *dest.Name)
+			if err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return copyResourcePermissionsInternalServerError(err.Error())
+			}
+			recordPermissionListChanges(changes, before, after)
+		}
+
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return copyResourcePermissionsInternalServerError(err.Error())
+			}
+			return copyResourcePermissionsAccepted(changes)
+		}
+
+		// Commit the transaction.
+		if err := tx.Commit(); err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return copyResourcePermissionsInternalServerError(err.Error())
+		}
+
+		return copyResourcePermissionsOk()
+	}
+}
@@ -0,0 +1,29 @@
+package permissions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// aclETag computes an entity tag for the access control list of a resource. The tag depends only on the subjects in
+// the list and their permission levels, so it changes whenever the list itself changes.
+func aclETag(perms []*models.Permission) string {
+
+	// Sort the entries so that the tag doesn't depend on the order of the listing.
+	entries := make([]string, len(perms))
+	for i, perm := range perms {
+		entries[i] = fmt.Sprintf("%s:%s", string(*perm.Subject.ID), string(*perm.PermissionLevel))
+	}
+	sort.Strings(entries)
+
+	// Compute the hash.
+	hash := sha256.New()
+	for _, entry := range entries {
+		hash.Write([]byte(entry + "\n")) // nolint:errcheck
+	}
+	return fmt.Sprintf(`"%s"`, hex.EncodeToString(hash.Sum(nil)))
+}
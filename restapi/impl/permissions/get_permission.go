@@ -0,0 +1,110 @@
+package permissions
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/etag"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
+	"github.com/cyverse-de/permissions/restapi/operations/permissions"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+func getPermissionOk(permission *models.Permission, version int64) middleware.Responder {
+	return permissions.NewGetPermissionOK().WithETag(etag.FromVersion(version)).WithPayload(permission)
+}
+
+func getPermissionNotFound(reason string) middleware.Responder {
+	return permissions.NewGetPermissionNotFound().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func getPermissionInternalServerError(reason string) middleware.Responder {
+	return permissions.NewGetPermissionInternalServerError().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+// BuildGetPermissionHandler builds the request handler for the get permission endpoint.
+func BuildGetPermissionHandler(
+	db permsdb.Store, grouperClient grouper.Grouper,
+) func(permissions.GetPermissionParams) middleware.Responder {
+
+	// Return the handler function.
+	return func(params permissions.GetPermissionParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type":  params.SubjectType,
+			"subject_id":    params.SubjectID,
+			"resource_type": params.ResourceType,
+			"resource_name": params.ResourceName,
+		})
+		subjectType := models.SubjectType(params.SubjectType)
+		subjectID := models.ExternalSubjectID(params.SubjectID)
+		notFoundReason := fmt.Sprintf(
+			"permission not found: %s/%s:%s/%s", params.ResourceType, params.ResourceName, subjectType, subjectID,
+		)
+
+		// Start a transaction for this request.
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(err)
+			return getPermissionInternalServerError(err.Error())
+		}
+		defer tx.Commit() // nolint:errcheck
+
+		// Look up the resource.
+		resource, err := tx.GetResourceByNameAndType(params.ResourceName, params.ResourceType)
+		if err != nil {
+			log.Error(err)
+			return getPermissionInternalServerError(err.Error())
+		}
+		if resource == nil {
+			return getPermissionNotFound(notFoundReason)
+		}
+
+		// Look up the subject.
+		subject, err := tx.GetSubject(subjectID, subjectType)
+		if err != nil {
+			log.Error(err)
+			return getPermissionInternalServerError(err.Error())
+		}
+		if subject == nil {
+			return getPermissionNotFound(notFoundReason)
+		}
+
+		// Look up the permission.
+		permission, err := tx.GetPermission(*subject.ID, *resource.ID)
+		if err != nil {
+			log.Error(err)
+			return getPermissionInternalServerError(err.Error())
+		}
+		if permission == nil {
+			return getPermissionNotFound(notFoundReason)
+		}
+
+		// Look up the permission version.
+		version, err := tx.GetPermissionVersion(*subject.ID, *resource.ID)
+		if err != nil {
+			log.Error(err)
+			return getPermissionInternalServerError(err.Error())
+		}
+		if version == nil {
+			return getPermissionNotFound(notFoundReason)
+		}
+
+		// Add the subject source ID to the response body.
+		if err := grouperClient.AddSourceIDToPermission(ctx, permission); err != nil {
+			log.Error(err)
+			return getPermissionInternalServerError(err.Error())
+		}
+
+		return getPermissionOk(permission, *version)
+	}
+}
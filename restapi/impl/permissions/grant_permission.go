@@ -1,16 +1,15 @@
 package permissions
 
 import (
-	"database/sql"
-	"fmt"
-
 	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func grantPermissionInternalServerError(reason string) middleware.Responder {
@@ -19,6 +18,10 @@ func grantPermissionInternalServerError(reason string) middleware.Responder {
 	)
 }
 
+func grantPermissionAccepted(changes *models.ChangeSet) middleware.Responder {
+	return permissions.NewGrantPermissionAccepted().WithPayload(changes)
+}
+
 func grantPermissionBadRequest(reason string) middleware.Responder {
 	return permissions.NewGrantPermissionBadRequest().WithPayload(
 		&models.ErrorOut{Reason: &reason},
@@ -27,7 +30,7 @@ func grantPermissionBadRequest(reason string) middleware.Responder {
 
 // BuildGrantPermissionHandler builds the request handler for the grant permissions endpoint.
 func BuildGrantPermissionHandler(
-	db *sql.DB, grouperClient grouper.Grouper, schema string,
+	db permsdb.Store, grouperClient grouper.Grouper,
 ) func(permissions.GrantPermissionParams) middleware.Responder {
 
 	erf := &ErrorResponseFns{
@@ -37,60 +40,85 @@ func BuildGrantPermissionHandler(
 
 	// Return the hnadler function.
 	return func(params permissions.GrantPermissionParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
 		req := params.PermissionGrantRequest
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type":  *req.Subject.SubjectType,
+			"subject_id":    *req.Subject.SubjectID,
+			"resource_type": *req.Resource.ResourceType,
+			"resource_name": *req.Resource.Name,
+		})
+		dryRun := params.DryRun != nil && *params.DryRun
+		changes := &models.ChangeSet{}
 
 		// Create a transaction for the request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return grantPermissionInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return grantPermissionInternalServerError(err.Error())
 		}
 
 		// Either get or add the subject.
-		subject, errorResponder := getOrAddSubject(tx, req.Subject, erf)
+		subject, errorResponder := getOrAddSubject(log, tx, req.Subject, changes, erf)
 		if errorResponder != nil {
 			tx.Rollback() // nolint:errcheck
 			return errorResponder
 		}
 
 		// Either get or add the resource.
-		resource, errorResponder := getOrAddResource(tx, req.Resource, erf)
+		resource, errorResponder := getOrAddResource(log, tx, req.Resource, changes, erf)
 		if errorResponder != nil {
 			tx.Rollback() // nolint:errcheck
 			return errorResponder
 		}
 
 		// Look up the permission level.
-		permissionLevelID, errorResponder := getPermissionLevel(tx, *req.PermissionLevel, erf)
+		permissionLevelID, errorResponder := getPermissionLevel(log, tx, *req.PermissionLevel, erf)
 		if errorResponder != nil {
 			tx.Rollback() // nolint:errcheck
 			return errorResponder
 		}
 
+		// Look up the existing permission so that the change can be recorded.
+		previous, err := tx.GetPermission(*subject.ID, *resource.ID)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return grantPermissionInternalServerError(err.Error())
+		}
+
 		// Either update or add the permission.
-		permission, err := permsdb.UpsertPermission(tx, *subject.ID, *resource.ID, *permissionLevelID)
+		permission, err := tx.UpsertPermission(*subject.ID, *resource.ID, *permissionLevelID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return grantPermissionInternalServerError(err.Error())
 		}
+		recordPermissionChange(changes, previous, permission)
+
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return grantPermissionInternalServerError(err.Error())
+			}
+			if err := addSourceIDToChangeSet(ctx, grouperClient, changes); err != nil {
+				log.Error(err)
+				return grantPermissionInternalServerError(err.Error())
+			}
+			return grantPermissionAccepted(changes)
+		}
 
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return grantPermissionInternalServerError(err.Error())
 		}
 
 		// Add the subject source ID to the permission object.
-		if err := grouperClient.AddSourceIDToPermission(permission); err != nil {
-			logger.Log.Error(err)
+		if err := grouperClient.AddSourceIDToPermission(ctx, permission); err != nil {
+			log.Error(err)
 			return grantPermissionInternalServerError(err.Error())
 		}
 
@@ -1,15 +1,20 @@
 package permissions
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/metrics"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 // ErrorResponseFns is a structure containing functions that can be used to generate responses for erroneous requests.
@@ -18,88 +23,63 @@ type ErrorResponseFns struct {
 	InternalServerError func(string) middleware.Responder
 }
 
+// lookupErrorResponse returns the response to use for an error returned by one of the shared get-or-add helpers.
+// Missing resource types are reported as bad requests because they're named in the request body.
+func lookupErrorResponse(log *logrus.Entry, err error, erf *ErrorResponseFns) middleware.Responder {
+	var invalidArgument *permsdb.InvalidArgumentError
+	var notFound *permsdb.NotFoundError
+	if errors.As(err, &invalidArgument) || errors.As(err, &notFound) {
+		return erf.BadRequest(err.Error())
+	}
+	log.Error(err)
+	return erf.InternalServerError(err.Error())
+}
+
 func getOrAddSubject(
-	tx *sql.Tx,
+	log *logrus.Entry,
+	tx permsdb.Tx,
 	subjectIn *models.SubjectIn,
+	changes *models.ChangeSet,
 	erf *ErrorResponseFns,
 ) (*models.SubjectOut, middleware.Responder) {
-
-	// Attempt to look up the subject.
-	subject, err := permsdb.GetSubject(tx, *subjectIn.SubjectID, *subjectIn.SubjectType)
-	if err != nil {
-		logger.Log.Error(err)
-		return nil, erf.InternalServerError(err.Error())
-	}
-	if subject != nil {
-		return subject, nil
-	}
-
-	// Make sure that another subject with the same ID doesn't exist already.
-	exists, err := permsdb.SubjectIDExists(tx, *subjectIn.SubjectID)
+	subject, added, err := permsdb.GetOrAddSubject(tx, *subjectIn.SubjectID, *subjectIn.SubjectType)
 	if err != nil {
-		logger.Log.Error(err)
-		return nil, erf.InternalServerError((err.Error()))
+		return nil, lookupErrorResponse(log, err, erf)
 	}
-	if exists {
-		reason := fmt.Sprintf("another subject with ID, %s, already exists", string(*subjectIn.SubjectID))
-		return nil, erf.BadRequest(reason)
-	}
-
-	// Attempt to add the subject.
-	subject, err = permsdb.AddSubject(tx, *subjectIn.SubjectID, *subjectIn.SubjectType)
-	if err != nil {
-		logger.Log.Error(err)
-		return nil, erf.InternalServerError(err.Error())
+	if added {
+		changes.AddedSubjects = append(changes.AddedSubjects, subject)
 	}
 	return subject, nil
 }
 
 func getOrAddResource(
-	tx *sql.Tx,
+	log *logrus.Entry,
+	tx permsdb.Tx,
 	resourceIn *models.ResourceIn,
+	changes *models.ChangeSet,
 	erf *ErrorResponseFns,
 ) (*models.ResourceOut, middleware.Responder) {
-
-	// Look up the resource type.
-	resourceType, err := permsdb.GetResourceTypeByName(tx, resourceIn.ResourceType)
-	if err != nil {
-		logger.Log.Error(err)
-		return nil, erf.InternalServerError(err.Error())
-	}
-	if resourceType == nil {
-		reason := fmt.Sprintf("no resource type named, %s, found", *resourceIn.ResourceType)
-		return nil, erf.BadRequest(reason)
-	}
-
-	// Attempt to look up the resource.
-	resource, err := permsdb.GetResourceByName(tx, resourceIn.Name, resourceType.ID)
+	resource, added, err := permsdb.GetOrAddResource(tx, *resourceIn.ResourceType, *resourceIn.Name)
 	if err != nil {
-		logger.Log.Error(err)
-		return nil, erf.InternalServerError(err.Error())
-	}
-	if resource != nil {
-		return resource, nil
+		return nil, lookupErrorResponse(log, err, erf)
 	}
-
-	// Attempt to add the resource.
-	resource, err = permsdb.AddResource(tx, resourceIn.Name, resourceType.ID)
-	if err != nil {
-		logger.Log.Error(err)
-		return nil, erf.InternalServerError(err.Error())
+	if added {
+		changes.AddedResources = append(changes.AddedResources, resource)
 	}
 	return resource, nil
 }
 
 func getPermissionLevel(
-	tx *sql.Tx,
+	log *logrus.Entry,
+	tx permsdb.Tx,
 	level models.PermissionLevel,
 	erf *ErrorResponseFns,
 ) (*string, middleware.Responder) {
 
 	// Look up the permission level.
-	permissionLevelID, err := permsdb.GetPermissionLevelIDByName(tx, level)
+	permissionLevelID, err := tx.GetPermissionLevelIDByName(level)
 	if err != nil {
-		logger.Log.Error(err)
+		log.Error(err)
 		return nil, erf.InternalServerError(err.Error())
 	}
 	if permissionLevelID == nil {
@@ -117,7 +97,57 @@ func extractLookupFlag(lookup *bool) bool {
 	return false
 }
 
-func groupIdsForSubject(grouperClient grouper.Grouper, subjectType, subjectID string) ([]string, error) {
+// beginLookup starts a transaction for a permission lookup. Lookups use read-only transactions, which may be served by
+// a read replica, unless the caller has asked to read its own writes.
+func beginLookup(ctx context.Context, db permsdb.Store, readYourWrites *bool) (permsdb.Tx, error) {
+	if readYourWrites != nil && *readYourWrites {
+		return db.Begin(ctx)
+	}
+	return db.BeginReadOnly(ctx)
+}
+
+// lookupFunc performs a permission lookup within a transaction. It returns a responder if the request can't be
+// completed for a reason other than a database error, and an error if a database query fails. A lookup that succeeds
+// returns neither.
+type lookupFunc func(tx permsdb.Tx) (middleware.Responder, error)
+
+// runLookup runs a permission lookup in a transaction started by beginLookup, committing the transaction unless a
+// database query fails. A lookup that fails in a read replica is run again in a read-only transaction in the primary
+// database, so that a replica that can accept connections but can't answer queries doesn't cause lookups to fail.
+func runLookup(
+	ctx context.Context, db permsdb.Store, readYourWrites *bool, lookup lookupFunc,
+) (middleware.Responder, error) {
+	tx, err := beginLookup(ctx, db, readYourWrites)
+	if err != nil {
+		return nil, err
+	}
+	responder, err := runLookupTx(tx, lookup)
+	if err == nil || !permsdb.UsesReplica(tx) {
+		return responder, err
+	}
+
+	// Retry the lookup in the primary database.
+	logger.FromContext(ctx).Warnf("lookup failed in the read replica, retrying in the primary database: %s", err)
+	if tx, err = db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true}); err != nil {
+		return nil, err
+	}
+	return runLookupTx(tx, lookup)
+}
+
+// runLookupTx runs a permission lookup in a transaction, committing the transaction unless a database query fails.
+func runLookupTx(tx permsdb.Tx, lookup lookupFunc) (middleware.Responder, error) {
+	defer tx.Rollback() // nolint:errcheck
+
+	responder, err := lookup(tx)
+	if err != nil {
+		return nil, err
+	}
+	return responder, tx.Commit()
+}
+
+func groupIdsForSubject(
+	ctx context.Context, grouperClient grouper.Grouper, subjectType, subjectID string,
+) ([]string, error) {
 	groupIds := make([]string, 0)
 
 	// Simply return an empty slice if the subject is a group.
@@ -126,7 +156,7 @@ func groupIdsForSubject(grouperClient grouper.Grouper, subjectType, subjectID st
 	}
 
 	// Look up the groups.
-	groups, err := grouperClient.GroupsForSubject(subjectID)
+	groups, err := grouperClient.GroupsForSubject(ctx, subjectID)
 	if err != nil {
 		return nil, err
 	}
@@ -139,13 +169,21 @@ func groupIdsForSubject(grouperClient grouper.Grouper, subjectType, subjectID st
 	return groupIds, nil
 }
 
-func buildSubjectIDList(grouperClient grouper.Grouper, subjectType, subjectID string, lookup bool) ([]string, error) {
-	if lookup {
-		groupIds, err := groupIdsForSubject(grouperClient, subjectType, subjectID)
-		if err != nil {
-			return nil, err
-		}
-		return append(groupIds, subjectID), nil
+// BuildSubjectIDList returns the subject IDs to use when looking up the permissions of a subject. If lookup mode is
+// enabled and the subject is a user then the IDs of the groups that the user belongs to are included. Grouper lookup
+// metrics are only recorded when Grouper is actually queried.
+func BuildSubjectIDList(
+	ctx context.Context, grouperClient grouper.Grouper, subjectType, subjectID string, lookup bool,
+) ([]string, error) {
+	if !lookup || subjectType == string(models.SubjectTypeGroup) {
+		return []string{subjectID}, nil
+	}
+
+	start := time.Now()
+	groupIds, err := groupIdsForSubject(ctx, grouperClient, subjectType, subjectID)
+	if err != nil {
+		return nil, err
 	}
-	return []string{subjectID}, nil
+	metrics.ObserveGrouperLookup(start, len(groupIds))
+	return append(groupIds, subjectID), nil
 }
@@ -1,12 +1,10 @@
 package permissions
 
 import (
-	"database/sql"
-	"fmt"
-
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 
 	"github.com/cyverse-de/permissions/clients/grouper"
@@ -21,36 +19,42 @@ func internalServerError(reason string) *permissions.ListPermissionsInternalServ
 
 // BuildListPermissionsHandler builds the request handler for the list permissions endpoint.
 func BuildListPermissionsHandler(
-	db *sql.DB, grouper grouper.Grouper, schema string,
+	db permsdb.Store, grouper grouper.Grouper,
 ) func(permissions.ListPermissionsParams) middleware.Responder {
 
 	// Return the handler function.
-	return func(permissions.ListPermissionsParams) middleware.Responder {
+	return func(params permissions.ListPermissionsParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx)
 
-		// Start a transaction for this request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return internalServerError(err.Error())
+		// Parse the label selector.
+		var selector permsdb.LabelSelector
+		if params.LabelSelector != nil {
+			var err error
+			if selector, err = permsdb.ParseLabelSelector(*params.LabelSelector); err != nil {
+				reason := err.Error()
+				return permissions.NewListPermissionsBadRequest().WithPayload(&models.ErrorOut{Reason: &reason})
+			}
 		}
-		defer tx.Commit() // nolint:errcheck
 
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		// Start a transaction for this request.
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return internalServerError(err.Error())
 		}
+		defer tx.Commit() // nolint:errcheck
 
-		// List all permissions.
-		result, err := permsdb.ListPermissions(tx)
+		// List all permissions for resources that match the label selector.
+		result, err := tx.ListPermissions(selector)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return internalServerError(err.Error())
 		}
 
 		// Add subject sources to the permission list.
-		if err = grouper.AddSourceIDToPermissions(result); err != nil {
-			logger.Log.Error(err)
+		if err = grouper.AddSourceIDToPermissions(ctx, result); err != nil {
+			log.Error(err)
 			return internalServerError(err.Error())
 		}
 
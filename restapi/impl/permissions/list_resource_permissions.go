@@ -1,22 +1,21 @@
 package permissions
 
 import (
-	"database/sql"
-	"fmt"
-
 	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func listResourcePermissionsOk(perms []*models.Permission) middleware.Responder {
 	return permissions.NewListResourcePermissionsOK().WithPayload(
 		&models.PermissionList{Permissions: perms},
-	)
+	).WithETag(aclETag(perms))
 }
 
 func listResourcePermissionsInternalServerError(reason string) middleware.Responder {
@@ -27,45 +26,44 @@ func listResourcePermissionsInternalServerError(reason string) middleware.Respon
 
 // BuildListResourcePermissionsHandler builds the request handler for the list resource permissions endpoint.
 func BuildListResourcePermissionsHandler(
-	db *sql.DB, grouperClient grouper.Grouper, schema string,
+	db permsdb.Store, grouperClient grouper.Grouper,
 ) func(permissions.ListResourcePermissionsParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params permissions.ListResourcePermissionsParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"resource_type": params.ResourceType,
+			"resource_name": params.ResourceName,
+		})
 		resourceTypeName := params.ResourceType
 		resourceName := params.ResourceName
 
 		// Start a transaction for this request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return listResourcePermissionsInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return listResourcePermissionsInternalServerError(err.Error())
 		}
 
 		// List the permissions for the resource.
-		perms, err := permsdb.ListResourcePermissions(tx, resourceTypeName, resourceName)
+		perms, err := tx.ListResourcePermissions(resourceTypeName, resourceName)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return listResourcePermissionsInternalServerError(err.Error())
 		}
 
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return listResourcePermissionsInternalServerError(err.Error())
 		}
 
 		// Add the subject source ID to the response body.
-		if err := grouperClient.AddSourceIDToPermissions(perms); err != nil {
-			logger.Log.Error(err)
+		if err := grouperClient.AddSourceIDToPermissions(ctx, perms); err != nil {
+			log.Error(err)
 			return listResourcePermissionsInternalServerError(err.Error())
 		}
 
@@ -1,16 +1,18 @@
 package permissions
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/etag"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func putPermissionInternalServerError(reason string) middleware.Responder {
@@ -19,15 +21,25 @@ func putPermissionInternalServerError(reason string) middleware.Responder {
 	)
 }
 
+func putPermissionAccepted(changes *models.ChangeSet) middleware.Responder {
+	return permissions.NewPutPermissionAccepted().WithPayload(changes)
+}
+
 func putPermissionBadRequest(reason string) middleware.Responder {
 	return permissions.NewPutPermissionBadRequest().WithPayload(
 		&models.ErrorOut{Reason: &reason},
 	)
 }
 
+func putPermissionPreconditionFailed(reason string) middleware.Responder {
+	return permissions.NewPutPermissionPreconditionFailed().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
 // BuildPutPermissionHandler builds the request handler for the put permission endpoint.
 func BuildPutPermissionHandler(
-	db *sql.DB, grouperClient grouper.Grouper, schema string,
+	db permsdb.Store, grouperClient grouper.Grouper,
 ) func(permissions.PutPermissionParams) middleware.Responder {
 
 	erf := &ErrorResponseFns{
@@ -37,18 +49,21 @@ func BuildPutPermissionHandler(
 
 	// Return the handler function.
 	return func(params permissions.PutPermissionParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type":  params.SubjectType,
+			"subject_id":    params.SubjectID,
+			"resource_type": params.ResourceType,
+			"resource_name": params.ResourceName,
+		})
 		req := params.Permission
+		dryRun := params.DryRun != nil && *params.DryRun
+		changes := &models.ChangeSet{}
 
 		// Create a transaction for the request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return putPermissionInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return putPermissionInternalServerError(err.Error())
 		}
 
@@ -59,7 +74,7 @@ func BuildPutPermissionHandler(
 			SubjectID:   &subjectID,
 			SubjectType: &subjectType,
 		}
-		subject, errorResponder := getOrAddSubject(tx, subjectIn, erf)
+		subject, errorResponder := getOrAddSubject(log, tx, subjectIn, changes, erf)
 		if errorResponder != nil {
 			tx.Rollback() // nolint:errcheck
 			return errorResponder
@@ -70,39 +85,89 @@ func BuildPutPermissionHandler(
 			Name:         &params.ResourceName,
 			ResourceType: &params.ResourceType,
 		}
-		resource, errorResponder := getOrAddResource(tx, resourceIn, erf)
+		resource, errorResponder := getOrAddResource(log, tx, resourceIn, changes, erf)
 		if errorResponder != nil {
 			tx.Rollback() // nolint:errcheck
 			return errorResponder
 		}
 
 		// Look up the permission level.
-		permissionLevelID, errorResponder := getPermissionLevel(tx, *req.PermissionLevel, erf)
+		permissionLevelID, errorResponder := getPermissionLevel(log, tx, *req.PermissionLevel, erf)
 		if errorResponder != nil {
 			tx.Rollback() // nolint:errcheck
 			return errorResponder
 		}
 
+		// Verify that the permission hasn't been modified since the client last retrieved it.
+		version, err := tx.LockPermissionVersion(*subject.ID, *resource.ID)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return putPermissionInternalServerError(err.Error())
+		}
+		if !etag.MatchesVersion(params.IfMatch, version) {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf(
+				"permission has been modified: %s/%s:%s/%s", params.ResourceType, params.ResourceName, subjectType, subjectID,
+			)
+			return putPermissionPreconditionFailed(reason)
+		}
+
+		// Look up the existing permission so that the change can be recorded.
+		previous, err := tx.GetPermission(*subject.ID, *resource.ID)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return putPermissionInternalServerError(err.Error())
+		}
+
 		// Either update or add the permission.
-		permission, err := permsdb.UpsertPermission(tx, *subject.ID, *resource.ID, *permissionLevelID)
+		permission, err := tx.UpsertPermission(*subject.ID, *resource.ID, *permissionLevelID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return putPermissionInternalServerError(err.Error())
 		}
+		recordPermissionChange(changes, previous, permission)
+
+		// Look up the new version of the permission.
+		version, err = tx.GetPermissionVersion(*subject.ID, *resource.ID)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return putPermissionInternalServerError(err.Error())
+		}
+		if version == nil {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf("unable to look up permission version after upsert: %s", string(*permission.ID))
+			return putPermissionInternalServerError(reason)
+		}
+
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return putPermissionInternalServerError(err.Error())
+			}
+			if err := addSourceIDToChangeSet(ctx, grouperClient, changes); err != nil {
+				log.Error(err)
+				return putPermissionInternalServerError(err.Error())
+			}
+			return putPermissionAccepted(changes)
+		}
 
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return putPermissionInternalServerError(err.Error())
 		}
 
 		// Add the subject source ID to the permission listing.
-		if err := grouperClient.AddSourceIDToPermission(permission); err != nil {
+		if err := grouperClient.AddSourceIDToPermission(ctx, permission); err != nil {
 			return putPermissionInternalServerError(err.Error())
 		}
 
-		return permissions.NewPutPermissionOK().WithPayload(permission)
+		return permissions.NewPutPermissionOK().WithETag(etag.FromVersion(*version)).WithPayload(permission)
 	}
 }
@@ -0,0 +1,201 @@
+package permissions
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/etag"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
+	"github.com/cyverse-de/permissions/restapi/operations/permissions"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+func replaceResourcePermissionsOk(changes *models.ChangeSet, tag string) middleware.Responder {
+	return permissions.NewReplaceResourcePermissionsOK().WithETag(tag).WithPayload(changes)
+}
+
+func replaceResourcePermissionsAccepted(changes *models.ChangeSet) middleware.Responder {
+	return permissions.NewReplaceResourcePermissionsAccepted().WithPayload(changes)
+}
+
+func replaceResourcePermissionsBadRequest(reason string) middleware.Responder {
+	return permissions.NewReplaceResourcePermissionsBadRequest().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func replaceResourcePermissionsPreconditionFailed(reason string) middleware.Responder {
+	return permissions.NewReplaceResourcePermissionsPreconditionFailed().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func replaceResourcePermissionsInternalServerError(reason string) middleware.Responder {
+	return permissions.NewReplaceResourcePermissionsInternalServerError().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+// BuildReplaceResourcePermissionsHandler builds the request handler for the replace resource permissions endpoint.
+func BuildReplaceResourcePermissionsHandler(
+	db permsdb.Store, grouperClient grouper.Grouper,
+) func(permissions.ReplaceResourcePermissionsParams) middleware.Responder {
+
+	erf := &ErrorResponseFns{
+		InternalServerError: replaceResourcePermissionsInternalServerError,
+		BadRequest:          replaceResourcePermissionsBadRequest,
+	}
+
+	// Return the handler function.
+	return func(params permissions.ReplaceResourcePermissionsParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"resource_type": params.ResourceType,
+			"resource_name": params.ResourceName,
+		})
+		resourceIn := &models.ResourceIn{ResourceType: &params.ResourceType, Name: &params.ResourceName}
+		entries := params.ACL.Permissions
+		dryRun := params.DryRun != nil && *params.DryRun
+		changes := &models.ChangeSet{}
+
+		// Make sure that each subject appears in the request body at most once.
+		seen := make(map[string]bool)
+		for _, entry := range entries {
+			key := fmt.Sprintf("%s:%s", string(*entry.Subject.SubjectType), string(*entry.Subject.SubjectID))
+			if seen[key] {
+				reason := fmt.Sprintf("subject listed more than once: %s", key)
+				return replaceResourcePermissionsBadRequest(reason)
+			}
+			seen[key] = true
+		}
+
+		// Start a transaction for this request.
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(err)
+			return replaceResourcePermissionsInternalServerError(err.Error())
+		}
+
+		// Either get or add the resource.
+		resource, errorResponse := getOrAddResource(log, tx, resourceIn, changes, erf)
+		if errorResponse != nil {
+			tx.Rollback() // nolint:errcheck
+			return errorResponse
+		}
+
+		// Lock the resource so that concurrent replacements of the same access control list are serialized.
+		if err := tx.LockResource(resource.ID); err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return replaceResourcePermissionsInternalServerError(err.Error())
+		}
+
+		// Load the current access control list.
+		before, err := tx.FindResourcePermissions(*resource.ID, nil)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return replaceResourcePermissionsInternalServerError(err.Error())
+		}
+
+		// Verify that the access control list hasn't changed since the client last retrieved it.
+		if !etag.Matches(params.IfMatch, aclETag(before)) {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf(
+				"the permissions for %s/%s have been modified", params.ResourceType, params.ResourceName,
+			)
+			return replaceResourcePermissionsPreconditionFailed(reason)
+		}
+
+		// Index the current access control list.
+		current := make(map[models.InternalSubjectID]*models.Permission)
+		for _, permission := range before {
+			current[*permission.Subject.ID] = permission
+		}
+
+		// Grant or update the permissions listed in the request body.
+		retained := make(map[models.InternalSubjectID]bool)
+		for _, entry := range entries {
+
+			// Either get or add the subject.
+			subject, errorResponse := getOrAddSubject(log, tx, entry.Subject, changes, erf)
+			if errorResponse != nil {
+				tx.Rollback() // nolint:errcheck
+				return errorResponse
+			}
+			retained[*subject.ID] = true
+
+			// Skip permissions that don't need to change.
+			existing := current[*subject.ID]
+			if existing != nil && *existing.PermissionLevel == *entry.PermissionLevel {
+				continue
+			}
+
+			// Look up the permission level.
+			permissionLevelID, errorResponse := getPermissionLevel(log, tx, *entry.PermissionLevel, erf)
+			if errorResponse != nil {
+				tx.Rollback() // nolint:errcheck
+				return errorResponse
+			}
+
+			// Update the permission.
+			if _, err := tx.UpsertPermission(*subject.ID, *resource.ID, *permissionLevelID); err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return replaceResourcePermissionsInternalServerError(err.Error())
+			}
+		}
+
+		// Revoke the permissions for subjects that weren't listed in the request body.
+		revoked := make([]*models.Permission, 0)
+		for _, permission := range before {
+			if !retained[*permission.Subject.ID] {
+				revoked = append(revoked, permission)
+			}
+		}
+		if len(revoked) > 0 {
+			if err := tx.DeletePermissions(revoked); err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return replaceResourcePermissionsInternalServerError(err.Error())
+			}
+		}
+
+		// Record the changes to the access control list.
+		after, err := tx.FindResourcePermissions(*resource.ID, nil)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return replaceResourcePermissionsInternalServerError(err.Error())
+		}
+		recordPermissionListChanges(changes, before, after)
+
+		// Roll back the transaction if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return replaceResourcePermissionsInternalServerError(err.Error())
+			}
+		} else if err := tx.Commit(); err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return replaceResourcePermissionsInternalServerError(err.Error())
+		}
+
+		// Add the subject source IDs to the response body.
+		if err := addSourceIDToChangeSet(ctx, grouperClient, changes); err != nil {
+			log.Error(err)
+			return replaceResourcePermissionsInternalServerError(err.Error())
+		}
+
+		// Return the results.
+		if dryRun {
+			return replaceResourcePermissionsAccepted(changes)
+		}
+		return replaceResourcePermissionsOk(changes, aclETag(after))
+	}
+}
@@ -1,15 +1,17 @@
 package permissions
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/etag"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func revokePermissionInternalServerError(reason string) middleware.Responder {
@@ -18,49 +20,65 @@ func revokePermissionInternalServerError(reason string) middleware.Responder {
 	)
 }
 
+func revokePermissionAccepted(changes *models.ChangeSet) middleware.Responder {
+	return permissions.NewRevokePermissionAccepted().WithPayload(changes)
+}
+
 func revokePermissionNotFound(reason string) middleware.Responder {
 	return permissions.NewRevokePermissionNotFound().WithPayload(
 		&models.ErrorOut{Reason: &reason},
 	)
 }
 
+func revokePermissionPreconditionFailed(reason string) middleware.Responder {
+	return permissions.NewRevokePermissionPreconditionFailed().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
 // BuildRevokePermissionHandler builds the request handler for the revoke permission endpoint.
-func BuildRevokePermissionHandler(db *sql.DB, schema string) func(permissions.RevokePermissionParams) middleware.Responder {
+func BuildRevokePermissionHandler(db permsdb.Store) func(permissions.RevokePermissionParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params permissions.RevokePermissionParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type":  params.SubjectType,
+			"subject_id":    params.SubjectID,
+			"resource_type": params.ResourceType,
+			"resource_name": params.ResourceName,
+		})
+		dryRun := params.DryRun != nil && *params.DryRun
 
 		// Create a transaction for the request.
-		tx, err := db.Begin()
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
-			return revokePermissionInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
-		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return revokePermissionInternalServerError(err.Error())
 		}
 
 		// Look up the resource type.
-		resourceType, err := permsdb.GetResourceTypeByName(tx, &params.ResourceType)
+		resourceType, err := tx.GetResourceTypeByName(&params.ResourceType)
 		if err != nil {
-			logger.Log.Error(err)
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
 			return revokePermissionInternalServerError(err.Error())
 		}
 		if resourceType == nil {
+			tx.Rollback() // nolint:errcheck
 			reason := fmt.Sprintf("resource type not found: %s", params.ResourceType)
 			return revokePermissionNotFound(reason)
 		}
 
 		// Look up the resource.
-		resource, err := permsdb.GetResourceByName(tx, &params.ResourceName, resourceType.ID)
+		resource, err := tx.GetResourceByName(&params.ResourceName, resourceType.ID)
 		if err != nil {
-			logger.Log.Error(err)
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
 			return revokePermissionInternalServerError(err.Error())
 		}
 		if resource == nil {
+			tx.Rollback() // nolint:errcheck
 			reason := fmt.Sprintf("resource not found: %s/%s", params.ResourceType, params.ResourceName)
 			return revokePermissionNotFound(reason)
 		}
@@ -68,40 +86,69 @@ func BuildRevokePermissionHandler(db *sql.DB, schema string) func(permissions.Re
 		// Look up the subject.
 		subjectType := models.SubjectType(params.SubjectType)
 		subjectID := models.ExternalSubjectID(params.SubjectID)
-		subject, err := permsdb.GetSubject(tx, subjectID, subjectType)
+		subject, err := tx.GetSubject(subjectID, subjectType)
 		if err != nil {
-			logger.Log.Error(err)
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
 			return revokePermissionInternalServerError(err.Error())
 		}
 		if subject == nil {
+			tx.Rollback() // nolint:errcheck
 			reason := fmt.Sprintf("subject not found: %s/%s", subjectType, subjectID)
 			return revokePermissionNotFound(reason)
 		}
 
 		// Look up the permission.
-		permission, err := permsdb.GetPermission(tx, *subject.ID, *resource.ID)
+		permission, err := tx.GetPermission(*subject.ID, *resource.ID)
 		if err != nil {
-			logger.Log.Error(err)
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
 			return revokePermissionInternalServerError(err.Error())
 		}
 		if permission == nil {
+			tx.Rollback() // nolint:errcheck
 			reason := fmt.Sprintf(
 				"permission not found: %s/%s:%s/%s", params.ResourceType, params.ResourceName, subjectType, subjectID,
 			)
 			return revokePermissionNotFound(reason)
 		}
 
+		// Verify that the permission hasn't been modified since the client last retrieved it.
+		version, err := tx.LockPermissionVersion(*subject.ID, *resource.ID)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return revokePermissionInternalServerError(err.Error())
+		}
+		if !etag.MatchesVersion(params.IfMatch, version) {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf(
+				"permission has been modified: %s/%s:%s/%s", params.ResourceType, params.ResourceName, subjectType, subjectID,
+			)
+			return revokePermissionPreconditionFailed(reason)
+		}
+
 		// Delete the permission.
-		err = permsdb.DeletePermission(tx, *permission.ID)
+		err = tx.DeletePermission(*permission.ID)
 		if err != nil {
-			logger.Log.Error(err)
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
 			return revokePermissionInternalServerError(err.Error())
 		}
 
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return revokePermissionInternalServerError(err.Error())
+			}
+			return revokePermissionAccepted(&models.ChangeSet{RemovedPermissions: []*models.Permission{permission}})
+		}
+
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return revokePermissionInternalServerError(err.Error())
 		}
 
@@ -0,0 +1,140 @@
+package permissions
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
+	"github.com/cyverse-de/permissions/restapi/operations/permissions"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// ownerPermissionLevel is the permission level retained when owners are excluded from a bulk revocation.
+const ownerPermissionLevel = models.PermissionLevel("own")
+
+func revokeResourcePermissionsOk(perms []*models.Permission) middleware.Responder {
+	return permissions.NewRevokeResourcePermissionsOK().WithPayload(
+		&models.PermissionList{Permissions: perms},
+	)
+}
+
+func revokeResourcePermissionsAccepted(changes *models.ChangeSet) middleware.Responder {
+	return permissions.NewRevokeResourcePermissionsAccepted().WithPayload(changes)
+}
+
+func revokeResourcePermissionsNotFound(reason string) middleware.Responder {
+	return permissions.NewRevokeResourcePermissionsNotFound().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func revokeResourcePermissionsInternalServerError(reason string) middleware.Responder {
+	return permissions.NewRevokeResourcePermissionsInternalServerError().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+// BuildRevokeResourcePermissionsHandler builds the request handler for the revoke resource permissions endpoint.
+func BuildRevokeResourcePermissionsHandler(
+	db permsdb.Store, grouperClient grouper.Grouper,
+) func(permissions.RevokeResourcePermissionsParams) middleware.Responder {
+
+	// Return the handler function.
+	return func(params permissions.RevokeResourcePermissionsParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"resource_type": params.ResourceType,
+			"resource_name": params.ResourceName,
+		})
+		dryRun := params.DryRun != nil && *params.DryRun
+
+		// Determine which permission level to exclude, if any.
+		var excludedLevel *models.PermissionLevel
+		if params.ExcludeOwners != nil && *params.ExcludeOwners {
+			level := ownerPermissionLevel
+			excludedLevel = &level
+		}
+
+		// Create a transaction for the request.
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(err)
+			return revokeResourcePermissionsInternalServerError(err.Error())
+		}
+
+		// Look up the resource type.
+		resourceType, err := tx.GetResourceTypeByName(&params.ResourceType)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return revokeResourcePermissionsInternalServerError(err.Error())
+		}
+		if resourceType == nil {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf("resource type not found: %s", params.ResourceType)
+			return revokeResourcePermissionsNotFound(reason)
+		}
+
+		// Look up the resource.
+		resource, err := tx.GetResourceByName(&params.ResourceName, resourceType.ID)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return revokeResourcePermissionsInternalServerError(err.Error())
+		}
+		if resource == nil {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf("resource not found: %s/%s", params.ResourceType, params.ResourceName)
+			return revokeResourcePermissionsNotFound(reason)
+		}
+
+		// Find the permissions to revoke.
+		perms, err := tx.FindResourcePermissions(*resource.ID, excludedLevel)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return revokeResourcePermissionsInternalServerError(err.Error())
+		}
+
+		// Revoke the permissions.
+		if err := tx.DeletePermissions(perms); err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return revokeResourcePermissionsInternalServerError(err.Error())
+		}
+
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return revokeResourcePermissionsInternalServerError(err.Error())
+			}
+			changes := &models.ChangeSet{RemovedPermissions: perms}
+			if err := addSourceIDToChangeSet(ctx, grouperClient, changes); err != nil {
+				log.Error(err)
+				return revokeResourcePermissionsInternalServerError(err.Error())
+			}
+			return revokeResourcePermissionsAccepted(changes)
+		}
+
+		// Commit the transaction.
+		if err := tx.Commit(); err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return revokeResourcePermissionsInternalServerError(err.Error())
+		}
+
+		// Add the subject source ID to the response body.
+		if err := grouperClient.AddSourceIDToPermissions(ctx, perms); err != nil {
+			log.Error(err)
+			return revokeResourcePermissionsInternalServerError(err.Error())
+		}
+
+		return revokeResourcePermissionsOk(perms)
+	}
+}
@@ -0,0 +1,136 @@
+package permissions
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
+	"github.com/cyverse-de/permissions/restapi/operations/permissions"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+func revokeSubjectPermissionsOk(perms []*models.Permission) middleware.Responder {
+	return permissions.NewRevokeSubjectPermissionsOK().WithPayload(
+		&models.PermissionList{Permissions: perms},
+	)
+}
+
+func revokeSubjectPermissionsAccepted(changes *models.ChangeSet) middleware.Responder {
+	return permissions.NewRevokeSubjectPermissionsAccepted().WithPayload(changes)
+}
+
+func revokeSubjectPermissionsNotFound(reason string) middleware.Responder {
+	return permissions.NewRevokeSubjectPermissionsNotFound().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func revokeSubjectPermissionsInternalServerError(reason string) middleware.Responder {
+	return permissions.NewRevokeSubjectPermissionsInternalServerError().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+// BuildRevokeSubjectPermissionsHandler builds the request handler for the revoke subject permissions endpoint.
+func BuildRevokeSubjectPermissionsHandler(
+	db permsdb.Store, grouperClient grouper.Grouper,
+) func(permissions.RevokeSubjectPermissionsParams) middleware.Responder {
+
+	// Return the handler function.
+	return func(params permissions.RevokeSubjectPermissionsParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type": params.SubjectType,
+			"subject_id":   params.SubjectID,
+		})
+		subjectType := models.SubjectType(params.SubjectType)
+		subjectID := models.ExternalSubjectID(params.SubjectID)
+		dryRun := params.DryRun != nil && *params.DryRun
+
+		// Create a transaction for the request.
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(err)
+			return revokeSubjectPermissionsInternalServerError(err.Error())
+		}
+
+		// Look up the subject.
+		subject, err := tx.GetSubject(subjectID, subjectType)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return revokeSubjectPermissionsInternalServerError(err.Error())
+		}
+		if subject == nil {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf("subject not found: %s/%s", subjectType, subjectID)
+			return revokeSubjectPermissionsNotFound(reason)
+		}
+
+		// Look up the resource type if one was specified.
+		var resourceTypeName *string
+		if params.ResourceType != nil {
+			resourceType, err := tx.GetResourceTypeByName(params.ResourceType)
+			if err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return revokeSubjectPermissionsInternalServerError(err.Error())
+			}
+			if resourceType == nil {
+				tx.Rollback() // nolint:errcheck
+				reason := fmt.Sprintf("resource type not found: %s", *params.ResourceType)
+				return revokeSubjectPermissionsNotFound(reason)
+			}
+			resourceTypeName = resourceType.Name
+		}
+
+		// Find the permissions to revoke.
+		perms, err := tx.FindSubjectPermissions(*subject.ID, resourceTypeName, params.MinLevel)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return revokeSubjectPermissionsInternalServerError(err.Error())
+		}
+
+		// Revoke the permissions.
+		if err := tx.DeletePermissions(perms); err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return revokeSubjectPermissionsInternalServerError(err.Error())
+		}
+
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return revokeSubjectPermissionsInternalServerError(err.Error())
+			}
+			changes := &models.ChangeSet{RemovedPermissions: perms}
+			if err := addSourceIDToChangeSet(ctx, grouperClient, changes); err != nil {
+				log.Error(err)
+				return revokeSubjectPermissionsInternalServerError(err.Error())
+			}
+			return revokeSubjectPermissionsAccepted(changes)
+		}
+
+		// Commit the transaction.
+		if err := tx.Commit(); err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return revokeSubjectPermissionsInternalServerError(err.Error())
+		}
+
+		// Add the subject source ID to the response body.
+		if err := grouperClient.AddSourceIDToPermissions(ctx, perms); err != nil {
+			log.Error(err)
+			return revokeSubjectPermissionsInternalServerError(err.Error())
+		}
+
+		return revokeSubjectPermissionsOk(perms)
+	}
+}
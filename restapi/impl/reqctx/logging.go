@@ -0,0 +1,111 @@
+package reqctx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cyverse-de/permissions/logger"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// RequestIDHeader is the name of the header used to correlate log messages with requests.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the maximum length of a request ID supplied by a caller. Longer request IDs are replaced.
+const maxRequestIDLength = 128
+
+// requestInfo contains information about a request that is only known after the request has been routed.
+type requestInfo struct {
+	operation string
+}
+
+// requestInfoKey is the type of the key used to store request information in a context.
+type requestInfoKey struct{}
+
+// statusWriter is a response writer that records the response status code.
+type statusWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+// WriteHeader records the status code before writing it.
+func (w *statusWriter) WriteHeader(code int) {
+	w.code = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Flush sends any buffered data to the client, which streaming responses rely on.
+func (w *statusWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// requestID returns the ID of a request. The ID supplied by the caller is used if there is one.
+func requestID(r *http.Request) string {
+	id := strings.TrimSpace(r.Header.Get(RequestIDHeader))
+	if id == "" || len(id) > maxRequestIDLength {
+		return permsdb.NewID()
+	}
+	return id
+}
+
+// remoteAddr returns the address of the caller, preferring the address reported by any proxies.
+func remoteAddr(r *http.Request) string {
+	if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+		return strings.TrimSpace(strings.Split(forwardedFor, ",")[0])
+	}
+	return r.RemoteAddr
+}
+
+// WithLogging returns a handler that assigns an ID to each request, makes a request-scoped logger that includes the
+// request ID available to handlers via logger.FromContext and logs a summary of each request once it completes. The
+// request ID is returned to the caller in the X-Request-ID header.
+func WithLogging(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := requestID(r)
+		w.Header().Set(RequestIDHeader, id)
+
+		info := &requestInfo{}
+		log := logger.Log.WithField("request_id", id)
+		ctx := logger.NewContext(r.Context(), log)
+		ctx = context.WithValue(ctx, requestInfoKey{}, info)
+
+		start := time.Now()
+		writer := &statusWriter{ResponseWriter: w, code: http.StatusOK}
+		handler.ServeHTTP(writer, r.WithContext(ctx))
+
+		log.WithFields(logrus.Fields{
+			"method":      r.Method,
+			"path":        r.URL.Path,
+			"operation":   info.operation,
+			"status":      writer.code,
+			"latency_ms":  float64(time.Since(start).Microseconds()) / 1000,
+			"remote_addr": remoteAddr(r),
+			"user_agent":  r.UserAgent(),
+		}).Info("request completed")
+	})
+}
+
+// WithOperation returns a handler that adds the ID of the swagger operation that a request was routed to to the
+// request-scoped logger and to the request summary. It must be installed after the request has been routed, so that
+// the matched route is available.
+func WithOperation(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := middleware.MatchedRouteFrom(r)
+		if route == nil || route.Operation == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		if info, ok := r.Context().Value(requestInfoKey{}).(*requestInfo); ok {
+			info.operation = route.Operation.ID
+		}
+		log := logger.FromContext(r.Context()).WithField("operation", route.Operation.ID)
+		handler.ServeHTTP(w, r.WithContext(logger.NewContext(r.Context(), log)))
+	})
+}
@@ -0,0 +1,55 @@
+package reqctx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestWithLogging(t *testing.T) {
+	hook := test.NewLocal(logrus.StandardLogger())
+	defer hook.Reset()
+
+	var handlerRequestID interface{}
+	handler := WithLogging(WithOperation(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerRequestID = logger.FromContext(r.Context()).Data["request_id"]
+		w.WriteHeader(http.StatusNotFound)
+	})))
+
+	// A request ID should be assigned if the caller doesn't supply one.
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/subjects", nil))
+	requestID := rec.Header().Get(RequestIDHeader)
+	if requestID == "" {
+		t.Fatal("no request ID was assigned")
+	}
+	if handlerRequestID != requestID {
+		t.Errorf("unexpected request ID in handler logger: %v", handlerRequestID)
+	}
+
+	// The request summary should be logged.
+	entry := hook.LastEntry()
+	if entry == nil {
+		t.Fatal("no request summary was logged")
+	}
+	if entry.Data["request_id"] != requestID || entry.Data["status"] != http.StatusNotFound ||
+		entry.Data["path"] != "/subjects" {
+		t.Errorf("unexpected request summary: %v", entry.Data)
+	}
+
+	// The caller's request ID should be used if there is one.
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/subjects", nil)
+	req.Header.Set(RequestIDHeader, "some-request-id")
+	handler.ServeHTTP(rec, req)
+	if id := rec.Header().Get(RequestIDHeader); id != "some-request-id" {
+		t.Errorf("unexpected request ID: %s", id)
+	}
+	if handlerRequestID != "some-request-id" {
+		t.Errorf("unexpected request ID in handler logger: %v", handlerRequestID)
+	}
+}
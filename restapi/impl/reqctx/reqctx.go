@@ -0,0 +1,16 @@
+// Package reqctx contains functions for working with the contexts of incoming requests.
+package reqctx
+
+import (
+	"context"
+	"net/http"
+)
+
+// FromRequest returns the context of an incoming request. Handler parameters that aren't bound to an actual request,
+// such as the ones built by tests, have no request, in which case a background context is returned.
+func FromRequest(r *http.Request) context.Context {
+	if r == nil {
+		return context.Background()
+	}
+	return r.Context()
+}
@@ -0,0 +1,59 @@
+package reqctx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cyverse-de/permissions/models"
+)
+
+// timeoutWriter is a response writer that replaces server error responses with gateway timeout responses once the
+// deadline for the request has passed. Handlers report failed database queries as internal server errors, but the
+// client should be able to tell that the request failed because it took too long.
+type timeoutWriter struct {
+	http.ResponseWriter
+	ctx      context.Context
+	timeout  time.Duration
+	timedOut bool
+}
+
+// WriteHeader writes the response status code, substituting a gateway timeout response if appropriate.
+func (w *timeoutWriter) WriteHeader(code int) {
+	if code < http.StatusInternalServerError || w.ctx.Err() != context.DeadlineExceeded {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	// Send the gateway timeout response.
+	w.timedOut = true
+	reason := fmt.Sprintf("the request did not complete within %s", w.timeout)
+	w.ResponseWriter.Header().Set("Content-Type", "application/json")
+	w.ResponseWriter.WriteHeader(http.StatusGatewayTimeout)
+	json.NewEncoder(w.ResponseWriter).Encode(&models.ErrorOut{Reason: &reason}) // nolint:errcheck
+}
+
+// Write writes the response body unless it has been replaced by a gateway timeout response.
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	if w.timedOut {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// WithTimeout returns a handler that cancels the context of each request after the given timeout. Database queries
+// that are still running when the timeout expires are canceled and the request fails with a 504 status code. A
+// timeout of zero or less disables request timeouts.
+func WithTimeout(handler http.Handler, timeout time.Duration) http.Handler {
+	if timeout <= 0 {
+		return handler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		handler.ServeHTTP(&timeoutWriter{ResponseWriter: w, ctx: ctx, timeout: timeout}, r.WithContext(ctx))
+	})
+}
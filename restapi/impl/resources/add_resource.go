@@ -1,37 +1,42 @@
 package resources
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/resources"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 // BuildAddResourceHandler builds the request handler for the add resource endpoint.
-func BuildAddResourceHandler(db *sql.DB, schema string) func(resources.AddResourceParams) middleware.Responder {
+func BuildAddResourceHandler(db permsdb.Store) func(resources.AddResourceParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params resources.AddResourceParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"resource_type": *params.ResourceIn.ResourceType,
+			"resource_name": *params.ResourceIn.Name,
+		})
 		resourceIn := params.ResourceIn
 
-		// Start a transaction for this request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
+		// Validate the labels.
+		if err := permsdb.ValidateLabels(resourceIn.Labels); err != nil {
 			reason := err.Error()
-			return resources.NewAddResourceInternalServerError().WithPayload(
+			return resources.NewAddResourceBadRequest().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		// Start a transaction for this request.
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewAddResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -39,16 +44,17 @@ func BuildAddResourceHandler(db *sql.DB, schema string) func(resources.AddResour
 		}
 
 		// Load the resource type.
-		resourceType, err := permsdb.GetResourceTypeByName(tx, resourceIn.ResourceType)
+		resourceType, err := tx.GetResourceTypeByName(resourceIn.ResourceType)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewAddResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 		if resourceType == nil {
+			tx.Rollback() // nolint:errcheck
 			reason := fmt.Sprintf("no resource type named, '%s', found", *resourceIn.ResourceType)
 			return resources.NewAddResourceBadRequest().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -56,10 +62,10 @@ func BuildAddResourceHandler(db *sql.DB, schema string) func(resources.AddResour
 		}
 
 		// Verify that another resource with the same name doesn't already exist.
-		duplicate, err := permsdb.GetResourceByName(tx, resourceIn.Name, resourceType.ID)
+		duplicate, err := tx.GetResourceByName(resourceIn.Name, resourceType.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewAddResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -76,20 +82,33 @@ func BuildAddResourceHandler(db *sql.DB, schema string) func(resources.AddResour
 		}
 
 		// Add the resource to the database.
-		resourceOut, err := permsdb.AddResource(tx, resourceIn.Name, resourceType.ID)
+		resourceOut, err := tx.AddResource(resourceIn.Name, resourceType.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewAddResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 
+		// Add the labels to the resource.
+		if len(resourceIn.Labels) > 0 {
+			if err := tx.SetResourceLabels(resourceOut.ID, resourceIn.Labels); err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				reason := err.Error()
+				return resources.NewAddResourceInternalServerError().WithPayload(
+					&models.ErrorOut{Reason: &reason},
+				)
+			}
+			resourceOut.Labels = resourceIn.Labels
+		}
+
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewAddResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -0,0 +1,23 @@
+package resources
+
+import (
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// resourceDeletionChanges returns the changes that will be made to the database when a resource is deleted. This
+// function must be called before the resource is deleted.
+func resourceDeletionChanges(tx permsdb.Tx, resource *models.ResourceOut) (*models.ChangeSet, error) {
+
+	// The resource's permissions are removed along with it.
+	perms, err := tx.ListResourcePermissions(*resource.ResourceType, *resource.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &models.ChangeSet{
+		RemovedResources:   []*models.ResourceOut{resource},
+		RemovedPermissions: perms,
+	}
+	return changes, nil
+}
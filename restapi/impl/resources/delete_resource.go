@@ -1,36 +1,30 @@
 package resources
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/resources"
 
 	"github.com/go-openapi/runtime/middleware"
 )
 
 // BuildDeleteResourceHandler builds the request handler for the delete resource endpoint.
-func BuildDeleteResourceHandler(db *sql.DB, schema string) func(resources.DeleteResourceParams) middleware.Responder {
+func BuildDeleteResourceHandler(db permsdb.Store) func(resources.DeleteResourceParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params resources.DeleteResourceParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithField("resource_id", params.ID)
+		dryRun := params.DryRun != nil && *params.DryRun
 
 		// Start a transaction for this request.
-		tx, err := db.Begin()
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
-			reason := err.Error()
-			return resources.NewDeleteResourceInternalServerError().WithPayload(
-				&models.ErrorOut{Reason: &reason},
-			)
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
-		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewDeleteResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -38,16 +32,16 @@ func BuildDeleteResourceHandler(db *sql.DB, schema string) func(resources.Delete
 		}
 
 		// Verify that the resource exists.
-		exists, err := permsdb.ResourceExists(tx, &params.ID)
+		resource, err := tx.GetResource(&params.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewDeleteResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
-		if !exists {
+		if resource == nil {
 			tx.Rollback() // nolint:errcheck
 			reason := fmt.Sprintf("resource, %s, not found", params.ID)
 			return resources.NewDeleteResourceNotFound().WithPayload(
@@ -55,21 +49,47 @@ func BuildDeleteResourceHandler(db *sql.DB, schema string) func(resources.Delete
 			)
 		}
 
+		// Record the changes that will be made if this is a dry run.
+		var changes *models.ChangeSet
+		if dryRun {
+			changes, err = resourceDeletionChanges(tx, resource)
+			if err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				reason := err.Error()
+				return resources.NewDeleteResourceInternalServerError().WithPayload(
+					&models.ErrorOut{Reason: &reason},
+				)
+			}
+		}
+
 		// Delete the resource.
-		err = permsdb.DeleteResource(tx, &params.ID)
+		err = tx.DeleteResource(&params.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewDeleteResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				reason := err.Error()
+				return resources.NewDeleteResourceInternalServerError().WithPayload(
+					&models.ErrorOut{Reason: &reason},
+				)
+			}
+			return resources.NewDeleteResourceAccepted().WithPayload(changes)
+		}
+
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewDeleteResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -1,15 +1,16 @@
 package resources
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/resources"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func deleteResourceByNameInternalServerError(reason string) middleware.Responder {
@@ -22,6 +23,10 @@ func deleteResourceByNameOK() middleware.Responder {
 	return resources.NewDeleteResourceByNameOK()
 }
 
+func deleteResourceByNameAccepted(changes *models.ChangeSet) middleware.Responder {
+	return resources.NewDeleteResourceByNameAccepted().WithPayload(changes)
+}
+
 func deleteResourceByNameNotFound(reason string) middleware.Responder {
 	return resources.NewDeleteResourceByNameNotFound().WithPayload(
 		&models.ErrorOut{Reason: &reason},
@@ -29,29 +34,29 @@ func deleteResourceByNameNotFound(reason string) middleware.Responder {
 }
 
 // BuildDeleteResourceByNameHandler builds the request handler for the delete resource by name endpoint.
-func BuildDeleteResourceByNameHandler(db *sql.DB, schema string) func(resources.DeleteResourceByNameParams) middleware.Responder {
+func BuildDeleteResourceByNameHandler(db permsdb.Store) func(resources.DeleteResourceByNameParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params resources.DeleteResourceByNameParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"resource_type": params.ResourceTypeName,
+			"resource_name": params.ResourceName,
+		})
+		dryRun := params.DryRun != nil && *params.DryRun
 
 		// Start a transaction for the request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return deleteResourceByNameInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteResourceByNameInternalServerError(err.Error())
 		}
 
 		// Look up the resource.
-		resource, err := permsdb.GetResourceByNameAndType(tx, params.ResourceName, params.ResourceTypeName)
+		resource, err := tx.GetResourceByNameAndType(params.ResourceName, params.ResourceTypeName)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteResourceByNameInternalServerError(err.Error())
 		}
 		if resource == nil {
@@ -60,17 +65,37 @@ func BuildDeleteResourceByNameHandler(db *sql.DB, schema string) func(resources.
 			return deleteResourceByNameNotFound(reason)
 		}
 
+		// Record the changes that will be made if this is a dry run.
+		var changes *models.ChangeSet
+		if dryRun {
+			changes, err = resourceDeletionChanges(tx, resource)
+			if err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return deleteResourceByNameInternalServerError(err.Error())
+			}
+		}
+
 		// Delete the resource.
-		if err := permsdb.DeleteResource(tx, resource.ID); err != nil {
+		if err := tx.DeleteResource(resource.ID); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteResourceByNameInternalServerError(err.Error())
 		}
 
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return deleteResourceByNameInternalServerError(err.Error())
+			}
+			return deleteResourceByNameAccepted(changes)
+		}
+
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteResourceByNameInternalServerError(err.Error())
 		}
 
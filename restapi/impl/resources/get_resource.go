@@ -0,0 +1,70 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/etag"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
+	"github.com/cyverse-de/permissions/restapi/operations/resources"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+func getResourceOk(resource *models.ResourceOut, version int64) middleware.Responder {
+	return resources.NewGetResourceOK().WithETag(etag.FromVersion(version)).WithPayload(resource)
+}
+
+func getResourceNotFound(reason string) middleware.Responder {
+	return resources.NewGetResourceNotFound().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func getResourceInternalServerError(reason string) middleware.Responder {
+	return resources.NewGetResourceInternalServerError().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+// BuildGetResourceHandler builds the request handler for the get resource endpoint.
+func BuildGetResourceHandler(db permsdb.Store) func(resources.GetResourceParams) middleware.Responder {
+
+	// Return the handler function.
+	return func(params resources.GetResourceParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithField("resource_id", params.ID)
+
+		// Start a transaction for this request.
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(err)
+			return getResourceInternalServerError(err.Error())
+		}
+		defer tx.Commit() // nolint:errcheck
+
+		// Look up the resource.
+		resource, err := tx.GetResource(&params.ID)
+		if err != nil {
+			log.Error(err)
+			return getResourceInternalServerError(err.Error())
+		}
+		if resource == nil {
+			return getResourceNotFound(fmt.Sprintf("resource, %s, not found", params.ID))
+		}
+
+		// Look up the resource version.
+		version, err := tx.GetResourceVersion(&params.ID)
+		if err != nil {
+			log.Error(err)
+			return getResourceInternalServerError(err.Error())
+		}
+		if version == nil {
+			return getResourceNotFound(fmt.Sprintf("resource, %s, not found", params.ID))
+		}
+
+		return getResourceOk(resource, *version)
+	}
+}
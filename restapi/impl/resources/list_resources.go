@@ -1,27 +1,37 @@
 package resources
 
 import (
-	"database/sql"
-	"fmt"
-
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/resources"
 
 	"github.com/go-openapi/runtime/middleware"
 )
 
 // BuildListResourcesHandler builds the request handler for the list resources endpoint.
-func BuildListResourcesHandler(db *sql.DB, schema string) func(resources.ListResourcesParams) middleware.Responder {
+func BuildListResourcesHandler(db permsdb.Store) func(resources.ListResourcesParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params resources.ListResourcesParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx)
+
+		// Parse the label selector.
+		var selector permsdb.LabelSelector
+		if params.LabelSelector != nil {
+			var err error
+			if selector, err = permsdb.ParseLabelSelector(*params.LabelSelector); err != nil {
+				reason := err.Error()
+				return resources.NewListResourcesBadRequest().WithPayload(&models.ErrorOut{Reason: &reason})
+			}
+		}
 
 		// Start a transaction for this request.
-		tx, err := db.Begin()
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewListResourcesInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -29,19 +39,10 @@ func BuildListResourcesHandler(db *sql.DB, schema string) func(resources.ListRes
 		}
 		defer tx.Commit() // nolint:errcheck
 
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
-		if err != nil {
-			logger.Log.Error(err)
-			reason := err.Error()
-			return resources.NewListResourcesInternalServerError().WithPayload(
-				&models.ErrorOut{Reason: &reason},
-			)
-		}
-
-		// List all resources.
-		result, err := permsdb.ListResources(tx, params.ResourceTypeName, params.ResourceName)
+		// List all matching resources.
+		result, err := tx.ListResources(params.ResourceTypeName, params.ResourceName, selector)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewListResourcesInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -1,37 +1,39 @@
 package resources
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/etag"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/resources"
 
 	"github.com/go-openapi/runtime/middleware"
 )
 
 // BuildUpdateResourceHandler builds the request handler for the update resource endpoint.
-func BuildUpdateResourceHandler(db *sql.DB, schema string) func(resources.UpdateResourceParams) middleware.Responder {
+func BuildUpdateResourceHandler(db permsdb.Store) func(resources.UpdateResourceParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params resources.UpdateResourceParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithField("resource_id", params.ID)
 		resourceUpdate := params.ResourceUpdate
 
-		// Start a transaction for this request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
+		// Validate the labels.
+		if err := permsdb.ValidateLabels(resourceUpdate.Labels); err != nil {
 			reason := err.Error()
-			return resources.NewUpdateResourceInternalServerError().WithPayload(
+			return resources.NewUpdateResourceBadRequest().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		// Start a transaction for this request.
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewUpdateResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -39,10 +41,10 @@ func BuildUpdateResourceHandler(db *sql.DB, schema string) func(resources.Update
 		}
 
 		// Verify that the resource exists.
-		exists, err := permsdb.ResourceExists(tx, &params.ID)
+		exists, err := tx.ResourceExists(&params.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewUpdateResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -56,11 +58,29 @@ func BuildUpdateResourceHandler(db *sql.DB, schema string) func(resources.Update
 			)
 		}
 
+		// Verify that the resource hasn't been modified since the client last retrieved it.
+		version, err := tx.LockResourceVersion(&params.ID)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			reason := err.Error()
+			return resources.NewUpdateResourceInternalServerError().WithPayload(
+				&models.ErrorOut{Reason: &reason},
+			)
+		}
+		if !etag.MatchesVersion(params.IfMatch, version) {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf("resource, %s, has been modified", params.ID)
+			return resources.NewUpdateResourcePreconditionFailed().WithPayload(
+				&models.ErrorOut{Reason: &reason},
+			)
+		}
+
 		// Verify that another resource with the same name doesn't already exist.
-		duplicate, err := permsdb.GetDuplicateResourceByName(tx, &params.ID, resourceUpdate.Name)
+		duplicate, err := tx.GetDuplicateResourceByName(&params.ID, resourceUpdate.Name)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewUpdateResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -74,11 +94,34 @@ func BuildUpdateResourceHandler(db *sql.DB, schema string) func(resources.Update
 			)
 		}
 
+		// Replace the labels if new labels were provided.
+		if resourceUpdate.Labels != nil {
+			if err := tx.SetResourceLabels(&params.ID, resourceUpdate.Labels); err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				reason := err.Error()
+				return resources.NewUpdateResourceInternalServerError().WithPayload(
+					&models.ErrorOut{Reason: &reason},
+				)
+			}
+		}
+
 		// Update the resource.
-		resourceOut, err := permsdb.UpdateResource(tx, &params.ID, resourceUpdate.Name)
+		resourceOut, err := tx.UpdateResource(&params.ID, resourceUpdate.Name)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			reason := err.Error()
+			return resources.NewUpdateResourceInternalServerError().WithPayload(
+				&models.ErrorOut{Reason: &reason},
+			)
+		}
+
+		// Look up the new version of the resource.
+		version, err = tx.GetResourceVersion(&params.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewUpdateResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -88,13 +131,13 @@ func BuildUpdateResourceHandler(db *sql.DB, schema string) func(resources.Update
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return resources.NewUpdateResourceInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 
-		return resources.NewUpdateResourceOK().WithPayload(resourceOut)
+		return resources.NewUpdateResourceOK().WithETag(etag.FromVersion(*version)).WithPayload(resourceOut)
 	}
 }
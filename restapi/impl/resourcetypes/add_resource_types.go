@@ -1,32 +1,25 @@
 package resourcetypes
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/resource_types"
 	"github.com/go-openapi/runtime/middleware"
 )
 
 // BuildResourceTypesPostHandler builds the request handler for the add resource types endpoint.
-func BuildResourceTypesPostHandler(db *sql.DB, schema string) func(resource_types.PostResourceTypesParams) middleware.Responder {
+func BuildResourceTypesPostHandler(db permsdb.Store) func(resource_types.PostResourceTypesParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params resource_types.PostResourceTypesParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
 		resourceTypeIn := params.ResourceTypeIn
 
 		// Start a transaction for this request.
-		tx, err := db.Begin()
-		if err != nil {
-			reason := err.Error()
-			return resource_types.NewPostResourceTypesInternalServerError().WithPayload(
-				&models.ErrorOut{Reason: &reason},
-			)
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		tx, err := db.Begin(ctx)
 		if err != nil {
 			reason := err.Error()
 			return resource_types.NewPostResourceTypesInternalServerError().WithPayload(
@@ -35,7 +28,7 @@ func BuildResourceTypesPostHandler(db *sql.DB, schema string) func(resource_type
 		}
 
 		// Check for a duplicate name.
-		duplicate, err := permsdb.GetResourceTypeByName(tx, resourceTypeIn.Name)
+		duplicate, err := tx.GetResourceTypeByName(resourceTypeIn.Name)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
 			reason := err.Error()
@@ -52,7 +45,7 @@ func BuildResourceTypesPostHandler(db *sql.DB, schema string) func(resource_type
 		}
 
 		// Save the resource type.
-		resourceTypeOut, err := permsdb.AddNewResourceType(tx, resourceTypeIn)
+		resourceTypeOut, err := tx.AddNewResourceType(resourceTypeIn)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
 			reason := err.Error()
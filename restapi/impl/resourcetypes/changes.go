@@ -0,0 +1,11 @@
+package resourcetypes
+
+import (
+	"github.com/cyverse-de/permissions/models"
+)
+
+// resourceTypeDeletionChanges returns the changes that will be made to the database when a resource type is deleted.
+// Resource types can only be deleted if no resources are associated with them, so no permissions are affected.
+func resourceTypeDeletionChanges(resourceType *models.ResourceTypeOut) *models.ChangeSet {
+	return &models.ChangeSet{RemovedResourceTypes: []*models.ResourceTypeOut{resourceType}}
+}
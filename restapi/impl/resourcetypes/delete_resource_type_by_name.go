@@ -1,12 +1,12 @@
 package resourcetypes
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/resource_types"
 
 	"github.com/go-openapi/runtime/middleware"
@@ -16,6 +16,10 @@ func deleteResourceTypeByNameOK() middleware.Responder {
 	return resource_types.NewDeleteResourceTypeByNameOK()
 }
 
+func deleteResourceTypeByNameAccepted(changes *models.ChangeSet) middleware.Responder {
+	return resource_types.NewDeleteResourceTypeByNameAccepted().WithPayload(changes)
+}
+
 func deleteResourceTypeByNameBadRequest(reason string) middleware.Responder {
 	return resource_types.NewDeleteResourceTypeByNameBadRequest().WithPayload(
 		&models.ErrorOut{Reason: &reason},
@@ -36,30 +40,27 @@ func deleteResourceTypeByNameNotFound(reason string) middleware.Responder {
 
 // BuildDeleteResourceTypeByNameHandler builds the request handler for the resource type by name endpoint.
 func BuildDeleteResourceTypeByNameHandler(
-	db *sql.DB, schema string,
+	db permsdb.Store,
 ) func(resource_types.DeleteResourceTypeByNameParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params resource_types.DeleteResourceTypeByNameParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithField("resource_type", params.ResourceTypeName)
+		dryRun := params.DryRun != nil && *params.DryRun
 
 		// Start a transaction for this request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return deleteResourceTypeByNameInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteResourceTypeByNameInternalServerError(err.Error())
 		}
 
 		// Verify that the resource type exists.
-		resourceType, err := permsdb.GetResourceTypeByName(tx, &params.ResourceTypeName)
+		resourceType, err := tx.GetResourceTypeByName(&params.ResourceTypeName)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteResourceTypeByNameInternalServerError(err.Error())
 		}
 		if resourceType == nil {
@@ -69,10 +70,10 @@ func BuildDeleteResourceTypeByNameHandler(
 		}
 
 		// Verify that the resource type has no resources associated with it.
-		numResources, err := permsdb.CountResourcesOfType(tx, resourceType.ID)
+		numResources, err := tx.CountResourcesOfType(resourceType.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteResourceTypeByNameInternalServerError(err.Error())
 		}
 		if numResources != 0 {
@@ -82,16 +83,25 @@ func BuildDeleteResourceTypeByNameHandler(
 		}
 
 		// Delete the resource type.
-		if err := permsdb.DeleteResourceType(tx, resourceType.ID); err != nil {
+		if err := tx.DeleteResourceType(resourceType.ID); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteResourceTypeByNameInternalServerError(err.Error())
 		}
 
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return deleteResourceTypeByNameInternalServerError(err.Error())
+			}
+			return deleteResourceTypeByNameAccepted(resourceTypeDeletionChanges(resourceType))
+		}
+
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteResourceTypeByNameInternalServerError(err.Error())
 		}
 
@@ -1,33 +1,27 @@
 package resourcetypes
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/resource_types"
 	"github.com/go-openapi/runtime/middleware"
 )
 
 // BuildResourceTypesIDDeleteHandler builds the request handler for the resource type deletion endpoint.
 func BuildResourceTypesIDDeleteHandler(
-	db *sql.DB, schema string,
+	db permsdb.Store,
 ) func(resource_types.DeleteResourceTypesIDParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params resource_types.DeleteResourceTypesIDParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		dryRun := params.DryRun != nil && *params.DryRun
 
 		// Start a transaction for this request.
-		tx, err := db.Begin()
-		if err != nil {
-			reason := err.Error()
-			return resource_types.NewDeleteResourceTypesIDInternalServerError().WithPayload(
-				&models.ErrorOut{Reason: &reason},
-			)
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		tx, err := db.Begin(ctx)
 		if err != nil {
 			reason := err.Error()
 			return resource_types.NewDeleteResourceTypesIDInternalServerError().WithPayload(
@@ -36,7 +30,7 @@ func BuildResourceTypesIDDeleteHandler(
 		}
 
 		// Verify that the resource type exists.
-		exists, err := permsdb.ResourceTypeExists(tx, &params.ID)
+		resourceType, err := tx.GetResourceType(&params.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
 			reason := err.Error()
@@ -44,7 +38,7 @@ func BuildResourceTypesIDDeleteHandler(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
-		if !exists {
+		if resourceType == nil {
 			tx.Rollback() // nolint:errcheck
 			reason := fmt.Sprintf("resource type %s not found", params.ID)
 			return resource_types.NewDeleteResourceTypesIDNotFound().WithPayload(
@@ -53,7 +47,7 @@ func BuildResourceTypesIDDeleteHandler(
 		}
 
 		// Verify that the resource type has no resources associated with it.
-		numResources, err := permsdb.CountResourcesOfType(tx, &params.ID)
+		numResources, err := tx.CountResourcesOfType(&params.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
 			reason := err.Error()
@@ -70,7 +64,7 @@ func BuildResourceTypesIDDeleteHandler(
 		}
 
 		// Delete the resource type.
-		err = permsdb.DeleteResourceType(tx, &params.ID)
+		err = tx.DeleteResourceType(&params.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
 			reason := err.Error()
@@ -79,6 +73,19 @@ func BuildResourceTypesIDDeleteHandler(
 			)
 		}
 
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				reason := err.Error()
+				return resource_types.NewDeleteResourceTypesIDInternalServerError().WithPayload(
+					&models.ErrorOut{Reason: &reason},
+				)
+			}
+			return resource_types.NewDeleteResourceTypesIDAccepted().WithPayload(
+				resourceTypeDeletionChanges(resourceType),
+			)
+		}
+
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
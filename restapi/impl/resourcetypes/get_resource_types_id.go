@@ -0,0 +1,69 @@
+package resourcetypes
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/etag"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
+	"github.com/cyverse-de/permissions/restapi/operations/resource_types"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+func getResourceTypesIDOk(resourceType *models.ResourceTypeOut, version int64) middleware.Responder {
+	return resource_types.NewGetResourceTypesIDOK().WithETag(etag.FromVersion(version)).WithPayload(resourceType)
+}
+
+func getResourceTypesIDNotFound(reason string) middleware.Responder {
+	return resource_types.NewGetResourceTypesIDNotFound().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func getResourceTypesIDInternalServerError(reason string) middleware.Responder {
+	return resource_types.NewGetResourceTypesIDInternalServerError().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+// BuildResourceTypesIDGetHandler builds the request handler for the get resource type endpoint.
+func BuildResourceTypesIDGetHandler(db permsdb.Store) func(resource_types.GetResourceTypesIDParams) middleware.Responder {
+
+	// Return the handler function.
+	return func(params resource_types.GetResourceTypesIDParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithField("resource_type_id", params.ID)
+
+		// Start a transaction for this request.
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(err)
+			return getResourceTypesIDInternalServerError(err.Error())
+		}
+		defer tx.Commit() // nolint:errcheck
+
+		// Look up the resource type.
+		resourceType, err := tx.GetResourceType(&params.ID)
+		if err != nil {
+			log.Error(err)
+			return getResourceTypesIDInternalServerError(err.Error())
+		}
+		if resourceType == nil {
+			return getResourceTypesIDNotFound(fmt.Sprintf("resource type %s not found", params.ID))
+		}
+
+		// Look up the resource type version.
+		version, err := tx.GetResourceTypeVersion(&params.ID)
+		if err != nil {
+			log.Error(err)
+			return getResourceTypesIDInternalServerError(err.Error())
+		}
+		if version == nil {
+			return getResourceTypesIDNotFound(fmt.Sprintf("resource type %s not found", params.ID))
+		}
+
+		return getResourceTypesIDOk(resourceType, *version)
+	}
+}
@@ -1,24 +1,26 @@
 package resourcetypes
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/etag"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/resource_types"
 	"github.com/go-openapi/runtime/middleware"
 )
 
 // BuildResourceTypesIDPutHandler builds the request handler for the update resource type endpoint.
-func BuildResourceTypesIDPutHandler(db *sql.DB, schema string) func(resource_types.PutResourceTypesIDParams) middleware.Responder {
+func BuildResourceTypesIDPutHandler(db permsdb.Store) func(resource_types.PutResourceTypesIDParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params resource_types.PutResourceTypesIDParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
 		resourceTypeIn := params.ResourceTypeIn
 
 		// Start a transaction for this request.
-		tx, err := db.Begin()
+		tx, err := db.Begin(ctx)
 		if err != nil {
 			reason := err.Error()
 			return resource_types.NewPutResourceTypesIDInternalServerError().WithPayload(
@@ -26,16 +28,25 @@ func BuildResourceTypesIDPutHandler(db *sql.DB, schema string) func(resource_typ
 			)
 		}
 
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		// Verify that the resource type exists.
+		exists, err := tx.ResourceTypeExists(&params.ID)
 		if err != nil {
+			tx.Rollback() // nolint:errcheck
 			reason := err.Error()
 			return resource_types.NewPutResourceTypesIDInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
+		if !exists {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf("resource type %s not found", params.ID)
+			return resource_types.NewPutResourceTypesIDNotFound().WithPayload(
+				&models.ErrorOut{Reason: &reason},
+			)
+		}
 
-		// Verify that the resource type exists.
-		exists, err := permsdb.ResourceTypeExists(tx, &params.ID)
+		// Verify that the resource type hasn't been modified since the client last retrieved it.
+		version, err := tx.LockResourceTypeVersion(&params.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
 			reason := err.Error()
@@ -43,16 +54,16 @@ func BuildResourceTypesIDPutHandler(db *sql.DB, schema string) func(resource_typ
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
-		if !exists {
+		if !etag.MatchesVersion(params.IfMatch, version) {
 			tx.Rollback() // nolint:errcheck
-			reason := fmt.Sprintf("resource type %s not found", params.ID)
-			return resource_types.NewPutResourceTypesIDNotFound().WithPayload(
+			reason := fmt.Sprintf("resource type %s has been modified", params.ID)
+			return resource_types.NewPutResourceTypesIDPreconditionFailed().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 
 		// Check for a duplicate name.
-		duplicate, err := permsdb.GetDuplicateResourceTypeByName(tx, &params.ID, resourceTypeIn.Name)
+		duplicate, err := tx.GetDuplicateResourceTypeByName(&params.ID, resourceTypeIn.Name)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
 			reason := err.Error()
@@ -69,7 +80,17 @@ func BuildResourceTypesIDPutHandler(db *sql.DB, schema string) func(resource_typ
 		}
 
 		// Update the resource type.
-		resourceTypeOut, err := permsdb.UpdateResourceType(tx, &params.ID, resourceTypeIn)
+		resourceTypeOut, err := tx.UpdateResourceType(&params.ID, resourceTypeIn)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			reason := err.Error()
+			return resource_types.NewPutResourceTypesIDInternalServerError().WithPayload(
+				&models.ErrorOut{Reason: &reason},
+			)
+		}
+
+		// Look up the new version of the resource type.
+		version, err = tx.GetResourceTypeVersion(&params.ID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
 			reason := err.Error()
@@ -87,6 +108,6 @@ func BuildResourceTypesIDPutHandler(db *sql.DB, schema string) func(resource_typ
 			)
 		}
 
-		return resource_types.NewPutResourceTypesIDOK().WithPayload(resourceTypeOut)
+		return resource_types.NewPutResourceTypesIDOK().WithETag(etag.FromVersion(*version)).WithPayload(resourceTypeOut)
 	}
 }
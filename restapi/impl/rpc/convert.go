@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cyverse-de/permissions/models"
+	"github.com/cyverse-de/permissions/permissionspb"
+)
+
+// The permission levels corresponding to each protobuf enumeration value.
+var levels = map[permissionspb.PermissionLevel]models.PermissionLevel{
+	permissionspb.PermissionLevel_PERMISSION_LEVEL_READ:  models.PermissionLevelRead,
+	permissionspb.PermissionLevel_PERMISSION_LEVEL_WRITE: models.PermissionLevelWrite,
+	permissionspb.PermissionLevel_PERMISSION_LEVEL_ADMIN: models.PermissionLevelAdmin,
+	permissionspb.PermissionLevel_PERMISSION_LEVEL_OWN:   models.PermissionLevelOwn,
+}
+
+// levelFromProto converts a protobuf permission level. An unspecified level is converted to an empty string if it's
+// allowed, and rejected otherwise.
+func levelFromProto(level permissionspb.PermissionLevel, allowUnspecified bool) (models.PermissionLevel, error) {
+	if level == permissionspb.PermissionLevel_PERMISSION_LEVEL_UNSPECIFIED && allowUnspecified {
+		return "", nil
+	}
+	if result, ok := levels[level]; ok {
+		return result, nil
+	}
+	return "", status.Errorf(codes.InvalidArgument, "invalid permission level: %s", level)
+}
+
+// levelToProto converts a permission level to its protobuf representation.
+func levelToProto(level models.PermissionLevel) permissionspb.PermissionLevel {
+	for result, name := range levels {
+		if name == level {
+			return result
+		}
+	}
+	return permissionspb.PermissionLevel_PERMISSION_LEVEL_UNSPECIFIED
+}
+
+// subjectTypeFromProto converts a protobuf subject type.
+func subjectTypeFromProto(subjectType permissionspb.SubjectType) (models.SubjectType, error) {
+	switch subjectType {
+	case permissionspb.SubjectType_SUBJECT_TYPE_USER:
+		return models.SubjectTypeUser, nil
+	case permissionspb.SubjectType_SUBJECT_TYPE_GROUP:
+		return models.SubjectTypeGroup, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "invalid subject type: %s", subjectType)
+	}
+}
+
+// subjectTypeToProto converts a subject type to its protobuf representation.
+func subjectTypeToProto(subjectType models.SubjectType) permissionspb.SubjectType {
+	switch subjectType {
+	case models.SubjectTypeUser:
+		return permissionspb.SubjectType_SUBJECT_TYPE_USER
+	case models.SubjectTypeGroup:
+		return permissionspb.SubjectType_SUBJECT_TYPE_GROUP
+	default:
+		return permissionspb.SubjectType_SUBJECT_TYPE_UNSPECIFIED
+	}
+}
+
+// permissionToProto converts a permission to its protobuf representation.
+func permissionToProto(permission *models.Permission) *permissionspb.Permission {
+	subject := &permissionspb.Subject{
+		Type: subjectTypeToProto(*permission.Subject.SubjectType),
+		Id:   string(*permission.Subject.SubjectID),
+	}
+	if permission.Subject.SubjectSourceID != nil {
+		subject.SourceId = string(*permission.Subject.SubjectSourceID)
+	}
+
+	return &permissionspb.Permission{
+		Id:      string(*permission.ID),
+		Subject: subject,
+		Resource: &permissionspb.Resource{
+			Type: *permission.Resource.ResourceType,
+			Name: *permission.Resource.Name,
+		},
+		Level: levelToProto(*permission.PermissionLevel),
+	}
+}
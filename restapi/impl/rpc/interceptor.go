@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/cyverse-de/permissions/logger"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// requestIDKey is the metadata key used to correlate log messages with requests. It matches the X-Request-ID header
+// used by the REST API.
+const requestIDKey = "x-request-id"
+
+// maxRequestIDLength is the maximum length of a request ID supplied by a caller. Longer request IDs are replaced.
+const maxRequestIDLength = 128
+
+// requestID returns the ID of a request. The ID supplied by the caller is used if there is one.
+func requestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, id := range md.Get(requestIDKey) {
+		id = strings.TrimSpace(id)
+		if id != "" && len(id) <= maxRequestIDLength {
+			return id
+		}
+	}
+	return permsdb.NewID()
+}
+
+// remoteAddr returns the address of the caller.
+func remoteAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// UnaryInterceptor returns an interceptor that does for gRPC calls what the REST API's middleware does for requests.
+// Each call is assigned an ID, which is returned to the caller in the x-request-id header, a request-scoped logger is
+// made available via logger.FromContext and a summary of the call is logged once it completes. The context of each
+// call is also canceled after the given timeout, and calls that fail because the timeout expired return
+// DEADLINE_EXCEEDED. A timeout of zero or less disables call timeouts.
+func UnaryInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		id := requestID(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id)) // nolint:errcheck
+
+		log := logger.Log.WithFields(logrus.Fields{"request_id": id, "method": info.FullMethod})
+		ctx = logger.NewContext(ctx, log)
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		if status.Code(err) == codes.Internal && ctx.Err() == context.DeadlineExceeded {
+			err = status.Error(codes.DeadlineExceeded, fmt.Sprintf("the request did not complete within %s", timeout))
+		}
+
+		log.WithFields(logrus.Fields{
+			"code":        status.Code(err).String(),
+			"latency_ms":  float64(time.Since(start).Microseconds()) / 1000,
+			"remote_addr": remoteAddr(ctx),
+		}).Info("request completed")
+		return resp, err
+	}
+}
@@ -0,0 +1,414 @@
+// Package rpc implements the gRPC API defined in permissionspb. The operations use the same storage and Grouper
+// logic as the corresponding REST endpoints.
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	"github.com/cyverse-de/permissions/permissionspb"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/permissions"
+)
+
+// Server implements permissionspb.PermissionsServer.
+type Server struct {
+	permissionspb.UnimplementedPermissionsServer
+
+	db            permsdb.Store
+	grouperClient grouper.Grouper
+}
+
+// NewServer returns a gRPC server implementation that uses the given store and Grouper client.
+func NewServer(db permsdb.Store, grouperClient grouper.Grouper) *Server {
+	return &Server{db: db, grouperClient: grouperClient}
+}
+
+// internalError logs an unexpected error and converts it to a gRPC status.
+func internalError(log *logrus.Entry, err error) error {
+	log.Error(err)
+	return status.Error(codes.Internal, err.Error())
+}
+
+// withTx calls a function within a transaction, committing the transaction if the function succeeds and rolling it
+// back otherwise. Lookups use read-only transactions unless the caller has asked to read its own writes. A lookup that
+// fails with an internal error in a read replica is run again in a read-only transaction in the primary database.
+func (s *Server) withTx(
+	ctx context.Context, log *logrus.Entry, readOnly bool, f func(permsdb.Tx) error,
+) error {
+	var tx permsdb.Tx
+	var err error
+	if readOnly {
+		tx, err = s.db.BeginReadOnly(ctx)
+	} else {
+		tx, err = s.db.Begin(ctx)
+	}
+	if err != nil {
+		return internalError(log, err)
+	}
+
+	err = runTx(log, tx, f)
+	if status.Code(err) != codes.Internal || !permsdb.UsesReplica(tx) {
+		return err
+	}
+
+	// Retry the lookup in the primary database.
+	log.Warn("lookup failed in the read replica, retrying in the primary database")
+	if tx, err = s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true}); err != nil {
+		return internalError(log, err)
+	}
+	return runTx(log, tx, f)
+}
+
+// runTx calls a function within a transaction, committing the transaction if the function succeeds and rolling it
+// back otherwise.
+func runTx(log *logrus.Entry, tx permsdb.Tx, f func(permsdb.Tx) error) error {
+	if err := f(tx); err != nil {
+		tx.Rollback() // nolint:errcheck
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback() // nolint:errcheck
+		return internalError(log, err)
+	}
+	return nil
+}
+
+// subjectIDs verifies that a subject's type is correct and returns the subject IDs to use when looking up its
+// permissions.
+func (s *Server) subjectIDs(
+	ctx context.Context, log *logrus.Entry, tx permsdb.Tx, subject *permissionspb.Subject, lookup bool,
+) ([]string, error) {
+	subjectType, err := subjectTypeFromProto(subject.GetType())
+	if err != nil {
+		return nil, err
+	}
+	if subject.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "subject ID is required")
+	}
+
+	// Verify that the subject type is correct.
+	existing, err := tx.GetSubjectByExternalID(models.ExternalSubjectID(subject.GetId()))
+	if err != nil {
+		return nil, internalError(log, err)
+	}
+	if existing != nil && *existing.SubjectType != subjectType {
+		reason := fmt.Sprintf("incorrect type for subject, %s: %s", subject.GetId(), subjectType)
+		return nil, status.Error(codes.InvalidArgument, reason)
+	}
+
+	// Get the list of subject IDs to use for the query.
+	ids, err := permissions.BuildSubjectIDList(ctx, s.grouperClient, string(subjectType), subject.GetId(), lookup)
+	if err != nil {
+		return nil, internalError(log, err)
+	}
+	return ids, nil
+}
+
+// subjectLog returns a logger annotated with a subject.
+func subjectLog(ctx context.Context, subject *permissionspb.Subject) *logrus.Entry {
+	return logger.FromContext(ctx).WithFields(logrus.Fields{
+		"subject_type": subject.GetType().String(),
+		"subject_id":   subject.GetId(),
+	})
+}
+
+// Check determines whether a subject has at least the given level of permission to access a resource.
+func (s *Server) Check(ctx context.Context, req *permissionspb.CheckRequest) (*permissionspb.CheckResponse, error) {
+	log := subjectLog(ctx, req.GetSubject()).WithFields(logrus.Fields{
+		"resource_type": req.GetResource().GetType(),
+		"resource_name": req.GetResource().GetName(),
+	})
+	minLevel, err := levelFromProto(req.GetMinLevel(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var perms []*models.Permission
+	err = s.withTx(ctx, log, !req.GetReadYourWrites(), func(tx permsdb.Tx) error {
+		ids, err := s.subjectIDs(ctx, log, tx, req.GetSubject(), true)
+		if err != nil {
+			return err
+		}
+
+		// Perform the lookup.
+		resourceType, resourceName := req.GetResource().GetType(), req.GetResource().GetName()
+		if minLevel == "" {
+			perms, err = tx.PermissionsForSubjectsAndResource(ids, resourceType, resourceName)
+		} else {
+			perms, err = tx.PermissionsForSubjectsAndResourceMinLevel(ids, resourceType, resourceName, string(minLevel))
+		}
+		if err != nil {
+			return internalError(log, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(perms) == 0 {
+		return &permissionspb.CheckResponse{}, nil
+	}
+	return &permissionspb.CheckResponse{Allowed: true, Level: levelToProto(*perms[0].PermissionLevel)}, nil
+}
+
+// BatchCheck checks the permissions of a single subject for many resources, using one query per resource type.
+func (s *Server) BatchCheck(
+	ctx context.Context, req *permissionspb.BatchCheckRequest,
+) (*permissionspb.BatchCheckResponse, error) {
+	log := subjectLog(ctx, req.GetSubject())
+	minLevel, err := levelFromProto(req.GetMinLevel(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the resource names by resource type.
+	levels := make(map[string]map[string]models.PermissionLevel)
+	for _, resource := range req.GetResources() {
+		levels[resource.GetType()] = make(map[string]models.PermissionLevel)
+	}
+
+	err = s.withTx(ctx, log, !req.GetReadYourWrites(), func(tx permsdb.Tx) error {
+		ids, err := s.subjectIDs(ctx, log, tx, req.GetSubject(), true)
+		if err != nil {
+			return err
+		}
+
+		// Look up the permissions for each resource type.
+		for resourceType, byName := range levels {
+			var perms []*models.Permission
+			if minLevel == "" {
+				perms, err = tx.PermissionsForSubjectsAndResourceType(ids, resourceType, nil)
+			} else {
+				perms, err = tx.PermissionsForSubjectsAndResourceTypeMinLevel(ids, resourceType, string(minLevel), nil)
+			}
+			if err != nil {
+				return internalError(log, err)
+			}
+			for _, perm := range perms {
+				byName[*perm.Resource.Name] = *perm.PermissionLevel
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Report the results in the same order as the request.
+	results := make([]*permissionspb.CheckResponse, len(req.GetResources()))
+	for i, resource := range req.GetResources() {
+		level, ok := levels[resource.GetType()][resource.GetName()]
+		if !ok {
+			results[i] = &permissionspb.CheckResponse{}
+			continue
+		}
+		results[i] = &permissionspb.CheckResponse{Allowed: true, Level: levelToProto(level)}
+	}
+	return &permissionspb.BatchCheckResponse{Results: results}, nil
+}
+
+// LookUp lists the permissions granted to a subject.
+func (s *Server) LookUp(ctx context.Context, req *permissionspb.LookUpRequest) (*permissionspb.LookUpResponse, error) {
+	log := subjectLog(ctx, req.GetSubject()).WithField("resource_type", req.GetResourceType())
+	minLevel, err := levelFromProto(req.GetMinLevel(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var perms []*models.Permission
+	err = s.withTx(ctx, log, !req.GetReadYourWrites(), func(tx permsdb.Tx) error {
+		ids, err := s.subjectIDs(ctx, log, tx, req.GetSubject(), req.GetLookup())
+		if err != nil {
+			return err
+		}
+
+		// Perform the lookup.
+		resourceType := req.GetResourceType()
+		switch {
+		case resourceType == "" && minLevel == "":
+			perms, err = tx.PermissionsForSubjects(ids)
+		case resourceType == "":
+			perms, err = tx.PermissionsForSubjectsMinLevel(ids, string(minLevel))
+		case minLevel == "":
+			perms, err = tx.PermissionsForSubjectsAndResourceType(ids, resourceType, nil)
+		default:
+			perms, err = tx.PermissionsForSubjectsAndResourceTypeMinLevel(ids, resourceType, string(minLevel), nil)
+		}
+		if err != nil {
+			return internalError(log, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the subject source ID to the results.
+	if err := s.grouperClient.AddSourceIDToPermissions(ctx, perms); err != nil {
+		return nil, internalError(log, err)
+	}
+
+	result := make([]*permissionspb.Permission, len(perms))
+	for i, perm := range perms {
+		result[i] = permissionToProto(perm)
+	}
+	return &permissionspb.LookUpResponse{Permissions: result}, nil
+}
+
+// lookupError converts an error returned by one of the shared get-or-add helpers to a gRPC status error. Missing
+// resource types are reported as invalid arguments because they're named in the request.
+func lookupError(log *logrus.Entry, err error) error {
+	var invalidArgument *permsdb.InvalidArgumentError
+	var notFound *permsdb.NotFoundError
+	if errors.As(err, &invalidArgument) || errors.As(err, &notFound) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return internalError(log, err)
+}
+
+// getOrAddSubject looks up a subject, adding it if it doesn't exist yet.
+func getOrAddSubject(log *logrus.Entry, tx permsdb.Tx, subject *permissionspb.Subject) (*models.SubjectOut, error) {
+	subjectType, err := subjectTypeFromProto(subject.GetType())
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := permsdb.GetOrAddSubject(tx, models.ExternalSubjectID(subject.GetId()), subjectType)
+	if err != nil {
+		return nil, lookupError(log, err)
+	}
+	return result, nil
+}
+
+// getOrAddResource looks up a resource, adding it if it doesn't exist yet.
+func getOrAddResource(log *logrus.Entry, tx permsdb.Tx, resource *permissionspb.Resource) (*models.ResourceOut, error) {
+	result, _, err := permsdb.GetOrAddResource(tx, resource.GetType(), resource.GetName())
+	if err != nil {
+		return nil, lookupError(log, err)
+	}
+	return result, nil
+}
+
+// Grant grants a subject permission to access a resource.
+func (s *Server) Grant(ctx context.Context, req *permissionspb.GrantRequest) (*permissionspb.GrantResponse, error) {
+	log := subjectLog(ctx, req.GetSubject()).WithFields(logrus.Fields{
+		"resource_type": req.GetResource().GetType(),
+		"resource_name": req.GetResource().GetName(),
+	})
+	level, err := levelFromProto(req.GetLevel(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	var permission *models.Permission
+	err = s.withTx(ctx, log, false, func(tx permsdb.Tx) error {
+		subject, err := getOrAddSubject(log, tx, req.GetSubject())
+		if err != nil {
+			return err
+		}
+		resource, err := getOrAddResource(log, tx, req.GetResource())
+		if err != nil {
+			return err
+		}
+
+		// Look up the permission level.
+		levelID, err := tx.GetPermissionLevelIDByName(level)
+		if err != nil {
+			return internalError(log, err)
+		}
+		if levelID == nil {
+			reason := fmt.Sprintf("no permission level named, %s, found", level)
+			return status.Error(codes.InvalidArgument, reason)
+		}
+
+		// Either update or add the permission.
+		if permission, err = tx.UpsertPermission(*subject.ID, *resource.ID, *levelID); err != nil {
+			return internalError(log, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the subject source ID to the permission.
+	if err := s.grouperClient.AddSourceIDToPermission(ctx, permission); err != nil {
+		return nil, internalError(log, err)
+	}
+
+	return &permissionspb.GrantResponse{Permission: permissionToProto(permission)}, nil
+}
+
+// Revoke revokes the permission granted directly to a subject for a resource.
+func (s *Server) Revoke(ctx context.Context, req *permissionspb.RevokeRequest) (*permissionspb.RevokeResponse, error) {
+	log := subjectLog(ctx, req.GetSubject()).WithFields(logrus.Fields{
+		"resource_type": req.GetResource().GetType(),
+		"resource_name": req.GetResource().GetName(),
+	})
+	subjectType, err := subjectTypeFromProto(req.GetSubject().GetType())
+	if err != nil {
+		return nil, err
+	}
+	subjectID := models.ExternalSubjectID(req.GetSubject().GetId())
+	resourceTypeName, resourceName := req.GetResource().GetType(), req.GetResource().GetName()
+
+	err = s.withTx(ctx, log, false, func(tx permsdb.Tx) error {
+		notFound := func(format string, args ...interface{}) error {
+			return status.Error(codes.NotFound, fmt.Sprintf(format, args...))
+		}
+
+		// Look up the resource.
+		resourceType, err := tx.GetResourceTypeByName(&resourceTypeName)
+		if err != nil {
+			return internalError(log, err)
+		}
+		if resourceType == nil {
+			return notFound("resource type not found: %s", resourceTypeName)
+		}
+		resource, err := tx.GetResourceByName(&resourceName, resourceType.ID)
+		if err != nil {
+			return internalError(log, err)
+		}
+		if resource == nil {
+			return notFound("resource not found: %s/%s", resourceTypeName, resourceName)
+		}
+
+		// Look up the subject.
+		subject, err := tx.GetSubject(subjectID, subjectType)
+		if err != nil {
+			return internalError(log, err)
+		}
+		if subject == nil {
+			return notFound("subject not found: %s/%s", subjectType, subjectID)
+		}
+
+		// Look up and delete the permission.
+		permission, err := tx.GetPermission(*subject.ID, *resource.ID)
+		if err != nil {
+			return internalError(log, err)
+		}
+		if permission == nil {
+			return notFound("permission not found: %s/%s:%s/%s", resourceTypeName, resourceName, subjectType, subjectID)
+		}
+		if err := tx.DeletePermission(*permission.ID); err != nil {
+			return internalError(log, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &permissionspb.RevokeResponse{}, nil
+}
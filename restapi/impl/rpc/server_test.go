@@ -0,0 +1,300 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/models"
+	pb "github.com/cyverse-de/permissions/permissionspb"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/db/memory"
+)
+
+func user(id string) *pb.Subject {
+	return &pb.Subject{Type: pb.SubjectType_SUBJECT_TYPE_USER, Id: id}
+}
+
+func group(id string) *pb.Subject {
+	return &pb.Subject{Type: pb.SubjectType_SUBJECT_TYPE_GROUP, Id: id}
+}
+
+func app(name string) *pb.Resource {
+	return &pb.Resource{Type: "app", Name: name}
+}
+
+// newClient starts a server with an in-memory database containing the app resource type, in which u1 is a member of
+// g1, and returns a client connected to it.
+func newClient(t *testing.T) pb.PermissionsClient {
+	store := memory.NewStore()
+	tx, err := store.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "app"
+	if _, err := tx.AddNewResourceType(&models.ResourceTypeIn{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	groups := map[string][]*grouper.GroupInfo{"u1": {{ID: "g1"}}}
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.UnaryInterceptor(UnaryInterceptor(0)))
+	pb.RegisterPermissionsServer(server, NewServer(store, grouper.NewMockGrouperClient(groups)))
+	go server.Serve(listener) // nolint:errcheck
+	t.Cleanup(server.Stop)
+
+	dialer := func(context.Context, string) (net.Conn, error) { return listener.Dial() }
+	conn, err := grpc.Dial("bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewPermissionsClient(conn)
+}
+
+func grant(t *testing.T, c pb.PermissionsClient, subject *pb.Subject, resource *pb.Resource, level pb.PermissionLevel) {
+	req := &pb.GrantRequest{Subject: subject, Resource: resource, Level: level}
+	if _, err := c.Grant(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGrantCheckRevoke(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	resp, err := c.Grant(ctx, &pb.GrantRequest{
+		Subject: user("u1"), Resource: app("a1"), Level: pb.PermissionLevel_PERMISSION_LEVEL_WRITE,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	perm := resp.GetPermission()
+	if perm.GetId() == "" || perm.GetSubject().GetId() != "u1" || perm.GetResource().GetName() != "a1" ||
+		perm.GetLevel() != pb.PermissionLevel_PERMISSION_LEVEL_WRITE {
+		t.Errorf("unexpected permission: %v", perm)
+	}
+
+	expected := map[pb.PermissionLevel]bool{
+		pb.PermissionLevel_PERMISSION_LEVEL_UNSPECIFIED: true,
+		pb.PermissionLevel_PERMISSION_LEVEL_READ:        true,
+		pb.PermissionLevel_PERMISSION_LEVEL_WRITE:       true,
+		pb.PermissionLevel_PERMISSION_LEVEL_OWN:         false,
+	}
+	for level, allowed := range expected {
+		result, err := c.Check(ctx, &pb.CheckRequest{Subject: user("u1"), Resource: app("a1"), MinLevel: level})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.GetAllowed() != allowed {
+			t.Errorf("unexpected result for %s: %v", level, result)
+		}
+	}
+
+	if _, err := c.Revoke(ctx, &pb.RevokeRequest{Subject: user("u1"), Resource: app("a1")}); err != nil {
+		t.Fatal(err)
+	}
+	result, err := c.Check(ctx, &pb.CheckRequest{Subject: user("u1"), Resource: app("a1")})
+	if err != nil || result.GetAllowed() {
+		t.Errorf("unexpected result after revoking the permission: %v, %v", result, err)
+	}
+
+	// Revoking the permission again should fail.
+	_, err = c.Revoke(ctx, &pb.RevokeRequest{Subject: user("u1"), Resource: app("a1")})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGroupPermissions(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+	grant(t, c, group("g1"), app("a1"), pb.PermissionLevel_PERMISSION_LEVEL_ADMIN)
+	grant(t, c, user("u1"), app("a1"), pb.PermissionLevel_PERMISSION_LEVEL_READ)
+	grant(t, c, user("u1"), app("a2"), pb.PermissionLevel_PERMISSION_LEVEL_READ)
+
+	// The group permission should take precedence because it's more permissive.
+	result, err := c.Check(ctx, &pb.CheckRequest{Subject: user("u1"), Resource: app("a1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.GetLevel() != pb.PermissionLevel_PERMISSION_LEVEL_ADMIN {
+		t.Errorf("unexpected level: %s", result.GetLevel())
+	}
+
+	// Group permissions should only be included in lookups when requested.
+	for lookup, count := range map[bool]int{false: 2, true: 2} {
+		resp, err := c.LookUp(ctx, &pb.LookUpRequest{Subject: user("u1"), ResourceType: "app", Lookup: lookup})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.GetPermissions()) != count {
+			t.Errorf("unexpected permissions for lookup=%t: %v", lookup, resp.GetPermissions())
+		}
+		for _, perm := range resp.GetPermissions() {
+			isGroup := perm.GetSubject().GetType() == pb.SubjectType_SUBJECT_TYPE_GROUP
+			if isGroup != (lookup && perm.GetResource().GetName() == "a1") {
+				t.Errorf("unexpected permission for lookup=%t: %v", lookup, perm)
+			}
+		}
+	}
+
+	resp, err := c.LookUp(ctx, &pb.LookUpRequest{
+		Subject: user("u1"), Lookup: true, MinLevel: pb.PermissionLevel_PERMISSION_LEVEL_WRITE,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetPermissions()) != 1 || resp.GetPermissions()[0].GetResource().GetName() != "a1" {
+		t.Errorf("unexpected permissions: %v", resp.GetPermissions())
+	}
+}
+
+func TestBatchCheck(t *testing.T) {
+	c := newClient(t)
+	grant(t, c, group("g1"), app("a1"), pb.PermissionLevel_PERMISSION_LEVEL_OWN)
+	grant(t, c, user("u1"), app("a2"), pb.PermissionLevel_PERMISSION_LEVEL_READ)
+
+	resp, err := c.BatchCheck(context.Background(), &pb.BatchCheckRequest{
+		Subject:   user("u1"),
+		Resources: []*pb.Resource{app("a3"), app("a2"), app("a1"), {Type: "tool", Name: "t1"}},
+		MinLevel:  pb.PermissionLevel_PERMISSION_LEVEL_READ,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []pb.PermissionLevel{
+		pb.PermissionLevel_PERMISSION_LEVEL_UNSPECIFIED,
+		pb.PermissionLevel_PERMISSION_LEVEL_READ,
+		pb.PermissionLevel_PERMISSION_LEVEL_OWN,
+		pb.PermissionLevel_PERMISSION_LEVEL_UNSPECIFIED,
+	}
+	if len(resp.GetResults()) != len(expected) {
+		t.Fatalf("unexpected results: %v", resp.GetResults())
+	}
+	for i, result := range resp.GetResults() {
+		allowed := expected[i] != pb.PermissionLevel_PERMISSION_LEVEL_UNSPECIFIED
+		if result.GetAllowed() != allowed || result.GetLevel() != expected[i] {
+			t.Errorf("unexpected result %d: %v", i, result)
+		}
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+	grant(t, c, user("u1"), app("a1"), pb.PermissionLevel_PERMISSION_LEVEL_READ)
+
+	tests := map[string]func() error{
+		"unspecified subject type": func() error {
+			_, err := c.Check(ctx, &pb.CheckRequest{Subject: &pb.Subject{Id: "u1"}, Resource: app("a1")})
+			return err
+		},
+		"incorrect subject type": func() error {
+			_, err := c.Check(ctx, &pb.CheckRequest{Subject: group("u1"), Resource: app("a1")})
+			return err
+		},
+		"unspecified level": func() error {
+			_, err := c.Grant(ctx, &pb.GrantRequest{Subject: user("u2"), Resource: app("a1")})
+			return err
+		},
+		"unknown resource type": func() error {
+			_, err := c.Grant(ctx, &pb.GrantRequest{
+				Subject: user("u2"), Resource: &pb.Resource{Type: "tool", Name: "t1"},
+				Level: pb.PermissionLevel_PERMISSION_LEVEL_READ,
+			})
+			return err
+		},
+		"conflicting subject": func() error {
+			_, err := c.Grant(ctx, &pb.GrantRequest{
+				Subject: group("u1"), Resource: app("a1"), Level: pb.PermissionLevel_PERMISSION_LEVEL_READ,
+			})
+			return err
+		},
+	}
+	for name, f := range tests {
+		if err := f(); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	c := newClient(t)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), requestIDKey, "some-request")
+
+	var header metadata.MD
+	_, err := c.Check(ctx, &pb.CheckRequest{Subject: user("u1"), Resource: app("a1")}, grpc.Header(&header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := header.Get(requestIDKey); len(ids) != 1 || ids[0] != "some-request" {
+		t.Errorf("unexpected request ID: %v", ids)
+	}
+}
+
+// brokenReplicaStore is a store whose read-only transactions behave like transactions in a read replica that accepts
+// connections but can't answer queries.
+type brokenReplicaStore struct {
+	permsdb.Store
+}
+
+func (s *brokenReplicaStore) BeginReadOnly(ctx context.Context) (permsdb.Tx, error) {
+	tx, err := s.Store.BeginReadOnly(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &brokenReplicaTx{Tx: tx}, nil
+}
+
+type brokenReplicaTx struct {
+	permsdb.Tx
+}
+
+func (t *brokenReplicaTx) InReplica() bool {
+	return true
+}
+
+func (t *brokenReplicaTx) GetSubjectByExternalID(models.ExternalSubjectID) (*models.SubjectOut, error) {
+	return nil, errors.New("the replica can't answer queries")
+}
+
+func TestLookupRetriedInPrimary(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "app"
+	if _, err := tx.AddNewResourceType(&models.ResourceTypeIn{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Grant a permission in the primary database and check it using the broken replica.
+	server := NewServer(&brokenReplicaStore{Store: store}, grouper.NewMockGrouperClient(nil))
+	req := &pb.GrantRequest{Subject: user("u1"), Resource: app("a1"), Level: pb.PermissionLevel_PERMISSION_LEVEL_READ}
+	if _, err := server.Grant(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	result, err := server.Check(ctx, &pb.CheckRequest{Subject: user("u1"), Resource: app("a1")})
+	if err != nil || !result.GetAllowed() {
+		t.Errorf("unexpected result: %v, %v", result, err)
+	}
+}
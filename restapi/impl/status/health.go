@@ -0,0 +1,175 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
+	"github.com/cyverse-de/permissions/restapi/operations/status"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// The possible values of the status fields in health check responses.
+const (
+	statusOK    = "ok"
+	statusError = "error"
+
+	statusShuttingDown = "shutting_down"
+)
+
+// shuttingDown is set to a non-zero value when the service begins shutting down.
+var shuttingDown int32
+
+// BeginShutdown causes the readiness check to fail from now on so that no new requests are routed to the service.
+func BeginShutdown() {
+	atomic.StoreInt32(&shuttingDown, 1)
+}
+
+// Check describes a readiness check for one of the dependencies of the service.
+type Check struct {
+	// Name is the name of the dependency.
+	Name string
+
+	// Required indicates that the service can't handle requests without the dependency.
+	Required bool
+
+	// Run performs the check, returning an error if the dependency is unavailable.
+	Run func(context.Context) error
+}
+
+// runCheck runs a single readiness check, abandoning it if it doesn't complete within the given timeout.
+func runCheck(ctx context.Context, check Check, timeout time.Duration) *models.DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Run the check in the background so that checks that ignore the context can't block the response.
+	start := time.Now()
+	result := make(chan error, 1)
+	go func() { result <- check.Run(ctx) }()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		err = fmt.Errorf("the check did not complete within %s", timeout)
+	}
+
+	// Build the dependency status.
+	name := check.Name
+	latency := float64(time.Since(start).Microseconds()) / 1000
+	dependencyStatus := &models.DependencyStatus{
+		Name:      &name,
+		Required:  check.Required,
+		LatencyMs: &latency,
+	}
+	if err != nil {
+		dependencyStatus.Status = stringPtr(statusError)
+		dependencyStatus.Error = err.Error()
+	} else {
+		dependencyStatus.Status = stringPtr(statusOK)
+	}
+	return dependencyStatus
+}
+
+// runChecks runs the readiness checks concurrently and summarizes the results.
+func runChecks(ctx context.Context, checks []Check, timeout time.Duration) *models.HealthStatus {
+	dependencies := make([]*models.DependencyStatus, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			dependencies[i] = runCheck(ctx, check, timeout)
+		}(i, check)
+	}
+	wg.Wait()
+
+	// The service is ready if all of its required dependencies are available.
+	overallStatus := statusOK
+	for _, dependency := range dependencies {
+		if dependency.Required && *dependency.Status != statusOK {
+			overallStatus = statusError
+		}
+	}
+
+	return &models.HealthStatus{Status: &overallStatus, Dependencies: dependencies}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+// PermissionLevelsCheck returns a readiness check function that verifies that all of the permission levels can be
+// found in the database, which can only be true if the database schema has been created and populated.
+func PermissionLevelsCheck(store permsdb.Store) func(context.Context) error {
+	return func(ctx context.Context) error {
+		tx, err := store.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Commit() // nolint:errcheck
+
+		for _, level := range []models.PermissionLevel{
+			models.PermissionLevelRead,
+			models.PermissionLevelWrite,
+			models.PermissionLevelAdmin,
+			models.PermissionLevelOwn,
+		} {
+			id, err := tx.GetPermissionLevelIDByName(level)
+			if err != nil {
+				return err
+			}
+			if id == nil {
+				return fmt.Errorf("permission level %s not found", string(level))
+			}
+		}
+
+		return nil
+	}
+}
+
+// BuildHealthzHandler builds the request handler for the liveness endpoint. The service is considered to be alive as
+// long as it can respond to requests, so none of its dependencies are checked.
+func BuildHealthzHandler() func(status.GetHealthzParams) middleware.Responder {
+	return func(status.GetHealthzParams) middleware.Responder {
+		return status.NewGetHealthzOK().WithPayload(&models.HealthStatus{
+			Status:       stringPtr(statusOK),
+			Dependencies: []*models.DependencyStatus{},
+		})
+	}
+}
+
+// BuildReadyzHandler builds the request handler for the readiness endpoint. Each check is given the specified amount
+// of time to complete.
+func BuildReadyzHandler(checks []Check, timeout time.Duration) func(status.GetReadyzParams) middleware.Responder {
+	return func(params status.GetReadyzParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+
+		// Don't bother checking the dependencies if the service is shutting down.
+		if atomic.LoadInt32(&shuttingDown) != 0 {
+			return status.NewGetReadyzServiceUnavailable().WithPayload(&models.HealthStatus{
+				Status:       stringPtr(statusShuttingDown),
+				Dependencies: []*models.DependencyStatus{},
+			})
+		}
+
+		result := runChecks(ctx, checks, timeout)
+
+		if *result.Status != statusOK {
+			for _, dependency := range result.Dependencies {
+				if *dependency.Status != statusOK {
+					logger.FromContext(ctx).WithField("dependency", *dependency.Name).Error(dependency.Error)
+				}
+			}
+			return status.NewGetReadyzServiceUnavailable().WithPayload(result)
+		}
+		return status.NewGetReadyzOK().WithPayload(result)
+	}
+}
@@ -0,0 +1,133 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cyverse-de/permissions/restapi/impl/db/memory"
+	"github.com/cyverse-de/permissions/restapi/operations/status"
+)
+
+func succeed(context.Context) error {
+	return nil
+}
+
+func fail(context.Context) error {
+	return errors.New("unavailable")
+}
+
+func hang(ctx context.Context) error {
+	<-ctx.Done()
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestReadyz(t *testing.T) {
+	tests := []struct {
+		name     string
+		checks   []Check
+		ready    bool
+		statuses []string
+	}{
+		{
+			name: "all available",
+			checks: []Check{
+				{Name: "db", Required: true, Run: PermissionLevelsCheck(memory.NewStore())},
+				{Name: "grouper", Required: true, Run: succeed},
+			},
+			ready:    true,
+			statuses: []string{statusOK, statusOK},
+		},
+		{
+			name: "optional dependency unavailable",
+			checks: []Check{
+				{Name: "db", Required: true, Run: succeed},
+				{Name: "replica", Required: false, Run: fail},
+			},
+			ready:    true,
+			statuses: []string{statusOK, statusError},
+		},
+		{
+			name: "required dependency unavailable",
+			checks: []Check{
+				{Name: "db", Required: true, Run: fail},
+				{Name: "replica", Required: false, Run: succeed},
+			},
+			ready:    false,
+			statuses: []string{statusError, statusOK},
+		},
+		{
+			name: "required dependency timed out",
+			checks: []Check{
+				{Name: "db", Required: true, Run: hang},
+			},
+			ready:    false,
+			statuses: []string{statusError},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := BuildReadyzHandler(test.checks, 10*time.Millisecond)
+			responder := handler(status.GetReadyzParams{})
+
+			var dependencyStatuses []string
+			switch r := responder.(type) {
+			case *status.GetReadyzOK:
+				if !test.ready {
+					t.Error("expected the service not to be ready")
+				}
+				for _, dependency := range r.Payload.Dependencies {
+					dependencyStatuses = append(dependencyStatuses, *dependency.Status)
+				}
+			case *status.GetReadyzServiceUnavailable:
+				if test.ready {
+					t.Error("expected the service to be ready")
+				}
+				for _, dependency := range r.Payload.Dependencies {
+					dependencyStatuses = append(dependencyStatuses, *dependency.Status)
+				}
+			default:
+				t.Fatalf("unexpected responder type: %T", responder)
+			}
+
+			if len(dependencyStatuses) != len(test.statuses) {
+				t.Fatalf("unexpected dependency statuses: %v", dependencyStatuses)
+			}
+			for i, s := range test.statuses {
+				if dependencyStatuses[i] != s {
+					t.Errorf("unexpected status for dependency %d: %s", i, dependencyStatuses[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	responder := BuildHealthzHandler()(status.GetHealthzParams{})
+	r, ok := responder.(*status.GetHealthzOK)
+	if !ok {
+		t.Fatalf("unexpected responder type: %T", responder)
+	}
+	if *r.Payload.Status != statusOK {
+		t.Errorf("unexpected status: %s", *r.Payload.Status)
+	}
+}
+
+func TestReadyzShuttingDown(t *testing.T) {
+	BeginShutdown()
+	defer atomic.StoreInt32(&shuttingDown, 0)
+
+	handler := BuildReadyzHandler([]Check{{Name: "db", Required: true, Run: succeed}}, time.Second)
+	responder := handler(status.GetReadyzParams{})
+	r, ok := responder.(*status.GetReadyzServiceUnavailable)
+	if !ok {
+		t.Fatalf("unexpected responder type: %T", responder)
+	}
+	if *r.Payload.Status != statusShuttingDown {
+		t.Errorf("unexpected status: %s", *r.Payload.Status)
+	}
+}
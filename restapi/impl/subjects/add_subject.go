@@ -1,37 +1,34 @@
 package subjects
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/subjects"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 // BuildAddSubjectHandler builds the request handler for the add subject endpoint.
-func BuildAddSubjectHandler(db *sql.DB, schema string) func(subjects.AddSubjectParams) middleware.Responder {
+func BuildAddSubjectHandler(db permsdb.Store) func(subjects.AddSubjectParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params subjects.AddSubjectParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type": *params.SubjectIn.SubjectType,
+			"subject_id":   *params.SubjectIn.SubjectID,
+		})
 		subjectIn := params.SubjectIn
 
 		// Start a transaction for this request.
-		tx, err := db.Begin()
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
-			reason := err.Error()
-			return subjects.NewAddSubjectInternalServerError().WithPayload(
-				&models.ErrorOut{Reason: &reason},
-			)
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
-		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewAddSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -39,10 +36,10 @@ func BuildAddSubjectHandler(db *sql.DB, schema string) func(subjects.AddSubjectP
 		}
 
 		// Make sure that a subject with the same ID doesn't exist already.
-		exists, err := permsdb.SubjectIDExists(tx, *subjectIn.SubjectID)
+		exists, err := tx.SubjectIDExists(*subjectIn.SubjectID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewAddSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -57,10 +54,10 @@ func BuildAddSubjectHandler(db *sql.DB, schema string) func(subjects.AddSubjectP
 		}
 
 		// Add the subject.
-		subjectOut, err := permsdb.AddSubject(tx, *subjectIn.SubjectID, *subjectIn.SubjectType)
+		subjectOut, err := tx.AddSubject(*subjectIn.SubjectID, *subjectIn.SubjectType)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewAddSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -70,7 +67,7 @@ func BuildAddSubjectHandler(db *sql.DB, schema string) func(subjects.AddSubjectP
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewAddSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -0,0 +1,23 @@
+package subjects
+
+import (
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+// subjectDeletionChanges returns the changes that will be made to the database when a subject is deleted. This
+// function must be called before the subject is deleted.
+func subjectDeletionChanges(tx permsdb.Tx, subject *models.SubjectOut) (*models.ChangeSet, error) {
+
+	// The subject's permissions are removed along with it.
+	perms, err := tx.ListSubjectPermissions(*subject.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &models.ChangeSet{
+		RemovedSubjects:    []*models.SubjectOut{subject},
+		RemovedPermissions: perms,
+	}
+	return changes, nil
+}
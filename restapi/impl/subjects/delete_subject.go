@@ -1,37 +1,31 @@
 package subjects
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/subjects"
 
 	"github.com/go-openapi/runtime/middleware"
 )
 
 // BuildDeleteSubjectHandler builds the request handler for the delete subject endpoint.
-func BuildDeleteSubjectHandler(db *sql.DB, schema string) func(subjects.DeleteSubjectParams) middleware.Responder {
+func BuildDeleteSubjectHandler(db permsdb.Store) func(subjects.DeleteSubjectParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params subjects.DeleteSubjectParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithField("subject_internal_id", params.ID)
 		id := models.InternalSubjectID(params.ID)
+		dryRun := params.DryRun != nil && *params.DryRun
 
 		// Start a transaction for this request.
-		tx, err := db.Begin()
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
-			reason := err.Error()
-			return subjects.NewDeleteSubjectInternalServerError().WithPayload(
-				&models.ErrorOut{Reason: &reason},
-			)
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
-		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewDeleteSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -39,16 +33,16 @@ func BuildDeleteSubjectHandler(db *sql.DB, schema string) func(subjects.DeleteSu
 		}
 
 		// Verify that the subject exists.
-		exists, err := permsdb.SubjectExists(tx, id)
+		subject, err := tx.GetSubjectByID(id)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewDeleteSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
-		if !exists {
+		if subject == nil {
 			tx.Rollback() // nolint:errcheck
 			reason := fmt.Sprintf("subject, %s, not found", string(id))
 			return subjects.NewDeleteSubjectNotFound().WithPayload(
@@ -56,20 +50,46 @@ func BuildDeleteSubjectHandler(db *sql.DB, schema string) func(subjects.DeleteSu
 			)
 		}
 
+		// Record the changes that will be made if this is a dry run.
+		var changes *models.ChangeSet
+		if dryRun {
+			changes, err = subjectDeletionChanges(tx, subject)
+			if err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				reason := err.Error()
+				return subjects.NewDeleteSubjectInternalServerError().WithPayload(
+					&models.ErrorOut{Reason: &reason},
+				)
+			}
+		}
+
 		// Delete the subject.
-		if err := permsdb.DeleteSubject(tx, id); err != nil {
+		if err := tx.DeleteSubject(id); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewDeleteSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				reason := err.Error()
+				return subjects.NewDeleteSubjectInternalServerError().WithPayload(
+					&models.ErrorOut{Reason: &reason},
+				)
+			}
+			return subjects.NewDeleteSubjectAccepted().WithPayload(changes)
+		}
+
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewDeleteSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
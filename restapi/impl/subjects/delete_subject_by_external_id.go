@@ -1,15 +1,16 @@
 package subjects
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/subjects"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
 )
 
 func deleteSubjectByExternalIDInternalServerError(reason string) middleware.Responder {
@@ -24,38 +25,42 @@ func deleteSubjectByExternalIDNotFound(reason string) middleware.Responder {
 	)
 }
 
+func deleteSubjectByExternalIDAccepted(changes *models.ChangeSet) middleware.Responder {
+	return subjects.NewDeleteSubjectByExternalIDAccepted().WithPayload(changes)
+}
+
 func deleteSubjectByExternalIDOk() middleware.Responder {
 	return subjects.NewDeleteSubjectByExternalIDOK()
 }
 
 // BuildDeleteSubjectByExternalIDHandler builds the request handler for the delete subject by external ID endpoint.
 func BuildDeleteSubjectByExternalIDHandler(
-	db *sql.DB, schema string,
+	db permsdb.Store,
 ) func(subjects.DeleteSubjectByExternalIDParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params subjects.DeleteSubjectByExternalIDParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithFields(logrus.Fields{
+			"subject_type": params.SubjectType,
+			"subject_id":   params.SubjectID,
+		})
 		subjectID := models.ExternalSubjectID(params.SubjectID)
 		subjectType := models.SubjectType(params.SubjectType)
+		dryRun := params.DryRun != nil && *params.DryRun
 
 		// Start a transaction for the request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return deleteSubjectByExternalIDInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteSubjectByExternalIDInternalServerError(err.Error())
 		}
 
 		// Look up the subject.
-		subject, err := permsdb.GetSubject(tx, subjectID, subjectType)
+		subject, err := tx.GetSubject(subjectID, subjectType)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteSubjectByExternalIDInternalServerError(err.Error())
 		}
 		if subject == nil {
@@ -64,16 +69,36 @@ func BuildDeleteSubjectByExternalIDHandler(
 			return deleteSubjectByExternalIDNotFound(reason)
 		}
 
+		// Record the changes that will be made if this is a dry run.
+		var changes *models.ChangeSet
+		if dryRun {
+			changes, err = subjectDeletionChanges(tx, subject)
+			if err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return deleteSubjectByExternalIDInternalServerError(err.Error())
+			}
+		}
+
 		// Delete the subject.
-		if err := permsdb.DeleteSubject(tx, *subject.ID); err != nil {
+		if err := tx.DeleteSubject(*subject.ID); err != nil {
 			tx.Rollback() // nolint:errcheck
 			return deleteSubjectByExternalIDInternalServerError(err.Error())
 		}
 
+		// Roll back the transaction and report the changes if this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return deleteSubjectByExternalIDInternalServerError(err.Error())
+			}
+			return deleteSubjectByExternalIDAccepted(changes)
+		}
+
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return deleteSubjectByExternalIDInternalServerError(err.Error())
 		}
 
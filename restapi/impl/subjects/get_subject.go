@@ -0,0 +1,71 @@
+package subjects
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/etag"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
+	"github.com/cyverse-de/permissions/restapi/operations/subjects"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+func getSubjectOk(subject *models.SubjectOut, version int64) middleware.Responder {
+	return subjects.NewGetSubjectOK().WithETag(etag.FromVersion(version)).WithPayload(subject)
+}
+
+func getSubjectNotFound(reason string) middleware.Responder {
+	return subjects.NewGetSubjectNotFound().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func getSubjectInternalServerError(reason string) middleware.Responder {
+	return subjects.NewGetSubjectInternalServerError().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+// BuildGetSubjectHandler builds the request handler for the get subject endpoint.
+func BuildGetSubjectHandler(db permsdb.Store) func(subjects.GetSubjectParams) middleware.Responder {
+
+	// Return the handler function.
+	return func(params subjects.GetSubjectParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithField("subject_internal_id", params.ID)
+		id := models.InternalSubjectID(params.ID)
+
+		// Start a transaction for this request.
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(err)
+			return getSubjectInternalServerError(err.Error())
+		}
+		defer tx.Commit() // nolint:errcheck
+
+		// Look up the subject.
+		subject, err := tx.GetSubjectByID(id)
+		if err != nil {
+			log.Error(err)
+			return getSubjectInternalServerError(err.Error())
+		}
+		if subject == nil {
+			return getSubjectNotFound(fmt.Sprintf("subject, %s, not found", string(id)))
+		}
+
+		// Look up the subject version.
+		version, err := tx.GetSubjectVersion(id)
+		if err != nil {
+			log.Error(err)
+			return getSubjectInternalServerError(err.Error())
+		}
+		if version == nil {
+			return getSubjectNotFound(fmt.Sprintf("subject, %s, not found", string(id)))
+		}
+
+		return getSubjectOk(subject, *version)
+	}
+}
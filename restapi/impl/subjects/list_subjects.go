@@ -1,12 +1,10 @@
 package subjects
 
 import (
-	"database/sql"
-	"fmt"
-
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/subjects"
 
 	"github.com/go-openapi/runtime/middleware"
@@ -19,36 +17,32 @@ func listSubjectsInternalServerError(reason string) middleware.Responder {
 }
 
 // BuildListSubjectsHandler builds the request handler for the list subjects endpoint.
-func BuildListSubjectsHandler(db *sql.DB, schema string) func(subjects.ListSubjectsParams) middleware.Responder {
+func BuildListSubjectsHandler(db permsdb.Store) func(subjects.ListSubjectsParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params subjects.ListSubjectsParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx)
 
 		// Start a transaction for the request.
-		tx, err := db.Begin()
-		if err != nil {
-			logger.Log.Error(err)
-			return listSubjectsInternalServerError(err.Error())
-		}
-
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			return listSubjectsInternalServerError(err.Error())
 		}
 
 		// Obtain the list of subjects.
-		result, err := permsdb.ListSubjects(tx, params.SubjectType, params.SubjectID)
+		result, err := tx.ListSubjects(params.SubjectType, params.SubjectID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return listSubjectsInternalServerError(err.Error())
 		}
 
 		// Commit the transaction for the request.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			return listSubjectsInternalServerError(err.Error())
 		}
 
@@ -0,0 +1,185 @@
+package subjects
+
+import (
+	"fmt"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
+	"github.com/cyverse-de/permissions/restapi/operations/subjects"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+func mergeSubjectOk(result *models.SubjectMergeResult) middleware.Responder {
+	return subjects.NewMergeSubjectOK().WithPayload(result)
+}
+
+func mergeSubjectBadRequest(reason string) middleware.Responder {
+	return subjects.NewMergeSubjectBadRequest().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func mergeSubjectNotFound(reason string) middleware.Responder {
+	return subjects.NewMergeSubjectNotFound().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+func mergeSubjectInternalServerError(reason string) middleware.Responder {
+	return subjects.NewMergeSubjectInternalServerError().WithPayload(
+		&models.ErrorOut{Reason: &reason},
+	)
+}
+
+// filterPermissionsByResource returns the permissions in the list that apply to resources in the given set.
+func filterPermissionsByResource(perms []*models.Permission, resourceIDs map[string]bool) []*models.Permission {
+	result := make([]*models.Permission, 0)
+	for _, perm := range perms {
+		if resourceIDs[*perm.Resource.ID] {
+			result = append(result, perm)
+		}
+	}
+	return result
+}
+
+// BuildMergeSubjectHandler builds the request handler for the merge subject endpoint.
+func BuildMergeSubjectHandler(
+	db permsdb.Store, grouperClient grouper.Grouper,
+) func(subjects.MergeSubjectParams) middleware.Responder {
+
+	// Return the handler function.
+	return func(params subjects.MergeSubjectParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithField("subject_internal_id", params.ID)
+		id := models.InternalSubjectID(params.ID)
+		target := params.Target
+		dryRun := params.DryRun != nil && *params.DryRun
+
+		// Start a transaction for this request.
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(err)
+			return mergeSubjectInternalServerError(err.Error())
+		}
+
+		// Look up the source subject.
+		source, err := tx.GetSubjectByID(id)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return mergeSubjectInternalServerError(err.Error())
+		}
+		if source == nil {
+			tx.Rollback() // nolint:errcheck
+			return mergeSubjectNotFound(fmt.Sprintf("subject, %s, not found", string(id)))
+		}
+
+		// A subject can't be merged into itself.
+		if *source.SubjectID == *target.SubjectID {
+			tx.Rollback() // nolint:errcheck
+			return mergeSubjectBadRequest(
+				fmt.Sprintf("subject, %s, can't be merged into itself", string(*target.SubjectID)),
+			)
+		}
+
+		// Look up the target subject, verifying that its type matches if it exists.
+		dest, err := tx.GetSubjectByExternalID(*target.SubjectID)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return mergeSubjectInternalServerError(err.Error())
+		}
+		if dest != nil && *dest.SubjectType != *target.SubjectType {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf(
+				"another subject with the ID, %s, already exists with type, %s",
+				string(*dest.SubjectID), string(*dest.SubjectType),
+			)
+			return mergeSubjectBadRequest(reason)
+		}
+
+		// Record the resources that the source subject has access to.
+		sourcePerms, err := tx.ListSubjectPermissions(id)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return mergeSubjectInternalServerError(err.Error())
+		}
+		resourceIDs := make(map[string]bool)
+		for _, perm := range sourcePerms {
+			resourceIDs[*perm.Resource.ID] = true
+		}
+
+		conflicts := make([]*models.PermissionConflict, 0)
+		if dest == nil {
+
+			// The target subject doesn't exist yet, so the source subject can simply be renamed.
+			dest, err = tx.UpdateSubject(id, *target.SubjectID, *target.SubjectType)
+			if err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return mergeSubjectInternalServerError(err.Error())
+			}
+		} else {
+
+			// Identify the resources that both subjects have access to.
+			conflicts, err = tx.ListPermissionConflicts(source, dest)
+			if err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return mergeSubjectInternalServerError(err.Error())
+			}
+
+			// Move the permissions to the target subject.
+			if err := tx.CopyPermissions(source, dest); err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return mergeSubjectInternalServerError(err.Error())
+			}
+
+			// Remove the source subject. Its permissions are removed along with it.
+			if err := tx.DeleteSubject(id); err != nil {
+				tx.Rollback() // nolint:errcheck
+				log.Error(err)
+				return mergeSubjectInternalServerError(err.Error())
+			}
+		}
+
+		// List the target subject's permissions to the affected resources.
+		destPerms, err := tx.ListSubjectPermissions(*dest.ID)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return mergeSubjectInternalServerError(err.Error())
+		}
+		perms := filterPermissionsByResource(destPerms, resourceIDs)
+
+		// Commit the transaction unless this is a dry run.
+		if dryRun {
+			if err := tx.Rollback(); err != nil {
+				log.Error(err)
+				return mergeSubjectInternalServerError(err.Error())
+			}
+		} else if err := tx.Commit(); err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			return mergeSubjectInternalServerError(err.Error())
+		}
+
+		// Add the subject source ID to the response body.
+		if err := grouperClient.AddSourceIDToPermissions(ctx, perms); err != nil {
+			log.Error(err)
+			return mergeSubjectInternalServerError(err.Error())
+		}
+
+		return mergeSubjectOk(&models.SubjectMergeResult{
+			Subject:     dest,
+			Permissions: perms,
+			Conflicts:   conflicts,
+		})
+	}
+}
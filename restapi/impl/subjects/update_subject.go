@@ -1,72 +1,86 @@
 package subjects
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/cyverse-de/permissions/logger"
 	"github.com/cyverse-de/permissions/models"
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/etag"
+	"github.com/cyverse-de/permissions/restapi/impl/reqctx"
 	"github.com/cyverse-de/permissions/restapi/operations/subjects"
 
 	"github.com/go-openapi/runtime/middleware"
 )
 
 // BuildUpdateSubjectHandler builds the request handler for the update subject endpoint.
-func BuildUpdateSubjectHandler(db *sql.DB, schema string) func(subjects.UpdateSubjectParams) middleware.Responder {
+func BuildUpdateSubjectHandler(db permsdb.Store) func(subjects.UpdateSubjectParams) middleware.Responder {
 
 	// Return the handler function.
 	return func(params subjects.UpdateSubjectParams) middleware.Responder {
+		ctx := reqctx.FromRequest(params.HTTPRequest)
+		log := logger.FromContext(ctx).WithField("subject_internal_id", params.ID)
 		id := models.InternalSubjectID(params.ID)
 		subjectIn := params.SubjectIn
 
 		// Start a transaction for this request.
-		tx, err := db.Begin()
+		tx, err := db.Begin(ctx)
 		if err != nil {
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewUpdateSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 
-		_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+		// Verify that the subject exists.
+		exists, err := tx.SubjectExists(id)
 		if err != nil {
-			logger.Log.Error(err)
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewUpdateSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
+		if !exists {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf("subject, %s, not found", string(id))
+			return subjects.NewUpdateSubjectNotFound().WithPayload(
+				&models.ErrorOut{Reason: &reason},
+			)
+		}
 
-		// Verify that the subject exists.
-		exists, err := permsdb.SubjectExists(tx, id)
+		// Verify that the subject hasn't been modified since the client last retrieved it.
+		version, err := tx.LockSubjectVersion(id)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewUpdateSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
-		if !exists {
-			reason := fmt.Sprintf("subject, %s, not found", string(id))
-			return subjects.NewUpdateSubjectNotFound().WithPayload(
+		if !etag.MatchesVersion(params.IfMatch, version) {
+			tx.Rollback() // nolint:errcheck
+			reason := fmt.Sprintf("subject, %s, has been modified", string(id))
+			return subjects.NewUpdateSubjectPreconditionFailed().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 
 		// Verify that a subject with the same external subject ID doesn't exist.
-		duplicateExists, err := permsdb.DuplicateSubjectExists(tx, id, *subjectIn.SubjectID)
+		duplicateExists, err := tx.DuplicateSubjectExists(id, *subjectIn.SubjectID)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewUpdateSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 		if duplicateExists {
+			tx.Rollback() // nolint:errcheck
 			reason := fmt.Sprintf("another subject with the ID, %s, already exists", string(*subjectIn.SubjectID))
 			return subjects.NewUpdateSubjectBadRequest().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -74,10 +88,21 @@ func BuildUpdateSubjectHandler(db *sql.DB, schema string) func(subjects.UpdateSu
 		}
 
 		// Update the subject.
-		subjectOut, err := permsdb.UpdateSubject(tx, id, *subjectIn.SubjectID, *subjectIn.SubjectType)
+		subjectOut, err := tx.UpdateSubject(id, *subjectIn.SubjectID, *subjectIn.SubjectType)
+		if err != nil {
+			tx.Rollback() // nolint:errcheck
+			log.Error(err)
+			reason := err.Error()
+			return subjects.NewUpdateSubjectInternalServerError().WithPayload(
+				&models.ErrorOut{Reason: &reason},
+			)
+		}
+
+		// Look up the new version of the subject.
+		version, err = tx.GetSubjectVersion(id)
 		if err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewUpdateSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
@@ -87,13 +112,13 @@ func BuildUpdateSubjectHandler(db *sql.DB, schema string) func(subjects.UpdateSu
 		// Commit the transaction.
 		if err := tx.Commit(); err != nil {
 			tx.Rollback() // nolint:errcheck
-			logger.Log.Error(err)
+			log.Error(err)
 			reason := err.Error()
 			return subjects.NewUpdateSubjectInternalServerError().WithPayload(
 				&models.ErrorOut{Reason: &reason},
 			)
 		}
 
-		return subjects.NewUpdateSubjectOK().WithPayload(subjectOut)
+		return subjects.NewUpdateSubjectOK().WithETag(etag.FromVersion(*version)).WithPayload(subjectOut)
 	}
 }
@@ -0,0 +1,450 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cyverse-de/permissions/restapi/impl/bulk"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+func exportAll(t *testing.T, db permsdb.Store, format string) string {
+	tx, err := db.BeginReadOnly(context.Background())
+	if err != nil {
+		t.Fatalf("unable to start a transaction: %s", err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	var buf bytes.Buffer
+	writer, err := bulk.NewWriter(&buf, format)
+	if err != nil {
+		t.Fatalf("unable to create the writer: %s", err)
+	}
+	if _, err := bulk.Export(tx, writer); err != nil {
+		t.Fatalf("unable to export the database: %s", err)
+	}
+
+	return buf.String()
+}
+
+func importAllAttempt(db permsdb.Store, format, data string) (*bulk.Summary, error) {
+	tx, err := db.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := bulk.NewReader(strings.NewReader(data), format)
+	if err != nil {
+		tx.Rollback() // nolint:errcheck
+		return nil, err
+	}
+	summary, err := bulk.Import(tx, reader)
+	if err != nil {
+		tx.Rollback() // nolint:errcheck
+		return nil, err
+	}
+
+	return summary, tx.Commit()
+}
+
+func importAll(t *testing.T, db permsdb.Store, format, data string) *bulk.Summary {
+	summary, err := importAllAttempt(db, format, data)
+	if err != nil {
+		t.Fatalf("unable to import the records: %s", err)
+	}
+	return summary
+}
+
+func TestExport(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	addDefaultPermissions(db)
+
+	// Export the database.
+	lines := strings.Split(strings.TrimSpace(exportAll(t, db, bulk.FormatJSONL)), "\n")
+	if len(lines) != 26 {
+		t.Fatalf("unexpected number of records exported: %d", len(lines))
+	}
+
+	// Verify that the records are written in the expected order.
+	expected := map[int]bulk.Record{
+		0:  {Kind: bulk.KindResourceType, ResourceType: "analysis", Description: "analysis"},
+		1:  {Kind: bulk.KindResourceType, ResourceType: "app", Description: "app"},
+		2:  {Kind: bulk.KindSubject, SubjectType: "user", SubjectID: "s2"},
+		5:  {Kind: bulk.KindSubject, SubjectType: "group", SubjectID: "g2id"},
+		6:  {Kind: bulk.KindResource, ResourceType: "analysis", ResourceName: "analysis1"},
+		11: {Kind: bulk.KindResource, ResourceType: "app", ResourceName: "app3"},
+		12: {
+			Kind: bulk.KindPermission, ResourceType: "analysis", ResourceName: "analysis1",
+			SubjectType: "user", SubjectID: "s2", PermissionLevel: "own",
+		},
+		14: {
+			Kind: bulk.KindPermission, ResourceType: "analysis", ResourceName: "analysis1",
+			SubjectType: "group", SubjectID: "g1id", PermissionLevel: "read",
+		},
+	}
+	for i, record := range expected {
+		var actual bulk.Record
+		if err := json.Unmarshal([]byte(lines[i]), &actual); err != nil {
+			t.Fatalf("unable to parse record %d: %s", i, err)
+		}
+		if !reflect.DeepEqual(actual, record) {
+			t.Errorf("unexpected record %d: %+v", i, actual)
+		}
+	}
+}
+
+func TestExportEmpty(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+
+	// Only the CSV header should be written.
+	if csv := exportAll(t, db, bulk.FormatCSV); strings.Count(csv, "\n") != 1 {
+		t.Errorf("unexpected CSV export: %s", csv)
+	}
+	if jsonl := exportAll(t, db, bulk.FormatJSONL); jsonl != "" {
+		t.Errorf("unexpected JSONL export: %s", jsonl)
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	for _, format := range []string{bulk.FormatJSONL, bulk.FormatCSV} {
+
+		// Initialize the database.
+		db := initdb(t)
+		addDefaultResourceTypes(db, t)
+		addDefaultPermissions(db)
+		addTestResource(db, "unused", "app", t)
+
+		// Export the database, clear it and import the export.
+		original := exportAll(t, db, format)
+		db = initdb(t)
+		summary := importAll(t, db, format, original)
+
+		// Verify the summary.
+		expected := bulk.Summary{ResourceTypes: 2, Subjects: 4, Resources: 7, Permissions: 14}
+		if *summary != expected {
+			t.Errorf("%s: unexpected import summary: %+v", format, summary)
+		}
+
+		// The contents of the database should be unchanged.
+		if actual := exportAll(t, db, format); actual != original {
+			t.Errorf("%s: unexpected export after import:\n%s", format, actual)
+		}
+	}
+}
+
+func TestExportImportLabelsRoundTrip(t *testing.T) {
+	labels := map[string]string{"env": "prod", "example.org/team": "de", "empty": ""}
+	expectedExports := map[string]string{
+		bulk.FormatJSONL: `"labels":{"empty":"","env":"prod","example.org/team":"de"}`,
+		bulk.FormatCSV:   "resource,app,labeled,,,,,empty=;env=prod;example.org/team=de\n",
+	}
+
+	for format, expectedExport := range expectedExports {
+
+		// Initialize the database.
+		db := initdb(t)
+		addDefaultResourceTypes(db, t)
+		addLabeledResource(db, "labeled", "app", labels)
+		addLabeledResource(db, "unlabeled", "app", nil)
+		putPermission(db, "user", "s1", "app", "labeled", "read")
+
+		// Verify that the labels are exported.
+		original := exportAll(t, db, format)
+		if !strings.Contains(original, expectedExport) {
+			t.Errorf("%s: labels not exported:\n%s", format, original)
+		}
+
+		// Clear the database and import the export.
+		db = initdb(t)
+		importAll(t, db, format, original)
+
+		// Verify the labels of the imported resources.
+		for _, resource := range listResources(db, nil, nil).Resources {
+			expected := map[string]string(nil)
+			if *resource.Name == "labeled" {
+				expected = labels
+			}
+			if !permsdb.SameLabels(resource.Labels, expected) {
+				t.Errorf("%s: unexpected labels for %s: %v", format, *resource.Name, resource.Labels)
+			}
+		}
+
+		// The contents of the database should be unchanged.
+		if actual := exportAll(t, db, format); actual != original {
+			t.Errorf("%s: unexpected export after import:\n%s", format, actual)
+		}
+	}
+}
+
+func TestImportReplacesLabels(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	addLabeledResource(db, "app1", "app", map[string]string{"env": "prod"})
+	addLabeledResource(db, "app2", "app", map[string]string{"env": "prod"})
+
+	// Import resource records with different labels and without labels. Permission records leave the labels alone.
+	data := strings.Join([]string{
+		`{"kind":"resource","resource_type":"app","resource_name":"app1","labels":{"env":"qa"}}`,
+		`{"kind":"resource","resource_type":"app","resource_name":"app2"}`,
+		`{"kind":"permission","resource_type":"app","resource_name":"app1","subject_type":"user","subject_id":"s1",` +
+			`"permission_level":"own"}`,
+	}, "\n")
+	importAll(t, db, bulk.FormatJSONL, data)
+
+	// Verify the labels.
+	expected := map[string]map[string]string{"app1": {"env": "qa"}, "app2": nil}
+	for _, resource := range listResources(db, nil, nil).Resources {
+		if !permsdb.SameLabels(resource.Labels, expected[*resource.Name]) {
+			t.Errorf("unexpected labels for %s: %v", *resource.Name, resource.Labels)
+		}
+	}
+}
+
+func TestImportIdempotent(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	addDefaultPermissions(db)
+	original := exportAll(t, db, bulk.FormatCSV)
+
+	// Importing the existing contents of the database shouldn't change anything.
+	importAll(t, db, bulk.FormatCSV, original)
+	importAll(t, db, bulk.FormatCSV, original)
+	if actual := exportAll(t, db, bulk.FormatCSV); actual != original {
+		t.Errorf("unexpected export after import:\n%s", actual)
+	}
+	if n := len(listPermissions(db).Permissions); n != 14 {
+		t.Errorf("unexpected number of permissions: %d", n)
+	}
+}
+
+func TestImportUpdatesExisting(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	putPermission(db, "user", "s1", "app", "app1", "read")
+
+	// Import records that change the permission and the resource type description, and add a new permission.
+	data := strings.Join([]string{
+		`{"kind":"resource_type","resource_type":"app","description":"A DE app."}`,
+		`{"kind":"permission","resource_type":"app","resource_name":"app1","subject_type":"user","subject_id":"s1",` +
+			`"permission_level":"own"}`,
+		`{"kind":"permission","resource_type":"app","resource_name":"app2","subject_type":"group","subject_id":"g1",` +
+			`"permission_level":"write"}`,
+	}, "\n")
+	importAll(t, db, bulk.FormatJSONL, data)
+
+	// Verify the permissions.
+	permissions := listPermissions(db).Permissions
+	if len(permissions) != 2 {
+		t.Fatalf("unexpected number of permissions: %d", len(permissions))
+	}
+	checkPerm(t, permissions, 0, "app2", "g1", "write")
+	checkPerm(t, permissions, 1, "app1", "s1", "own")
+
+	// Verify the resource type description.
+	export := exportAll(t, db, bulk.FormatJSONL)
+	if !strings.Contains(export, `"description":"A DE app."`) {
+		t.Errorf("resource type description not updated:\n%s", export)
+	}
+}
+
+func TestImportCSVColumnOrder(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+
+	// Import a CSV file with reordered and omitted columns.
+	data := "resource_type,kind,description\nfoo,resource_type,The foo resource type.\n"
+	summary := importAll(t, db, bulk.FormatCSV, data)
+	if summary.ResourceTypes != 1 {
+		t.Errorf("unexpected import summary: %+v", summary)
+	}
+	if export := exportAll(t, db, bulk.FormatJSONL); !strings.Contains(export, "The foo resource type.") {
+		t.Errorf("unexpected export after import:\n%s", export)
+	}
+}
+
+func TestImportInvalidRecords(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	tests := []struct {
+		format string
+		data   string
+		record int
+	}{
+		{bulk.FormatJSONL, `{"kind":"foo"}`, 1},
+		{bulk.FormatJSONL, `{"kind":"subject","subject_type":"user"}`, 1},
+		{bulk.FormatJSONL, `{"kind":"subject","subject_type":"robot","subject_id":"r1"}`, 1},
+		{bulk.FormatJSONL, "{\"kind\":\"subject\",\"subject_type\":\"user\",\"subject_id\":\"s1\"}\n{\"kind\":", 2},
+		{bulk.FormatJSONL, `{"kind":"resource","resource_type":"foo","resource_name":"bar"}`, 1},
+		{bulk.FormatJSONL, `{"kind":"subject","subjectid":"s1"}`, 1},
+		{
+			bulk.FormatJSONL,
+			"{\"kind\":\"subject\",\"subject_type\":\"user\",\"subject_id\":\"s1\"}\n" +
+				"{\"kind\":\"subject\",\"subject_type\":\"group\",\"subject_id\":\"s1\"}",
+			2,
+		},
+		{
+			bulk.FormatJSONL,
+			`{"kind":"permission","resource_type":"app","resource_name":"a","subject_type":"user","subject_id":"s1",` +
+				`"permission_level":"superuser"}`,
+			1,
+		},
+		{bulk.FormatJSONL, `{"kind":"resource","resource_type":"app","resource_name":"a","labels":{"-env":"x"}}`, 1},
+		{bulk.FormatCSV, "kind,foo\nsubject,bar\n", 1},
+		{bulk.FormatCSV, "kind,resource_type,resource_name,labels\nresource,app,a,env\n", 1},
+		{bulk.FormatCSV, "", 1},
+	}
+
+	for _, test := range tests {
+		_, err := importAllAttempt(db, test.format, test.data)
+		var invalidRecord *bulk.InvalidRecordError
+		if !errors.As(err, &invalidRecord) {
+			t.Errorf("%s: unexpected error: %v", test.data, err)
+			continue
+		}
+		if invalidRecord.Record != test.record {
+			t.Errorf("%s: unexpected record number: %d", test.data, invalidRecord.Record)
+		}
+	}
+
+	// Nothing should have been imported.
+	if n := len(listPermissions(db).Permissions); n != 0 {
+		t.Errorf("unexpected number of permissions: %d", n)
+	}
+	if n := len(listSubjects(db, nil, nil).Subjects); n != 0 {
+		t.Errorf("unexpected number of subjects: %d", n)
+	}
+}
+
+func TestBulkEndpoints(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	addDefaultPermissions(db)
+	server := httptest.NewServer(bulk.WithEndpoints(http.NotFoundHandler(), db))
+	defer server.Close()
+
+	// Export the database.
+	resp, err := http.Get(server.URL + bulk.ExportPath + "?format=csv")
+	if err != nil {
+		t.Fatalf("export request failed: %s", err)
+	}
+	var export bytes.Buffer
+	export.ReadFrom(resp.Body) // nolint:errcheck
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected export status code: %d", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/csv" {
+		t.Errorf("unexpected export content type: %s", contentType)
+	}
+	if export.String() != exportAll(t, db, bulk.FormatCSV) {
+		t.Errorf("unexpected export:\n%s", export.String())
+	}
+
+	// Verify that unsupported formats and methods are rejected.
+	statusCode := func(method, url, body string) int {
+		req, err := http.NewRequest(method, server.URL+url, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("unable to create the request: %s", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %s", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := statusCode(http.MethodGet, bulk.ExportPath+"?format=xml", ""); code != http.StatusBadRequest {
+		t.Errorf("unexpected status code for an unsupported format: %d", code)
+	}
+	if code := statusCode(http.MethodGet, bulk.ImportPath, ""); code != http.StatusMethodNotAllowed {
+		t.Errorf("unexpected status code for an unsupported method: %d", code)
+	}
+	if code := statusCode(http.MethodPost, bulk.ImportPath, `{"kind":"foo"}`); code != http.StatusBadRequest {
+		t.Errorf("unexpected status code for an invalid record: %d", code)
+	}
+
+	// A dry run shouldn't change anything.
+	body := `{"kind":"permission","resource_type":"app","resource_name":"app9","subject_type":"user",` +
+		`"subject_id":"s9","permission_level":"read"}`
+	if code := statusCode(http.MethodPost, bulk.ImportPath+"?dry_run=true", body); code != http.StatusAccepted {
+		t.Errorf("unexpected status code for a dry run: %d", code)
+	}
+	if n := len(listPermissions(db).Permissions); n != 14 {
+		t.Errorf("unexpected number of permissions after a dry run: %d", n)
+	}
+
+	// Import the permission.
+	resp, err = http.Post(server.URL+bulk.ImportPath+"?format=jsonl", "application/x-ndjson", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("import request failed: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected import status code: %d", resp.StatusCode)
+	}
+	var summary bulk.Summary
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		t.Fatalf("unable to decode the import summary: %s", err)
+	}
+	if summary.Permissions != 1 {
+		t.Errorf("unexpected import summary: %+v", summary)
+	}
+	if n := len(listPermissions(db).Permissions); n != 15 {
+		t.Errorf("unexpected number of permissions after an import: %d", n)
+	}
+}
+
+// txOptionsStore is a store that records the options used to start each transaction.
+type txOptionsStore struct {
+	permsdb.Store
+	opts []*sql.TxOptions
+}
+
+func (s *txOptionsStore) BeginTx(ctx context.Context, opts *sql.TxOptions) (permsdb.Tx, error) {
+	s.opts = append(s.opts, opts)
+	return s.Store.BeginTx(ctx, opts)
+}
+
+func TestBulkExportSnapshot(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	store := &txOptionsStore{Store: db}
+	server := httptest.NewServer(bulk.WithEndpoints(http.NotFoundHandler(), store))
+	defer server.Close()
+
+	// Export the database.
+	resp, err := http.Get(server.URL + bulk.ExportPath)
+	if err != nil {
+		t.Fatalf("export request failed: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected export status code: %d", resp.StatusCode)
+	}
+
+	// The export should have used a single read-only, repeatable read transaction.
+	if len(store.opts) != 1 || !reflect.DeepEqual(store.opts[0], bulk.ExportTxOptions()) {
+		t.Errorf("unexpected transaction options: %+v", store.opts)
+	}
+	if opts := bulk.ExportTxOptions(); !opts.ReadOnly || opts.Isolation != sql.LevelRepeatableRead {
+		t.Errorf("unexpected export transaction options: %+v", opts)
+	}
+}
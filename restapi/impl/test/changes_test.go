@@ -0,0 +1,277 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyverse-de/permissions/restapi/impl/changes"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+func newChangeFeedServer(t *testing.T, db permsdb.Store) *httptest.Server {
+	feed := changes.NewFeed(db, 10*time.Millisecond)
+	server := httptest.NewServer(changes.WithEndpoint(http.NotFoundHandler(), feed))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func latestChangeSeq(t *testing.T, db permsdb.Store) int64 {
+	tx, err := db.BeginReadOnly(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	seq, err := tx.LatestChangeSeq()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return seq
+}
+
+func getChanges(t *testing.T, server *httptest.Server, query string) (int, *changes.Response) {
+	resp, err := http.Get(server.URL + changes.Path + query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	var result changes.Response
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, &result
+}
+
+// describeChange summarizes a change for comparison.
+func describeChange(c *permsdb.Change) string {
+	return fmt.Sprintf("%s %s:%s %s:%s %s",
+		c.ChangeType, c.SubjectType, c.SubjectID, c.ResourceType, c.ResourceName, c.PermissionLevel)
+}
+
+func TestChangeFeed(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	server := newChangeFeedServer(t, db)
+	since := latestChangeSeq(t, db)
+
+	// Make some changes.
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	putPermission(db, "user", "s1", "app", "app1", "write")
+	putPermission(db, "user", "s2", "app", "app2", "own")
+	copyPermissions(db, "user", "s1", "user", "s3")
+	revokePermission(db, "user", "s1", "app", "app1")
+	deleteSubjectByExternalID(db, "s3", "user")
+	name := "app2"
+	resource := listResources(db, nil, &name).Resources[0]
+	updateResource(db, *resource.ID, "app3")
+
+	// Verify that the changes were recorded.
+	code, result := getChanges(t, server, fmt.Sprintf("?since=%d", since))
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", code)
+	}
+	expected := []string{
+		"granted user:s1 app:app1 read",
+		"updated user:s1 app:app1 write",
+		"granted user:s2 app:app2 own",
+		"granted user:s3 app:app1 write",
+		"revoked user:s1 app:app1 write",
+		"revoked user:s3 app:app1 write",
+		"revoked user:s2 app:app2 own",
+		"granted user:s2 app:app3 own",
+	}
+	if len(result.Changes) != len(expected) {
+		t.Fatalf("unexpected changes: %d", len(result.Changes))
+	}
+	for i, change := range result.Changes {
+		if actual := describeChange(change); actual != expected[i] {
+			t.Errorf("unexpected change %d: %s", i, actual)
+		}
+		if change.Seq != since+int64(i)+1 {
+			t.Errorf("unexpected sequence number for change %d: %d", i, change.Seq)
+		}
+	}
+	if result.LastSeq != since+int64(len(expected)) {
+		t.Errorf("unexpected last sequence number: %d", result.LastSeq)
+	}
+
+	// The limit should be honored, and the last sequence number should be the sequence number of the last change.
+	_, result = getChanges(t, server, fmt.Sprintf("?since=%d&limit=2", since+1))
+	if len(result.Changes) != 2 || result.LastSeq != since+3 {
+		t.Errorf("unexpected result: %d changes, last sequence number %d", len(result.Changes), result.LastSeq)
+	}
+
+	// The latest sequence number should be returned if no sequence number is given.
+	_, result = getChanges(t, server, "")
+	if len(result.Changes) != 0 || result.LastSeq != since+int64(len(expected)) {
+		t.Errorf("unexpected result: %d changes, last sequence number %d", len(result.Changes), result.LastSeq)
+	}
+
+	// Invalid parameters should be rejected.
+	for _, query := range []string{"?since=-1", "?since=x", "?since=0&limit=0", "?since=0&wait=1h"} {
+		if code, _ := getChanges(t, server, query); code != http.StatusBadRequest {
+			t.Errorf("unexpected status code for %s: %d", query, code)
+		}
+	}
+}
+
+func TestChangeFeedDryRun(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	since := latestChangeSeq(t, db)
+
+	// Changes that are rolled back shouldn't be recorded.
+	revokePermissionDryRun(db, "user", "s1", "app", "app1")
+	if seq := latestChangeSeq(t, db); seq != since {
+		t.Errorf("unexpected latest sequence number: %d", seq)
+	}
+}
+
+func TestChangeFeedExpired(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	server := newChangeFeedServer(t, db)
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	putPermission(db, "user", "s1", "app", "app2", "read")
+	latest := latestChangeSeq(t, db)
+
+	// Prune all of the changes.
+	tx, err := db.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.PruneChanges(time.Now().Add(time.Hour)); err != nil {
+		tx.Rollback() // nolint:errcheck
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests for the pruned changes should fail, but the latest sequence number should still be usable.
+	for query, expected := range map[string]int{
+		fmt.Sprintf("?since=%d", latest-1): http.StatusGone,
+		fmt.Sprintf("?since=%d", latest):   http.StatusOK,
+		fmt.Sprintf("?since=%d", latest+1): http.StatusGone,
+	} {
+		if code, _ := getChanges(t, server, query); code != expected {
+			t.Errorf("unexpected status code for %s: %d", query, code)
+		}
+	}
+	if _, result := getChanges(t, server, ""); result.LastSeq != latest {
+		t.Errorf("unexpected last sequence number: %d", result.LastSeq)
+	}
+}
+
+func TestChangeFeedWait(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	server := newChangeFeedServer(t, db)
+	since := latestChangeSeq(t, db)
+
+	// Make a change while the request is waiting.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		putPermission(db, "user", "s1", "app", "app1", "read")
+	}()
+	_, result := getChanges(t, server, fmt.Sprintf("?since=%d&wait=10s", since))
+	if len(result.Changes) != 1 || describeChange(result.Changes[0]) != "granted user:s1 app:app1 read" {
+		t.Errorf("unexpected changes: %v", result.Changes)
+	}
+
+	// The request should return once the wait time has passed even if there are no changes.
+	_, result = getChanges(t, server, fmt.Sprintf("?since=%d&wait=50ms", since+1))
+	if len(result.Changes) != 0 || result.LastSeq != since+1 {
+		t.Errorf("unexpected result: %d changes, last sequence number %d", len(result.Changes), result.LastSeq)
+	}
+}
+
+func TestChangeFeedEvents(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	server := newChangeFeedServer(t, db)
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	since := latestChangeSeq(t, db)
+
+	// Start streaming events.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+changes.Path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Last-Event-ID", fmt.Sprint(since-1))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected content type: %s", resp.Header.Get("Content-Type"))
+	}
+
+	// The stream should resume after the last event ID and include changes made after it started.
+	putPermission(db, "user", "s2", "app", "app1", "write")
+	scanner := bufio.NewScanner(resp.Body)
+	var events []string
+	for len(events) < 2 && scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "data: ") {
+			var change permsdb.Change
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &change); err != nil {
+				t.Fatal(err)
+			}
+			events = append(events, fmt.Sprintf("%d %s", change.Seq, describeChange(&change)))
+		}
+	}
+
+	expected := []string{
+		fmt.Sprintf("%d granted user:s1 app:app1 read", since),
+		fmt.Sprintf("%d granted user:s2 app:app1 write", since+1),
+	}
+	if strings.Join(events, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected events: %v", events)
+	}
+}
+
+// primaryOnlyStore is a store that refuses to start transactions that could be served by a read replica.
+type primaryOnlyStore struct {
+	permsdb.Store
+}
+
+func (s *primaryOnlyStore) BeginReadOnly(ctx context.Context) (permsdb.Tx, error) {
+	return nil, errors.New("the read replica shouldn't be used")
+}
+
+func TestChangeFeedUsesPrimary(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	server := newChangeFeedServer(t, &primaryOnlyStore{Store: db})
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	latest := latestChangeSeq(t, db)
+
+	// The feed should be read from the primary database, which can't lag behind the sequence numbers it has issued.
+	if _, result := getChanges(t, server, ""); result == nil || result.LastSeq != latest {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if code, result := getChanges(t, server, fmt.Sprintf("?since=%d", latest-1)); code != http.StatusOK {
+		t.Errorf("unexpected status code: %d", code)
+	} else if len(result.Changes) != 1 {
+		t.Errorf("unexpected changes: %v", result.Changes)
+	}
+}
@@ -6,6 +6,11 @@ import (
 	"os"
 	"testing"
 
+	"github.com/cyverse-de/permissions/migrations"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/db/memory"
+	"github.com/cyverse-de/permissions/restapi/impl/db/sqlite"
+
 	_ "github.com/lib/pq"
 )
 
@@ -31,12 +36,26 @@ func schema() string {
 	return "permissions"
 }
 
+func migrate(db *sql.DB, schema string) error {
+	migrator, err := migrations.NewMigrator(db, schema)
+	if err != nil {
+		return err
+	}
+	_, err = migrator.Up()
+	return err
+}
+
+// truncateTables removes all rows from the tables used by the tests. The table names are qualified with the schema
+// name if one is provided.
 func truncateTables(db *sql.DB, schema string) error {
 
 	// Truncate all tables.
 	tables := []string{"permissions", "subjects", "resources", "resource_types"}
 	for _, table := range tables {
-		_, err := db.Exec(fmt.Sprintf("DELETE FROM %s.%s", schema, table))
+		if schema != "" {
+			table = fmt.Sprintf("%s.%s", schema, table)
+		}
+		_, err := db.Exec(fmt.Sprintf("DELETE FROM %s", table))
 		if err != nil {
 			return err
 		}
@@ -45,21 +64,76 @@ func truncateTables(db *sql.DB, schema string) error {
 	return nil
 }
 
-func initdb(t *testing.T) (*sql.DB, string) {
-	db, err := sql.Open("postgres", dburi())
+// The store that the tests are currently being run against, along with a function to remove all of its contents.
+var store permsdb.Store
+var clearStore func() error
+
+// TestMain runs the tests against an in-memory store, a SQLite database and, if integration tests are enabled, a
+// PostgreSQL database.
+func TestMain(m *testing.M) {
+
+	// Run the tests against the in-memory store.
+	memoryStore := memory.NewStore()
+	store = memoryStore
+	clearStore = func() error {
+		memoryStore.Clear()
+		return nil
+	}
+	code := m.Run()
+	if code != 0 {
+		os.Exit(code)
+	}
+
+	// Run the tests against an in-memory SQLite database.
+	sqliteDB, err := sql.Open(sqlite.DriverName, ":memory:")
 	if err != nil {
-		t.Error(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	err = db.Ping()
+	sqliteStore, err := sqlite.NewStore(sqliteDB)
 	if err != nil {
-		t.Error(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	store = sqliteStore
+	clearStore = func() error {
+		return truncateTables(sqliteDB, "")
+	}
+	code = m.Run()
+	sqliteDB.Close()
+	if code != 0 || !shouldRun() {
+		os.Exit(code)
 	}
 
+	// Connect to the database.
+	db, err := sql.Open("postgres", dburi())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Make sure that the schema is up to date.
 	dbschema := schema()
+	if err := migrate(db, dbschema); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
-	if err := truncateTables(db, dbschema); err != nil {
-		t.Error(err)
+	// Run the tests against the database.
+	store = permsdb.NewPostgresStore(db, dbschema)
+	clearStore = func() error {
+		return truncateTables(db, dbschema)
 	}
+	code = m.Run()
 
-	return db, dbschema
+	db.Close()
+	os.Exit(code)
+}
+
+func initdb(t *testing.T) permsdb.Store {
+	if err := clearStore(); err != nil {
+		t.Fatal(err)
+	}
+	return store
 }
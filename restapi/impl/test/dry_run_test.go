@@ -0,0 +1,330 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/operations/permissions"
+	"github.com/cyverse-de/permissions/restapi/operations/resource_types"
+	"github.com/cyverse-de/permissions/restapi/operations/resources"
+	"github.com/cyverse-de/permissions/restapi/operations/subjects"
+
+	permsimpl "github.com/cyverse-de/permissions/restapi/impl/permissions"
+	resourcesimpl "github.com/cyverse-de/permissions/restapi/impl/resources"
+	rtimpl "github.com/cyverse-de/permissions/restapi/impl/resourcetypes"
+	subjectsimpl "github.com/cyverse-de/permissions/restapi/impl/subjects"
+)
+
+var dryRun = true
+
+func grantPermissionDryRun(
+	db permsdb.Store,
+	subject *models.SubjectIn,
+	resource *models.ResourceIn,
+	level models.PermissionLevel,
+) *models.ChangeSet {
+
+	// Build the request handler.
+	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
+	handler := permsimpl.BuildGrantPermissionHandler(db, grouperClient)
+
+	// Preview adding the permission.
+	req := &models.PermissionGrantRequest{Subject: subject, Resource: resource, PermissionLevel: &level}
+	params := permissions.GrantPermissionParams{PermissionGrantRequest: req, DryRun: &dryRun}
+	return handler(params).(*permissions.GrantPermissionAccepted).Payload
+}
+
+func putPermissionDryRun(
+	db permsdb.Store, subjectType, subjectID, resourceType, resourceName, level string,
+) *models.ChangeSet {
+
+	// Build the request handler.
+	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
+	handler := permsimpl.BuildPutPermissionHandler(db, grouperClient)
+
+	// Preview putting the permission.
+	permissionLevel := models.PermissionLevel(level)
+	params := permissions.PutPermissionParams{
+		SubjectType:  subjectType,
+		SubjectID:    subjectID,
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		Permission:   &models.PermissionPutRequest{PermissionLevel: &permissionLevel},
+		DryRun:       &dryRun,
+	}
+	return handler(params).(*permissions.PutPermissionAccepted).Payload
+}
+
+func revokePermissionDryRun(
+	db permsdb.Store, subjectType, subjectID, resourceType, resourceName string,
+) *models.ChangeSet {
+
+	// Build the request handler.
+	handler := permsimpl.BuildRevokePermissionHandler(db)
+
+	// Preview revoking the permission.
+	params := permissions.RevokePermissionParams{
+		SubjectType:  subjectType,
+		SubjectID:    subjectID,
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		DryRun:       &dryRun,
+	}
+	return handler(params).(*permissions.RevokePermissionAccepted).Payload
+}
+
+func copyPermissionsDryRun(db permsdb.Store, sourceType, sourceID, destType, destID string) *models.ChangeSet {
+
+	// Build the request handler.
+	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
+	handler := permsimpl.BuildCopyPermissionsHandler(db, grouperClient)
+
+	// Preview copying the permissions.
+	destinationSubjectType := models.SubjectType(destType)
+	destinationSubjectID := models.ExternalSubjectID(destID)
+	dest := models.SubjectIn{
+		SubjectType: &destinationSubjectType,
+		SubjectID:   &destinationSubjectID,
+	}
+	params := permissions.CopyPermissionsParams{
+		SubjectType:  sourceType,
+		SubjectID:    sourceID,
+		DestSubjects: &models.SubjectsIn{Subjects: []*models.SubjectIn{&dest}},
+		DryRun:       &dryRun,
+	}
+	return handler(params).(*permissions.CopyPermissionsAccepted).Payload
+}
+
+func replaceResourcePermissionsDryRun(
+	db permsdb.Store, resourceType, resourceName string, entries []*models.ResourceACLEntry,
+) *models.ChangeSet {
+
+	// Build the request handler.
+	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
+	handler := permsimpl.BuildReplaceResourcePermissionsHandler(db, grouperClient)
+
+	// Preview replacing the permissions.
+	params := permissions.ReplaceResourcePermissionsParams{
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		ACL:          &models.ResourceACLIn{Permissions: entries},
+		DryRun:       &dryRun,
+	}
+	return handler(params).(*permissions.ReplaceResourcePermissionsAccepted).Payload
+}
+
+func deleteSubjectDryRun(db permsdb.Store, id models.InternalSubjectID) *models.ChangeSet {
+	handler := subjectsimpl.BuildDeleteSubjectHandler(db)
+	params := subjects.DeleteSubjectParams{ID: string(id), DryRun: &dryRun}
+	return handler(params).(*subjects.DeleteSubjectAccepted).Payload
+}
+
+func deleteResourceByNameDryRun(db permsdb.Store, resourceTypeName, name string) *models.ChangeSet {
+	handler := resourcesimpl.BuildDeleteResourceByNameHandler(db)
+	params := resources.DeleteResourceByNameParams{
+		ResourceTypeName: resourceTypeName,
+		ResourceName:     name,
+		DryRun:           &dryRun,
+	}
+	return handler(params).(*resources.DeleteResourceByNameAccepted).Payload
+}
+
+func deleteResourceTypeDryRun(db permsdb.Store, id string) *models.ChangeSet {
+	handler := rtimpl.BuildResourceTypesIDDeleteHandler(db)
+	params := resource_types.DeleteResourceTypesIDParams{ID: id, DryRun: &dryRun}
+	return handler(params).(*resource_types.DeleteResourceTypesIDAccepted).Payload
+}
+
+func TestGrantPermissionDryRun(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Preview granting a permission to a new subject for a new resource.
+	subject := newSubjectIn("s1", "user")
+	resource := newResourceIn("r1", "app")
+	changes := grantPermissionDryRun(db, subject, resource, "own")
+
+	// Verify the change set.
+	if len(changes.AddedSubjects) != 1 {
+		t.Errorf("unexpected number of added subjects: %d", len(changes.AddedSubjects))
+	}
+	if len(changes.AddedResources) != 1 {
+		t.Errorf("unexpected number of added resources: %d", len(changes.AddedResources))
+	}
+	if len(changes.AddedPermissions) != 1 {
+		t.Fatalf("unexpected number of added permissions: %d", len(changes.AddedPermissions))
+	}
+	checkPerm(t, changes.AddedPermissions, 0, "r1", "s1", "own")
+
+	// Verify that nothing was added.
+	if perms := listPermissions(db).Permissions; len(perms) != 0 {
+		t.Errorf("unexpected number of permissions: %d", len(perms))
+	}
+	if subjectList := listSubjects(db, nil, nil).Subjects; len(subjectList) != 0 {
+		t.Errorf("unexpected number of subjects: %d", len(subjectList))
+	}
+}
+
+func TestPutPermissionDryRun(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	putPermission(db, "user", "s1", "app", "app1", "read")
+
+	// Preview changing the permission level.
+	changes := putPermissionDryRun(db, "user", "s1", "app", "app1", "write")
+	if len(changes.UpdatedPermissions) != 1 {
+		t.Fatalf("unexpected number of updated permissions: %d", len(changes.UpdatedPermissions))
+	}
+	update := changes.UpdatedPermissions[0]
+	if *update.PreviousLevel != "read" {
+		t.Errorf("unexpected previous permission level: %s", *update.PreviousLevel)
+	}
+	if *update.Permission.PermissionLevel != "write" {
+		t.Errorf("unexpected permission level: %s", *update.Permission.PermissionLevel)
+	}
+
+	// Verify that the permission level wasn't changed.
+	perms := listPermissions(db).Permissions
+	if len(perms) != 1 {
+		t.Fatalf("unexpected number of permissions: %d", len(perms))
+	}
+	checkPerm(t, perms, 0, "app1", "s1", "read")
+}
+
+func TestRevokePermissionDryRun(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	putPermission(db, "user", "s1", "app", "app1", "read")
+
+	// Preview revoking the permission.
+	changes := revokePermissionDryRun(db, "user", "s1", "app", "app1")
+	if len(changes.RemovedPermissions) != 1 {
+		t.Fatalf("unexpected number of removed permissions: %d", len(changes.RemovedPermissions))
+	}
+	checkPerm(t, changes.RemovedPermissions, 0, "app1", "s1", "read")
+
+	// Verify that the permission still exists.
+	if perms := listPermissions(db).Permissions; len(perms) != 1 {
+		t.Errorf("unexpected number of permissions: %d", len(perms))
+	}
+}
+
+func TestCopyPermissionsDryRun(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	addDefaultPermissions(db)
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	putPermission(db, "user", "s1", "app", "app2", "own")
+
+	// Preview copying permissions from subject s2 to subject s1.
+	changes := copyPermissionsDryRun(db, "user", "s2", "user", "s1")
+	if len(changes.AddedPermissions) != 2 {
+		t.Fatalf("unexpected number of added permissions: %d", len(changes.AddedPermissions))
+	}
+	if len(changes.UpdatedPermissions) != 1 {
+		t.Fatalf("unexpected number of updated permissions: %d", len(changes.UpdatedPermissions))
+	}
+	update := changes.UpdatedPermissions[0]
+	if *update.Permission.Resource.Name != "app1" || *update.PreviousLevel != "read" {
+		t.Errorf("unexpected permission update: %s, %s", *update.Permission.Resource.Name, *update.PreviousLevel)
+	}
+
+	// Verify that nothing was copied.
+	perms := listSubjectPermissions(db, "user", "s1").Permissions
+	if len(perms) != 2 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+	checkPerm(t, perms, 0, "app1", "s1", "read")
+	checkPerm(t, perms, 1, "app2", "s1", "own")
+}
+
+func TestDeleteSubjectDryRun(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	s1 := putPermission(db, "user", "s1", "app", "app1", "read").Subject
+
+	// Preview deleting the subject.
+	changes := deleteSubjectDryRun(db, *s1.ID)
+	if len(changes.RemovedSubjects) != 1 {
+		t.Errorf("unexpected number of removed subjects: %d", len(changes.RemovedSubjects))
+	}
+	if len(changes.RemovedPermissions) != 1 {
+		t.Errorf("unexpected number of removed permissions: %d", len(changes.RemovedPermissions))
+	}
+
+	// Verify that the subject wasn't deleted.
+	if subjectList := listSubjects(db, nil, nil).Subjects; len(subjectList) != 1 {
+		t.Errorf("unexpected number of subjects: %d", len(subjectList))
+	}
+}
+
+func TestDeleteResourceDryRun(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	putPermission(db, "user", "s2", "app", "app1", "own")
+
+	// Preview deleting the resource.
+	changes := deleteResourceByNameDryRun(db, "app", "app1")
+	if len(changes.RemovedResources) != 1 {
+		t.Errorf("unexpected number of removed resources: %d", len(changes.RemovedResources))
+	}
+	if len(changes.RemovedPermissions) != 2 {
+		t.Errorf("unexpected number of removed permissions: %d", len(changes.RemovedPermissions))
+	}
+
+	// Verify that the resource wasn't deleted.
+	if resourceList := listResources(db, nil, nil).Resources; len(resourceList) != 1 {
+		t.Errorf("unexpected number of resources: %d", len(resourceList))
+	}
+}
+
+func TestDeleteResourceTypeDryRun(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	rt := addResourceType(db, "rt1", "rt1")
+
+	// Preview deleting the resource type.
+	changes := deleteResourceTypeDryRun(db, *rt.ID)
+	if len(changes.RemovedResourceTypes) != 1 {
+		t.Fatalf("unexpected number of removed resource types: %d", len(changes.RemovedResourceTypes))
+	}
+	if *changes.RemovedResourceTypes[0].Name != "rt1" {
+		t.Errorf("unexpected resource type name: %s", *changes.RemovedResourceTypes[0].Name)
+	}
+
+	// Verify that the resource type wasn't deleted.
+	if resourceTypes := listResourceTypes(db, nil).ResourceTypes; len(resourceTypes) != 1 {
+		t.Errorf("unexpected number of resource types: %d", len(resourceTypes))
+	}
+}
+
+func TestReplaceResourcePermissionsDryRun(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	addDefaultPermissions(db)
+
+	// Preview replacing the access control list for app1.
+	entries := []*models.ResourceACLEntry{aclEntry("user", "s2", "own"), aclEntry("user", "s4", "read")}
+	changes := replaceResourcePermissionsDryRun(db, "app", "app1", entries)
+	if len(changes.AddedPermissions) != 1 {
+		t.Errorf("unexpected number of added permissions: %d", len(changes.AddedPermissions))
+	}
+	if len(changes.RemovedPermissions) != 3 {
+		t.Errorf("unexpected number of removed permissions: %d", len(changes.RemovedPermissions))
+	}
+
+	// Verify that the access control list wasn't modified.
+	if perms := listResourcePermissions(db, "app", "app1").Permissions; len(perms) != 4 {
+		t.Errorf("unexpected number of results: %d", len(perms))
+	}
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+)
+
+func TestGetOrAdd(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	tx, err := db.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("unable to start a transaction: %s", err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	// The subject should only be added the first time.
+	for i, expected := range []bool{true, false} {
+		subject, added, err := permsdb.GetOrAddSubject(tx, "s1", models.SubjectTypeUser)
+		if err != nil {
+			t.Fatalf("unable to get or add the subject: %s", err)
+		}
+		if added != expected || string(*subject.SubjectID) != "s1" {
+			t.Errorf("unexpected result %d: %+v (added: %t)", i, subject, added)
+		}
+	}
+
+	// The resource should only be added the first time.
+	for i, expected := range []bool{true, false} {
+		resource, added, err := permsdb.GetOrAddResource(tx, "app", "a1")
+		if err != nil {
+			t.Fatalf("unable to get or add the resource: %s", err)
+		}
+		if added != expected || *resource.Name != "a1" {
+			t.Errorf("unexpected result %d: %+v (added: %t)", i, resource, added)
+		}
+	}
+}
+
+func TestGetOrAddErrors(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	tx, err := db.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("unable to start a transaction: %s", err)
+	}
+	defer tx.Rollback() // nolint:errcheck
+	if _, _, err := permsdb.GetOrAddSubject(tx, "s1", models.SubjectTypeUser); err != nil {
+		t.Fatalf("unable to add the subject: %s", err)
+	}
+
+	var invalidArgument *permsdb.InvalidArgumentError
+	if _, _, err := permsdb.GetOrAddSubject(tx, "", models.SubjectTypeUser); !errors.As(err, &invalidArgument) {
+		t.Errorf("unexpected error for an empty subject ID: %v", err)
+	}
+	if _, _, err := permsdb.GetOrAddSubject(tx, "s1", models.SubjectTypeGroup); !errors.As(err, &invalidArgument) {
+		t.Errorf("unexpected error for a conflicting subject: %v", err)
+	}
+	if _, _, err := permsdb.GetOrAddResource(tx, "app", ""); !errors.As(err, &invalidArgument) {
+		t.Errorf("unexpected error for an empty resource name: %v", err)
+	}
+
+	var notFound *permsdb.NotFoundError
+	if _, _, err := permsdb.GetOrAddResource(tx, "tool", "t1"); !errors.As(err, &notFound) {
+		t.Errorf("unexpected error for an unknown resource type: %v", err)
+	}
+}
@@ -2,8 +2,7 @@
 package test
 
 import (
-	"database/sql"
-	"fmt"
+	"context"
 	"testing"
 
 	"github.com/cyverse-de/permissions/models"
@@ -11,23 +10,18 @@ import (
 	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
 )
 
-func addDefaultResourceType(tx *sql.Tx, name, description string, t *testing.T) {
+func addDefaultResourceType(tx permsdb.Tx, name, description string, t *testing.T) {
 	rt := &models.ResourceTypeIn{Name: &name, Description: description}
-	if _, err := permsdb.AddNewResourceType(tx, rt); err != nil {
+	if _, err := tx.AddNewResourceType(rt); err != nil {
 		tx.Rollback()
 		t.Fatalf("unable to add default resource types: %s", err)
 	}
 }
 
-func addDefaultResourceTypes(db *sql.DB, schema string, t *testing.T) {
+func addDefaultResourceTypes(db permsdb.Store, t *testing.T) {
 
 	// Start a transaction.
-	tx, err := db.Begin()
-	if err != nil {
-		t.Fatalf("unable to add default resource types: %s", err)
-	}
-
-	_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+	tx, err := db.Begin(context.Background())
 	if err != nil {
 		t.Fatalf("unable to add default resource types: %s", err)
 	}
@@ -43,21 +37,16 @@ func addDefaultResourceTypes(db *sql.DB, schema string, t *testing.T) {
 	}
 }
 
-func addTestResource(db *sql.DB, schema, name, resourceType string, t *testing.T) {
+func addTestResource(db permsdb.Store, name, resourceType string, t *testing.T) {
 
 	// Start a Transaction.
-	tx, err := db.Begin()
-	if err != nil {
-		t.Fatalf("unable to add a resource: %s", err)
-	}
-
-	_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
+	tx, err := db.Begin(context.Background())
 	if err != nil {
 		t.Fatalf("unable to add a resource: %s", err)
 	}
 
 	// Get the resource type.
-	rt, err := permsdb.GetResourceTypeByName(tx, &resourceType)
+	rt, err := tx.GetResourceTypeByName(&resourceType)
 	if err != nil {
 		tx.Rollback()
 		t.Fatalf("unable to add a resource: %s", err)
@@ -68,7 +57,7 @@ func addTestResource(db *sql.DB, schema, name, resourceType string, t *testing.T
 	}
 
 	// Insert the resource.
-	if _, err := permsdb.AddResource(tx, &name, rt.ID); err != nil {
+	if _, err := tx.AddResource(&name, rt.ID); err != nil {
 		tx.Rollback()
 		t.Fatalf("unable to add a resource: %s", err)
 	}
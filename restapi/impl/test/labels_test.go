@@ -0,0 +1,298 @@
+package test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/operations/permissions"
+	"github.com/cyverse-de/permissions/restapi/operations/resources"
+	middleware "github.com/go-openapi/runtime/middleware"
+
+	permsimpl "github.com/cyverse-de/permissions/restapi/impl/permissions"
+	resourcesimpl "github.com/cyverse-de/permissions/restapi/impl/resources"
+)
+
+func addLabeledResourceAttempt(
+	db permsdb.Store, name, resourceType string, labels map[string]string,
+) middleware.Responder {
+	handler := resourcesimpl.BuildAddResourceHandler(db)
+	resourceIn := &models.ResourceIn{Name: &name, ResourceType: &resourceType, Labels: labels}
+	return handler(resources.AddResourceParams{ResourceIn: resourceIn})
+}
+
+func addLabeledResource(db permsdb.Store, name, resourceType string, labels map[string]string) *models.ResourceOut {
+	return addLabeledResourceAttempt(db, name, resourceType, labels).(*resources.AddResourceCreated).Payload
+}
+
+func updateResourceLabelsAttempt(db permsdb.Store, id, name string, labels map[string]string) middleware.Responder {
+	handler := resourcesimpl.BuildUpdateResourceHandler(db)
+	resourceUpdate := &models.ResourceUpdate{Name: &name, Labels: labels}
+	return handler(resources.UpdateResourceParams{ID: id, ResourceUpdate: resourceUpdate})
+}
+
+func listResourcesBySelectorAttempt(db permsdb.Store, selector string) middleware.Responder {
+	handler := resourcesimpl.BuildListResourcesHandler(db)
+	return handler(resources.ListResourcesParams{LabelSelector: &selector})
+}
+
+func listPermissionsBySelectorAttempt(db permsdb.Store, selector string) middleware.Responder {
+	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
+	handler := permsimpl.BuildListPermissionsHandler(db, grouperClient)
+	return handler(permissions.ListPermissionsParams{LabelSelector: &selector})
+}
+
+func bySubjectAndResourceTypeBySelectorAttempt(
+	db permsdb.Store, subjectID, resourceType, selector string, minLevel *string,
+) middleware.Responder {
+	handler := permsimpl.BuildBySubjectAndResourceTypeHandler(db, grouper.Grouper(mockGrouperClient))
+	lookup := true
+	params := permissions.BySubjectAndResourceTypeParams{
+		SubjectType:   "user",
+		SubjectID:     subjectID,
+		ResourceType:  resourceType,
+		Lookup:        &lookup,
+		MinLevel:      minLevel,
+		LabelSelector: &selector,
+	}
+	return handler(params)
+}
+
+func bySubjectAndResourceTypeAbbreviatedBySelectorAttempt(
+	db permsdb.Store, subjectID, resourceType, selector string,
+) middleware.Responder {
+	handler := permsimpl.BuildBySubjectAndResourceTypeAbbreviatedHandler(db, grouper.Grouper(mockGrouperClient))
+	lookup := true
+	params := permissions.BySubjectAndResourceTypeAbbreviatedParams{
+		SubjectType:   "user",
+		SubjectID:     subjectID,
+		ResourceType:  resourceType,
+		Lookup:        &lookup,
+		LabelSelector: &selector,
+	}
+	return handler(params)
+}
+
+// resourceNames returns the sorted, comma-separated names of a list of resources.
+func resourceNames(resources []*models.ResourceOut) string {
+	names := make([]string, len(resources))
+	for i, resource := range resources {
+		names[i] = *resource.Name
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// permissionResourceNames returns the sorted, comma-separated resource names of a list of permissions.
+func permissionResourceNames(perms []*models.Permission) string {
+	names := make([]string, len(perms))
+	for i, perm := range perms {
+		names[i] = *perm.Resource.Name
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// addLabeledResources adds some labeled resources along with permissions for them.
+func addLabeledResources(db permsdb.Store) {
+	addLabeledResource(db, "app1", "app", map[string]string{"env": "prod", "team": "de"})
+	addLabeledResource(db, "app2", "app", map[string]string{"env": "qa", "team": "de"})
+	addLabeledResource(db, "app3", "app", map[string]string{"env": "prod"})
+	addLabeledResource(db, "app4", "app", nil)
+	putPermission(db, "user", "s2", "app", "app1", "own")
+	putPermission(db, "user", "s2", "app", "app2", "read")
+	putPermission(db, "group", "g1id", "app", "app3", "write")
+	putPermission(db, "user", "s2", "app", "app4", "read")
+}
+
+func TestResourceLabels(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// The labels should be returned when the resource is added.
+	labels := map[string]string{"env": "prod", "example.org/team": "de"}
+	resource := addLabeledResource(db, "app1", "app", labels)
+	if !permsdb.SameLabels(resource.Labels, labels) {
+		t.Errorf("unexpected labels: %v", resource.Labels)
+	}
+
+	// The labels should be returned when the resource is retrieved.
+	got := getResourceAttempt(db, *resource.ID).(*resources.GetResourceOK).Payload
+	if !permsdb.SameLabels(got.Labels, labels) {
+		t.Errorf("unexpected labels: %v", got.Labels)
+	}
+
+	// The labels should be kept if they're omitted from an update.
+	etag := getResourceETag(db, *resource.ID)
+	updated := updateResourceLabelsAttempt(db, *resource.ID, "app1", nil).(*resources.UpdateResourceOK).Payload
+	if !permsdb.SameLabels(updated.Labels, labels) {
+		t.Errorf("unexpected labels: %v", updated.Labels)
+	}
+	if actual := getResourceETag(db, *resource.ID); actual != etag {
+		t.Errorf("unexpected ETag change: %s", actual)
+	}
+
+	// The labels should be replaced if they're included in an update, and the version should change.
+	labels = map[string]string{"env": "qa"}
+	updated = updateResourceLabelsAttempt(db, *resource.ID, "app1", labels).(*resources.UpdateResourceOK).Payload
+	if !permsdb.SameLabels(updated.Labels, labels) {
+		t.Errorf("unexpected labels: %v", updated.Labels)
+	}
+	if actual := getResourceETag(db, *resource.ID); actual == etag {
+		t.Error("the ETag didn't change when the labels changed")
+	}
+
+	// The labels should be removed if an empty set of labels is included in an update.
+	responder := updateResourceLabelsAttempt(db, *resource.ID, "app1", map[string]string{})
+	updated = responder.(*resources.UpdateResourceOK).Payload
+	if len(updated.Labels) != 0 {
+		t.Errorf("unexpected labels: %v", updated.Labels)
+	}
+	if listed := listResources(db, nil, nil).Resources; len(listed) != 1 || len(listed[0].Labels) != 0 {
+		t.Errorf("unexpected resources: %v", listed)
+	}
+}
+
+func TestInvalidResourceLabels(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Invalid labels should be rejected when a resource is added.
+	responder := addLabeledResourceAttempt(db, "app1", "app", map[string]string{"env": "prod/qa"})
+	if _, ok := responder.(*resources.AddResourceBadRequest); !ok {
+		t.Fatalf("unexpected response: %T", responder)
+	}
+	if len(listResourcesDirectly(db, t)) != 0 {
+		t.Error("a resource with invalid labels was added")
+	}
+
+	// Invalid labels should be rejected when a resource is updated.
+	resource := addResource(db, "app1", "app")
+	responder = updateResourceLabelsAttempt(db, *resource.ID, "app1", map[string]string{"-env": "prod"})
+	if _, ok := responder.(*resources.UpdateResourceBadRequest); !ok {
+		t.Fatalf("unexpected response: %T", responder)
+	}
+}
+
+func TestListResourcesByLabel(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	addLabeledResources(db)
+
+	for selector, expected := range map[string]string{
+		"":                      "app1,app2,app3,app4",
+		"env=prod":              "app1,app3",
+		"env!=prod":             "app2,app4",
+		"env in (prod, qa)":     "app1,app2,app3",
+		"env notin (qa)":        "app1,app3,app4",
+		"team":                  "app1,app2",
+		"!team":                 "app3,app4",
+		"env=prod,team=de":      "app1",
+		"env=prod,!team":        "app3",
+		"env=staging":           "",
+		"env in (prod),team!=x": "app1,app3",
+	} {
+		responder := listResourcesBySelectorAttempt(db, selector)
+		ok, isOK := responder.(*resources.ListResourcesOK)
+		if !isOK {
+			t.Errorf("unexpected response for %q: %T", selector, responder)
+			continue
+		}
+		if actual := resourceNames(ok.Payload.Resources); actual != expected {
+			t.Errorf("unexpected resources for %q: %s", selector, actual)
+		}
+	}
+
+	// Invalid selectors should be rejected.
+	responder := listResourcesBySelectorAttempt(db, "env in (prod")
+	if _, ok := responder.(*resources.ListResourcesBadRequest); !ok {
+		t.Errorf("unexpected response: %T", responder)
+	}
+}
+
+func TestListPermissionsByLabel(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	addLabeledResources(db)
+
+	responder := listPermissionsBySelectorAttempt(db, "env=prod")
+	ok, isOK := responder.(*permissions.ListPermissionsOK)
+	if !isOK {
+		t.Fatalf("unexpected response: %T", responder)
+	}
+	if actual := permissionResourceNames(ok.Payload.Permissions); actual != "app1,app3" {
+		t.Errorf("unexpected permissions: %s", actual)
+	}
+
+	// Invalid selectors should be rejected.
+	responder = listPermissionsBySelectorAttempt(db, "=prod")
+	if _, ok := responder.(*permissions.ListPermissionsBadRequest); !ok {
+		t.Errorf("unexpected response: %T", responder)
+	}
+}
+
+func TestBySubjectAndResourceTypeByLabel(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	addLabeledResources(db)
+
+	// Look up the permissions without a minimum level.
+	responder := bySubjectAndResourceTypeBySelectorAttempt(db, "s2", "app", "env=prod", nil)
+	ok, isOK := responder.(*permissions.BySubjectAndResourceTypeOK)
+	if !isOK {
+		t.Fatalf("unexpected response: %T", responder)
+	}
+	if actual := permissionResourceNames(ok.Payload.Permissions); actual != "app1,app3" {
+		t.Errorf("unexpected permissions: %s", actual)
+	}
+
+	// Look up the permissions with a minimum level.
+	minLevel := "write"
+	responder = bySubjectAndResourceTypeBySelectorAttempt(db, "s2", "app", "team", &minLevel)
+	ok, isOK = responder.(*permissions.BySubjectAndResourceTypeOK)
+	if !isOK {
+		t.Fatalf("unexpected response: %T", responder)
+	}
+	if actual := permissionResourceNames(ok.Payload.Permissions); actual != "app1" {
+		t.Errorf("unexpected permissions: %s", actual)
+	}
+
+	// Invalid selectors should be rejected.
+	responder = bySubjectAndResourceTypeBySelectorAttempt(db, "s2", "app", "env==", nil)
+	if _, ok := responder.(*permissions.BySubjectAndResourceTypeBadRequest); ok {
+		t.Errorf("an empty value was rejected")
+	}
+	responder = bySubjectAndResourceTypeBySelectorAttempt(db, "s2", "app", "env=a b", nil)
+	if _, ok := responder.(*permissions.BySubjectAndResourceTypeBadRequest); !ok {
+		t.Errorf("unexpected response: %T", responder)
+	}
+}
+
+func TestBySubjectAndResourceTypeAbbreviatedByLabel(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	addLabeledResources(db)
+
+	responder := bySubjectAndResourceTypeAbbreviatedBySelectorAttempt(db, "s2", "app", "env notin (prod)")
+	ok, isOK := responder.(*permissions.BySubjectAndResourceTypeAbbreviatedOK)
+	if !isOK {
+		t.Fatalf("unexpected response: %T", responder)
+	}
+	names := make([]string, len(ok.Payload.Permissions))
+	for i, perm := range ok.Payload.Permissions {
+		names[i] = *perm.ResourceName
+	}
+	sort.Strings(names)
+	if actual := strings.Join(names, ","); actual != "app2,app4" {
+		t.Errorf("unexpected permissions: %s", actual)
+	}
+
+	// Invalid selectors should be rejected.
+	responder = bySubjectAndResourceTypeAbbreviatedBySelectorAttempt(db, "s2", "app", "in (prod)")
+	if _, ok := responder.(*permissions.BySubjectAndResourceTypeAbbreviatedBadRequest); !ok {
+		t.Errorf("unexpected response: %T", responder)
+	}
+}
@@ -1,11 +1,14 @@
 package test
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"testing"
 
 	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
 	impl "github.com/cyverse-de/permissions/restapi/impl/permissions"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 	middleware "github.com/go-openapi/runtime/middleware"
@@ -25,10 +28,10 @@ var groupMemberships = map[string][]*grouper.GroupInfo{
 
 var mockGrouperClient = grouper.NewMockGrouperClient(groupMemberships)
 
-func bySubjectAttempt(db *sql.DB, schema, subjectType, subjectID string, lookup bool, minLevel *string) middleware.Responder {
+func bySubjectAttempt(db permsdb.Store, subjectType, subjectID string, lookup bool, minLevel *string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildBySubjectHandler(db, grouper.Grouper(mockGrouperClient), schema)
+	handler := impl.BuildBySubjectHandler(db, grouper.Grouper(mockGrouperClient))
 
 	// Attempt to look up the permissions.
 	params := permissions.BySubjectParams{
@@ -40,17 +43,17 @@ func bySubjectAttempt(db *sql.DB, schema, subjectType, subjectID string, lookup
 	return handler(params)
 }
 
-func bySubject(db *sql.DB, schema, subjectType, subjectID string, lookup bool, minLevel *string) *models.PermissionList {
-	responder := bySubjectAttempt(db, schema, subjectType, subjectID, lookup, minLevel)
+func bySubject(db permsdb.Store, subjectType, subjectID string, lookup bool, minLevel *string) *models.PermissionList {
+	responder := bySubjectAttempt(db, subjectType, subjectID, lookup, minLevel)
 	return responder.(*permissions.BySubjectOK).Payload
 }
 
 func bySubjectAndResourceTypeAttempt(
-	db *sql.DB, schema, subjectType, subjectID, resourceType string, lookup bool, minLevel *string,
+	db permsdb.Store, subjectType, subjectID, resourceType string, lookup bool, minLevel *string,
 ) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildBySubjectAndResourceTypeHandler(db, grouper.Grouper(mockGrouperClient), schema)
+	handler := impl.BuildBySubjectAndResourceTypeHandler(db, grouper.Grouper(mockGrouperClient))
 
 	// Attempt to look up the permissions.
 	params := permissions.BySubjectAndResourceTypeParams{
@@ -64,18 +67,18 @@ func bySubjectAndResourceTypeAttempt(
 }
 
 func bySubjectAndResourceType(
-	db *sql.DB, schema, subjectType, subjectID, resourceType string, lookup bool, minLevel *string,
+	db permsdb.Store, subjectType, subjectID, resourceType string, lookup bool, minLevel *string,
 ) *models.PermissionList {
-	responder := bySubjectAndResourceTypeAttempt(db, schema, subjectType, subjectID, resourceType, lookup, minLevel)
+	responder := bySubjectAndResourceTypeAttempt(db, subjectType, subjectID, resourceType, lookup, minLevel)
 	return responder.(*permissions.BySubjectAndResourceTypeOK).Payload
 }
 
 func bySubjectAndResourceAttempt(
-	db *sql.DB, schema, subjectType, subjectID, resourceType, resourceName string, lookup bool, minLevel *string,
+	db permsdb.Store, subjectType, subjectID, resourceType, resourceName string, lookup bool, minLevel *string,
 ) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildBySubjectAndResourceHandler(db, grouper.Grouper(mockGrouperClient), schema)
+	handler := impl.BuildBySubjectAndResourceHandler(db, grouper.Grouper(mockGrouperClient))
 
 	// Attempt to look up the permissions.
 	params := permissions.BySubjectAndResourceParams{
@@ -90,28 +93,24 @@ func bySubjectAndResourceAttempt(
 }
 
 func bySubjectAndResource(
-	db *sql.DB, schema, subjectType, subjectID, resourceType, resourceName string, lookup bool, minLevel *string,
+	db permsdb.Store, subjectType, subjectID, resourceType, resourceName string, lookup bool, minLevel *string,
 ) *models.PermissionList {
-	responder := bySubjectAndResourceAttempt(db, schema, subjectType, subjectID, resourceType, resourceName, lookup, minLevel)
+	responder := bySubjectAndResourceAttempt(db, subjectType, subjectID, resourceType, resourceName, lookup, minLevel)
 	return responder.(*permissions.BySubjectAndResourceOK).Payload
 }
 
 func TestBySubject(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "r1", "own")
-	putPermission(db, schema, "group", "g1id", "analysis", "r2", "read")
-	putPermission(db, schema, "group", "g2id", "analysis", "r3", "read")
+	putPermission(db, "user", "s2", "app", "r1", "own")
+	putPermission(db, "group", "g1id", "analysis", "r2", "read")
+	putPermission(db, "group", "g2id", "analysis", "r3", "read")
 
 	// Look up the permissions and verify that we get the expected number of results.
-	perms := bySubject(db, schema, "user", "s2", true, nil).Permissions
+	perms := bySubject(db, "user", "s2", true, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -122,22 +121,18 @@ func TestBySubject(t *testing.T) {
 }
 
 func TestBySubjectMultiplePermissions(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "r1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "r1", "read")
-	putPermission(db, schema, "user", "s2", "analysis", "r2", "read")
-	putPermission(db, schema, "group", "g1id", "analysis", "r2", "write")
+	putPermission(db, "user", "s2", "app", "r1", "own")
+	putPermission(db, "group", "g1id", "app", "r1", "read")
+	putPermission(db, "user", "s2", "analysis", "r2", "read")
+	putPermission(db, "group", "g1id", "analysis", "r2", "write")
 
 	// Look up the permissions and verify that we get the expected number of results.
-	perms := bySubject(db, schema, "user", "s2", true, nil).Permissions
+	perms := bySubject(db, "user", "s2", true, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -148,22 +143,18 @@ func TestBySubjectMultiplePermissions(t *testing.T) {
 }
 
 func TestBySubjectIncorrectSubjectType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "r1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "r1", "read")
-	putPermission(db, schema, "user", "s2", "analysis", "r2", "read")
-	putPermission(db, schema, "group", "g1id", "analysis", "r2", "write")
+	putPermission(db, "user", "s2", "app", "r1", "own")
+	putPermission(db, "group", "g1id", "app", "r1", "read")
+	putPermission(db, "user", "s2", "analysis", "r2", "read")
+	putPermission(db, "group", "g1id", "analysis", "r2", "write")
 
 	// Attempt the lookup.
-	responder := bySubjectAttempt(db, schema, "group", "s2", true, nil)
+	responder := bySubjectAttempt(db, "group", "s2", true, nil)
 	errorOut := responder.(*permissions.BySubjectBadRequest).Payload
 	expected := "incorrect type for subject, s2: group"
 	if *errorOut.Reason != expected {
@@ -172,23 +163,19 @@ func TestBySubjectIncorrectSubjectType(t *testing.T) {
 }
 
 func TestBySubjectGroupsNotTransitive(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "r1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "r1", "read")
-	putPermission(db, schema, "user", "s2", "analysis", "r2", "read")
-	putPermission(db, schema, "group", "g1id", "analysis", "r2", "write")
-	putPermission(db, schema, "group", "g2id", "analysis", "r3", "own")
+	putPermission(db, "user", "s2", "app", "r1", "own")
+	putPermission(db, "group", "g1id", "app", "r1", "read")
+	putPermission(db, "user", "s2", "analysis", "r2", "read")
+	putPermission(db, "group", "g1id", "analysis", "r2", "write")
+	putPermission(db, "group", "g2id", "analysis", "r3", "own")
 
 	// Look up permissions and verify that we get the expected number of results.
-	perms := bySubject(db, schema, "group", "g1id", true, nil).Permissions
+	perms := bySubject(db, "group", "g1id", true, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -199,23 +186,19 @@ func TestBySubjectGroupsNotTransitive(t *testing.T) {
 }
 
 func TestBySubjectNonLookup(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "r1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "r1", "read")
-	putPermission(db, schema, "user", "s2", "analysis", "r2", "read")
-	putPermission(db, schema, "group", "g1id", "analysis", "r2", "write")
-	putPermission(db, schema, "group", "g2id", "analysis", "r3", "own")
+	putPermission(db, "user", "s2", "app", "r1", "own")
+	putPermission(db, "group", "g1id", "app", "r1", "read")
+	putPermission(db, "user", "s2", "analysis", "r2", "read")
+	putPermission(db, "group", "g1id", "analysis", "r2", "write")
+	putPermission(db, "group", "g2id", "analysis", "r3", "own")
 
 	// List permissions for s2 and verify that we get the expected results.
-	perms := bySubject(db, schema, "user", "s2", false, nil).Permissions
+	perms := bySubject(db, "user", "s2", false, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -225,7 +208,7 @@ func TestBySubjectNonLookup(t *testing.T) {
 	checkPerm(t, perms, 1, "r2", "s2", "read")
 
 	// List permissions for g1id and verify that we get the expected results.
-	perms = bySubject(db, schema, "group", "g1id", false, nil).Permissions
+	perms = bySubject(db, "group", "g1id", false, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -236,27 +219,23 @@ func TestBySubjectNonLookup(t *testing.T) {
 }
 
 func TestBySubjectMinLevel(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "r1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "r1", "read")
-	putPermission(db, schema, "user", "s2", "analysis", "r2", "read")
-	putPermission(db, schema, "group", "g1id", "analysis", "r2", "write")
-	putPermission(db, schema, "group", "g2id", "analysis", "r3", "own")
-	putPermission(db, schema, "user", "s2", "app", "r4", "read")
+	putPermission(db, "user", "s2", "app", "r1", "own")
+	putPermission(db, "group", "g1id", "app", "r1", "read")
+	putPermission(db, "user", "s2", "analysis", "r2", "read")
+	putPermission(db, "group", "g1id", "analysis", "r2", "write")
+	putPermission(db, "group", "g2id", "analysis", "r3", "own")
+	putPermission(db, "user", "s2", "app", "r4", "read")
 
 	// The minimum level for this search.
 	minLevel := "write"
 
 	// List permissions for s2 and verify that we get the expected number of results.
-	perms := bySubject(db, schema, "user", "s2", true, &minLevel).Permissions
+	perms := bySubject(db, "user", "s2", true, &minLevel).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -267,28 +246,24 @@ func TestBySubjectMinLevel(t *testing.T) {
 }
 
 func TestBySubjectAndResourceType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "app1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "app1", "read")
-	putPermission(db, schema, "user", "s2", "app", "app2", "read")
-	putPermission(db, schema, "group", "g1id", "app", "app2", "write")
-	putPermission(db, schema, "group", "g2id", "app", "app3", "own")
-	putPermission(db, schema, "user", "s2", "analysis", "analysis1", "own")
-	putPermission(db, schema, "group", "g1id", "analysis", "analysis1", "read")
-	putPermission(db, schema, "user", "s2", "analysis", "analysis2", "read")
-	putPermission(db, schema, "group", "g1id", "analysis", "analysis2", "write")
-	putPermission(db, schema, "group", "g2id", "analysis", "analysis3", "own")
+	putPermission(db, "user", "s2", "app", "app1", "own")
+	putPermission(db, "group", "g1id", "app", "app1", "read")
+	putPermission(db, "user", "s2", "app", "app2", "read")
+	putPermission(db, "group", "g1id", "app", "app2", "write")
+	putPermission(db, "group", "g2id", "app", "app3", "own")
+	putPermission(db, "user", "s2", "analysis", "analysis1", "own")
+	putPermission(db, "group", "g1id", "analysis", "analysis1", "read")
+	putPermission(db, "user", "s2", "analysis", "analysis2", "read")
+	putPermission(db, "group", "g1id", "analysis", "analysis2", "write")
+	putPermission(db, "group", "g2id", "analysis", "analysis3", "own")
 
 	// Look up app permissions and verify that we get the expected number of results.
-	perms := bySubjectAndResourceType(db, schema, "user", "s2", "app", true, nil).Permissions
+	perms := bySubjectAndResourceType(db, "user", "s2", "app", true, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -298,7 +273,7 @@ func TestBySubjectAndResourceType(t *testing.T) {
 	checkPerm(t, perms, 1, "app2", "g1id", "write")
 
 	// Look up analysis permissions and verify that we get the expected number of results.
-	perms = bySubjectAndResourceType(db, schema, "user", "s2", "analysis", true, nil).Permissions
+	perms = bySubjectAndResourceType(db, "user", "s2", "analysis", true, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -309,23 +284,19 @@ func TestBySubjectAndResourceType(t *testing.T) {
 }
 
 func TestBySubjectAndResourceTypeIncorrectSubjectType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "app1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "app1", "read")
-	putPermission(db, schema, "user", "s2", "app", "app2", "read")
-	putPermission(db, schema, "group", "g1id", "app", "app2", "write")
-	putPermission(db, schema, "group", "g2id", "app", "app3", "own")
+	putPermission(db, "user", "s2", "app", "app1", "own")
+	putPermission(db, "group", "g1id", "app", "app1", "read")
+	putPermission(db, "user", "s2", "app", "app2", "read")
+	putPermission(db, "group", "g1id", "app", "app2", "write")
+	putPermission(db, "group", "g2id", "app", "app3", "own")
 
 	// Look up permissions and verify that we get the expected number of results.
-	responder := bySubjectAndResourceTypeAttempt(db, schema, "group", "s2", "app", true, nil)
+	responder := bySubjectAndResourceTypeAttempt(db, "group", "s2", "app", true, nil)
 	errorOut := responder.(*permissions.BySubjectAndResourceTypeBadRequest).Payload
 	expected := "incorrect type for subject, s2: group"
 	if *errorOut.Reason != expected {
@@ -334,46 +305,38 @@ func TestBySubjectAndResourceTypeIncorrectSubjectType(t *testing.T) {
 }
 
 func TestBySubjectAndResourceTypeUnknownResourceType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "app1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "app1", "read")
-	putPermission(db, schema, "user", "s2", "app", "app2", "read")
-	putPermission(db, schema, "group", "g1id", "app", "app2", "write")
-	putPermission(db, schema, "group", "g2id", "app", "app3", "own")
+	putPermission(db, "user", "s2", "app", "app1", "own")
+	putPermission(db, "group", "g1id", "app", "app1", "read")
+	putPermission(db, "user", "s2", "app", "app2", "read")
+	putPermission(db, "group", "g1id", "app", "app2", "write")
+	putPermission(db, "group", "g2id", "app", "app3", "own")
 
 	// Look up permissions and verify that we get the expected number of results.
-	perms := bySubjectAndResourceType(db, schema, "user", "s2", "blargle", true, nil).Permissions
+	perms := bySubjectAndResourceType(db, "user", "s2", "blargle", true, nil).Permissions
 	if len(perms) != 0 {
 		t.Errorf("unexpected number of results: %d", len(perms))
 	}
 }
 
 func TestBySubjectAndResourceTypeGroupsNotTransitive(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "app1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "app1", "read")
-	putPermission(db, schema, "user", "s2", "app", "app2", "read")
-	putPermission(db, schema, "group", "g1id", "app", "app2", "write")
-	putPermission(db, schema, "group", "g2id", "app", "app3", "own")
+	putPermission(db, "user", "s2", "app", "app1", "own")
+	putPermission(db, "group", "g1id", "app", "app1", "read")
+	putPermission(db, "user", "s2", "app", "app2", "read")
+	putPermission(db, "group", "g1id", "app", "app2", "write")
+	putPermission(db, "group", "g2id", "app", "app3", "own")
 
 	// Look up permissions and verify that we get the expected number of results.
-	perms := bySubjectAndResourceType(db, schema, "group", "g1id", "app", true, nil).Permissions
+	perms := bySubjectAndResourceType(db, "group", "g1id", "app", true, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -384,28 +347,24 @@ func TestBySubjectAndResourceTypeGroupsNotTransitive(t *testing.T) {
 }
 
 func TestBySubjectAndResourceTypeNonLookup(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "app1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "app1", "read")
-	putPermission(db, schema, "user", "s2", "app", "app2", "read")
-	putPermission(db, schema, "group", "g1id", "app", "app2", "write")
-	putPermission(db, schema, "group", "g2id", "app", "app3", "own")
-	putPermission(db, schema, "user", "s2", "analysis", "analysis1", "own")
-	putPermission(db, schema, "group", "g1id", "analysis", "analysis1", "read")
-	putPermission(db, schema, "user", "s2", "analysis", "analysis2", "read")
-	putPermission(db, schema, "group", "g1id", "analysis", "analysis2", "write")
-	putPermission(db, schema, "group", "g2id", "analysis", "analysis3", "own")
+	putPermission(db, "user", "s2", "app", "app1", "own")
+	putPermission(db, "group", "g1id", "app", "app1", "read")
+	putPermission(db, "user", "s2", "app", "app2", "read")
+	putPermission(db, "group", "g1id", "app", "app2", "write")
+	putPermission(db, "group", "g2id", "app", "app3", "own")
+	putPermission(db, "user", "s2", "analysis", "analysis1", "own")
+	putPermission(db, "group", "g1id", "analysis", "analysis1", "read")
+	putPermission(db, "user", "s2", "analysis", "analysis2", "read")
+	putPermission(db, "group", "g1id", "analysis", "analysis2", "write")
+	putPermission(db, "group", "g2id", "analysis", "analysis3", "own")
 
 	// Look up the app permissions for s2 and verify that we get the expected number of results.
-	perms := bySubjectAndResourceType(db, schema, "user", "s2", "app", false, nil).Permissions
+	perms := bySubjectAndResourceType(db, "user", "s2", "app", false, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -415,7 +374,7 @@ func TestBySubjectAndResourceTypeNonLookup(t *testing.T) {
 	checkPerm(t, perms, 1, "app2", "s2", "read")
 
 	// Look up the app permissions for g1id and verify that we get the expected number of results.
-	perms = bySubjectAndResourceType(db, schema, "group", "g1id", "app", false, nil).Permissions
+	perms = bySubjectAndResourceType(db, "group", "g1id", "app", false, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -425,7 +384,7 @@ func TestBySubjectAndResourceTypeNonLookup(t *testing.T) {
 	checkPerm(t, perms, 1, "app2", "g1id", "write")
 
 	// Look up the analysis permissions for s2 and verify that we got the expected number of results.
-	perms = bySubjectAndResourceType(db, schema, "user", "s2", "analysis", false, nil).Permissions
+	perms = bySubjectAndResourceType(db, "user", "s2", "analysis", false, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -435,7 +394,7 @@ func TestBySubjectAndResourceTypeNonLookup(t *testing.T) {
 	checkPerm(t, perms, 1, "analysis2", "s2", "read")
 
 	// Look up the analysis permissions for g1id and verify that we get the expected number of results.
-	perms = bySubjectAndResourceType(db, schema, "group", "g1id", "analysis", false, nil).Permissions
+	perms = bySubjectAndResourceType(db, "group", "g1id", "analysis", false, nil).Permissions
 	if len(perms) != 2 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -446,31 +405,27 @@ func TestBySubjectAndResourceTypeNonLookup(t *testing.T) {
 }
 
 func TestBySubjectAndResourceTypeMinLevel(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "app1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "app1", "read")
-	putPermission(db, schema, "user", "s2", "app", "app2", "read")
-	putPermission(db, schema, "group", "g1id", "app", "app2", "write")
-	putPermission(db, schema, "group", "g2id", "app", "app3", "own")
-	putPermission(db, schema, "user", "s2", "analysis", "analysis1", "own")
-	putPermission(db, schema, "group", "g1id", "analysis", "analysis1", "read")
-	putPermission(db, schema, "user", "s2", "analysis", "analysis2", "read")
-	putPermission(db, schema, "group", "g1id", "analysis", "analysis2", "write")
-	putPermission(db, schema, "group", "g2id", "analysis", "analysis3", "own")
+	putPermission(db, "user", "s2", "app", "app1", "own")
+	putPermission(db, "group", "g1id", "app", "app1", "read")
+	putPermission(db, "user", "s2", "app", "app2", "read")
+	putPermission(db, "group", "g1id", "app", "app2", "write")
+	putPermission(db, "group", "g2id", "app", "app3", "own")
+	putPermission(db, "user", "s2", "analysis", "analysis1", "own")
+	putPermission(db, "group", "g1id", "analysis", "analysis1", "read")
+	putPermission(db, "user", "s2", "analysis", "analysis2", "read")
+	putPermission(db, "group", "g1id", "analysis", "analysis2", "write")
+	putPermission(db, "group", "g2id", "analysis", "analysis3", "own")
 
 	// The minimum level for the search.
 	minLevel := "own"
 
 	// Look up app permissions and verify that we get the expected number of results.
-	perms := bySubjectAndResourceType(db, schema, "user", "s2", "app", true, &minLevel).Permissions
+	perms := bySubjectAndResourceType(db, "user", "s2", "app", true, &minLevel).Permissions
 	if len(perms) != 1 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -479,7 +434,7 @@ func TestBySubjectAndResourceTypeMinLevel(t *testing.T) {
 	checkPerm(t, perms, 0, "app1", "s2", "own")
 
 	// Look up analysis permissions and verify that we get the expected number of results.
-	perms = bySubjectAndResourceType(db, schema, "user", "s2", "analysis", true, &minLevel).Permissions
+	perms = bySubjectAndResourceType(db, "user", "s2", "analysis", true, &minLevel).Permissions
 	if len(perms) != 1 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -489,23 +444,19 @@ func TestBySubjectAndResourceTypeMinLevel(t *testing.T) {
 }
 
 func TestBySubjectAndResource(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "app1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "app1", "read")
-	putPermission(db, schema, "user", "s2", "app", "app2", "read")
-	putPermission(db, schema, "group", "g1id", "app", "app2", "write")
-	putPermission(db, schema, "group", "g2id", "app", "app3", "own")
+	putPermission(db, "user", "s2", "app", "app1", "own")
+	putPermission(db, "group", "g1id", "app", "app1", "read")
+	putPermission(db, "user", "s2", "app", "app2", "read")
+	putPermission(db, "group", "g1id", "app", "app2", "write")
+	putPermission(db, "group", "g2id", "app", "app3", "own")
 
 	// Look up permissions for app1 and verify that we get the expected number of results.
-	perms := bySubjectAndResource(db, schema, "user", "s2", "app", "app1", true, nil).Permissions
+	perms := bySubjectAndResource(db, "user", "s2", "app", "app1", true, nil).Permissions
 	if len(perms) != 1 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -514,7 +465,7 @@ func TestBySubjectAndResource(t *testing.T) {
 	checkPerm(t, perms, 0, "app1", "s2", "own")
 
 	// Look up permissions for app2 and verify that we get the expected number of results.
-	perms = bySubjectAndResource(db, schema, "user", "s2", "app", "app2", true, nil).Permissions
+	perms = bySubjectAndResource(db, "user", "s2", "app", "app2", true, nil).Permissions
 	if len(perms) != 1 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -523,30 +474,26 @@ func TestBySubjectAndResource(t *testing.T) {
 	checkPerm(t, perms, 0, "app2", "g1id", "write")
 
 	// Look up permissions for app3 and verify that we get the expected number of results.
-	perms = bySubjectAndResource(db, schema, "user", "s2", "app", "app3", true, nil).Permissions
+	perms = bySubjectAndResource(db, "user", "s2", "app", "app3", true, nil).Permissions
 	if len(perms) != 0 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
 }
 
 func TestBySubjectAndResourceNonLookup(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "app1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "app1", "read")
-	putPermission(db, schema, "user", "s2", "app", "app2", "read")
-	putPermission(db, schema, "group", "g1id", "app", "app2", "write")
-	putPermission(db, schema, "group", "g2id", "app", "app3", "own")
+	putPermission(db, "user", "s2", "app", "app1", "own")
+	putPermission(db, "group", "g1id", "app", "app1", "read")
+	putPermission(db, "user", "s2", "app", "app2", "read")
+	putPermission(db, "group", "g1id", "app", "app2", "write")
+	putPermission(db, "group", "g2id", "app", "app3", "own")
 
 	// Look up permissions for app1 and verify that we get the expected number of results.
-	perms := bySubjectAndResource(db, schema, "user", "s2", "app", "app1", false, nil).Permissions
+	perms := bySubjectAndResource(db, "user", "s2", "app", "app1", false, nil).Permissions
 	if len(perms) != 1 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -555,7 +502,7 @@ func TestBySubjectAndResourceNonLookup(t *testing.T) {
 	checkPerm(t, perms, 0, "app1", "s2", "own")
 
 	// Look up permissions for app2 and verify that we get the expected number of results.
-	perms = bySubjectAndResource(db, schema, "user", "s2", "app", "app2", false, nil).Permissions
+	perms = bySubjectAndResource(db, "user", "s2", "app", "app2", false, nil).Permissions
 	if len(perms) != 1 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -564,33 +511,29 @@ func TestBySubjectAndResourceNonLookup(t *testing.T) {
 	checkPerm(t, perms, 0, "app2", "s2", "read")
 
 	// Look up permissions for app3 and verify that we get the expected number of results.
-	perms = bySubjectAndResource(db, schema, "user", "s2", "app", "app3", true, nil).Permissions
+	perms = bySubjectAndResource(db, "user", "s2", "app", "app3", true, nil).Permissions
 	if len(perms) != 0 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
 }
 
 func TestBySubjectAndResourceMinLevel(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	putPermission(db, schema, "user", "s2", "app", "app1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "app1", "read")
-	putPermission(db, schema, "user", "s2", "app", "app2", "read")
-	putPermission(db, schema, "group", "g1id", "app", "app2", "write")
-	putPermission(db, schema, "group", "g2id", "app", "app3", "own")
+	putPermission(db, "user", "s2", "app", "app1", "own")
+	putPermission(db, "group", "g1id", "app", "app1", "read")
+	putPermission(db, "user", "s2", "app", "app2", "read")
+	putPermission(db, "group", "g1id", "app", "app2", "write")
+	putPermission(db, "group", "g2id", "app", "app3", "own")
 
 	// The  minimum level for the search.
 	minLevel := "own"
 
 	// Look up permissions for app1 and verify that we get the expected number of results.
-	perms := bySubjectAndResource(db, schema, "user", "s2", "app", "app1", true, &minLevel).Permissions
+	perms := bySubjectAndResource(db, "user", "s2", "app", "app1", true, &minLevel).Permissions
 	if len(perms) != 1 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -599,14 +542,120 @@ func TestBySubjectAndResourceMinLevel(t *testing.T) {
 	checkPerm(t, perms, 0, "app1", "s2", "own")
 
 	// Look up permissions for app2 and verify that we get the expected number of results.
-	perms = bySubjectAndResource(db, schema, "user", "s2", "app", "app2", true, &minLevel).Permissions
+	perms = bySubjectAndResource(db, "user", "s2", "app", "app2", true, &minLevel).Permissions
 	if len(perms) != 0 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
 
 	// Look up permissions for app3 and verify that we get the expected number of results.
-	perms = bySubjectAndResource(db, schema, "user", "s2", "app", "app3", true, nil).Permissions
+	perms = bySubjectAndResource(db, "user", "s2", "app", "app3", true, nil).Permissions
 	if len(perms) != 0 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
 }
+
+// countingStore is a store that records the number of transactions of each type that were started.
+type countingStore struct {
+	permsdb.Store
+	readWrite int
+	readOnly  int
+}
+
+func (s *countingStore) Begin(ctx context.Context) (permsdb.Tx, error) {
+	s.readWrite++
+	return s.Store.Begin(ctx)
+}
+
+func (s *countingStore) BeginReadOnly(ctx context.Context) (permsdb.Tx, error) {
+	s.readOnly++
+	return s.Store.BeginReadOnly(ctx)
+}
+
+func TestLookupReadYourWrites(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	putPermission(db, "user", "s2", "app", "app1", "own")
+
+	// Lookups should use read-only transactions by default.
+	store := &countingStore{Store: db}
+	handler := impl.BuildBySubjectHandler(store, grouper.Grouper(mockGrouperClient))
+	lookup := true
+	params := permissions.BySubjectParams{SubjectType: "user", SubjectID: "s2", Lookup: &lookup}
+	perms := handler(params).(*permissions.BySubjectOK).Payload.Permissions
+	if len(perms) != 1 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+	if store.readOnly != 1 || store.readWrite != 0 {
+		t.Errorf("unexpected transactions: %d read-only, %d read-write", store.readOnly, store.readWrite)
+	}
+
+	// The primary database should be used if the caller asks to read its own writes.
+	readYourWrites := true
+	params.XReadYourWrites = &readYourWrites
+	perms = handler(params).(*permissions.BySubjectOK).Payload.Permissions
+	if len(perms) != 1 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+	if store.readOnly != 1 || store.readWrite != 1 {
+		t.Errorf("unexpected transactions: %d read-only, %d read-write", store.readOnly, store.readWrite)
+	}
+}
+
+// brokenReplicaStore is a store whose read-only transactions behave like transactions in a read replica that accepts
+// connections but can't answer queries. The number of read-only transactions started in the primary database is
+// recorded.
+type brokenReplicaStore struct {
+	permsdb.Store
+	primaryReadOnly int
+}
+
+func (s *brokenReplicaStore) BeginReadOnly(ctx context.Context) (permsdb.Tx, error) {
+	tx, err := s.Store.BeginReadOnly(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &brokenReplicaTx{Tx: tx}, nil
+}
+
+func (s *brokenReplicaStore) BeginTx(ctx context.Context, opts *sql.TxOptions) (permsdb.Tx, error) {
+	if opts != nil && opts.ReadOnly {
+		s.primaryReadOnly++
+	}
+	return s.Store.BeginTx(ctx, opts)
+}
+
+type brokenReplicaTx struct {
+	permsdb.Tx
+}
+
+func (t *brokenReplicaTx) InReplica() bool {
+	return true
+}
+
+func (t *brokenReplicaTx) GetSubjectByExternalID(models.ExternalSubjectID) (*models.SubjectOut, error) {
+	return nil, errors.New("the replica can't answer queries")
+}
+
+func TestLookupRetriedInPrimary(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	putPermission(db, "user", "s2", "app", "app1", "own")
+
+	// A lookup that fails in the replica should be run again in the primary database.
+	store := &brokenReplicaStore{Store: db}
+	handler := impl.BuildBySubjectHandler(store, grouper.Grouper(mockGrouperClient))
+	params := permissions.BySubjectParams{SubjectType: "user", SubjectID: "s2"}
+	responder := handler(params)
+	ok, isOK := responder.(*permissions.BySubjectOK)
+	if !isOK {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+	if len(ok.Payload.Permissions) != 1 {
+		t.Fatalf("unexpected number of results: %d", len(ok.Payload.Permissions))
+	}
+	if store.primaryReadOnly != 1 {
+		t.Errorf("unexpected number of read-only transactions in the primary database: %d", store.primaryReadOnly)
+	}
+}
@@ -1,11 +1,11 @@
 package test
 
 import (
-	"database/sql"
 	"testing"
 
 	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
 	"github.com/cyverse-de/permissions/restapi/operations/permissions"
 
 	impl "github.com/cyverse-de/permissions/restapi/impl/permissions"
@@ -26,8 +26,7 @@ func checkPerm(t *testing.T, ps []*models.Permission, i int32, resource, subject
 }
 
 func grantPermissionAttempt(
-	db *sql.DB,
-	schema string,
+	db permsdb.Store,
 	subject *models.SubjectIn,
 	resource *models.ResourceIn,
 	level models.PermissionLevel,
@@ -35,7 +34,7 @@ func grantPermissionAttempt(
 
 	// Build the request handler.
 	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
-	handler := impl.BuildGrantPermissionHandler(db, grouperClient, schema)
+	handler := impl.BuildGrantPermissionHandler(db, grouperClient)
 
 	// Attempt to add the permission.
 	req := &models.PermissionGrantRequest{Subject: subject, Resource: resource, PermissionLevel: &level}
@@ -44,22 +43,21 @@ func grantPermissionAttempt(
 }
 
 func grantPermission(
-	db *sql.DB,
-	schema string,
+	db permsdb.Store,
 	subject *models.SubjectIn,
 	resource *models.ResourceIn,
 	level models.PermissionLevel,
 ) *models.Permission {
-	responder := grantPermissionAttempt(db, schema, subject, resource, level)
+	responder := grantPermissionAttempt(db, subject, resource, level)
 	return responder.(*permissions.GrantPermissionOK).Payload
 }
 
 func revokePermissionAttempt(
-	db *sql.DB, schema, subjectType, subjectID, resourceType, resourceName string,
+	db permsdb.Store, subjectType, subjectID, resourceType, resourceName string,
 ) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildRevokePermissionHandler(db, schema)
+	handler := impl.BuildRevokePermissionHandler(db)
 
 	// Attempt to revoke the permission.
 	params := permissions.RevokePermissionParams{
@@ -71,18 +69,99 @@ func revokePermissionAttempt(
 	return handler(params)
 }
 
-func revokePermission(db *sql.DB, schema, subjectType, subjectID, resourceType, resourceName string) {
-	responder := revokePermissionAttempt(db, schema, subjectType, subjectID, resourceType, resourceName)
+func revokePermission(db permsdb.Store, subjectType, subjectID, resourceType, resourceName string) {
+	responder := revokePermissionAttempt(db, subjectType, subjectID, resourceType, resourceName)
 	_ = responder.(*permissions.RevokePermissionOK)
 }
 
+func revokeSubjectPermissionsAttempt(
+	db permsdb.Store, subjectType, subjectID string, resourceType, minLevel *string,
+) middleware.Responder {
+
+	// Build the request handler.
+	handler := impl.BuildRevokeSubjectPermissionsHandler(db, grouper.Grouper(mockGrouperClient))
+
+	// Attempt to revoke the permissions.
+	params := permissions.RevokeSubjectPermissionsParams{
+		SubjectType:  subjectType,
+		SubjectID:    subjectID,
+		ResourceType: resourceType,
+		MinLevel:     minLevel,
+	}
+	return handler(params)
+}
+
+func revokeSubjectPermissions(
+	db permsdb.Store, subjectType, subjectID string, resourceType, minLevel *string,
+) *models.PermissionList {
+	responder := revokeSubjectPermissionsAttempt(db, subjectType, subjectID, resourceType, minLevel)
+	return responder.(*permissions.RevokeSubjectPermissionsOK).Payload
+}
+
+func revokeResourcePermissionsAttempt(
+	db permsdb.Store, resourceType, resourceName string, excludeOwners bool,
+) middleware.Responder {
+
+	// Build the request handler.
+	handler := impl.BuildRevokeResourcePermissionsHandler(db, grouper.Grouper(mockGrouperClient))
+
+	// Attempt to revoke the permissions.
+	params := permissions.RevokeResourcePermissionsParams{
+		ResourceType:  resourceType,
+		ResourceName:  resourceName,
+		ExcludeOwners: &excludeOwners,
+	}
+	return handler(params)
+}
+
+func revokeResourcePermissions(
+	db permsdb.Store, resourceType, resourceName string, excludeOwners bool,
+) *models.PermissionList {
+	responder := revokeResourcePermissionsAttempt(db, resourceType, resourceName, excludeOwners)
+	return responder.(*permissions.RevokeResourcePermissionsOK).Payload
+}
+
+func replaceResourcePermissionsAttempt(
+	db permsdb.Store, resourceType, resourceName string, ifMatch *string, entries []*models.ResourceACLEntry,
+) middleware.Responder {
+
+	// Build the request handler.
+	handler := impl.BuildReplaceResourcePermissionsHandler(db, grouper.Grouper(mockGrouperClient))
+
+	// Attempt to replace the permissions.
+	params := permissions.ReplaceResourcePermissionsParams{
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		IfMatch:      ifMatch,
+		ACL:          &models.ResourceACLIn{Permissions: entries},
+	}
+	return handler(params)
+}
+
+func replaceResourcePermissions(
+	db permsdb.Store, resourceType, resourceName string, ifMatch *string, entries []*models.ResourceACLEntry,
+) *permissions.ReplaceResourcePermissionsOK {
+	responder := replaceResourcePermissionsAttempt(db, resourceType, resourceName, ifMatch, entries)
+	return responder.(*permissions.ReplaceResourcePermissionsOK)
+}
+
+func aclEntry(subjectType, subjectID, level string) *models.ResourceACLEntry {
+	st := models.SubjectType(subjectType)
+	sid := models.ExternalSubjectID(subjectID)
+	pl := models.PermissionLevel(level)
+	return &models.ResourceACLEntry{
+		Subject:         &models.SubjectIn{SubjectType: &st, SubjectID: &sid},
+		PermissionLevel: &pl,
+	}
+}
+
 func putPermissionAttempt(
-	db *sql.DB, schema, subjectType, subjectID, resourceType, resourceName, level string,
+	db permsdb.Store, subjectType, subjectID, resourceType, resourceName, level string,
 ) middleware.Responder {
 
 	// Build the request handler.
 	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
-	handler := impl.BuildPutPermissionHandler(db, grouperClient, schema)
+	handler := impl.BuildPutPermissionHandler(db, grouperClient)
 
 	// Attempt to put the permission.
 	permissionLevel := models.PermissionLevel(level)
@@ -96,31 +175,31 @@ func putPermissionAttempt(
 	return handler(params)
 }
 
-func putPermission(db *sql.DB, schema, subjectType, subjectID, resourceType, resourceName, level string) *models.Permission {
-	responder := putPermissionAttempt(db, schema, subjectType, subjectID, resourceType, resourceName, level)
+func putPermission(db permsdb.Store, subjectType, subjectID, resourceType, resourceName, level string) *models.Permission {
+	responder := putPermissionAttempt(db, subjectType, subjectID, resourceType, resourceName, level)
 	return responder.(*permissions.PutPermissionOK).Payload
 }
 
-func listPermissionsAttempt(db *sql.DB, schema string) middleware.Responder {
+func listPermissionsAttempt(db permsdb.Store) middleware.Responder {
 
 	// Build the request handler.
 	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
-	handler := impl.BuildListPermissionsHandler(db, grouperClient, schema)
+	handler := impl.BuildListPermissionsHandler(db, grouperClient)
 
 	// Attempt to list the permissions.
 	return handler(permissions.NewListPermissionsParams())
 }
 
-func listPermissions(db *sql.DB, schema string) *models.PermissionList {
-	responder := listPermissionsAttempt(db, schema)
+func listPermissions(db permsdb.Store) *models.PermissionList {
+	responder := listPermissionsAttempt(db)
 	return responder.(*permissions.ListPermissionsOK).Payload
 }
 
-func listResourcePermissionsAttempt(db *sql.DB, schema, resourceType, resourceName string) middleware.Responder {
+func listResourcePermissionsAttempt(db permsdb.Store, resourceType, resourceName string) middleware.Responder {
 
 	// Build the request handler.
 	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
-	handler := impl.BuildListResourcePermissionsHandler(db, grouperClient, schema)
+	handler := impl.BuildListResourcePermissionsHandler(db, grouperClient)
 
 	// Attempt to list the permissions for the resource.
 	params := permissions.ListResourcePermissionsParams{
@@ -130,15 +209,15 @@ func listResourcePermissionsAttempt(db *sql.DB, schema, resourceType, resourceNa
 	return handler(params)
 }
 
-func listResourcePermissions(db *sql.DB, schema, resourceType, resourceName string) *models.PermissionList {
-	responder := listResourcePermissionsAttempt(db, schema, resourceType, resourceName)
+func listResourcePermissions(db permsdb.Store, resourceType, resourceName string) *models.PermissionList {
+	responder := listResourcePermissionsAttempt(db, resourceType, resourceName)
 	return responder.(*permissions.ListResourcePermissionsOK).Payload
 }
 
-func listSubjectPermissionsAttempt(db *sql.DB, schema, subjectType, subjectID string) middleware.Responder {
+func listSubjectPermissionsAttempt(db permsdb.Store, subjectType, subjectID string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildBySubjectHandler(db, grouper.Grouper(mockGrouperClient), schema)
+	handler := impl.BuildBySubjectHandler(db, grouper.Grouper(mockGrouperClient))
 
 	// Attempt to look up the permissions.
 	lookup := false
@@ -151,15 +230,16 @@ func listSubjectPermissionsAttempt(db *sql.DB, schema, subjectType, subjectID st
 	return handler(params)
 }
 
-func listSubjectPermissions(db *sql.DB, schema, subjectType, subjectID string) *models.PermissionList {
-	responder := listSubjectPermissionsAttempt(db, schema, subjectType, subjectID)
+func listSubjectPermissions(db permsdb.Store, subjectType, subjectID string) *models.PermissionList {
+	responder := listSubjectPermissionsAttempt(db, subjectType, subjectID)
 	return responder.(*permissions.BySubjectOK).Payload
 }
 
-func copyPermissionsAttempt(db *sql.DB, schema, sourceType, sourceID, destType, destID string) middleware.Responder {
+func copyPermissionsAttempt(db permsdb.Store, sourceType, sourceID, destType, destID string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildCopyPermissionsHandler(db, schema)
+	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
+	handler := impl.BuildCopyPermissionsHandler(db, grouperClient)
 
 	// Attempt to copy the permissions.
 	destinationSubjectType := models.SubjectType(destType)
@@ -176,59 +256,78 @@ func copyPermissionsAttempt(db *sql.DB, schema, sourceType, sourceID, destType,
 	return handler(params)
 }
 
-func copyPermissions(db *sql.DB, schema, sourceType, sourceID, destType, destID string) middleware.Responder {
-	responder := copyPermissionsAttempt(db, schema, sourceType, sourceID, destType, destID)
+func copyPermissions(db permsdb.Store, sourceType, sourceID, destType, destID string) middleware.Responder {
+	responder := copyPermissionsAttempt(db, sourceType, sourceID, destType, destID)
 	return responder.(*permissions.CopyPermissionsOK)
 }
 
-func addDefaultPermissions(db *sql.DB, schema string) {
-	putPermission(db, schema, "user", "s2", "app", "app1", "own")
-	putPermission(db, schema, "group", "g1id", "app", "app1", "read")
-	putPermission(db, schema, "group", "g2id", "app", "app1", "write")
-	putPermission(db, schema, "user", "s3", "app", "app1", "read")
-	putPermission(db, schema, "user", "s2", "app", "app2", "read")
-	putPermission(db, schema, "group", "g1id", "app", "app2", "write")
-	putPermission(db, schema, "group", "g2id", "app", "app3", "own")
-	putPermission(db, schema, "user", "s2", "analysis", "analysis1", "own")
-	putPermission(db, schema, "group", "g1id", "analysis", "analysis1", "read")
-	putPermission(db, schema, "group", "g2id", "analysis", "analysis1", "write")
-	putPermission(db, schema, "user", "s3", "analysis", "analysis1", "read")
-	putPermission(db, schema, "user", "s2", "analysis", "analysis2", "read")
-	putPermission(db, schema, "group", "g1id", "analysis", "analysis2", "write")
-	putPermission(db, schema, "group", "g2id", "analysis", "analysis3", "own")
-}
+func copyResourcePermissionsAttempt(
+	db permsdb.Store, resourceType, resourceName, destType, destName, mergePolicy string,
+) middleware.Responder {
 
-func TestGrantPermission(t *testing.T) {
-	if !shouldRun() {
-		return
+	// Build the request handler.
+	handler := impl.BuildCopyResourcePermissionsHandler(db)
+
+	// Attempt to copy the permissions.
+	dest := newResourceIn(destName, destType)
+	params := permissions.CopyResourcePermissionsParams{
+		ResourceType:  resourceType,
+		ResourceName:  resourceName,
+		DestResources: &models.ResourcesIn{Resources: []*models.ResourceIn{dest}},
+		MergePolicy:   &mergePolicy,
 	}
+	return handler(params)
+}
 
+func copyResourcePermissions(db permsdb.Store, resourceType, resourceName, destType, destName, mergePolicy string) {
+	responder := copyResourcePermissionsAttempt(db, resourceType, resourceName, destType, destName, mergePolicy)
+	_ = responder.(*permissions.CopyResourcePermissionsOK)
+}
+
+func addDefaultPermissions(db permsdb.Store) {
+	putPermission(db, "user", "s2", "app", "app1", "own")
+	putPermission(db, "group", "g1id", "app", "app1", "read")
+	putPermission(db, "group", "g2id", "app", "app1", "write")
+	putPermission(db, "user", "s3", "app", "app1", "read")
+	putPermission(db, "user", "s2", "app", "app2", "read")
+	putPermission(db, "group", "g1id", "app", "app2", "write")
+	putPermission(db, "group", "g2id", "app", "app3", "own")
+	putPermission(db, "user", "s2", "analysis", "analysis1", "own")
+	putPermission(db, "group", "g1id", "analysis", "analysis1", "read")
+	putPermission(db, "group", "g2id", "analysis", "analysis1", "write")
+	putPermission(db, "user", "s3", "analysis", "analysis1", "read")
+	putPermission(db, "user", "s2", "analysis", "analysis2", "read")
+	putPermission(db, "group", "g1id", "analysis", "analysis2", "write")
+	putPermission(db, "group", "g2id", "analysis", "analysis3", "own")
+}
+
+func TestGrantPermission(t *testing.T) {
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Define a subject.
 	subjectIn := newSubjectIn("s1", "user")
-	subjectOut := addSubject(db, schema, *subjectIn.SubjectID, *subjectIn.SubjectType)
+	subjectOut := addSubject(db, *subjectIn.SubjectID, *subjectIn.SubjectType)
 
 	// Define a resource.
 	resourceIn := newResourceIn("r1", "app")
-	resourceOut := addResource(db, schema, *resourceIn.Name, *resourceIn.ResourceType)
+	resourceOut := addResource(db, *resourceIn.Name, *resourceIn.ResourceType)
 
 	// Grant the subject access to the resource.
-	permission := grantPermission(db, schema, subjectIn, resourceIn, "own")
+	permission := grantPermission(db, subjectIn, resourceIn, "own")
 
 	// Verify that we got the expected result.
 	if len(*permission.ID) != 36 {
 		t.Errorf("unexpected internal permission ID returned: %s", *permission.ID)
 	}
-	if permission.Subject.ID != subjectOut.ID {
+	if *permission.Subject.ID != *subjectOut.ID {
 		t.Errorf("unexpected internal subject ID returned: %s", *permission.Subject.ID)
 	}
-	if permission.Subject.SubjectID != subjectOut.SubjectID {
+	if *permission.Subject.SubjectID != *subjectOut.SubjectID {
 		t.Errorf("unexpected external subject ID returned: %s", *permission.Subject.SubjectID)
 	}
-	if permission.Subject.SubjectType != subjectOut.SubjectType {
+	if *permission.Subject.SubjectType != *subjectOut.SubjectType {
 		t.Errorf("unexpedted subject type returned: %s", *permission.Subject.SubjectType)
 	}
 	if *permission.Resource.ID != *resourceOut.ID {
@@ -246,27 +345,23 @@ func TestGrantPermission(t *testing.T) {
 }
 
 func TestListPermissions(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Define a subject.
 	subjectIn := newSubjectIn("s1", "user")
-	subjectOut := addSubject(db, schema, *subjectIn.SubjectID, *subjectIn.SubjectType)
+	subjectOut := addSubject(db, *subjectIn.SubjectID, *subjectIn.SubjectType)
 
 	// Define a resource.
 	resourceIn := newResourceIn("r1", "app")
-	resourceOut := addResource(db, schema, *resourceIn.Name, *resourceIn.ResourceType)
+	resourceOut := addResource(db, *resourceIn.Name, *resourceIn.ResourceType)
 
 	// Grant the subject access to the resource.
-	_ = grantPermission(db, schema, subjectIn, resourceIn, "own")
+	_ = grantPermission(db, subjectIn, resourceIn, "own")
 
 	// List the permissions and verify that we get the expected number of results.
-	permissions := listPermissions(db, schema).Permissions
+	permissions := listPermissions(db).Permissions
 	if len(permissions) != 1 {
 		t.Fatalf("unexpected number of permissions listed: %d", len(permissions))
 	}
@@ -276,13 +371,13 @@ func TestListPermissions(t *testing.T) {
 	if len(*permission.ID) != 36 {
 		t.Errorf("unexpected internal permission ID returned: %s", *permission.ID)
 	}
-	if permission.Subject.ID != subjectOut.ID {
+	if *permission.Subject.ID != *subjectOut.ID {
 		t.Errorf("unexpected internal subject ID listed: %s", *permission.Subject.ID)
 	}
-	if permission.Subject.SubjectID != subjectOut.SubjectID {
+	if *permission.Subject.SubjectID != *subjectOut.SubjectID {
 		t.Errorf("unexpected external subject ID listed: %s", *permission.Subject.SubjectID)
 	}
-	if permission.Subject.SubjectType != subjectOut.SubjectType {
+	if *permission.Subject.SubjectType != *subjectOut.SubjectType {
 		t.Errorf("unexpedted subject type listed: %s", *permission.Subject.SubjectType)
 	}
 	if *permission.Resource.ID != *resourceOut.ID {
@@ -300,26 +395,22 @@ func TestListPermissions(t *testing.T) {
 }
 
 func TestAutoInsertSubject(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Create, but don't register, a subject.
 	subjectIn := newSubjectIn("s1", "user")
 
 	// Define a resource.
 	resourceIn := newResourceIn("r1", "app")
-	resourceOut := addResource(db, schema, *resourceIn.Name, *resourceIn.ResourceType)
+	resourceOut := addResource(db, *resourceIn.Name, *resourceIn.ResourceType)
 
 	// Grant the subject access to the resource.
-	_ = grantPermission(db, schema, subjectIn, resourceIn, "own")
+	_ = grantPermission(db, subjectIn, resourceIn, "own")
 
 	// List the permissions and verify that we get the expected number of results.
-	permissions := listPermissions(db, schema).Permissions
+	permissions := listPermissions(db).Permissions
 	if len(permissions) != 1 {
 		t.Fatalf("unexpected number of permissions listed: %d", len(permissions))
 	}
@@ -332,10 +423,10 @@ func TestAutoInsertSubject(t *testing.T) {
 	if len(*permission.Subject.ID) != 36 {
 		t.Errorf("unexpected internal subject ID listed: %s", *permission.Subject.ID)
 	}
-	if permission.Subject.SubjectID != subjectIn.SubjectID {
+	if *permission.Subject.SubjectID != *subjectIn.SubjectID {
 		t.Errorf("unexpected external subject ID listed: %s", *permission.Subject.SubjectID)
 	}
-	if permission.Subject.SubjectType != subjectIn.SubjectType {
+	if *permission.Subject.SubjectType != *subjectIn.SubjectType {
 		t.Errorf("unexpedted subject type listed: %s", *permission.Subject.SubjectType)
 	}
 	if *permission.Resource.ID != *resourceOut.ID {
@@ -353,26 +444,22 @@ func TestAutoInsertSubject(t *testing.T) {
 }
 
 func TestAutoInsertResource(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Define a subject.
 	subjectIn := newSubjectIn("s1", "user")
-	subjectOut := addSubject(db, schema, *subjectIn.SubjectID, *subjectIn.SubjectType)
+	subjectOut := addSubject(db, *subjectIn.SubjectID, *subjectIn.SubjectType)
 
 	// Create, but don't register, a subject.
 	resourceIn := newResourceIn("r1", "app")
 
 	// Grant the subject access to the resource.
-	_ = grantPermission(db, schema, subjectIn, resourceIn, "own")
+	_ = grantPermission(db, subjectIn, resourceIn, "own")
 
 	// List the permissions and verify that we get the expected number of results.
-	permissions := listPermissions(db, schema).Permissions
+	permissions := listPermissions(db).Permissions
 	if len(permissions) != 1 {
 		t.Fatalf("unexpected number of permissions listed: %d", len(permissions))
 	}
@@ -382,13 +469,13 @@ func TestAutoInsertResource(t *testing.T) {
 	if len(*permission.ID) != 36 {
 		t.Errorf("unexpected internal permission ID returned: %s", *permission.ID)
 	}
-	if permission.Subject.ID != subjectOut.ID {
+	if *permission.Subject.ID != *subjectOut.ID {
 		t.Errorf("unexpected internal subject ID listed: %s", *permission.Subject.ID)
 	}
-	if permission.Subject.SubjectID != subjectOut.SubjectID {
+	if *permission.Subject.SubjectID != *subjectOut.SubjectID {
 		t.Errorf("unexpected external subject ID listed: %s", *permission.Subject.SubjectID)
 	}
-	if permission.Subject.SubjectType != subjectOut.SubjectType {
+	if *permission.Subject.SubjectType != *subjectOut.SubjectType {
 		t.Errorf("unexpedted subject type listed: %s", *permission.Subject.SubjectType)
 	}
 	if permission.Resource.ID == nil {
@@ -406,30 +493,26 @@ func TestAutoInsertResource(t *testing.T) {
 }
 
 func TestUpdatePermissionLevel(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Define a subject.
 	subjectIn := newSubjectIn("s1", "user")
-	subjectOut := addSubject(db, schema, *subjectIn.SubjectID, *subjectIn.SubjectType)
+	subjectOut := addSubject(db, *subjectIn.SubjectID, *subjectIn.SubjectType)
 
 	// Define a resource.
 	resourceIn := newResourceIn("r1", "app")
-	resourceOut := addResource(db, schema, *resourceIn.Name, *resourceIn.ResourceType)
+	resourceOut := addResource(db, *resourceIn.Name, *resourceIn.ResourceType)
 
 	// Grant the subject access to the resource.
-	_ = grantPermission(db, schema, subjectIn, resourceIn, "own")
+	_ = grantPermission(db, subjectIn, resourceIn, "own")
 
 	// Revise the ownership level.
-	_ = grantPermission(db, schema, subjectIn, resourceIn, "write")
+	_ = grantPermission(db, subjectIn, resourceIn, "write")
 
 	// List the permissions and verify that we get the expected number of results.
-	permissions := listPermissions(db, schema).Permissions
+	permissions := listPermissions(db).Permissions
 	if len(permissions) != 1 {
 		t.Fatalf("unexpected number of permissions listed: %d", len(permissions))
 	}
@@ -439,13 +522,13 @@ func TestUpdatePermissionLevel(t *testing.T) {
 	if len(*permission.ID) != 36 {
 		t.Errorf("unexpected internal permission ID returned: %s", *permission.ID)
 	}
-	if permission.Subject.ID != subjectOut.ID {
+	if *permission.Subject.ID != *subjectOut.ID {
 		t.Errorf("unexpected internal subject ID listed: %s", *permission.Subject.ID)
 	}
-	if permission.Subject.SubjectID != subjectOut.SubjectID {
+	if *permission.Subject.SubjectID != *subjectOut.SubjectID {
 		t.Errorf("unexpected external subject ID listed: %s", *permission.Subject.SubjectID)
 	}
-	if permission.Subject.SubjectType != subjectOut.SubjectType {
+	if *permission.Subject.SubjectType != *subjectOut.SubjectType {
 		t.Errorf("unexpedted subject type listed: %s", *permission.Subject.SubjectType)
 	}
 	if *permission.Resource.ID != *resourceOut.ID {
@@ -463,38 +546,30 @@ func TestUpdatePermissionLevel(t *testing.T) {
 }
 
 func TestRevokePermission(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the datasbase.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Grant the subject access to the resource.
-	_ = grantPermission(db, schema, newSubjectIn("s1", "user"), newResourceIn("r1", "app"), "own")
+	_ = grantPermission(db, newSubjectIn("s1", "user"), newResourceIn("r1", "app"), "own")
 
 	// Revoke the permission.
-	revokePermission(db, schema, "user", "s1", "app", "r1")
+	revokePermission(db, "user", "s1", "app", "r1")
 
 	// List the permissions and verify that we get the expected number of results.
-	permissions := listPermissions(db, schema).Permissions
+	permissions := listPermissions(db).Permissions
 	if len(permissions) != 0 {
 		t.Errorf("unexpected number of permissions listed: %d", len(permissions))
 	}
 }
 
 func TestRevokePermissionResourceTypeNotFound(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Attempt to revoke a permission for a bogus resource type.
-	responder := revokePermissionAttempt(db, schema, "user", "s1", "foo", "r1")
+	responder := revokePermissionAttempt(db, "user", "s1", "foo", "r1")
 	errorOut := responder.(*permissions.RevokePermissionNotFound).Payload
 
 	// Verify that we got the expected error message.
@@ -505,16 +580,12 @@ func TestRevokePermissionResourceTypeNotFound(t *testing.T) {
 }
 
 func TestRevokePermissionResourceNotFound(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Attempt to revoke a permission for a bogus resource.
-	responder := revokePermissionAttempt(db, schema, "user", "s1", "app", "r1")
+	responder := revokePermissionAttempt(db, "user", "s1", "app", "r1")
 	errorOut := responder.(*permissions.RevokePermissionNotFound).Payload
 
 	// Verify that we got the expected error message.
@@ -525,20 +596,16 @@ func TestRevokePermissionResourceNotFound(t *testing.T) {
 }
 
 func TestRevokePermissionUserNotFound(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Define a resource.
 	resourceIn := newResourceIn("r1", "app")
-	_ = addResource(db, schema, *resourceIn.Name, *resourceIn.ResourceType)
+	_ = addResource(db, *resourceIn.Name, *resourceIn.ResourceType)
 
 	// Attempt to revoke a permission for a bogus user.
-	responder := revokePermissionAttempt(db, schema, "user", "nobody", "app", "r1")
+	responder := revokePermissionAttempt(db, "user", "nobody", "app", "r1")
 	errorOut := responder.(*permissions.RevokePermissionNotFound).Payload
 
 	// Verify that we got the expected error message.
@@ -549,24 +616,20 @@ func TestRevokePermissionUserNotFound(t *testing.T) {
 }
 
 func TestRevokePermissionNotFound(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Define a subject.
 	subjectIn := newSubjectIn("s1", "user")
-	_ = addSubject(db, schema, *subjectIn.SubjectID, *subjectIn.SubjectType)
+	_ = addSubject(db, *subjectIn.SubjectID, *subjectIn.SubjectType)
 
 	// Define a resource.
 	resourceIn := newResourceIn("r1", "app")
-	_ = addResource(db, schema, *resourceIn.Name, *resourceIn.ResourceType)
+	_ = addResource(db, *resourceIn.Name, *resourceIn.ResourceType)
 
 	// Attempt to revoke a permission for a bogus user.
-	responder := revokePermissionAttempt(db, schema, "user", "s1", "app", "r1")
+	responder := revokePermissionAttempt(db, "user", "s1", "app", "r1")
 	errorOut := responder.(*permissions.RevokePermissionNotFound).Payload
 
 	// Verify that we got the expected error message.
@@ -577,36 +640,32 @@ func TestRevokePermissionNotFound(t *testing.T) {
 }
 
 func TestPutPermission(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Define a subject.
 	subjectIn := newSubjectIn("s1", "user")
-	subjectOut := addSubject(db, schema, *subjectIn.SubjectID, *subjectIn.SubjectType)
+	subjectOut := addSubject(db, *subjectIn.SubjectID, *subjectIn.SubjectType)
 
 	// Define a resource.
 	resourceIn := newResourceIn("r1", "app")
-	resourceOut := addResource(db, schema, *resourceIn.Name, *resourceIn.ResourceType)
+	resourceOut := addResource(db, *resourceIn.Name, *resourceIn.ResourceType)
 
 	// Grant the subject access to the resource.
-	permission := putPermission(db, schema, "user", "s1", "app", "r1", "own")
+	permission := putPermission(db, "user", "s1", "app", "r1", "own")
 
 	// Verify that we got the expected result.
 	if len(*permission.ID) != 36 {
 		t.Errorf("unexpected internal permission ID returned: %s", *permission.ID)
 	}
-	if permission.Subject.ID != subjectOut.ID {
+	if *permission.Subject.ID != *subjectOut.ID {
 		t.Errorf("unexpected internal subject ID returned: %s", *permission.Subject.ID)
 	}
-	if permission.Subject.SubjectID != subjectOut.SubjectID {
+	if *permission.Subject.SubjectID != *subjectOut.SubjectID {
 		t.Errorf("unexpected external subject ID returned: %s", *permission.Subject.SubjectID)
 	}
-	if permission.Subject.SubjectType != subjectOut.SubjectType {
+	if *permission.Subject.SubjectType != *subjectOut.SubjectType {
 		t.Errorf("unexpedted subject type returned: %s", *permission.Subject.SubjectType)
 	}
 	if *permission.Resource.ID != *resourceOut.ID {
@@ -624,23 +683,19 @@ func TestPutPermission(t *testing.T) {
 }
 
 func TestPutPermissionNewSubject(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Define, but don't register a subject.
 	subjectIn := newSubjectIn("s1", "user")
 
 	// Define a resource.
 	resourceIn := newResourceIn("r1", "app")
-	resourceOut := addResource(db, schema, *resourceIn.Name, *resourceIn.ResourceType)
+	resourceOut := addResource(db, *resourceIn.Name, *resourceIn.ResourceType)
 
 	// Grant the subject access to the resource.
-	permission := putPermission(db, schema, "user", "s1", "app", "r1", "own")
+	permission := putPermission(db, "user", "s1", "app", "r1", "own")
 
 	// Verify that we got the expected result.
 	if len(*permission.ID) != 36 {
@@ -649,10 +704,10 @@ func TestPutPermissionNewSubject(t *testing.T) {
 	if len(*permission.Subject.ID) != 36 {
 		t.Errorf("unexpected internal subject ID returned: %s", *permission.Subject.ID)
 	}
-	if permission.Subject.SubjectID != subjectIn.SubjectID {
+	if *permission.Subject.SubjectID != *subjectIn.SubjectID {
 		t.Errorf("unexpected external subject ID returned: %s", *permission.Subject.SubjectID)
 	}
-	if permission.Subject.SubjectType != subjectIn.SubjectType {
+	if *permission.Subject.SubjectType != *subjectIn.SubjectType {
 		t.Errorf("unexpedted subject type returned: %s", *permission.Subject.SubjectType)
 	}
 	if *permission.Resource.ID != *resourceOut.ID {
@@ -670,35 +725,31 @@ func TestPutPermissionNewSubject(t *testing.T) {
 }
 
 func TestPutPermissionNewResource(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Define a subject.
 	subjectIn := newSubjectIn("s1", "user")
-	subjectOut := addSubject(db, schema, *subjectIn.SubjectID, *subjectIn.SubjectType)
+	subjectOut := addSubject(db, *subjectIn.SubjectID, *subjectIn.SubjectType)
 
 	// Define, but don't register a resource.
 	resourceIn := newResourceIn("r1", "app")
 
 	// Grant the subject access to the resource.
-	permission := putPermission(db, schema, "user", "s1", "app", "r1", "own")
+	permission := putPermission(db, "user", "s1", "app", "r1", "own")
 
 	// Verify that we got the expected result.
 	if len(*permission.ID) != 36 {
 		t.Errorf("unexpected internal permission ID returned: %s", *permission.ID)
 	}
-	if permission.Subject.ID != subjectOut.ID {
+	if *permission.Subject.ID != *subjectOut.ID {
 		t.Errorf("unexpected internal subject ID returned: %s", *permission.Subject.ID)
 	}
-	if permission.Subject.SubjectID != subjectOut.SubjectID {
+	if *permission.Subject.SubjectID != *subjectOut.SubjectID {
 		t.Errorf("unexpected external subject ID returned: %s", *permission.Subject.SubjectID)
 	}
-	if permission.Subject.SubjectType != subjectOut.SubjectType {
+	if *permission.Subject.SubjectType != *subjectOut.SubjectType {
 		t.Errorf("unexpedted subject type returned: %s", *permission.Subject.SubjectType)
 	}
 	if len(*permission.Resource.ID) != 36 {
@@ -716,20 +767,16 @@ func TestPutPermissionNewResource(t *testing.T) {
 }
 
 func TestPutPermissionDuplicateSubjectId(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Define a subject.
 	subjectIn := newSubjectIn("s1", "user")
-	_ = addSubject(db, schema, *subjectIn.SubjectID, *subjectIn.SubjectType)
+	_ = addSubject(db, *subjectIn.SubjectID, *subjectIn.SubjectType)
 
 	// Attempt to add a permission using a duplicate subject ID.
-	responder := putPermissionAttempt(db, schema, "group", "s1", "app", "r1", "own")
+	responder := putPermissionAttempt(db, "group", "s1", "app", "r1", "own")
 	errorOut := responder.(*permissions.PutPermissionBadRequest).Payload
 
 	// Verify that we got the expected error.
@@ -740,16 +787,12 @@ func TestPutPermissionDuplicateSubjectId(t *testing.T) {
 }
 
 func TestPutPermissionBogusResourceType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Attempt to add a permission using a bogus resource type.
-	responder := putPermissionAttempt(db, schema, "user", "s1", "foo", "r1", "own")
+	responder := putPermissionAttempt(db, "user", "s1", "foo", "r1", "own")
 	errorOut := responder.(*permissions.PutPermissionBadRequest).Payload
 
 	// Verify that we got the expected error.
@@ -760,35 +803,27 @@ func TestPutPermissionBogusResourceType(t *testing.T) {
 }
 
 func TestListResourcePermissionsEmpty(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// List permissions and verify that we get the expected number of results.
-	perms := listResourcePermissions(db, schema, "app", "r1").Permissions
+	perms := listResourcePermissions(db, "app", "r1").Permissions
 	if len(perms) != 0 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
 }
 
 func TestListResourcePermissions(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	addDefaultPermissions(db, schema)
+	addDefaultPermissions(db)
 
 	// List permissions and verify that we get the expected number of results.
-	perms := listResourcePermissions(db, schema, "app", "app1").Permissions
+	perms := listResourcePermissions(db, "app", "app1").Permissions
 	if len(perms) != 4 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -801,22 +836,18 @@ func TestListResourcePermissions(t *testing.T) {
 }
 
 func TestCopyPermissions(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	addDefaultPermissions(db, schema)
+	addDefaultPermissions(db)
 
 	// Copy permissions from subject s2 to subject s1.
-	copyPermissions(db, schema, "user", "s2", "user", "s1")
+	copyPermissions(db, "user", "s2", "user", "s1")
 
 	// Verify that the permissions were copied.
-	perms := listSubjectPermissions(db, schema, "user", "s1").Permissions
+	perms := listSubjectPermissions(db, "user", "s1").Permissions
 	if len(perms) != 4 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -829,25 +860,21 @@ func TestCopyPermissions(t *testing.T) {
 }
 
 func TestCopyPermissionsOverwrite(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	addDefaultPermissions(db, schema)
+	addDefaultPermissions(db)
 
 	// Add a permission that should be overwritten.
-	putPermission(db, schema, "user", "s1", "app", "app1", "read")
+	putPermission(db, "user", "s1", "app", "app1", "read")
 
 	// Copy permissions from subject s2 to subject s1.
-	copyPermissions(db, schema, "user", "s2", "user", "s1")
+	copyPermissions(db, "user", "s2", "user", "s1")
 
 	// Verify that the permissions were copied.
-	perms := listSubjectPermissions(db, schema, "user", "s1").Permissions
+	perms := listSubjectPermissions(db, "user", "s1").Permissions
 	if len(perms) != 4 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -860,25 +887,21 @@ func TestCopyPermissionsOverwrite(t *testing.T) {
 }
 
 func TestCopyPermissionsRetain(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some permissions.
-	addDefaultPermissions(db, schema)
+	addDefaultPermissions(db)
 
 	// Add a permission that should not be overwritten
-	putPermission(db, schema, "user", "s1", "app", "app2", "own")
+	putPermission(db, "user", "s1", "app", "app2", "own")
 
 	// Copy permissions from subject s2 to subject s1.
-	copyPermissions(db, schema, "user", "s2", "user", "s1")
+	copyPermissions(db, "user", "s2", "user", "s1")
 
 	// Verify that the permissions were copied.
-	perms := listSubjectPermissions(db, schema, "user", "s1").Permissions
+	perms := listSubjectPermissions(db, "user", "s1").Permissions
 	if len(perms) != 4 {
 		t.Fatalf("unexpected number of results: %d", len(perms))
 	}
@@ -889,3 +912,287 @@ func TestCopyPermissionsRetain(t *testing.T) {
 	checkPerm(t, perms, 2, "analysis1", "s1", "own")
 	checkPerm(t, perms, 3, "analysis2", "s1", "read")
 }
+
+func TestCopyResourcePermissions(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Add some permissions.
+	addDefaultPermissions(db)
+
+	// Copy permissions from app1 to a new app.
+	copyResourcePermissions(db, "app", "app1", "app", "app4", "keep-higher")
+
+	// Verify that the permissions were copied.
+	perms := listResourcePermissions(db, "app", "app4").Permissions
+	if len(perms) != 4 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+
+	// Verify that we got the expected results.
+	checkPerm(t, perms, 0, "app4", "g1id", "read")
+	checkPerm(t, perms, 1, "app4", "g2id", "write")
+	checkPerm(t, perms, 2, "app4", "s2", "own")
+	checkPerm(t, perms, 3, "app4", "s3", "read")
+}
+
+func TestCopyResourcePermissionsMergePolicies(t *testing.T) {
+	// The expected permission levels for subjects g1id and s2 on app2 after copying the permissions from app1.
+	expected := map[string][]string{
+		"keep-higher":   {"write", "own"},
+		"overwrite":     {"read", "own"},
+		"skip-existing": {"write", "read"},
+	}
+
+	for mergePolicy, levels := range expected {
+
+		// Initialize the database.
+		db := initdb(t)
+		addDefaultResourceTypes(db, t)
+
+		// Add some permissions.
+		addDefaultPermissions(db)
+
+		// Copy permissions from app1 to app2.
+		copyResourcePermissions(db, "app", "app1", "app", "app2", mergePolicy)
+
+		// Verify that the permissions were merged correctly.
+		perms := listResourcePermissions(db, "app", "app2").Permissions
+		if len(perms) != 4 {
+			t.Fatalf("unexpected number of results for %s: %d", mergePolicy, len(perms))
+		}
+		checkPerm(t, perms, 0, "app2", "g1id", levels[0])
+		checkPerm(t, perms, 1, "app2", "g2id", "write")
+		checkPerm(t, perms, 2, "app2", "s2", levels[1])
+		checkPerm(t, perms, 3, "app2", "s3", "read")
+	}
+}
+
+func TestCopyResourcePermissionsNotFound(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Attempt to copy permissions from a resource that doesn't exist.
+	responder := copyResourcePermissionsAttempt(db, "app", "app1", "app", "app2", "keep-higher")
+	errorOut := responder.(*permissions.CopyResourcePermissionsNotFound).Payload
+
+	// Verify that we got the expected error message.
+	expected := "resource not found: app/app1"
+	if *errorOut.Reason != expected {
+		t.Errorf("unexpected failure reason: %s", *errorOut.Reason)
+	}
+}
+
+func TestRevokeSubjectPermissions(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Add some permissions.
+	addDefaultPermissions(db)
+
+	// Revoke all of the permissions for subject s2.
+	perms := revokeSubjectPermissions(db, "user", "s2", nil, nil).Permissions
+	if len(perms) != 4 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+	checkPerm(t, perms, 0, "analysis1", "s2", "own")
+	checkPerm(t, perms, 1, "analysis2", "s2", "read")
+	checkPerm(t, perms, 2, "app1", "s2", "own")
+	checkPerm(t, perms, 3, "app2", "s2", "read")
+
+	// Verify that the permissions were revoked.
+	if remaining := listSubjectPermissions(db, "user", "s2").Permissions; len(remaining) != 0 {
+		t.Errorf("unexpected number of remaining permissions: %d", len(remaining))
+	}
+}
+
+func TestRevokeSubjectPermissionsFiltered(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Add some permissions.
+	addDefaultPermissions(db)
+
+	// Revoke subject s2's app permissions of at least the own level.
+	resourceType := "app"
+	minLevel := "own"
+	perms := revokeSubjectPermissions(db, "user", "s2", &resourceType, &minLevel).Permissions
+	if len(perms) != 1 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+	checkPerm(t, perms, 0, "app1", "s2", "own")
+
+	// Verify that the other permissions were retained.
+	remaining := listSubjectPermissions(db, "user", "s2").Permissions
+	if len(remaining) != 3 {
+		t.Fatalf("unexpected number of remaining permissions: %d", len(remaining))
+	}
+}
+
+func TestRevokeSubjectPermissionsNotFound(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+
+	// Attempt to revoke permissions for a subject that doesn't exist.
+	responder := revokeSubjectPermissionsAttempt(db, "user", "s1", nil, nil)
+	errorOut := responder.(*permissions.RevokeSubjectPermissionsNotFound).Payload
+
+	// Verify that we got the expected error message.
+	expected := "subject not found: user/s1"
+	if *errorOut.Reason != expected {
+		t.Errorf("unexpected failure reason: %s", *errorOut.Reason)
+	}
+}
+
+func TestRevokeResourcePermissions(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Add some permissions.
+	addDefaultPermissions(db)
+
+	// Revoke all of the permissions for app1.
+	perms := revokeResourcePermissions(db, "app", "app1", false).Permissions
+	if len(perms) != 4 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+
+	// Verify that the permissions were revoked.
+	if remaining := listResourcePermissions(db, "app", "app1").Permissions; len(remaining) != 0 {
+		t.Errorf("unexpected number of remaining permissions: %d", len(remaining))
+	}
+}
+
+func TestRevokeResourcePermissionsExcludeOwners(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Add some permissions.
+	addDefaultPermissions(db)
+
+	// Revoke all of the permissions for app1 except for ownership.
+	perms := revokeResourcePermissions(db, "app", "app1", true).Permissions
+	if len(perms) != 3 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+	checkPerm(t, perms, 0, "app1", "g1id", "read")
+	checkPerm(t, perms, 1, "app1", "g2id", "write")
+	checkPerm(t, perms, 2, "app1", "s3", "read")
+
+	// Verify that the owner retained access.
+	remaining := listResourcePermissions(db, "app", "app1").Permissions
+	if len(remaining) != 1 {
+		t.Fatalf("unexpected number of remaining permissions: %d", len(remaining))
+	}
+	checkPerm(t, remaining, 0, "app1", "s2", "own")
+}
+
+func TestReplaceResourcePermissions(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Add some permissions.
+	addDefaultPermissions(db)
+
+	// Replace the access control list for app1.
+	entries := []*models.ResourceACLEntry{
+		aclEntry("user", "s2", "own"),
+		aclEntry("group", "g1id", "write"),
+		aclEntry("user", "s4", "read"),
+	}
+	response := replaceResourcePermissions(db, "app", "app1", nil, entries)
+	changes := response.Payload
+
+	// Verify the change set.
+	if len(changes.AddedSubjects) != 1 {
+		t.Errorf("unexpected number of added subjects: %d", len(changes.AddedSubjects))
+	}
+	if len(changes.AddedPermissions) != 1 {
+		t.Fatalf("unexpected number of added permissions: %d", len(changes.AddedPermissions))
+	}
+	checkPerm(t, changes.AddedPermissions, 0, "app1", "s4", "read")
+	if len(changes.UpdatedPermissions) != 1 {
+		t.Fatalf("unexpected number of updated permissions: %d", len(changes.UpdatedPermissions))
+	}
+	updated := changes.UpdatedPermissions[0]
+	checkPerm(t, []*models.Permission{updated.Permission}, 0, "app1", "g1id", "write")
+	if *updated.PreviousLevel != models.PermissionLevel("read") {
+		t.Errorf("unexpected previous level: %s", string(*updated.PreviousLevel))
+	}
+	if len(changes.RemovedPermissions) != 2 {
+		t.Fatalf("unexpected number of removed permissions: %d", len(changes.RemovedPermissions))
+	}
+
+	// Verify the updated access control list.
+	list := listResourcePermissionsAttempt(db, "app", "app1").(*permissions.ListResourcePermissionsOK)
+	perms := list.Payload.Permissions
+	if len(perms) != 3 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+	checkPerm(t, perms, 0, "app1", "g1id", "write")
+	checkPerm(t, perms, 1, "app1", "s2", "own")
+	checkPerm(t, perms, 2, "app1", "s4", "read")
+
+	// The entity tag returned by the replacement should match the one returned by the listing.
+	if response.ETag != list.ETag {
+		t.Errorf("mismatched entity tags: %s != %s", response.ETag, list.ETag)
+	}
+
+	// Replacing the list with the same entries shouldn't change anything.
+	response = replaceResourcePermissions(db, "app", "app1", &list.ETag, entries)
+	changes = response.Payload
+	if len(changes.AddedPermissions)+len(changes.UpdatedPermissions)+len(changes.RemovedPermissions) != 0 {
+		t.Errorf("unexpected changes to an unchanged access control list")
+	}
+	if response.ETag != list.ETag {
+		t.Errorf("entity tag changed unexpectedly: %s != %s", response.ETag, list.ETag)
+	}
+}
+
+func TestReplaceResourcePermissionsPreconditionFailed(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Add some permissions.
+	addDefaultPermissions(db)
+
+	// Get the current entity tag, then modify the access control list.
+	etag := listResourcePermissionsAttempt(db, "app", "app1").(*permissions.ListResourcePermissionsOK).ETag
+	putPermission(db, "user", "s4", "app", "app1", "read")
+
+	// Attempt to replace the access control list using the stale entity tag.
+	entries := []*models.ResourceACLEntry{aclEntry("user", "s2", "own")}
+	responder := replaceResourcePermissionsAttempt(db, "app", "app1", &etag, entries)
+	if _, ok := responder.(*permissions.ReplaceResourcePermissionsPreconditionFailed); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+
+	// Verify that the access control list wasn't modified.
+	if perms := listResourcePermissions(db, "app", "app1").Permissions; len(perms) != 5 {
+		t.Errorf("unexpected number of results: %d", len(perms))
+	}
+}
+
+func TestReplaceResourcePermissionsDuplicateSubject(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Attempt to list the same subject twice.
+	entries := []*models.ResourceACLEntry{
+		aclEntry("user", "s2", "own"),
+		aclEntry("user", "s2", "read"),
+	}
+	responder := replaceResourcePermissionsAttempt(db, "app", "app1", nil, entries)
+	if _, ok := responder.(*permissions.ReplaceResourcePermissionsBadRequest); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+}
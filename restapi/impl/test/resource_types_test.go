@@ -1,21 +1,21 @@
 package test
 
 import (
-	"database/sql"
 	"fmt"
 	"testing"
 
 	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
 	"github.com/cyverse-de/permissions/restapi/operations/resource_types"
 
 	impl "github.com/cyverse-de/permissions/restapi/impl/resourcetypes"
 	middleware "github.com/go-openapi/runtime/middleware"
 )
 
-func addResourceTypeAttempt(db *sql.DB, schema, name, description string) middleware.Responder {
+func addResourceTypeAttempt(db permsdb.Store, name, description string) middleware.Responder {
 
 	// build the request handler.
-	handler := impl.BuildResourceTypesPostHandler(db, schema)
+	handler := impl.BuildResourceTypesPostHandler(db)
 
 	// Attempt to add the resource type to the database.
 	resourceTypeIn := &models.ResourceTypeIn{Name: &name, Description: description}
@@ -23,15 +23,15 @@ func addResourceTypeAttempt(db *sql.DB, schema, name, description string) middle
 	return handler(params)
 }
 
-func addResourceType(db *sql.DB, schema, name string, description string) *models.ResourceTypeOut {
-	responder := addResourceTypeAttempt(db, schema, name, description)
+func addResourceType(db permsdb.Store, name string, description string) *models.ResourceTypeOut {
+	responder := addResourceTypeAttempt(db, name, description)
 	return responder.(*resource_types.PostResourceTypesCreated).Payload
 }
 
-func listResourceTypes(db *sql.DB, schema string, resourceTypeName *string) *models.ResourceTypesOut {
+func listResourceTypes(db permsdb.Store, resourceTypeName *string) *models.ResourceTypesOut {
 
 	// Build the request handler.
-	handler := impl.BuildResourceTypesGetHandler(db, schema)
+	handler := impl.BuildResourceTypesGetHandler(db)
 
 	// Get the resource types from the database.
 	params := resource_types.GetResourceTypesParams{ResourceTypeName: resourceTypeName}
@@ -40,10 +40,10 @@ func listResourceTypes(db *sql.DB, schema string, resourceTypeName *string) *mod
 	return responder.Payload
 }
 
-func modifyResourceTypeAttempt(db *sql.DB, schema, id, name, description string) middleware.Responder {
+func modifyResourceTypeAttempt(db permsdb.Store, id, name, description string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildResourceTypesIDPutHandler(db, schema)
+	handler := impl.BuildResourceTypesIDPutHandler(db)
 
 	// Update the resource type in the database.
 	resourceTypeIn := &models.ResourceTypeIn{Name: &name, Description: description}
@@ -51,53 +51,49 @@ func modifyResourceTypeAttempt(db *sql.DB, schema, id, name, description string)
 	return handler(params)
 }
 
-func modifyResourceType(db *sql.DB, schema, id string, name string, description string) *models.ResourceTypeOut {
-	responder := modifyResourceTypeAttempt(db, schema, id, name, description)
+func modifyResourceType(db permsdb.Store, id string, name string, description string) *models.ResourceTypeOut {
+	responder := modifyResourceTypeAttempt(db, id, name, description)
 	return responder.(*resource_types.PutResourceTypesIDOK).Payload
 }
 
-func deleteResourceTypeAttempt(db *sql.DB, schema, id string) middleware.Responder {
+func deleteResourceTypeAttempt(db permsdb.Store, id string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildResourceTypesIDDeleteHandler(db, schema)
+	handler := impl.BuildResourceTypesIDDeleteHandler(db)
 
 	// Attempt to remove the resource type from the database.
 	params := resource_types.DeleteResourceTypesIDParams{ID: id}
 	return handler(params)
 }
 
-func deleteResourceType(db *sql.DB, schema, id string) {
-	responder := deleteResourceTypeAttempt(db, schema, id)
+func deleteResourceType(db permsdb.Store, id string) {
+	responder := deleteResourceTypeAttempt(db, id)
 	_ = responder.(*resource_types.DeleteResourceTypesIDOK)
 }
 
-func deleteResourceTypeByNameAttempt(db *sql.DB, schema, name string) middleware.Responder {
+func deleteResourceTypeByNameAttempt(db permsdb.Store, name string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildDeleteResourceTypeByNameHandler(db, schema)
+	handler := impl.BuildDeleteResourceTypeByNameHandler(db)
 
 	// Attempt to remove the resource type from the database.
 	params := resource_types.DeleteResourceTypeByNameParams{ResourceTypeName: name}
 	return handler(params)
 }
 
-func deleteResourceTypeByName(db *sql.DB, schema, name string) {
-	responder := deleteResourceTypeByNameAttempt(db, schema, name)
+func deleteResourceTypeByName(db permsdb.Store, name string) {
+	responder := deleteResourceTypeByNameAttempt(db, name)
 	_ = responder.(*resource_types.DeleteResourceTypeByNameOK)
 }
 
 func TestAddResourceType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add the resource type.
 	name := "resource_type"
 	description := "The resource type."
-	resourceTypeOut := addResourceType(db, schema, name, description)
+	resourceTypeOut := addResourceType(db, name, description)
 
 	// Verify the name and description.
 	if *resourceTypeOut.Name != name {
@@ -109,19 +105,15 @@ func TestAddResourceType(t *testing.T) {
 }
 
 func TestAddDuplicateResourceType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add a resource type.
 	name := "duplicate_resource_type"
-	addResourceType(db, schema, name, "The original!")
+	addResourceType(db, name, "The original!")
 
 	// Attempt to add another resource type with the same name.
-	responder := addResourceTypeAttempt(db, schema, name, "The impostor!")
+	responder := addResourceTypeAttempt(db, name, "The impostor!")
 	errorOut := responder.(*resource_types.PostResourceTypesBadRequest).Payload
 
 	// Verify that we got the expected error message.
@@ -132,18 +124,14 @@ func TestAddDuplicateResourceType(t *testing.T) {
 }
 
 func TestGetResourceTypes(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add a resource type.
-	expected := addResourceType(db, schema, "resource_type", "The resource type.")
+	expected := addResourceType(db, "resource_type", "The resource type.")
 
 	// List the resource types.
-	resourceTypesOut := listResourceTypes(db, schema, nil)
+	resourceTypesOut := listResourceTypes(db, nil)
 
 	// Verify the number of resource types in the response.
 	resourceTypes := resourceTypesOut.ResourceTypes
@@ -165,19 +153,15 @@ func TestGetResourceTypes(t *testing.T) {
 }
 
 func TestFindResourceType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add some resource types.
-	addResourceType(db, schema, "a", "a")
-	expected := addResourceType(db, schema, "resource_type", "The resource type.")
+	addResourceType(db, "a", "a")
+	expected := addResourceType(db, "resource_type", "The resource type.")
 
 	// Search for a resource type.
-	resourceTypesOut := listResourceTypes(db, schema, expected.Name)
+	resourceTypesOut := listResourceTypes(db, expected.Name)
 
 	// Verify the number of resource types in the response.
 	resourceTypes := resourceTypesOut.ResourceTypes
@@ -199,15 +183,11 @@ func TestFindResourceType(t *testing.T) {
 }
 
 func TestGetResourceTypesEmpty(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// List the resource types.
-	resourceTypesOut := listResourceTypes(db, schema, nil)
+	resourceTypesOut := listResourceTypes(db, nil)
 
 	// Verify that we got the expected result.
 	resourceTypes := resourceTypesOut.ResourceTypes
@@ -220,20 +200,16 @@ func TestGetResourceTypesEmpty(t *testing.T) {
 }
 
 func TestFindResourceTypeNotFound(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add some resource types.
-	addResourceType(db, schema, "a", "a")
-	addResourceType(db, schema, "b", "b")
+	addResourceType(db, "a", "a")
+	addResourceType(db, "b", "b")
 
 	// List the resource types.
 	search := "c"
-	resourceTypesOut := listResourceTypes(db, schema, &search)
+	resourceTypesOut := listResourceTypes(db, &search)
 
 	// Verify the number of resource types in the response.
 	resourceTypes := resourceTypesOut.ResourceTypes
@@ -246,21 +222,17 @@ func TestFindResourceTypeNotFound(t *testing.T) {
 }
 
 func TestModifyResourceType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add a resource type.
-	original := addResourceType(db, schema, "resource_type", "The resource type.")
+	original := addResourceType(db, "resource_type", "The resource type.")
 	id := *original.ID
 
 	// Modify the resource type.
 	newName := "new_resource_type"
 	newDescription := "New and Improved!"
-	modified := modifyResourceType(db, schema, id, newName, newDescription)
+	modified := modifyResourceType(db, id, newName, newDescription)
 
 	// The modified resource type should have the original ID with the new name and description.
 	if *modified.ID != id {
@@ -274,7 +246,7 @@ func TestModifyResourceType(t *testing.T) {
 	}
 
 	// List the resource types.
-	resourceTypesOut := listResourceTypes(db, schema, nil)
+	resourceTypesOut := listResourceTypes(db, nil)
 
 	// Verify the number of resource types in the response.
 	resourceTypes := resourceTypesOut.ResourceTypes
@@ -297,15 +269,11 @@ func TestModifyResourceType(t *testing.T) {
 }
 
 func TestModifyNonExistentResourceType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Attempt to modify a non-existent resource type.
-	responder := modifyResourceTypeAttempt(db, schema, FakeID, "n", "d")
+	responder := modifyResourceTypeAttempt(db, FakeID, "n", "d")
 	errorOut := responder.(*resource_types.PutResourceTypesIDNotFound).Payload
 
 	// Verify that we got the expected error message.
@@ -316,19 +284,15 @@ func TestModifyNonExistentResourceType(t *testing.T) {
 }
 
 func TestModifyDuplicateResourceType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Create two new resource types.
-	rt1 := addResourceType(db, schema, "rt1", "rt1")
-	rt2 := addResourceType(db, schema, "rt2", "rt2")
+	rt1 := addResourceType(db, "rt1", "rt1")
+	rt2 := addResourceType(db, "rt2", "rt2")
 
 	// Attempt to rename the second resource type to the name of the first resource type.
-	responder := modifyResourceTypeAttempt(db, schema, *rt2.ID, *rt1.Name, rt2.Description)
+	responder := modifyResourceTypeAttempt(db, *rt2.ID, *rt1.Name, rt2.Description)
 	errorOut := responder.(*resource_types.PutResourceTypesIDBadRequest).Payload
 
 	// Verify that we got the expected error message.
@@ -339,22 +303,18 @@ func TestModifyDuplicateResourceType(t *testing.T) {
 }
 
 func TestDeleteResourceType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Create two resource types.
-	rt1 := addResourceType(db, schema, "rt1", "rt1")
-	rt2 := addResourceType(db, schema, "rt2", "rt2")
+	rt1 := addResourceType(db, "rt1", "rt1")
+	rt2 := addResourceType(db, "rt2", "rt2")
 
 	// Delete the second resource type.
-	deleteResourceType(db, schema, *rt2.ID)
+	deleteResourceType(db, *rt2.ID)
 
 	// List the resource types.
-	resourceTypesOut := listResourceTypes(db, schema, nil)
+	resourceTypesOut := listResourceTypes(db, nil)
 
 	// Verify the number of resource types in the response.
 	resourceTypes := resourceTypesOut.ResourceTypes
@@ -377,22 +337,18 @@ func TestDeleteResourceType(t *testing.T) {
 }
 
 func TestDeleteResourceTypeByName(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Create two resource types.
-	rt1 := addResourceType(db, schema, "rt1", "rt1")
-	rt2 := addResourceType(db, schema, "rt2", "rt2")
+	rt1 := addResourceType(db, "rt1", "rt1")
+	rt2 := addResourceType(db, "rt2", "rt2")
 
 	// Delete the second resource type.
-	deleteResourceTypeByName(db, schema, *rt2.Name)
+	deleteResourceTypeByName(db, *rt2.Name)
 
 	// List the resource types.
-	resourceTypesOut := listResourceTypes(db, schema, nil)
+	resourceTypesOut := listResourceTypes(db, nil)
 
 	// Verify the number of resource types in the response.
 	resourceTypes := resourceTypesOut.ResourceTypes
@@ -415,15 +371,11 @@ func TestDeleteResourceTypeByName(t *testing.T) {
 }
 
 func TestDeleteNonExistentResourceType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Attempt to delete a non-existent resource type.
-	responder := deleteResourceTypeAttempt(db, schema, FakeID)
+	responder := deleteResourceTypeAttempt(db, FakeID)
 	errorOut := responder.(*resource_types.DeleteResourceTypesIDNotFound).Payload
 
 	// Verify that we got the expected error message.
@@ -434,15 +386,11 @@ func TestDeleteNonExistentResourceType(t *testing.T) {
 }
 
 func TestDeleteNonExistentResourceTypeByName(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Attempt to delete a non-existent resource type.
-	responder := deleteResourceTypeByNameAttempt(db, schema, "missing_rt")
+	responder := deleteResourceTypeByNameAttempt(db, "missing_rt")
 	errorOut := responder.(*resource_types.DeleteResourceTypeByNameNotFound).Payload
 
 	// Verify that we got the expected error message.
@@ -453,19 +401,15 @@ func TestDeleteNonExistentResourceTypeByName(t *testing.T) {
 }
 
 func TestDeleteResourceTypeWithResources(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Create a resource type and a resource
-	rt := addResourceType(db, schema, "rt", "rt")
-	addTestResource(db, schema, "r", "rt", t)
+	rt := addResourceType(db, "rt", "rt")
+	addTestResource(db, "r", "rt", t)
 
 	// Attempt to delete the resource type.
-	responder := deleteResourceTypeAttempt(db, schema, *rt.ID)
+	responder := deleteResourceTypeAttempt(db, *rt.ID)
 	errorOut := responder.(*resource_types.DeleteResourceTypesIDBadRequest).Payload
 
 	// Verify that we got the expected error message.
@@ -476,19 +420,15 @@ func TestDeleteResourceTypeWithResources(t *testing.T) {
 }
 
 func TestDeleteResourceTypeWithResourcesByName(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Create a resource type and a resource
-	rt := addResourceType(db, schema, "rt", "rt")
-	addTestResource(db, schema, "r", "rt", t)
+	rt := addResourceType(db, "rt", "rt")
+	addTestResource(db, "r", "rt", t)
 
 	// Attempt to delete the resource type.
-	responder := deleteResourceTypeByNameAttempt(db, schema, *rt.Name)
+	responder := deleteResourceTypeByNameAttempt(db, *rt.Name)
 	errorOut := responder.(*resource_types.DeleteResourceTypeByNameBadRequest).Payload
 
 	// Verify that we got the expected error message.
@@ -2,7 +2,7 @@
 package test
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
 	"testing"
 
@@ -14,22 +14,17 @@ import (
 	middleware "github.com/go-openapi/runtime/middleware"
 )
 
-func listResourcesDirectly(db *sql.DB, schema string, t *testing.T) []*models.ResourceOut {
+func listResourcesDirectly(db permsdb.Store, t *testing.T) []*models.ResourceOut {
 
 	// Start a transaction.
-	tx, err := db.Begin()
+	tx, err := db.Begin(context.Background())
 	if err != nil {
 		t.Fatalf("unable to list resources: %s", err)
 	}
 	defer tx.Rollback()
 
-	_, err = tx.Exec(fmt.Sprintf("SET search_path TO %s", schema))
-	if err != nil {
-		t.Fatalf("unable to list resources: %s", err)
-	}
-
 	// List the resources.
-	resources, err := permsdb.ListResources(tx, nil, nil)
+	resources, err := tx.ListResources(nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unable to list resources: %s", err)
 	}
@@ -37,10 +32,10 @@ func listResourcesDirectly(db *sql.DB, schema string, t *testing.T) []*models.Re
 	return resources
 }
 
-func addResourceAttempt(db *sql.DB, schema, name, resourceType string) middleware.Responder {
+func addResourceAttempt(db permsdb.Store, name, resourceType string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildAddResourceHandler(db, schema)
+	handler := impl.BuildAddResourceHandler(db)
 
 	// Attempt to add the resource to the database.
 	resourceIn := &models.ResourceIn{Name: &name, ResourceType: &resourceType}
@@ -48,30 +43,30 @@ func addResourceAttempt(db *sql.DB, schema, name, resourceType string) middlewar
 	return handler(params)
 }
 
-func addResource(db *sql.DB, schema, name, resourceType string) *models.ResourceOut {
-	responder := addResourceAttempt(db, schema, name, resourceType)
+func addResource(db permsdb.Store, name, resourceType string) *models.ResourceOut {
+	responder := addResourceAttempt(db, name, resourceType)
 	return responder.(*resources.AddResourceCreated).Payload
 }
 
-func listResourcesAttempt(db *sql.DB, schema string, resourceType, name *string) middleware.Responder {
+func listResourcesAttempt(db permsdb.Store, resourceType, name *string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildListResourcesHandler(db, schema)
+	handler := impl.BuildListResourcesHandler(db)
 
 	// Attempt to list the resources.
 	params := resources.ListResourcesParams{ResourceTypeName: resourceType, ResourceName: name}
 	return handler(params)
 }
 
-func listResources(db *sql.DB, schema string, resourceType, name *string) *models.ResourcesOut {
-	responder := listResourcesAttempt(db, schema, resourceType, name)
+func listResources(db permsdb.Store, resourceType, name *string) *models.ResourcesOut {
+	responder := listResourcesAttempt(db, resourceType, name)
 	return responder.(*resources.ListResourcesOK).Payload
 }
 
-func updateResourceAttempt(db *sql.DB, schema, id, name string) middleware.Responder {
+func updateResourceAttempt(db permsdb.Store, id, name string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildUpdateResourceHandler(db, schema)
+	handler := impl.BuildUpdateResourceHandler(db)
 
 	// Attempt to update the resource.
 	resourceUpdate := &models.ResourceUpdate{Name: &name}
@@ -79,54 +74,50 @@ func updateResourceAttempt(db *sql.DB, schema, id, name string) middleware.Respo
 	return handler(params)
 }
 
-func updateResource(db *sql.DB, schema, id, name string) *models.ResourceOut {
-	responder := updateResourceAttempt(db, schema, id, name)
+func updateResource(db permsdb.Store, id, name string) *models.ResourceOut {
+	responder := updateResourceAttempt(db, id, name)
 	return responder.(*resources.UpdateResourceOK).Payload
 }
 
-func deleteResourceAttempt(db *sql.DB, schema, id string) middleware.Responder {
+func deleteResourceAttempt(db permsdb.Store, id string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildDeleteResourceHandler(db, schema)
+	handler := impl.BuildDeleteResourceHandler(db)
 
 	// Attempt to delete the resource.
 	params := resources.DeleteResourceParams{ID: id}
 	return handler(params)
 }
 
-func deleteResource(db *sql.DB, schema, id string) {
-	responder := deleteResourceAttempt(db, schema, id)
+func deleteResource(db permsdb.Store, id string) {
+	responder := deleteResourceAttempt(db, id)
 	_ = responder.(*resources.DeleteResourceOK)
 }
 
-func deleteResourceByNameAttempt(db *sql.DB, schema, resourceTypeName, name string) middleware.Responder {
+func deleteResourceByNameAttempt(db permsdb.Store, resourceTypeName, name string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildDeleteResourceByNameHandler(db, schema)
+	handler := impl.BuildDeleteResourceByNameHandler(db)
 
 	// Attempt to delete the resource.
 	params := resources.DeleteResourceByNameParams{ResourceTypeName: resourceTypeName, ResourceName: name}
 	return handler(params)
 }
 
-func deleteResourceByName(db *sql.DB, schema, resourceTypeName, name string) {
-	responder := deleteResourceByNameAttempt(db, schema, resourceTypeName, name)
+func deleteResourceByName(db permsdb.Store, resourceTypeName, name string) {
+	responder := deleteResourceByNameAttempt(db, resourceTypeName, name)
 	_ = responder.(*resources.DeleteResourceByNameOK)
 }
 
 func TestAddResource(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add a resource.
 	resourceName := "resource"
 	resourceType := "app"
-	resource := addResource(db, schema, resourceName, resourceType)
+	resource := addResource(db, resourceName, resourceType)
 
 	// Verify the name and description.
 	if *resource.Name != resourceName {
@@ -137,7 +128,7 @@ func TestAddResource(t *testing.T) {
 	}
 
 	// List the resources and verify that we got the expected number of results.
-	resources := listResourcesDirectly(db, schema, t)
+	resources := listResourcesDirectly(db, t)
 	if len(resources) != 1 {
 		t.Fatalf("unexpected number of resource types: %d", len(resources))
 	}
@@ -153,19 +144,15 @@ func TestAddResource(t *testing.T) {
 }
 
 func TestAddDuplicateResource(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
-	addResource(db, schema, "resource", "app")
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	addResource(db, "resource", "app")
 
 	// Attempt to add a duplicate resource.
 	resourceName := "resource"
 	resourceType := "app"
-	responder := addResourceAttempt(db, schema, resourceName, resourceType)
+	responder := addResourceAttempt(db, resourceName, resourceType)
 	errorOut := responder.(*resources.AddResourceBadRequest).Payload
 
 	// Verify that we got the expected error message.
@@ -176,18 +163,14 @@ func TestAddDuplicateResource(t *testing.T) {
 }
 
 func TestAddResourceInvalidType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Attempt to add a resource with an invalid type.
 	resourceName := "resource"
 	resourceType := "invisible_resource_type"
-	responder := addResourceAttempt(db, schema, resourceName, resourceType)
+	responder := addResourceAttempt(db, resourceName, resourceType)
 	errorOut := responder.(*resources.AddResourceBadRequest).Payload
 
 	// Verify that we got the expected error message.
@@ -198,19 +181,15 @@ func TestAddResourceInvalidType(t *testing.T) {
 }
 
 func TestListResources(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add a resource to the database.
-	r1 := addResource(db, schema, "r1", "app")
+	r1 := addResource(db, "r1", "app")
 
 	// List the resources and verify we get the expected number of results.
-	result := listResources(db, schema, nil, nil)
+	result := listResources(db, nil, nil)
 	if len(result.Resources) != 1 {
 		t.Fatalf("unexpected number of resources listed: %d", len(result.Resources))
 	}
@@ -226,20 +205,16 @@ func TestListResources(t *testing.T) {
 }
 
 func TestListResourcesByName(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some resources to the database.
-	r1 := addResource(db, schema, "r1", "app")
-	addResource(db, schema, "r2", "app")
+	r1 := addResource(db, "r1", "app")
+	addResource(db, "r2", "app")
 
 	// List the resources and verify we get the expected number of results.
-	result := listResources(db, schema, nil, r1.Name)
+	result := listResources(db, nil, r1.Name)
 	if len(result.Resources) != 1 {
 		t.Fatalf("unexpected number of resources listed: %d", len(result.Resources))
 	}
@@ -255,20 +230,16 @@ func TestListResourcesByName(t *testing.T) {
 }
 
 func TestListResourcesByType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some resources to the database.
-	r1 := addResource(db, schema, "r1", "app")
-	addResource(db, schema, "r1", "analysis")
+	r1 := addResource(db, "r1", "app")
+	addResource(db, "r1", "analysis")
 
 	// List the resources and verify we get the expected number of results.
-	result := listResources(db, schema, r1.ResourceType, nil)
+	result := listResources(db, r1.ResourceType, nil)
 	if len(result.Resources) != 1 {
 		t.Fatalf("unexpected number of resources listed: %d", len(result.Resources))
 	}
@@ -284,22 +255,18 @@ func TestListResourcesByType(t *testing.T) {
 }
 
 func TestListResourcesByNameAndType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add some resources to the database.
-	r1 := addResource(db, schema, "r1", "app")
-	addResource(db, schema, "r2", "app")
-	addResource(db, schema, "r1", "analysis")
-	addResource(db, schema, "r2", "analysis")
+	r1 := addResource(db, "r1", "app")
+	addResource(db, "r2", "app")
+	addResource(db, "r1", "analysis")
+	addResource(db, "r2", "analysis")
 
 	// List the resources and verify we get the expected number of results.
-	result := listResources(db, schema, r1.ResourceType, r1.Name)
+	result := listResources(db, r1.ResourceType, r1.Name)
 	if len(result.Resources) != 1 {
 		t.Fatalf("unexpected number of resources listed: %d", len(result.Resources))
 	}
@@ -315,34 +282,26 @@ func TestListResourcesByNameAndType(t *testing.T) {
 }
 
 func TestListResourcesEmpty(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add a resource to the database.
-	result := listResources(db, schema, nil, nil)
+	result := listResources(db, nil, nil)
 	if result.Resources == nil {
 		t.Errorf("recieved a nil resource list")
 	}
 }
 
 func TestUpdateResource(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add a resource to the database.
-	r2 := addResource(db, schema, "r2", "app")
+	r2 := addResource(db, "r2", "app")
 
 	// Update the resource and verify the result,
-	d2 := updateResource(db, schema, *r2.ID, "d2")
+	d2 := updateResource(db, *r2.ID, "d2")
 	if *d2.Name != "d2" {
 		t.Errorf("unexpected resource name: %s", *d2.Name)
 	}
@@ -351,7 +310,7 @@ func TestUpdateResource(t *testing.T) {
 	}
 
 	// List the resources and verify that we get the expected number of results.
-	result := listResources(db, schema, nil, nil)
+	result := listResources(db, nil, nil)
 	if len(result.Resources) != 1 {
 		t.Fatalf("unexpected number of resources listed: %d", len(result.Resources))
 	}
@@ -367,16 +326,12 @@ func TestUpdateResource(t *testing.T) {
 }
 
 func TestUpdateNonExistentResource(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Attempt to update a non-existent resource.
-	responder := updateResourceAttempt(db, schema, FakeID, "foo")
+	responder := updateResourceAttempt(db, FakeID, "foo")
 
 	// Verify that we got the expected result.
 	errorOut := responder.(*resources.UpdateResourceNotFound).Payload
@@ -387,20 +342,16 @@ func TestUpdateNonExistentResource(t *testing.T) {
 }
 
 func TestUpdateResourceDuplicateName(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add two resources to the database.
-	r1 := addResource(db, schema, "r1", "app")
-	r2 := addResource(db, schema, "r2", "app")
+	r1 := addResource(db, "r1", "app")
+	r2 := addResource(db, "r2", "app")
 
 	// Attempt to give the second resource the first one's name.
-	responder := updateResourceAttempt(db, schema, *r2.ID, *r1.Name)
+	responder := updateResourceAttempt(db, *r2.ID, *r1.Name)
 
 	// Verify that we got the expected result.
 	errorOut := responder.(*resources.UpdateResourceBadRequest).Payload
@@ -411,56 +362,44 @@ func TestUpdateResourceDuplicateName(t *testing.T) {
 }
 
 func TestDeleteResource(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add a resource to the database then delete it.
-	r1 := addResource(db, schema, "r1", "app")
-	deleteResource(db, schema, *r1.ID)
+	r1 := addResource(db, "r1", "app")
+	deleteResource(db, *r1.ID)
 
 	// Verify that the resource was deleted.
-	result := listResources(db, schema, nil, nil)
+	result := listResources(db, nil, nil)
 	if len(result.Resources) != 0 {
 		t.Fatalf("unexpected number of resources listed: %d", len(result.Resources))
 	}
 }
 
 func TestDeleteResourceByName(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Add a resource to the database then delete it.
-	r1 := addResource(db, schema, "r1", "app")
-	deleteResourceByName(db, schema, *r1.ResourceType, *r1.Name)
+	r1 := addResource(db, "r1", "app")
+	deleteResourceByName(db, *r1.ResourceType, *r1.Name)
 
 	// Verify that the resource was deleted.
-	result := listResources(db, schema, nil, nil)
+	result := listResources(db, nil, nil)
 	if len(result.Resources) != 0 {
 		t.Fatalf("unexpected number of resources listed: %d", len(result.Resources))
 	}
 }
 
 func TestDeleteNonExistentResource(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Attempt to delete a non-existent resource.
-	responder := deleteResourceAttempt(db, schema, FakeID)
+	responder := deleteResourceAttempt(db, FakeID)
 
 	// Verify that we got the expected result.
 	errorOut := responder.(*resources.DeleteResourceNotFound).Payload
@@ -471,16 +410,12 @@ func TestDeleteNonExistentResource(t *testing.T) {
 }
 
 func TestDeleteNonExistentResourceByName(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
-	addDefaultResourceTypes(db, schema, t)
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
 
 	// Attempt to delete a non-existent resource.
-	responder := deleteResourceByNameAttempt(db, schema, "foo", "bar")
+	responder := deleteResourceByNameAttempt(db, "foo", "bar")
 
 	// Verify that we got the expected result.
 	errorOut := responder.(*resources.DeleteResourceByNameNotFound).Payload
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	impl "github.com/cyverse-de/permissions/restapi/impl/status"
+)
+
+// rollbackCountingStore is a permsdb.Store that counts the transactions that are rolled back before they complete.
+type rollbackCountingStore struct {
+	permsdb.Store
+	rollbacks int
+}
+
+func (s *rollbackCountingStore) Begin(ctx context.Context) (permsdb.Tx, error) {
+	tx, err := s.Store.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &rollbackCountingTx{Tx: tx, store: s}, nil
+}
+
+type rollbackCountingTx struct {
+	permsdb.Tx
+	store *rollbackCountingStore
+}
+
+func (t *rollbackCountingTx) Rollback() error {
+	err := t.Tx.Rollback()
+	if err == nil {
+		t.store.rollbacks++
+	}
+	return err
+}
+
+func TestReadsDontRollBack(t *testing.T) {
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+	permission := putPermission(db, "user", "s1", "app", "app1", "read")
+
+	// Look up the resource type ID.
+	tx, err := db.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("unable to start a transaction: %s", err)
+	}
+	name := "app"
+	resourceType, err := tx.GetResourceTypeByName(&name)
+	if err != nil {
+		t.Fatalf("unable to look up the resource type: %s", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("unable to commit the transaction: %s", err)
+	}
+
+	// Successful reads shouldn't roll back their transactions.
+	store := &rollbackCountingStore{Store: db}
+	getSubjectETag(store, *permission.Subject.ID)
+	getResourceETag(store, *permission.Resource.ID)
+	getResourceTypeETag(store, *resourceType.ID)
+	getPermissionETag(store, "user", "s1", "app", "app1")
+	if err := impl.PermissionLevelsCheck(store)(context.Background()); err != nil {
+		t.Fatalf("readiness check failed: %s", err)
+	}
+	if store.rollbacks != 0 {
+		t.Errorf("unexpected number of rollbacks: %d", store.rollbacks)
+	}
+}
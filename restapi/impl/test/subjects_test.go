@@ -1,11 +1,12 @@
 package test
 
 import (
-	"database/sql"
 	"fmt"
 	"testing"
 
+	"github.com/cyverse-de/permissions/clients/grouper"
 	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
 	"github.com/cyverse-de/permissions/restapi/operations/subjects"
 
 	impl "github.com/cyverse-de/permissions/restapi/impl/subjects"
@@ -23,14 +24,13 @@ func checkSubject(t *testing.T, subjects []*models.SubjectOut, i int32, subjectI
 }
 
 func addSubjectAttempt(
-	db *sql.DB,
-	schema string,
+	db permsdb.Store,
 	subjectID models.ExternalSubjectID,
 	subjectType models.SubjectType,
 ) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildAddSubjectHandler(db, schema)
+	handler := impl.BuildAddSubjectHandler(db)
 
 	// Attempt to add the subject to the database.
 	subjectIn := &models.SubjectIn{SubjectID: &subjectID, SubjectType: &subjectType}
@@ -38,36 +38,35 @@ func addSubjectAttempt(
 	return handler(params)
 }
 
-func addSubject(db *sql.DB, schema string, subjectID models.ExternalSubjectID, subjectType models.SubjectType) *models.SubjectOut {
-	responder := addSubjectAttempt(db, schema, subjectID, subjectType)
+func addSubject(db permsdb.Store, subjectID models.ExternalSubjectID, subjectType models.SubjectType) *models.SubjectOut {
+	responder := addSubjectAttempt(db, subjectID, subjectType)
 	return responder.(*subjects.AddSubjectCreated).Payload
 }
 
-func listSubjectsAttempt(db *sql.DB, schema string, subjectType, subjectID *string) middleware.Responder {
+func listSubjectsAttempt(db permsdb.Store, subjectType, subjectID *string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildListSubjectsHandler(db, schema)
+	handler := impl.BuildListSubjectsHandler(db)
 
 	// Attempt to list the subjects.
 	params := subjects.ListSubjectsParams{SubjectType: subjectType, SubjectID: subjectID}
 	return handler(params)
 }
 
-func listSubjects(db *sql.DB, schema string, subjectType, subjectID *string) *models.SubjectsOut {
-	responder := listSubjectsAttempt(db, schema, subjectType, subjectID)
+func listSubjects(db permsdb.Store, subjectType, subjectID *string) *models.SubjectsOut {
+	responder := listSubjectsAttempt(db, subjectType, subjectID)
 	return responder.(*subjects.ListSubjectsOK).Payload
 }
 
 func updateSubjectAttempt(
-	db *sql.DB,
-	schema string,
+	db permsdb.Store,
 	id models.InternalSubjectID,
 	subjectID models.ExternalSubjectID,
 	subjectType models.SubjectType,
 ) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildUpdateSubjectHandler(db, schema)
+	handler := impl.BuildUpdateSubjectHandler(db)
 
 	// Attempt to update the subject.
 	subjectIn := &models.SubjectIn{SubjectID: &subjectID, SubjectType: &subjectType}
@@ -76,58 +75,81 @@ func updateSubjectAttempt(
 }
 
 func updateSubject(
-	db *sql.DB,
-	schema string,
+	db permsdb.Store,
 	id models.InternalSubjectID,
 	subjectID models.ExternalSubjectID,
 	subjectType models.SubjectType,
 ) *models.SubjectOut {
-	responder := updateSubjectAttempt(db, schema, id, subjectID, subjectType)
+	responder := updateSubjectAttempt(db, id, subjectID, subjectType)
 	return responder.(*subjects.UpdateSubjectOK).Payload
 }
 
-func deleteSubjectAttempt(db *sql.DB, schema string, id models.InternalSubjectID) middleware.Responder {
+func deleteSubjectAttempt(db permsdb.Store, id models.InternalSubjectID) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildDeleteSubjectHandler(db, schema)
+	handler := impl.BuildDeleteSubjectHandler(db)
 
 	// Attempt to delete the subject.
 	params := subjects.DeleteSubjectParams{ID: string(id)}
 	return handler(params)
 }
 
-func deleteSubject(db *sql.DB, schema string, id models.InternalSubjectID) {
-	responder := deleteSubjectAttempt(db, schema, id)
+func deleteSubject(db permsdb.Store, id models.InternalSubjectID) {
+	responder := deleteSubjectAttempt(db, id)
 	_ = responder.(*subjects.DeleteSubjectOK)
 }
 
-func deleteSubjectByExternalIDAttempt(db *sql.DB, schema, subjectID, subjectType string) middleware.Responder {
+func deleteSubjectByExternalIDAttempt(db permsdb.Store, subjectID, subjectType string) middleware.Responder {
 
 	// Build the request handler.
-	handler := impl.BuildDeleteSubjectByExternalIDHandler(db, schema)
+	handler := impl.BuildDeleteSubjectByExternalIDHandler(db)
 
 	// Attempt to delete the subject.
 	params := subjects.DeleteSubjectByExternalIDParams{SubjectID: subjectID, SubjectType: subjectType}
 	return handler(params)
 }
 
-func deleteSubjectByExternalID(db *sql.DB, schema, subjectID, subjectType string) {
-	responder := deleteSubjectByExternalIDAttempt(db, schema, subjectID, subjectType)
+func deleteSubjectByExternalID(db permsdb.Store, subjectID, subjectType string) {
+	responder := deleteSubjectByExternalIDAttempt(db, subjectID, subjectType)
 	_ = responder.(*subjects.DeleteSubjectByExternalIDOK)
 }
 
-func TestAddSubject(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
+func mergeSubjectAttempt(
+	db permsdb.Store,
+	id models.InternalSubjectID,
+	subjectID models.ExternalSubjectID,
+	subjectType models.SubjectType,
+	dryRun bool,
+) middleware.Responder {
+
+	// Build the request handler.
+	handler := impl.BuildMergeSubjectHandler(db, grouper.Grouper(mockGrouperClient))
+
+	// Attempt to merge the subject.
+	target := &models.SubjectIn{SubjectID: &subjectID, SubjectType: &subjectType}
+	params := subjects.MergeSubjectParams{ID: string(id), Target: target, DryRun: &dryRun}
+	return handler(params)
+}
+
+func mergeSubject(
+	db permsdb.Store,
+	id models.InternalSubjectID,
+	subjectID models.ExternalSubjectID,
+	subjectType models.SubjectType,
+	dryRun bool,
+) *models.SubjectMergeResult {
+	responder := mergeSubjectAttempt(db, id, subjectID, subjectType, dryRun)
+	return responder.(*subjects.MergeSubjectOK).Payload
+}
 
+func TestAddSubject(t *testing.T) {
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add a subject.
 	subjectID := models.ExternalSubjectID("nobody")
 	subjectType := models.SubjectType("user")
-	subject := addSubject(db, schema, subjectID, subjectType)
+	subject := addSubject(db, subjectID, subjectType)
 
 	// Verify that we got the expected response.
 	if *subject.SubjectID != subjectID {
@@ -139,20 +161,16 @@ func TestAddSubject(t *testing.T) {
 }
 
 func TestAddDuplicateSubject(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add a subject.
 	subjectID := models.ExternalSubjectID("nobody")
 	subjectType := models.SubjectType("user")
-	addSubject(db, schema, subjectID, subjectType)
+	addSubject(db, subjectID, subjectType)
 
 	// Attempt to add a subject with the same ID.
-	responder := addSubjectAttempt(db, schema, subjectID, subjectType)
+	responder := addSubjectAttempt(db, subjectID, subjectType)
 	errorOut := responder.(*subjects.AddSubjectBadRequest).Payload
 
 	// Verify that we got the expected result.
@@ -163,88 +181,76 @@ func TestAddDuplicateSubject(t *testing.T) {
 }
 
 func TestListSubjects(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add a subject.
 	subjectID := models.ExternalSubjectID("nobody")
 	subjectType := models.SubjectType("user")
-	expected := addSubject(db, schema, subjectID, subjectType)
+	expected := addSubject(db, subjectID, subjectType)
 
 	// List the subjects and verify that we get the expected number of results.
-	subjectList := listSubjects(db, schema, nil, nil).Subjects
+	subjectList := listSubjects(db, nil, nil).Subjects
 	if len(subjectList) != 1 {
 		t.Fatalf("unexpected number of subjects listed: %d", len(subjectList))
 	}
 
 	// Verify that we got the expected result.
 	actual := subjectList[0]
-	if expected.ID != actual.ID {
+	if *expected.ID != *actual.ID {
 		t.Errorf("unexpected ID: %s", string(*actual.ID))
 	}
-	if expected.SubjectID != actual.SubjectID {
+	if *expected.SubjectID != *actual.SubjectID {
 		t.Errorf("unexpected subject ID: %s", string(*actual.SubjectID))
 	}
-	if expected.SubjectType != actual.SubjectType {
+	if *expected.SubjectType != *actual.SubjectType {
 		t.Errorf("unexpected subject type: %s", string(*actual.SubjectType))
 	}
 }
 
 func TestListSubjectsByExternalId(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add a subject.
-	expected := addSubject(db, schema, models.ExternalSubjectID("a"), models.SubjectType("user"))
-	addSubject(db, schema, models.ExternalSubjectID("b"), models.SubjectType("group"))
-	addSubject(db, schema, models.ExternalSubjectID("c"), models.SubjectType("user"))
-	addSubject(db, schema, models.ExternalSubjectID("d"), models.SubjectType("group"))
+	expected := addSubject(db, models.ExternalSubjectID("a"), models.SubjectType("user"))
+	addSubject(db, models.ExternalSubjectID("b"), models.SubjectType("group"))
+	addSubject(db, models.ExternalSubjectID("c"), models.SubjectType("user"))
+	addSubject(db, models.ExternalSubjectID("d"), models.SubjectType("group"))
 
 	// List the subjects and verify that we get the expected number of results.
 	subjectID := "a"
-	subjectList := listSubjects(db, schema, nil, &subjectID).Subjects
+	subjectList := listSubjects(db, nil, &subjectID).Subjects
 	if len(subjectList) != 1 {
 		t.Fatalf("unexpected number of subjects listed: %d", len(subjectList))
 	}
 
 	// Verify that we got the expected result.
 	actual := subjectList[0]
-	if expected.ID != actual.ID {
+	if *expected.ID != *actual.ID {
 		t.Errorf("unexpected ID: %s", string(*actual.ID))
 	}
-	if expected.SubjectID != actual.SubjectID {
+	if *expected.SubjectID != *actual.SubjectID {
 		t.Errorf("unexpected subject ID: %s", string(*actual.SubjectID))
 	}
-	if expected.SubjectType != actual.SubjectType {
+	if *expected.SubjectType != *actual.SubjectType {
 		t.Errorf("unexpected subject type: %s", string(*actual.SubjectType))
 	}
 }
 
 func TestListSubjectsByType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add a subject.
-	addSubject(db, schema, models.ExternalSubjectID("a"), models.SubjectType("user"))
-	addSubject(db, schema, models.ExternalSubjectID("b"), models.SubjectType("group"))
-	addSubject(db, schema, models.ExternalSubjectID("c"), models.SubjectType("user"))
-	addSubject(db, schema, models.ExternalSubjectID("d"), models.SubjectType("group"))
+	addSubject(db, models.ExternalSubjectID("a"), models.SubjectType("user"))
+	addSubject(db, models.ExternalSubjectID("b"), models.SubjectType("group"))
+	addSubject(db, models.ExternalSubjectID("c"), models.SubjectType("user"))
+	addSubject(db, models.ExternalSubjectID("d"), models.SubjectType("group"))
 
 	// List the subjects and verify that we get the expected number of results.
 	subjectType := "user"
-	subjectList := listSubjects(db, schema, &subjectType, nil).Subjects
+	subjectList := listSubjects(db, &subjectType, nil).Subjects
 	if len(subjectList) != 2 {
 		t.Fatalf("unexpected number of subjects listed: %d", len(subjectList))
 	}
@@ -255,23 +261,19 @@ func TestListSubjectsByType(t *testing.T) {
 }
 
 func TestListSubjectsByExternalIdAndType(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add a subject.
-	addSubject(db, schema, models.ExternalSubjectID("a"), models.SubjectType("user"))
-	addSubject(db, schema, models.ExternalSubjectID("b"), models.SubjectType("group"))
-	addSubject(db, schema, models.ExternalSubjectID("c"), models.SubjectType("user"))
-	addSubject(db, schema, models.ExternalSubjectID("d"), models.SubjectType("group"))
+	addSubject(db, models.ExternalSubjectID("a"), models.SubjectType("user"))
+	addSubject(db, models.ExternalSubjectID("b"), models.SubjectType("group"))
+	addSubject(db, models.ExternalSubjectID("c"), models.SubjectType("user"))
+	addSubject(db, models.ExternalSubjectID("d"), models.SubjectType("group"))
 
 	// List the subjects and verify that we get the expected number of results.
 	subjectID := "a"
 	subjectType := "user"
-	subjectList := listSubjects(db, schema, &subjectType, &subjectID).Subjects
+	subjectList := listSubjects(db, &subjectType, &subjectID).Subjects
 	if len(subjectList) != 1 {
 		t.Fatalf("unexpected number of subjects listed: %d", len(subjectList))
 	}
@@ -281,15 +283,11 @@ func TestListSubjectsByExternalIdAndType(t *testing.T) {
 }
 
 func TestListSubjectsEmpty(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// List the subjects and verify that we get the expected result.
-	subjectList := listSubjects(db, schema, nil, nil).Subjects
+	subjectList := listSubjects(db, nil, nil).Subjects
 	if subjectList == nil {
 		t.Error("nil value returned as a subject list")
 	}
@@ -299,25 +297,21 @@ func TestListSubjectsEmpty(t *testing.T) {
 }
 
 func TestUpdateSubject(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add a subject to the database.
 	origID := models.ExternalSubjectID("s1")
 	origType := models.SubjectType("user")
-	orig := addSubject(db, schema, origID, origType)
+	orig := addSubject(db, origID, origType)
 
 	// Change the subject ID and type.
 	newID := models.ExternalSubjectID("s2")
 	newType := models.SubjectType("group")
-	new := updateSubject(db, schema, *orig.ID, newID, newType)
+	new := updateSubject(db, *orig.ID, newID, newType)
 
 	// Verify that we got the expected result.
-	if new.ID != orig.ID {
+	if *new.ID != *orig.ID {
 		t.Errorf("unexpected internal ID returned: %s", *new.ID)
 	}
 	if *new.SubjectID != newID {
@@ -328,14 +322,14 @@ func TestUpdateSubject(t *testing.T) {
 	}
 
 	// List the subjects and verify that we get the expected number of results.
-	subjectList := listSubjects(db, schema, nil, nil).Subjects
+	subjectList := listSubjects(db, nil, nil).Subjects
 	if len(subjectList) != 1 {
 		t.Fatalf("unexpected number of results: %d", len(subjectList))
 	}
 
 	// Verify that we get the expected result.
 	listed := subjectList[0]
-	if listed.ID != orig.ID {
+	if *listed.ID != *orig.ID {
 		t.Errorf("unexpected internal ID listed: %s", *listed.ID)
 	}
 	if *listed.SubjectID != newID {
@@ -347,17 +341,13 @@ func TestUpdateSubject(t *testing.T) {
 }
 
 func TestUpdateSubjectNotFound(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Attempt to update a subject.
 	newID := models.ExternalSubjectID("s1")
 	newType := models.SubjectType("group")
-	responder := updateSubjectAttempt(db, schema, FakeID, newID, newType)
+	responder := updateSubjectAttempt(db, FakeID, newID, newType)
 	errorOut := responder.(*subjects.UpdateSubjectNotFound).Payload
 
 	// Verify that we got the expected error message.
@@ -368,25 +358,21 @@ func TestUpdateSubjectNotFound(t *testing.T) {
 }
 
 func TestUpdateSubjectDuplicate(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Insert the first subject into the database.
 	s1Id := models.ExternalSubjectID("s1")
 	s1Type := models.SubjectType("user")
-	s1 := addSubject(db, schema, s1Id, s1Type)
+	s1 := addSubject(db, s1Id, s1Type)
 
 	// Insert the second subject into the database.
 	s2Id := models.ExternalSubjectID("s2")
 	s2Type := models.SubjectType("group")
-	s2 := addSubject(db, schema, s2Id, s2Type)
+	s2 := addSubject(db, s2Id, s2Type)
 
 	// Attempt to change the ID of the second subject to be the same as the first.
-	responder := updateSubjectAttempt(db, schema, *s2.ID, *s1.SubjectID, *s2.SubjectType)
+	responder := updateSubjectAttempt(db, *s2.ID, *s1.SubjectID, *s2.SubjectType)
 	errorOut := responder.(*subjects.UpdateSubjectBadRequest).Payload
 
 	// Verify that we got the expected error message.
@@ -397,38 +383,30 @@ func TestUpdateSubjectDuplicate(t *testing.T) {
 }
 
 func TestDeleteSubject(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Insert a subject into the database.
 	s1Id := models.ExternalSubjectID("s1")
 	s1Type := models.SubjectType("user")
-	s1 := addSubject(db, schema, s1Id, s1Type)
+	s1 := addSubject(db, s1Id, s1Type)
 
 	// Delete the subject.
-	deleteSubject(db, schema, *s1.ID)
+	deleteSubject(db, *s1.ID)
 
 	// Verify that the subject was deleted.
-	subjectList := listSubjects(db, schema, nil, nil).Subjects
+	subjectList := listSubjects(db, nil, nil).Subjects
 	if len(subjectList) != 0 {
 		t.Fatalf("unexpected number of results: %d", len(subjectList))
 	}
 }
 
 func TestDeleteSubjectNotFound(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Attempt to delete a non-existent subject.
-	responder := deleteSubjectAttempt(db, schema, models.InternalSubjectID(FakeID))
+	responder := deleteSubjectAttempt(db, models.InternalSubjectID(FakeID))
 	errorOut := responder.(*subjects.DeleteSubjectNotFound).Payload
 
 	// Verify that we got the expected error message.
@@ -439,22 +417,18 @@ func TestDeleteSubjectNotFound(t *testing.T) {
 }
 
 func TestDeleteSubjectByName(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Add a subject to the database.
-	addSubject(db, schema, models.ExternalSubjectID("a"), models.SubjectType("user"))
-	addSubject(db, schema, models.ExternalSubjectID("b"), models.SubjectType("user"))
+	addSubject(db, models.ExternalSubjectID("a"), models.SubjectType("user"))
+	addSubject(db, models.ExternalSubjectID("b"), models.SubjectType("user"))
 
 	// Delete the subject.
-	deleteSubjectByExternalID(db, schema, "a", "user")
+	deleteSubjectByExternalID(db, "a", "user")
 
 	// Verify that the subject was deleted.
-	subjectList := listSubjects(db, schema, nil, nil).Subjects
+	subjectList := listSubjects(db, nil, nil).Subjects
 	if len(subjectList) != 1 {
 		t.Fatalf("unexpected number of results: %d", len(subjectList))
 	}
@@ -464,15 +438,11 @@ func TestDeleteSubjectByName(t *testing.T) {
 }
 
 func TestDeleteSubjectByNameNotFound(t *testing.T) {
-	if !shouldRun() {
-		return
-	}
-
 	// Initialize the database.
-	db, schema := initdb(t)
+	db := initdb(t)
 
 	// Attempt to delete a subject.
-	responder := deleteSubjectByExternalIDAttempt(db, schema, "a", "user")
+	responder := deleteSubjectByExternalIDAttempt(db, "a", "user")
 	errorOut := responder.(*subjects.DeleteSubjectByExternalIDNotFound).Payload
 
 	// Verify that we got the expected error message.
@@ -481,3 +451,139 @@ func TestDeleteSubjectByNameNotFound(t *testing.T) {
 		t.Errorf("unexpected failure reason: %s", *errorOut.Reason)
 	}
 }
+
+func TestMergeSubject(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Add some permissions.
+	addDefaultPermissions(db)
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	s2 := putPermission(db, "user", "s2", "app", "app4", "write").Subject
+
+	// Merge subject s2 into subject s1.
+	result := mergeSubject(db, *s2.ID, "s1", "user", false)
+	if *result.Subject.SubjectID != "s1" {
+		t.Errorf("unexpected subject ID: %s", string(*result.Subject.SubjectID))
+	}
+
+	// Verify that the conflict was reported.
+	if len(result.Conflicts) != 1 {
+		t.Fatalf("unexpected number of conflicts: %d", len(result.Conflicts))
+	}
+	conflict := result.Conflicts[0]
+	if *conflict.Resource.Name != "app1" {
+		t.Errorf("unexpected conflicting resource: %s", *conflict.Resource.Name)
+	}
+	if *conflict.SourceLevel != "own" || *conflict.TargetLevel != "read" || *conflict.ResolvedLevel != "own" {
+		t.Errorf(
+			"unexpected conflict levels: %s, %s, %s",
+			*conflict.SourceLevel, *conflict.TargetLevel, *conflict.ResolvedLevel,
+		)
+	}
+
+	// Verify that the permissions were moved.
+	perms := listSubjectPermissions(db, "user", "s1").Permissions
+	if len(perms) != 5 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+	checkPerm(t, perms, 0, "app1", "s1", "own")
+	checkPerm(t, perms, 1, "app2", "s1", "read")
+	checkPerm(t, perms, 2, "analysis1", "s1", "own")
+	checkPerm(t, perms, 3, "analysis2", "s1", "read")
+	checkPerm(t, perms, 4, "app4", "s1", "write")
+
+	// Verify that the source subject was removed.
+	subjectID := "s2"
+	subjectList := listSubjects(db, nil, &subjectID).Subjects
+	if len(subjectList) != 0 {
+		t.Fatalf("unexpected number of results: %d", len(subjectList))
+	}
+}
+
+func TestMergeSubjectRename(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Add some permissions.
+	addDefaultPermissions(db)
+	s3 := putPermission(db, "user", "s3", "app", "app2", "write").Subject
+
+	// Merge subject s3 into a subject that doesn't exist yet.
+	result := mergeSubject(db, *s3.ID, "s4", "user", false)
+	if *result.Subject.ID != *s3.ID {
+		t.Errorf("unexpected internal subject ID: %s", string(*result.Subject.ID))
+	}
+	if len(result.Conflicts) != 0 {
+		t.Errorf("unexpected number of conflicts: %d", len(result.Conflicts))
+	}
+
+	// Verify that the subject was renamed.
+	perms := listSubjectPermissions(db, "user", "s4").Permissions
+	if len(perms) != 3 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+	checkPerm(t, perms, 0, "app1", "s4", "read")
+	checkPerm(t, perms, 1, "app2", "s4", "write")
+	checkPerm(t, perms, 2, "analysis1", "s4", "read")
+}
+
+func TestMergeSubjectDryRun(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Add some permissions.
+	addDefaultPermissions(db)
+	s1 := putPermission(db, "user", "s1", "app", "app1", "read").Subject
+
+	// Preview merging subject s1 into subject s2.
+	result := mergeSubject(db, *s1.ID, "s2", "user", true)
+	if len(result.Conflicts) != 1 {
+		t.Fatalf("unexpected number of conflicts: %d", len(result.Conflicts))
+	}
+	checkPerm(t, result.Permissions, 0, "app1", "s2", "own")
+
+	// Verify that nothing was changed.
+	perms := listSubjectPermissions(db, "user", "s1").Permissions
+	if len(perms) != 1 {
+		t.Fatalf("unexpected number of results: %d", len(perms))
+	}
+	checkPerm(t, perms, 0, "app1", "s1", "read")
+}
+
+func TestMergeSubjectNotFound(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+
+	// Attempt to merge a non-existent subject.
+	responder := mergeSubjectAttempt(db, models.InternalSubjectID(FakeID), "s1", "user", false)
+	errorOut := responder.(*subjects.MergeSubjectNotFound).Payload
+
+	// Verify that we got the expected error message.
+	expected := fmt.Sprintf("subject, %s, not found", FakeID)
+	if *errorOut.Reason != expected {
+		t.Errorf("unexpected failure reason: %s", *errorOut.Reason)
+	}
+}
+
+func TestMergeSubjectTypeMismatch(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+
+	// Add the subjects.
+	s1 := addSubject(db, models.ExternalSubjectID("s1"), models.SubjectType("user"))
+	addSubject(db, models.ExternalSubjectID("g1"), models.SubjectType("group"))
+
+	// Attempt to merge subject s1 into a subject with the wrong type.
+	responder := mergeSubjectAttempt(db, *s1.ID, "g1", "user", false)
+	errorOut := responder.(*subjects.MergeSubjectBadRequest).Payload
+
+	// Verify that we got the expected error message.
+	expected := "another subject with the ID, g1, already exists with type, group"
+	if *errorOut.Reason != expected {
+		t.Errorf("unexpected failure reason: %s", *errorOut.Reason)
+	}
+}
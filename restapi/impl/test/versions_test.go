@@ -0,0 +1,330 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/cyverse-de/permissions/clients/grouper"
+	"github.com/cyverse-de/permissions/models"
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/operations/permissions"
+	"github.com/cyverse-de/permissions/restapi/operations/resource_types"
+	"github.com/cyverse-de/permissions/restapi/operations/resources"
+	"github.com/cyverse-de/permissions/restapi/operations/subjects"
+	middleware "github.com/go-openapi/runtime/middleware"
+
+	permsimpl "github.com/cyverse-de/permissions/restapi/impl/permissions"
+	resourcesimpl "github.com/cyverse-de/permissions/restapi/impl/resources"
+	rtimpl "github.com/cyverse-de/permissions/restapi/impl/resourcetypes"
+	subjectsimpl "github.com/cyverse-de/permissions/restapi/impl/subjects"
+)
+
+func getSubjectAttempt(db permsdb.Store, id models.InternalSubjectID) middleware.Responder {
+	handler := subjectsimpl.BuildGetSubjectHandler(db)
+	return handler(subjects.GetSubjectParams{ID: string(id)})
+}
+
+func getSubjectETag(db permsdb.Store, id models.InternalSubjectID) string {
+	return getSubjectAttempt(db, id).(*subjects.GetSubjectOK).ETag
+}
+
+func updateSubjectIfMatch(
+	db permsdb.Store, id models.InternalSubjectID, subjectID, subjectType, ifMatch string,
+) middleware.Responder {
+
+	// Build the request handler.
+	handler := subjectsimpl.BuildUpdateSubjectHandler(db)
+
+	// Attempt to update the subject.
+	sid := models.ExternalSubjectID(subjectID)
+	st := models.SubjectType(subjectType)
+	params := subjects.UpdateSubjectParams{
+		ID:        string(id),
+		SubjectIn: &models.SubjectIn{SubjectID: &sid, SubjectType: &st},
+		IfMatch:   &ifMatch,
+	}
+	return handler(params)
+}
+
+func getResourceAttempt(db permsdb.Store, id string) middleware.Responder {
+	handler := resourcesimpl.BuildGetResourceHandler(db)
+	return handler(resources.GetResourceParams{ID: id})
+}
+
+func getResourceETag(db permsdb.Store, id string) string {
+	return getResourceAttempt(db, id).(*resources.GetResourceOK).ETag
+}
+
+func updateResourceIfMatch(db permsdb.Store, id, name, ifMatch string) middleware.Responder {
+
+	// Build the request handler.
+	handler := resourcesimpl.BuildUpdateResourceHandler(db)
+
+	// Attempt to update the resource.
+	params := resources.UpdateResourceParams{
+		ID:             id,
+		ResourceUpdate: &models.ResourceUpdate{Name: &name},
+		IfMatch:        &ifMatch,
+	}
+	return handler(params)
+}
+
+func getResourceTypeETag(db permsdb.Store, id string) string {
+	handler := rtimpl.BuildResourceTypesIDGetHandler(db)
+	return handler(resource_types.GetResourceTypesIDParams{ID: id}).(*resource_types.GetResourceTypesIDOK).ETag
+}
+
+func modifyResourceTypeIfMatch(db permsdb.Store, id, name, ifMatch string) middleware.Responder {
+
+	// Build the request handler.
+	handler := rtimpl.BuildResourceTypesIDPutHandler(db)
+
+	// Attempt to update the resource type.
+	params := resource_types.PutResourceTypesIDParams{
+		ID:             id,
+		ResourceTypeIn: &models.ResourceTypeIn{Name: &name},
+		IfMatch:        &ifMatch,
+	}
+	return handler(params)
+}
+
+func getPermissionAttempt(
+	db permsdb.Store, subjectType, subjectID, resourceType, resourceName string,
+) middleware.Responder {
+
+	// Build the request handler.
+	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
+	handler := permsimpl.BuildGetPermissionHandler(db, grouperClient)
+
+	// Attempt to look up the permission.
+	params := permissions.GetPermissionParams{
+		SubjectType:  subjectType,
+		SubjectID:    subjectID,
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+	}
+	return handler(params)
+}
+
+func getPermissionETag(db permsdb.Store, subjectType, subjectID, resourceType, resourceName string) string {
+	responder := getPermissionAttempt(db, subjectType, subjectID, resourceType, resourceName)
+	return responder.(*permissions.GetPermissionOK).ETag
+}
+
+func putPermissionIfMatch(
+	db permsdb.Store, subjectType, subjectID, resourceType, resourceName, level, ifMatch string,
+) middleware.Responder {
+
+	// Build the request handler.
+	grouperClient := grouper.NewMockGrouperClient(make(map[string][]*grouper.GroupInfo))
+	handler := permsimpl.BuildPutPermissionHandler(db, grouperClient)
+
+	// Attempt to put the permission.
+	permissionLevel := models.PermissionLevel(level)
+	params := permissions.PutPermissionParams{
+		SubjectType:  subjectType,
+		SubjectID:    subjectID,
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		Permission:   &models.PermissionPutRequest{PermissionLevel: &permissionLevel},
+		IfMatch:      &ifMatch,
+	}
+	return handler(params)
+}
+
+func revokePermissionIfMatch(
+	db permsdb.Store, subjectType, subjectID, resourceType, resourceName, ifMatch string,
+) middleware.Responder {
+
+	// Build the request handler.
+	handler := permsimpl.BuildRevokePermissionHandler(db)
+
+	// Attempt to revoke the permission.
+	params := permissions.RevokePermissionParams{
+		SubjectType:  subjectType,
+		SubjectID:    subjectID,
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		IfMatch:      &ifMatch,
+	}
+	return handler(params)
+}
+
+func TestSubjectVersions(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+
+	// Add a subject and get its entity tag.
+	subject := addSubject(db, "s1", "user")
+	original := getSubjectETag(db, *subject.ID)
+
+	// Update the subject using the current entity tag.
+	responder := updateSubjectIfMatch(db, *subject.ID, "s2", "user", original)
+	updated, ok := responder.(*subjects.UpdateSubjectOK)
+	if !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+	if updated.ETag == original {
+		t.Errorf("the entity tag didn't change after an update: %s", updated.ETag)
+	}
+	if current := getSubjectETag(db, *subject.ID); current != updated.ETag {
+		t.Errorf("unexpected entity tag: %s != %s", current, updated.ETag)
+	}
+
+	// A second update using the original entity tag should fail.
+	responder = updateSubjectIfMatch(db, *subject.ID, "s3", "user", original)
+	if _, ok := responder.(*subjects.UpdateSubjectPreconditionFailed); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+	checkSubject(t, listSubjects(db, nil, nil).Subjects, 0, "s2", "user")
+}
+
+func TestGetSubjectNotFound(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+
+	// Attempt to look up a subject that doesn't exist.
+	responder := getSubjectAttempt(db, models.InternalSubjectID(FakeID))
+	if _, ok := responder.(*subjects.GetSubjectNotFound); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+}
+
+func TestResourceVersions(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Add a resource and get its entity tag.
+	resource := addResource(db, "r1", "app")
+	original := getResourceETag(db, *resource.ID)
+
+	// Update the resource using the current entity tag.
+	responder := updateResourceIfMatch(db, *resource.ID, "r2", original)
+	updated, ok := responder.(*resources.UpdateResourceOK)
+	if !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+	if updated.ETag == original {
+		t.Errorf("the entity tag didn't change after an update: %s", updated.ETag)
+	}
+
+	// A second update using the original entity tag should fail.
+	responder = updateResourceIfMatch(db, *resource.ID, "r3", original)
+	if _, ok := responder.(*resources.UpdateResourcePreconditionFailed); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+	if name := *getResourceAttempt(db, *resource.ID).(*resources.GetResourceOK).Payload.Name; name != "r2" {
+		t.Errorf("unexpected resource name: %s", name)
+	}
+}
+
+func TestResourceTypeVersions(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+
+	// Add a resource type and get its entity tag.
+	resourceType := addResourceType(db, "foo", "The foo resource type.")
+	original := getResourceTypeETag(db, *resourceType.ID)
+
+	// Update the resource type using the current entity tag.
+	responder := modifyResourceTypeIfMatch(db, *resourceType.ID, "bar", original)
+	if _, ok := responder.(*resource_types.PutResourceTypesIDOK); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+
+	// A second update using the original entity tag should fail.
+	responder = modifyResourceTypeIfMatch(db, *resourceType.ID, "baz", original)
+	if _, ok := responder.(*resource_types.PutResourceTypesIDPreconditionFailed); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+}
+
+func TestPermissionVersions(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Grant a permission and get its entity tag.
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	original := getPermissionETag(db, "user", "s1", "app", "app1")
+
+	// Putting the same permission level shouldn't change the entity tag.
+	responder := putPermissionIfMatch(db, "user", "s1", "app", "app1", "read", original)
+	unchanged, ok := responder.(*permissions.PutPermissionOK)
+	if !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+	if unchanged.ETag != original {
+		t.Errorf("the entity tag changed unexpectedly: %s != %s", unchanged.ETag, original)
+	}
+
+	// Update the permission using the current entity tag.
+	responder = putPermissionIfMatch(db, "user", "s1", "app", "app1", "write", original)
+	updated, ok := responder.(*permissions.PutPermissionOK)
+	if !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+	if updated.ETag == original {
+		t.Errorf("the entity tag didn't change after an update: %s", updated.ETag)
+	}
+
+	// Updating or revoking the permission using the original entity tag should fail.
+	responder = putPermissionIfMatch(db, "user", "s1", "app", "app1", "own", original)
+	if _, ok := responder.(*permissions.PutPermissionPreconditionFailed); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+	responder = revokePermissionIfMatch(db, "user", "s1", "app", "app1", original)
+	if _, ok := responder.(*permissions.RevokePermissionPreconditionFailed); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+
+	// Revoking the permission using the current entity tag should succeed.
+	responder = revokePermissionIfMatch(db, "user", "s1", "app", "app1", updated.ETag)
+	if _, ok := responder.(*permissions.RevokePermissionOK); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+	responder = getPermissionAttempt(db, "user", "s1", "app", "app1")
+	if _, ok := responder.(*permissions.GetPermissionNotFound); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+}
+
+func TestPermissionVersionsNotReused(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// Grant a permission, update it and get its entity tag.
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	putPermission(db, "user", "s1", "app", "app1", "write")
+	stale := getPermissionETag(db, "user", "s1", "app", "app1")
+
+	// Revoke the permission and grant it again.
+	responder := revokePermissionIfMatch(db, "user", "s1", "app", "app1", stale)
+	if _, ok := responder.(*permissions.RevokePermissionOK); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+	putPermission(db, "user", "s1", "app", "app1", "read")
+	if regranted := getPermissionETag(db, "user", "s1", "app", "app1"); regranted == stale {
+		t.Errorf("the entity tag was reused after the permission was granted again: %s", regranted)
+	}
+
+	// Updating the new permission using the entity tag of the old one should fail, even after another update.
+	putPermission(db, "user", "s1", "app", "app1", "write")
+	responder = putPermissionIfMatch(db, "user", "s1", "app", "app1", "own", stale)
+	if _, ok := responder.(*permissions.PutPermissionPreconditionFailed); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+}
+
+func TestPutPermissionIfMatchMissing(t *testing.T) {
+	// Initialize the database.
+	db := initdb(t)
+	addDefaultResourceTypes(db, t)
+
+	// An entity tag can't match a permission that doesn't exist yet.
+	responder := putPermissionIfMatch(db, "user", "s1", "app", "app1", "read", `"1"`)
+	if _, ok := responder.(*permissions.PutPermissionPreconditionFailed); !ok {
+		t.Fatalf("unexpected response type: %T", responder)
+	}
+}
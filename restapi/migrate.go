@@ -0,0 +1,142 @@
+package restapi
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/migrations"
+)
+
+// The migrate subcommand and its own subcommands.
+type migrateCommand struct {
+	Up     migrateUpCommand     `command:"up" description:"Apply all pending migrations"`
+	Down   migrateDownCommand   `command:"down" description:"Revert the most recently applied migrations"`
+	Status migrateStatusCommand `command:"status" description:"List the known migrations and when they were applied"`
+}
+
+type migrateUpCommand struct{}
+
+type migrateDownCommand struct {
+	Steps int `long:"steps" default:"1" description:"The number of migrations to revert"`
+}
+
+type migrateStatusCommand struct{}
+
+// Run the migrate subcommand and return the process exit code.
+func runMigrateCommand(args []string) int {
+	parser := flags.NewParser(&migrateCommand{}, flags.Default)
+	parser.Name = "permissions-server migrate"
+	parser.ShortDescription = "Manages the permissions database schema"
+	if _, err := parser.AddGroup("Service Options", "", &options); err != nil {
+		logger.Log.Error(err)
+		return 1
+	}
+
+	if _, err := parser.ParseArgs(args); err != nil {
+		if fe, ok := err.(*flags.Error); ok && fe.Type == flags.ErrHelp {
+			return 0
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// Apply all pending migrations to the permissions schema.
+func migrateUp(db *sql.DB, schema string) error {
+	migrator, err := migrations.NewMigrator(db, schema)
+	if err != nil {
+		return err
+	}
+
+	count, err := migrator.Up()
+	if err != nil {
+		return err
+	}
+	logger.Log.Infof("%d migration(s) applied", count)
+
+	return nil
+}
+
+// Connect to the database and call a function with a migrator for the configured schema.
+func withMigrator(f func(*migrations.Migrator) error) error {
+	if err := validateOptions(); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if usesSQLite(cfg) {
+		return fmt.Errorf("SQLite databases don't use schema migrations; the schema is created when the service starts")
+	}
+
+	db, err := connectDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migrations.NewMigrator(db, cfg.GetString("db.schema"))
+	if err != nil {
+		return err
+	}
+
+	return f(migrator)
+}
+
+// Execute applies all pending migrations.
+func (c *migrateUpCommand) Execute(args []string) error {
+	return withMigrator(func(migrator *migrations.Migrator) error {
+		count, err := migrator.Up()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) applied\n", count)
+		return nil
+	})
+}
+
+// Execute reverts the most recently applied migrations.
+func (c *migrateDownCommand) Execute(args []string) error {
+	if c.Steps < 1 {
+		return fmt.Errorf("--steps must be at least 1")
+	}
+
+	return withMigrator(func(migrator *migrations.Migrator) error {
+		count, err := migrator.Down(c.Steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) reverted\n", count)
+		return nil
+	})
+}
+
+// Execute lists the known migrations and when they were applied.
+func (c *migrateStatusCommand) Execute(args []string) error {
+	return withMigrator(func(migrator *migrations.Migrator) error {
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Migration.Version, status.Migration.Name, applied)
+		}
+		return w.Flush()
+	})
+}
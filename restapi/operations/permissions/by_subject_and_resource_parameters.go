@@ -24,10 +24,12 @@ func NewBySubjectAndResourceParams() BySubjectAndResourceParams {
 		// initialize parameters with default values
 
 		lookupDefault = bool(false)
+		xReadYourWritesDefault = bool(false)
 	)
 
 	return BySubjectAndResourceParams{
 		Lookup: &lookupDefault,
+		XReadYourWrites: &xReadYourWritesDefault,
 	}
 }
 
@@ -69,6 +71,11 @@ type BySubjectAndResourceParams struct {
 	  In: path
 	*/
 	SubjectType string
+	/*True if the lookup should be performed using the primary database even if a read replica is configured. This can be used to ensure that the results reflect changes that were made immediately before the lookup. This header is optional and defaults to False.
+	  In: header
+	  Default: false
+	*/
+	XReadYourWrites *bool
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -111,6 +118,10 @@ func (o *BySubjectAndResourceParams) BindRequest(r *http.Request, route *middlew
 	if err := o.bindSubjectType(rSubjectType, rhkSubjectType, route.Formats); err != nil {
 		res = append(res, err)
 	}
+
+	if err := o.bindXReadYourWrites(r.Header[http.CanonicalHeaderKey("X-Read-Your-Writes")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -242,3 +253,26 @@ func (o *BySubjectAndResourceParams) validateSubjectType(formats strfmt.Registry
 
 	return nil
 }
+
+// bindXReadYourWrites binds and validates parameter XReadYourWrites from header.
+func (o *BySubjectAndResourceParams) bindXReadYourWrites(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewBySubjectAndResourceParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("X-Read-Your-Writes", "header", "bool", raw)
+	}
+	o.XReadYourWrites = &value
+
+	return nil
+}
@@ -24,10 +24,12 @@ func NewBySubjectAndResourceTypeAbbreviatedParams() BySubjectAndResourceTypeAbbr
 		// initialize parameters with default values
 
 		lookupDefault = bool(false)
+		xReadYourWritesDefault = bool(false)
 	)
 
 	return BySubjectAndResourceTypeAbbreviatedParams{
 		Lookup: &lookupDefault,
+		XReadYourWrites: &xReadYourWritesDefault,
 	}
 }
 
@@ -40,6 +42,10 @@ type BySubjectAndResourceTypeAbbreviatedParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*Limits the results to resources whose labels match the selector: a comma-separated list of requirements, all of which must be met. Each requirement has one of these forms: key=value, key!=value, key in (value1, value2), key notin (value1, value2), key or !key. A resource without the label meets key!=value and key notin requirements. All resources match by default.
+	  In: query
+	*/
+	LabelSelector *string
 	/*True if a permission lookup should be performed. A permission lookup differs from standard permisison retrieval in two ways. First, only the most permissive permission level available to the subject is returned for any given resource. Second, if the subject happens to be a user then permissions granted to groups that the user belongs to are also included in the results. This parameter is optional and defaults to False.
 	  In: query
 	  Default: false
@@ -64,6 +70,11 @@ type BySubjectAndResourceTypeAbbreviatedParams struct {
 	  In: path
 	*/
 	SubjectType string
+	/*True if the lookup should be performed using the primary database even if a read replica is configured. This can be used to ensure that the results reflect changes that were made immediately before the lookup. This header is optional and defaults to False.
+	  In: header
+	  Default: false
+	*/
+	XReadYourWrites *bool
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -77,6 +88,11 @@ func (o *BySubjectAndResourceTypeAbbreviatedParams) BindRequest(r *http.Request,
 
 	qs := runtime.Values(r.URL.Query())
 
+	qLabelSelector, qhkLabelSelector, _ := qs.GetOK("label_selector")
+	if err := o.bindLabelSelector(qLabelSelector, qhkLabelSelector, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qLookup, qhkLookup, _ := qs.GetOK("lookup")
 	if err := o.bindLookup(qLookup, qhkLookup, route.Formats); err != nil {
 		res = append(res, err)
@@ -101,12 +117,34 @@ func (o *BySubjectAndResourceTypeAbbreviatedParams) BindRequest(r *http.Request,
 	if err := o.bindSubjectType(rSubjectType, rhkSubjectType, route.Formats); err != nil {
 		res = append(res, err)
 	}
+
+	if err := o.bindXReadYourWrites(r.Header[http.CanonicalHeaderKey("X-Read-Your-Writes")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+// bindLabelSelector binds and validates parameter LabelSelector from query.
+func (o *BySubjectAndResourceTypeAbbreviatedParams) bindLabelSelector(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.LabelSelector = &raw
+
+	return nil
+}
+
 // bindLookup binds and validates parameter Lookup from query.
 func (o *BySubjectAndResourceTypeAbbreviatedParams) bindLookup(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -218,3 +256,26 @@ func (o *BySubjectAndResourceTypeAbbreviatedParams) validateSubjectType(formats
 
 	return nil
 }
+
+// bindXReadYourWrites binds and validates parameter XReadYourWrites from header.
+func (o *BySubjectAndResourceTypeAbbreviatedParams) bindXReadYourWrites(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewBySubjectAndResourceTypeAbbreviatedParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("X-Read-Your-Writes", "header", "bool", raw)
+	}
+	o.XReadYourWrites = &value
+
+	return nil
+}
@@ -20,8 +20,9 @@ type BySubjectAndResourceTypeAbbreviatedURL struct {
 	SubjectID    string
 	SubjectType  string
 
-	Lookup   *bool
-	MinLevel *string
+	LabelSelector *string
+	Lookup        *bool
+	MinLevel      *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -75,6 +76,14 @@ func (o *BySubjectAndResourceTypeAbbreviatedURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var labelSelectorQ string
+	if o.LabelSelector != nil {
+		labelSelectorQ = *o.LabelSelector
+	}
+	if labelSelectorQ != "" {
+		qs.Set("label_selector", labelSelectorQ)
+	}
+
 	var lookupQ string
 	if o.Lookup != nil {
 		lookupQ = swag.FormatBool(*o.Lookup)
@@ -24,10 +24,12 @@ func NewBySubjectParams() BySubjectParams {
 		// initialize parameters with default values
 
 		lookupDefault = bool(false)
+		xReadYourWritesDefault = bool(false)
 	)
 
 	return BySubjectParams{
 		Lookup: &lookupDefault,
+		XReadYourWrites: &xReadYourWritesDefault,
 	}
 }
 
@@ -59,6 +61,11 @@ type BySubjectParams struct {
 	  In: path
 	*/
 	SubjectType string
+	/*True if the lookup should be performed using the primary database even if a read replica is configured. This can be used to ensure that the results reflect changes that were made immediately before the lookup. This header is optional and defaults to False.
+	  In: header
+	  Default: false
+	*/
+	XReadYourWrites *bool
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -91,6 +98,10 @@ func (o *BySubjectParams) BindRequest(r *http.Request, route *middleware.Matched
 	if err := o.bindSubjectType(rSubjectType, rhkSubjectType, route.Formats); err != nil {
 		res = append(res, err)
 	}
+
+	if err := o.bindXReadYourWrites(r.Header[http.CanonicalHeaderKey("X-Read-Your-Writes")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -194,3 +205,26 @@ func (o *BySubjectParams) validateSubjectType(formats strfmt.Registry) error {
 
 	return nil
 }
+
+// bindXReadYourWrites binds and validates parameter XReadYourWrites from header.
+func (o *BySubjectParams) bindXReadYourWrites(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewBySubjectParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("X-Read-Your-Writes", "header", "bool", raw)
+	}
+	o.XReadYourWrites = &value
+
+	return nil
+}
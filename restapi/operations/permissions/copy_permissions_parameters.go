@@ -14,17 +14,25 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 
 	"github.com/cyverse-de/permissions/models"
 )
 
 // NewCopyPermissionsParams creates a new CopyPermissionsParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewCopyPermissionsParams() CopyPermissionsParams {
 
-	return CopyPermissionsParams{}
+	var (
+		// initialize parameters with default values
+
+		dryRunDefault = bool(false)
+	)
+
+	return CopyPermissionsParams{
+		DryRun: &dryRunDefault,
+	}
 }
 
 // CopyPermissionsParams contains all the bound params for the copy permissions operation
@@ -41,6 +49,11 @@ type CopyPermissionsParams struct {
 	  In: body
 	*/
 	DestSubjects *models.SubjectsIn
+	/*True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.
+	  In: query
+	  Default: false
+	*/
+	DryRun *bool
 	/*The external subject identifier.
 	  Required: true
 	  In: path
@@ -62,6 +75,8 @@ func (o *CopyPermissionsParams) BindRequest(r *http.Request, route *middleware.M
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body models.SubjectsIn
@@ -90,6 +105,11 @@ func (o *CopyPermissionsParams) BindRequest(r *http.Request, route *middleware.M
 		res = append(res, errors.Required("destSubjects", "body", ""))
 	}
 
+	qDryRun, qhkDryRun, _ := qs.GetOK("dry_run")
+	if err := o.bindDryRun(qDryRun, qhkDryRun, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rSubjectID, rhkSubjectID, _ := route.Params.GetOK("subject_id")
 	if err := o.bindSubjectID(rSubjectID, rhkSubjectID, route.Formats); err != nil {
 		res = append(res, err)
@@ -105,6 +125,30 @@ func (o *CopyPermissionsParams) BindRequest(r *http.Request, route *middleware.M
 	return nil
 }
 
+// bindDryRun binds and validates parameter DryRun from query.
+func (o *CopyPermissionsParams) bindDryRun(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewCopyPermissionsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dry_run", "query", "bool", raw)
+	}
+	o.DryRun = &value
+
+	return nil
+}
+
 // bindSubjectID binds and validates parameter SubjectID from path.
 func (o *CopyPermissionsParams) bindSubjectID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
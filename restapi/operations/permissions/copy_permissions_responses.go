@@ -37,6 +37,50 @@ func (o *CopyPermissionsOK) WriteResponse(rw http.ResponseWriter, producer runti
 	rw.WriteHeader(200)
 }
 
+// CopyPermissionsAcceptedCode is the HTTP code returned for type CopyPermissionsAccepted
+const CopyPermissionsAcceptedCode int = 202
+
+/*CopyPermissionsAccepted Accepted: the request was valid but no changes were made because dry run mode was enabled
+
+swagger:response copyPermissionsAccepted
+*/
+type CopyPermissionsAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ChangeSet `json:"body,omitempty"`
+}
+
+// NewCopyPermissionsAccepted creates CopyPermissionsAccepted with default headers values
+func NewCopyPermissionsAccepted() *CopyPermissionsAccepted {
+
+	return &CopyPermissionsAccepted{}
+}
+
+// WithPayload adds the payload to the copy permissions accepted response
+func (o *CopyPermissionsAccepted) WithPayload(payload *models.ChangeSet) *CopyPermissionsAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the copy permissions accepted response
+func (o *CopyPermissionsAccepted) SetPayload(payload *models.ChangeSet) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CopyPermissionsAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // CopyPermissionsBadRequestCode is the HTTP code returned for type CopyPermissionsBadRequest
 const CopyPermissionsBadRequestCode int = 400
 
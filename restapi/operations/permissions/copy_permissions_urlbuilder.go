@@ -10,6 +10,8 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 	"strings"
+
+	"github.com/go-openapi/swag"
 )
 
 // CopyPermissionsURL generates an URL for the copy permissions operation
@@ -17,6 +19,8 @@ type CopyPermissionsURL struct {
 	SubjectID   string
 	SubjectType string
 
+	DryRun *bool
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -60,6 +64,18 @@ func (o *CopyPermissionsURL) Build() (*url.URL, error) {
 	_basePath := o._basePath
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var dryRunQ string
+	if o.DryRun != nil {
+		dryRunQ = swag.FormatBool(*o.DryRun)
+	}
+	if dryRunQ != "" {
+		qs.Set("dry_run", dryRunQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -13,17 +13,26 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 
 	"github.com/cyverse-de/permissions/models"
 )
 
 // NewGrantPermissionParams creates a new GrantPermissionParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewGrantPermissionParams() GrantPermissionParams {
 
-	return GrantPermissionParams{}
+	var (
+		// initialize parameters with default values
+
+		dryRunDefault = bool(false)
+	)
+
+	return GrantPermissionParams{
+		DryRun: &dryRunDefault,
+	}
 }
 
 // GrantPermissionParams contains all the bound params for the grant permission operation
@@ -35,6 +44,11 @@ type GrantPermissionParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.
+	  In: query
+	  Default: false
+	*/
+	DryRun *bool
 	/*Information about the permission to add.
 	  Required: true
 	  In: body
@@ -51,6 +65,13 @@ func (o *GrantPermissionParams) BindRequest(r *http.Request, route *middleware.M
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qDryRun, qhkDryRun, _ := qs.GetOK("dry_run")
+	if err := o.bindDryRun(qDryRun, qhkDryRun, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body models.PermissionGrantRequest
@@ -83,3 +104,27 @@ func (o *GrantPermissionParams) BindRequest(r *http.Request, route *middleware.M
 	}
 	return nil
 }
+
+// bindDryRun binds and validates parameter DryRun from query.
+func (o *GrantPermissionParams) bindDryRun(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGrantPermissionParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dry_run", "query", "bool", raw)
+	}
+	o.DryRun = &value
+
+	return nil
+}
@@ -57,6 +57,50 @@ func (o *GrantPermissionOK) WriteResponse(rw http.ResponseWriter, producer runti
 	}
 }
 
+// GrantPermissionAcceptedCode is the HTTP code returned for type GrantPermissionAccepted
+const GrantPermissionAcceptedCode int = 202
+
+/*GrantPermissionAccepted Accepted: the request was valid but no changes were made because dry run mode was enabled
+
+swagger:response grantPermissionAccepted
+*/
+type GrantPermissionAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ChangeSet `json:"body,omitempty"`
+}
+
+// NewGrantPermissionAccepted creates GrantPermissionAccepted with default headers values
+func NewGrantPermissionAccepted() *GrantPermissionAccepted {
+
+	return &GrantPermissionAccepted{}
+}
+
+// WithPayload adds the payload to the grant permission accepted response
+func (o *GrantPermissionAccepted) WithPayload(payload *models.ChangeSet) *GrantPermissionAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the grant permission accepted response
+func (o *GrantPermissionAccepted) SetPayload(payload *models.ChangeSet) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GrantPermissionAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // GrantPermissionBadRequestCode is the HTTP code returned for type GrantPermissionBadRequest
 const GrantPermissionBadRequestCode int = 400
 
@@ -9,7 +9,9 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 )
 
 // NewListPermissionsParams creates a new ListPermissionsParams object
@@ -28,6 +30,11 @@ type ListPermissionsParams struct {
 
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
+
+	/*Limits the results to resources whose labels match the selector: a comma-separated list of requirements, all of which must be met. Each requirement has one of these forms: key=value, key!=value, key in (value1, value2), key notin (value1, value2), key or !key. A resource without the label meets key!=value and key notin requirements. All resources match by default.
+	  In: query
+	*/
+	LabelSelector *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -39,8 +46,32 @@ func (o *ListPermissionsParams) BindRequest(r *http.Request, route *middleware.M
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qLabelSelector, qhkLabelSelector, _ := qs.GetOK("label_selector")
+	if err := o.bindLabelSelector(qLabelSelector, qhkLabelSelector, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+// bindLabelSelector binds and validates parameter LabelSelector from query.
+func (o *ListPermissionsParams) bindLabelSelector(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.LabelSelector = &raw
+
+	return nil
+}
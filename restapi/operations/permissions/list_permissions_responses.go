@@ -57,6 +57,50 @@ func (o *ListPermissionsOK) WriteResponse(rw http.ResponseWriter, producer runti
 	}
 }
 
+// ListPermissionsBadRequestCode is the HTTP code returned for type ListPermissionsBadRequest
+const ListPermissionsBadRequestCode int = 400
+
+/*ListPermissionsBadRequest Bad Request
+
+swagger:response listPermissionsBadRequest
+*/
+type ListPermissionsBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorOut `json:"body,omitempty"`
+}
+
+// NewListPermissionsBadRequest creates ListPermissionsBadRequest with default headers values
+func NewListPermissionsBadRequest() *ListPermissionsBadRequest {
+
+	return &ListPermissionsBadRequest{}
+}
+
+// WithPayload adds the payload to the list permissions bad request response
+func (o *ListPermissionsBadRequest) WithPayload(payload *models.ErrorOut) *ListPermissionsBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list permissions bad request response
+func (o *ListPermissionsBadRequest) SetPayload(payload *models.ErrorOut) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListPermissionsBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // ListPermissionsInternalServerErrorCode is the HTTP code returned for type ListPermissionsInternalServerError
 const ListPermissionsInternalServerErrorCode int = 500
 
@@ -13,7 +13,11 @@ import (
 
 // ListPermissionsURL generates an URL for the list permissions operation
 type ListPermissionsURL struct {
+	LabelSelector *string
+
 	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
 }
 
 // WithBasePath sets the base path for this url builder, only required when it's different from the
@@ -40,6 +44,18 @@ func (o *ListPermissionsURL) Build() (*url.URL, error) {
 	_basePath := o._basePath
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var labelSelectorQ string
+	if o.LabelSelector != nil {
+		labelSelectorQ = *o.LabelSelector
+	}
+	if labelSelectorQ != "" {
+		qs.Set("label_selector", labelSelectorQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -21,6 +21,10 @@ const ListResourcePermissionsOKCode int = 200
 swagger:response listResourcePermissionsOK
 */
 type ListResourcePermissionsOK struct {
+	/*The current version of the access control list for the resource.
+	 */
+	ETag string `json:"ETag"`
+
 
 	/*
 	  In: Body
@@ -34,6 +38,17 @@ func NewListResourcePermissionsOK() *ListResourcePermissionsOK {
 	return &ListResourcePermissionsOK{}
 }
 
+// WithETag adds the eTag to the list resource permissions o k response
+func (o *ListResourcePermissionsOK) WithETag(eTag string) *ListResourcePermissionsOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the list resource permissions o k response
+func (o *ListResourcePermissionsOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithPayload adds the payload to the list resource permissions o k response
 func (o *ListResourcePermissionsOK) WithPayload(payload *models.PermissionList) *ListResourcePermissionsOK {
 	o.Payload = payload
@@ -48,6 +63,13 @@ func (o *ListResourcePermissionsOK) SetPayload(payload *models.PermissionList) {
 // WriteResponse to the client
 func (o *ListResourcePermissionsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	rw.WriteHeader(200)
 	if o.Payload != nil {
 		payload := o.Payload
@@ -14,17 +14,25 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 
 	"github.com/cyverse-de/permissions/models"
 )
 
 // NewPutPermissionParams creates a new PutPermissionParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewPutPermissionParams() PutPermissionParams {
 
-	return PutPermissionParams{}
+	var (
+		// initialize parameters with default values
+
+		dryRunDefault = bool(false)
+	)
+
+	return PutPermissionParams{
+		DryRun: &dryRunDefault,
+	}
 }
 
 // PutPermissionParams contains all the bound params for the put permission operation
@@ -36,6 +44,15 @@ type PutPermissionParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.
+	  In: query
+	  Default: false
+	*/
+	DryRun *bool
+	/*The version of the permission that the changes are based on.
+	  In: header
+	*/
+	IfMatch *string
 	/*The permission level to assign.
 	  Required: true
 	  In: body
@@ -72,6 +89,17 @@ func (o *PutPermissionParams) BindRequest(r *http.Request, route *middleware.Mat
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qDryRun, qhkDryRun, _ := qs.GetOK("dry_run")
+	if err := o.bindDryRun(qDryRun, qhkDryRun, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.bindIfMatch(r.Header[http.CanonicalHeaderKey("If-Match")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body models.PermissionPutRequest
@@ -125,6 +153,47 @@ func (o *PutPermissionParams) BindRequest(r *http.Request, route *middleware.Mat
 	return nil
 }
 
+// bindDryRun binds and validates parameter DryRun from query.
+func (o *PutPermissionParams) bindDryRun(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewPutPermissionParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dry_run", "query", "bool", raw)
+	}
+	o.DryRun = &value
+
+	return nil
+}
+
+// bindIfMatch binds and validates parameter IfMatch from header.
+func (o *PutPermissionParams) bindIfMatch(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IfMatch = &raw
+
+	return nil
+}
+
 // bindResourceName binds and validates parameter ResourceName from path.
 func (o *PutPermissionParams) bindResourceName(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -21,6 +21,10 @@ const PutPermissionOKCode int = 200
 swagger:response putPermissionOK
 */
 type PutPermissionOK struct {
+	/*The updated version of the permission.
+	 */
+	ETag string `json:"ETag"`
+
 
 	/*
 	  In: Body
@@ -34,6 +38,17 @@ func NewPutPermissionOK() *PutPermissionOK {
 	return &PutPermissionOK{}
 }
 
+// WithETag adds the eTag to the put permission o k response
+func (o *PutPermissionOK) WithETag(eTag string) *PutPermissionOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the put permission o k response
+func (o *PutPermissionOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithPayload adds the payload to the put permission o k response
 func (o *PutPermissionOK) WithPayload(payload *models.Permission) *PutPermissionOK {
 	o.Payload = payload
@@ -48,6 +63,13 @@ func (o *PutPermissionOK) SetPayload(payload *models.Permission) {
 // WriteResponse to the client
 func (o *PutPermissionOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	rw.WriteHeader(200)
 	if o.Payload != nil {
 		payload := o.Payload
@@ -57,6 +79,50 @@ func (o *PutPermissionOK) WriteResponse(rw http.ResponseWriter, producer runtime
 	}
 }
 
+// PutPermissionAcceptedCode is the HTTP code returned for type PutPermissionAccepted
+const PutPermissionAcceptedCode int = 202
+
+/*PutPermissionAccepted Accepted: the request was valid but no changes were made because dry run mode was enabled
+
+swagger:response putPermissionAccepted
+*/
+type PutPermissionAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ChangeSet `json:"body,omitempty"`
+}
+
+// NewPutPermissionAccepted creates PutPermissionAccepted with default headers values
+func NewPutPermissionAccepted() *PutPermissionAccepted {
+
+	return &PutPermissionAccepted{}
+}
+
+// WithPayload adds the payload to the put permission accepted response
+func (o *PutPermissionAccepted) WithPayload(payload *models.ChangeSet) *PutPermissionAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put permission accepted response
+func (o *PutPermissionAccepted) SetPayload(payload *models.ChangeSet) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutPermissionAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // PutPermissionBadRequestCode is the HTTP code returned for type PutPermissionBadRequest
 const PutPermissionBadRequestCode int = 400
 
@@ -101,6 +167,50 @@ func (o *PutPermissionBadRequest) WriteResponse(rw http.ResponseWriter, producer
 	}
 }
 
+// PutPermissionPreconditionFailedCode is the HTTP code returned for type PutPermissionPreconditionFailed
+const PutPermissionPreconditionFailedCode int = 412
+
+/*PutPermissionPreconditionFailed Precondition Failed
+
+swagger:response putPermissionPreconditionFailed
+*/
+type PutPermissionPreconditionFailed struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorOut `json:"body,omitempty"`
+}
+
+// NewPutPermissionPreconditionFailed creates PutPermissionPreconditionFailed with default headers values
+func NewPutPermissionPreconditionFailed() *PutPermissionPreconditionFailed {
+
+	return &PutPermissionPreconditionFailed{}
+}
+
+// WithPayload adds the payload to the put permission precondition failed response
+func (o *PutPermissionPreconditionFailed) WithPayload(payload *models.ErrorOut) *PutPermissionPreconditionFailed {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put permission precondition failed response
+func (o *PutPermissionPreconditionFailed) SetPayload(payload *models.ErrorOut) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutPermissionPreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(412)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // PutPermissionInternalServerErrorCode is the HTTP code returned for type PutPermissionInternalServerError
 const PutPermissionInternalServerErrorCode int = 500
 
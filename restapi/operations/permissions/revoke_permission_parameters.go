@@ -9,17 +9,26 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 )
 
 // NewRevokePermissionParams creates a new RevokePermissionParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewRevokePermissionParams() RevokePermissionParams {
 
-	return RevokePermissionParams{}
+	var (
+		// initialize parameters with default values
+
+		dryRunDefault = bool(false)
+	)
+
+	return RevokePermissionParams{
+		DryRun: &dryRunDefault,
+	}
 }
 
 // RevokePermissionParams contains all the bound params for the revoke permission operation
@@ -31,6 +40,15 @@ type RevokePermissionParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.
+	  In: query
+	  Default: false
+	*/
+	DryRun *bool
+	/*The version of the permission that the request is based on.
+	  In: header
+	*/
+	IfMatch *string
 	/*The resource name.
 	  Required: true
 	  In: path
@@ -62,6 +80,17 @@ func (o *RevokePermissionParams) BindRequest(r *http.Request, route *middleware.
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qDryRun, qhkDryRun, _ := qs.GetOK("dry_run")
+	if err := o.bindDryRun(qDryRun, qhkDryRun, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.bindIfMatch(r.Header[http.CanonicalHeaderKey("If-Match")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rResourceName, rhkResourceName, _ := route.Params.GetOK("resource_name")
 	if err := o.bindResourceName(rResourceName, rhkResourceName, route.Formats); err != nil {
 		res = append(res, err)
@@ -87,6 +116,47 @@ func (o *RevokePermissionParams) BindRequest(r *http.Request, route *middleware.
 	return nil
 }
 
+// bindDryRun binds and validates parameter DryRun from query.
+func (o *RevokePermissionParams) bindDryRun(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewRevokePermissionParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dry_run", "query", "bool", raw)
+	}
+	o.DryRun = &value
+
+	return nil
+}
+
+// bindIfMatch binds and validates parameter IfMatch from header.
+func (o *RevokePermissionParams) bindIfMatch(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IfMatch = &raw
+
+	return nil
+}
+
 // bindResourceName binds and validates parameter ResourceName from path.
 func (o *RevokePermissionParams) bindResourceName(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -37,6 +37,50 @@ func (o *RevokePermissionOK) WriteResponse(rw http.ResponseWriter, producer runt
 	rw.WriteHeader(200)
 }
 
+// RevokePermissionAcceptedCode is the HTTP code returned for type RevokePermissionAccepted
+const RevokePermissionAcceptedCode int = 202
+
+/*RevokePermissionAccepted Accepted: the request was valid but no changes were made because dry run mode was enabled
+
+swagger:response revokePermissionAccepted
+*/
+type RevokePermissionAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ChangeSet `json:"body,omitempty"`
+}
+
+// NewRevokePermissionAccepted creates RevokePermissionAccepted with default headers values
+func NewRevokePermissionAccepted() *RevokePermissionAccepted {
+
+	return &RevokePermissionAccepted{}
+}
+
+// WithPayload adds the payload to the revoke permission accepted response
+func (o *RevokePermissionAccepted) WithPayload(payload *models.ChangeSet) *RevokePermissionAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the revoke permission accepted response
+func (o *RevokePermissionAccepted) SetPayload(payload *models.ChangeSet) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *RevokePermissionAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // RevokePermissionNotFoundCode is the HTTP code returned for type RevokePermissionNotFound
 const RevokePermissionNotFoundCode int = 404
 
@@ -81,6 +125,50 @@ func (o *RevokePermissionNotFound) WriteResponse(rw http.ResponseWriter, produce
 	}
 }
 
+// RevokePermissionPreconditionFailedCode is the HTTP code returned for type RevokePermissionPreconditionFailed
+const RevokePermissionPreconditionFailedCode int = 412
+
+/*RevokePermissionPreconditionFailed Precondition Failed
+
+swagger:response revokePermissionPreconditionFailed
+*/
+type RevokePermissionPreconditionFailed struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorOut `json:"body,omitempty"`
+}
+
+// NewRevokePermissionPreconditionFailed creates RevokePermissionPreconditionFailed with default headers values
+func NewRevokePermissionPreconditionFailed() *RevokePermissionPreconditionFailed {
+
+	return &RevokePermissionPreconditionFailed{}
+}
+
+// WithPayload adds the payload to the revoke permission precondition failed response
+func (o *RevokePermissionPreconditionFailed) WithPayload(payload *models.ErrorOut) *RevokePermissionPreconditionFailed {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the revoke permission precondition failed response
+func (o *RevokePermissionPreconditionFailed) SetPayload(payload *models.ErrorOut) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *RevokePermissionPreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(412)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // RevokePermissionInternalServerErrorCode is the HTTP code returned for type RevokePermissionInternalServerError
 const RevokePermissionInternalServerErrorCode int = 500
 
@@ -10,6 +10,8 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 	"strings"
+
+	"github.com/go-openapi/swag"
 )
 
 // RevokePermissionURL generates an URL for the revoke permission operation
@@ -19,6 +21,8 @@ type RevokePermissionURL struct {
 	SubjectID    string
 	SubjectType  string
 
+	DryRun *bool
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -76,6 +80,18 @@ func (o *RevokePermissionURL) Build() (*url.URL, error) {
 	_basePath := o._basePath
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var dryRunQ string
+	if o.DryRun != nil {
+		dryRunQ = swag.FormatBool(*o.DryRun)
+	}
+	if dryRunQ != "" {
+		qs.Set("dry_run", dryRunQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
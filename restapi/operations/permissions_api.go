@@ -57,6 +57,9 @@ func NewPermissionsAPI(spec *loads.Document) *PermissionsAPI {
 		ResourceTypesGetResourceTypesHandler: resource_types.GetResourceTypesHandlerFunc(func(params resource_types.GetResourceTypesParams) middleware.Responder {
 			return middleware.NotImplemented("operation resource_types.GetResourceTypes has not yet been implemented")
 		}),
+		ResourceTypesGetResourceTypesIDHandler: resource_types.GetResourceTypesIDHandlerFunc(func(params resource_types.GetResourceTypesIDParams) middleware.Responder {
+			return middleware.NotImplemented("operation resource_types.GetResourceTypesID has not yet been implemented")
+		}),
 		ResourceTypesPostResourceTypesHandler: resource_types.PostResourceTypesHandlerFunc(func(params resource_types.PostResourceTypesParams) middleware.Responder {
 			return middleware.NotImplemented("operation resource_types.PostResourceTypes has not yet been implemented")
 		}),
@@ -84,6 +87,9 @@ func NewPermissionsAPI(spec *loads.Document) *PermissionsAPI {
 		PermissionsCopyPermissionsHandler: permissions.CopyPermissionsHandlerFunc(func(params permissions.CopyPermissionsParams) middleware.Responder {
 			return middleware.NotImplemented("operation permissions.CopyPermissions has not yet been implemented")
 		}),
+		PermissionsCopyResourcePermissionsHandler: permissions.CopyResourcePermissionsHandlerFunc(func(params permissions.CopyResourcePermissionsParams) middleware.Responder {
+			return middleware.NotImplemented("operation permissions.CopyResourcePermissions has not yet been implemented")
+		}),
 		ResourcesDeleteResourceHandler: resources.DeleteResourceHandlerFunc(func(params resources.DeleteResourceParams) middleware.Responder {
 			return middleware.NotImplemented("operation resources.DeleteResource has not yet been implemented")
 		}),
@@ -99,6 +105,21 @@ func NewPermissionsAPI(spec *loads.Document) *PermissionsAPI {
 		SubjectsDeleteSubjectByExternalIDHandler: subjects.DeleteSubjectByExternalIDHandlerFunc(func(params subjects.DeleteSubjectByExternalIDParams) middleware.Responder {
 			return middleware.NotImplemented("operation subjects.DeleteSubjectByExternalID has not yet been implemented")
 		}),
+		StatusGetHealthzHandler: status.GetHealthzHandlerFunc(func(params status.GetHealthzParams) middleware.Responder {
+			return middleware.NotImplemented("operation status.GetHealthz has not yet been implemented")
+		}),
+		PermissionsGetPermissionHandler: permissions.GetPermissionHandlerFunc(func(params permissions.GetPermissionParams) middleware.Responder {
+			return middleware.NotImplemented("operation permissions.GetPermission has not yet been implemented")
+		}),
+		StatusGetReadyzHandler: status.GetReadyzHandlerFunc(func(params status.GetReadyzParams) middleware.Responder {
+			return middleware.NotImplemented("operation status.GetReadyz has not yet been implemented")
+		}),
+		ResourcesGetResourceHandler: resources.GetResourceHandlerFunc(func(params resources.GetResourceParams) middleware.Responder {
+			return middleware.NotImplemented("operation resources.GetResource has not yet been implemented")
+		}),
+		SubjectsGetSubjectHandler: subjects.GetSubjectHandlerFunc(func(params subjects.GetSubjectParams) middleware.Responder {
+			return middleware.NotImplemented("operation subjects.GetSubject has not yet been implemented")
+		}),
 		PermissionsGrantPermissionHandler: permissions.GrantPermissionHandlerFunc(func(params permissions.GrantPermissionParams) middleware.Responder {
 			return middleware.NotImplemented("operation permissions.GrantPermission has not yet been implemented")
 		}),
@@ -114,12 +135,24 @@ func NewPermissionsAPI(spec *loads.Document) *PermissionsAPI {
 		SubjectsListSubjectsHandler: subjects.ListSubjectsHandlerFunc(func(params subjects.ListSubjectsParams) middleware.Responder {
 			return middleware.NotImplemented("operation subjects.ListSubjects has not yet been implemented")
 		}),
+		SubjectsMergeSubjectHandler: subjects.MergeSubjectHandlerFunc(func(params subjects.MergeSubjectParams) middleware.Responder {
+			return middleware.NotImplemented("operation subjects.MergeSubject has not yet been implemented")
+		}),
 		PermissionsPutPermissionHandler: permissions.PutPermissionHandlerFunc(func(params permissions.PutPermissionParams) middleware.Responder {
 			return middleware.NotImplemented("operation permissions.PutPermission has not yet been implemented")
 		}),
+		PermissionsReplaceResourcePermissionsHandler: permissions.ReplaceResourcePermissionsHandlerFunc(func(params permissions.ReplaceResourcePermissionsParams) middleware.Responder {
+			return middleware.NotImplemented("operation permissions.ReplaceResourcePermissions has not yet been implemented")
+		}),
 		PermissionsRevokePermissionHandler: permissions.RevokePermissionHandlerFunc(func(params permissions.RevokePermissionParams) middleware.Responder {
 			return middleware.NotImplemented("operation permissions.RevokePermission has not yet been implemented")
 		}),
+		PermissionsRevokeResourcePermissionsHandler: permissions.RevokeResourcePermissionsHandlerFunc(func(params permissions.RevokeResourcePermissionsParams) middleware.Responder {
+			return middleware.NotImplemented("operation permissions.RevokeResourcePermissions has not yet been implemented")
+		}),
+		PermissionsRevokeSubjectPermissionsHandler: permissions.RevokeSubjectPermissionsHandlerFunc(func(params permissions.RevokeSubjectPermissionsParams) middleware.Responder {
+			return middleware.NotImplemented("operation permissions.RevokeSubjectPermissions has not yet been implemented")
+		}),
 		ResourcesUpdateResourceHandler: resources.UpdateResourceHandlerFunc(func(params resources.UpdateResourceParams) middleware.Responder {
 			return middleware.NotImplemented("operation resources.UpdateResource has not yet been implemented")
 		}),
@@ -168,6 +201,8 @@ type PermissionsAPI struct {
 	StatusGetHandler status.GetHandler
 	// ResourceTypesGetResourceTypesHandler sets the operation handler for the get resource types operation
 	ResourceTypesGetResourceTypesHandler resource_types.GetResourceTypesHandler
+	// ResourceTypesGetResourceTypesIDHandler sets the operation handler for the get resource types ID operation
+	ResourceTypesGetResourceTypesIDHandler resource_types.GetResourceTypesIDHandler
 	// ResourceTypesPostResourceTypesHandler sets the operation handler for the post resource types operation
 	ResourceTypesPostResourceTypesHandler resource_types.PostResourceTypesHandler
 	// ResourceTypesPutResourceTypesIDHandler sets the operation handler for the put resource types ID operation
@@ -186,6 +221,8 @@ type PermissionsAPI struct {
 	PermissionsBySubjectAndResourceTypeAbbreviatedHandler permissions.BySubjectAndResourceTypeAbbreviatedHandler
 	// PermissionsCopyPermissionsHandler sets the operation handler for the copy permissions operation
 	PermissionsCopyPermissionsHandler permissions.CopyPermissionsHandler
+	// PermissionsCopyResourcePermissionsHandler sets the operation handler for the copy resource permissions operation
+	PermissionsCopyResourcePermissionsHandler permissions.CopyResourcePermissionsHandler
 	// ResourcesDeleteResourceHandler sets the operation handler for the delete resource operation
 	ResourcesDeleteResourceHandler resources.DeleteResourceHandler
 	// ResourcesDeleteResourceByNameHandler sets the operation handler for the delete resource by name operation
@@ -196,6 +233,16 @@ type PermissionsAPI struct {
 	SubjectsDeleteSubjectHandler subjects.DeleteSubjectHandler
 	// SubjectsDeleteSubjectByExternalIDHandler sets the operation handler for the delete subject by external Id operation
 	SubjectsDeleteSubjectByExternalIDHandler subjects.DeleteSubjectByExternalIDHandler
+	// StatusGetHealthzHandler sets the operation handler for the get healthz operation
+	StatusGetHealthzHandler status.GetHealthzHandler
+	// PermissionsGetPermissionHandler sets the operation handler for the get permission operation
+	PermissionsGetPermissionHandler permissions.GetPermissionHandler
+	// StatusGetReadyzHandler sets the operation handler for the get readyz operation
+	StatusGetReadyzHandler status.GetReadyzHandler
+	// ResourcesGetResourceHandler sets the operation handler for the get resource operation
+	ResourcesGetResourceHandler resources.GetResourceHandler
+	// SubjectsGetSubjectHandler sets the operation handler for the get subject operation
+	SubjectsGetSubjectHandler subjects.GetSubjectHandler
 	// PermissionsGrantPermissionHandler sets the operation handler for the grant permission operation
 	PermissionsGrantPermissionHandler permissions.GrantPermissionHandler
 	// PermissionsListPermissionsHandler sets the operation handler for the list permissions operation
@@ -206,10 +253,18 @@ type PermissionsAPI struct {
 	ResourcesListResourcesHandler resources.ListResourcesHandler
 	// SubjectsListSubjectsHandler sets the operation handler for the list subjects operation
 	SubjectsListSubjectsHandler subjects.ListSubjectsHandler
+	// SubjectsMergeSubjectHandler sets the operation handler for the merge subject operation
+	SubjectsMergeSubjectHandler subjects.MergeSubjectHandler
 	// PermissionsPutPermissionHandler sets the operation handler for the put permission operation
 	PermissionsPutPermissionHandler permissions.PutPermissionHandler
+	// PermissionsReplaceResourcePermissionsHandler sets the operation handler for the replace resource permissions operation
+	PermissionsReplaceResourcePermissionsHandler permissions.ReplaceResourcePermissionsHandler
 	// PermissionsRevokePermissionHandler sets the operation handler for the revoke permission operation
 	PermissionsRevokePermissionHandler permissions.RevokePermissionHandler
+	// PermissionsRevokeResourcePermissionsHandler sets the operation handler for the revoke resource permissions operation
+	PermissionsRevokeResourcePermissionsHandler permissions.RevokeResourcePermissionsHandler
+	// PermissionsRevokeSubjectPermissionsHandler sets the operation handler for the revoke subject permissions operation
+	PermissionsRevokeSubjectPermissionsHandler permissions.RevokeSubjectPermissionsHandler
 	// ResourcesUpdateResourceHandler sets the operation handler for the update resource operation
 	ResourcesUpdateResourceHandler resources.UpdateResourceHandler
 	// SubjectsUpdateSubjectHandler sets the operation handler for the update subject operation
@@ -300,6 +355,9 @@ func (o *PermissionsAPI) Validate() error {
 	if o.ResourceTypesGetResourceTypesHandler == nil {
 		unregistered = append(unregistered, "resource_types.GetResourceTypesHandler")
 	}
+	if o.ResourceTypesGetResourceTypesIDHandler == nil {
+		unregistered = append(unregistered, "resource_types.GetResourceTypesIDHandler")
+	}
 	if o.ResourceTypesPostResourceTypesHandler == nil {
 		unregistered = append(unregistered, "resource_types.PostResourceTypesHandler")
 	}
@@ -327,6 +385,9 @@ func (o *PermissionsAPI) Validate() error {
 	if o.PermissionsCopyPermissionsHandler == nil {
 		unregistered = append(unregistered, "permissions.CopyPermissionsHandler")
 	}
+	if o.PermissionsCopyResourcePermissionsHandler == nil {
+		unregistered = append(unregistered, "permissions.CopyResourcePermissionsHandler")
+	}
 	if o.ResourcesDeleteResourceHandler == nil {
 		unregistered = append(unregistered, "resources.DeleteResourceHandler")
 	}
@@ -342,6 +403,21 @@ func (o *PermissionsAPI) Validate() error {
 	if o.SubjectsDeleteSubjectByExternalIDHandler == nil {
 		unregistered = append(unregistered, "subjects.DeleteSubjectByExternalIDHandler")
 	}
+	if o.StatusGetHealthzHandler == nil {
+		unregistered = append(unregistered, "status.GetHealthzHandler")
+	}
+	if o.PermissionsGetPermissionHandler == nil {
+		unregistered = append(unregistered, "permissions.GetPermissionHandler")
+	}
+	if o.StatusGetReadyzHandler == nil {
+		unregistered = append(unregistered, "status.GetReadyzHandler")
+	}
+	if o.ResourcesGetResourceHandler == nil {
+		unregistered = append(unregistered, "resources.GetResourceHandler")
+	}
+	if o.SubjectsGetSubjectHandler == nil {
+		unregistered = append(unregistered, "subjects.GetSubjectHandler")
+	}
 	if o.PermissionsGrantPermissionHandler == nil {
 		unregistered = append(unregistered, "permissions.GrantPermissionHandler")
 	}
@@ -357,12 +433,24 @@ func (o *PermissionsAPI) Validate() error {
 	if o.SubjectsListSubjectsHandler == nil {
 		unregistered = append(unregistered, "subjects.ListSubjectsHandler")
 	}
+	if o.SubjectsMergeSubjectHandler == nil {
+		unregistered = append(unregistered, "subjects.MergeSubjectHandler")
+	}
 	if o.PermissionsPutPermissionHandler == nil {
 		unregistered = append(unregistered, "permissions.PutPermissionHandler")
 	}
+	if o.PermissionsReplaceResourcePermissionsHandler == nil {
+		unregistered = append(unregistered, "permissions.ReplaceResourcePermissionsHandler")
+	}
 	if o.PermissionsRevokePermissionHandler == nil {
 		unregistered = append(unregistered, "permissions.RevokePermissionHandler")
 	}
+	if o.PermissionsRevokeResourcePermissionsHandler == nil {
+		unregistered = append(unregistered, "permissions.RevokeResourcePermissionsHandler")
+	}
+	if o.PermissionsRevokeSubjectPermissionsHandler == nil {
+		unregistered = append(unregistered, "permissions.RevokeSubjectPermissionsHandler")
+	}
 	if o.ResourcesUpdateResourceHandler == nil {
 		unregistered = append(unregistered, "resources.UpdateResourceHandler")
 	}
@@ -469,6 +557,10 @@ func (o *PermissionsAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/resource_types"] = resource_types.NewGetResourceTypes(o.context, o.ResourceTypesGetResourceTypesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/resource_types/{id}"] = resource_types.NewGetResourceTypesID(o.context, o.ResourceTypesGetResourceTypesIDHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -505,6 +597,10 @@ func (o *PermissionsAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/permissions/subjects/{subject_type}/{subject_id}/copy"] = permissions.NewCopyPermissions(o.context, o.PermissionsCopyPermissionsHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/permissions/resources/{resource_type}/{resource_name}/copy"] = permissions.NewCopyResourcePermissions(o.context, o.PermissionsCopyResourcePermissionsHandler)
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
@@ -525,6 +621,26 @@ func (o *PermissionsAPI) initHandlerCache() {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
 	o.handlers["DELETE"]["/subjects"] = subjects.NewDeleteSubjectByExternalID(o.context, o.SubjectsDeleteSubjectByExternalIDHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/healthz"] = status.NewGetHealthz(o.context, o.StatusGetHealthzHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/permissions/resources/{resource_type}/{resource_name}/subjects/{subject_type}/{subject_id}"] = permissions.NewGetPermission(o.context, o.PermissionsGetPermissionHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/readyz"] = status.NewGetReadyz(o.context, o.StatusGetReadyzHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/resources/{id}"] = resources.NewGetResource(o.context, o.ResourcesGetResourceHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/subjects/{id}"] = subjects.NewGetSubject(o.context, o.SubjectsGetSubjectHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -545,14 +661,30 @@ func (o *PermissionsAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/subjects"] = subjects.NewListSubjects(o.context, o.SubjectsListSubjectsHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/subjects/{id}/merge"] = subjects.NewMergeSubject(o.context, o.SubjectsMergeSubjectHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
 	o.handlers["PUT"]["/permissions/resources/{resource_type}/{resource_name}/subjects/{subject_type}/{subject_id}"] = permissions.NewPutPermission(o.context, o.PermissionsPutPermissionHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/permissions/resources/{resource_type}/{resource_name}"] = permissions.NewReplaceResourcePermissions(o.context, o.PermissionsReplaceResourcePermissionsHandler)
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
 	o.handlers["DELETE"]["/permissions/resources/{resource_type}/{resource_name}/subjects/{subject_type}/{subject_id}"] = permissions.NewRevokePermission(o.context, o.PermissionsRevokePermissionHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/permissions/resources/{resource_type}/{resource_name}"] = permissions.NewRevokeResourcePermissions(o.context, o.PermissionsRevokeResourcePermissionsHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/permissions/subjects/{subject_type}/{subject_id}"] = permissions.NewRevokeSubjectPermissions(o.context, o.PermissionsRevokeSubjectPermissionsHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
@@ -12,15 +12,23 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 )
 
 // NewDeleteResourceTypeByNameParams creates a new DeleteResourceTypeByNameParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewDeleteResourceTypeByNameParams() DeleteResourceTypeByNameParams {
 
-	return DeleteResourceTypeByNameParams{}
+	var (
+		// initialize parameters with default values
+
+		dryRunDefault = bool(false)
+	)
+
+	return DeleteResourceTypeByNameParams{
+		DryRun: &dryRunDefault,
+	}
 }
 
 // DeleteResourceTypeByNameParams contains all the bound params for the delete resource type by name operation
@@ -32,6 +40,11 @@ type DeleteResourceTypeByNameParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.
+	  In: query
+	  Default: false
+	*/
+	DryRun *bool
 	/*The resource type name to search for.
 	  Required: true
 	  In: query
@@ -50,6 +63,11 @@ func (o *DeleteResourceTypeByNameParams) BindRequest(r *http.Request, route *mid
 
 	qs := runtime.Values(r.URL.Query())
 
+	qDryRun, qhkDryRun, _ := qs.GetOK("dry_run")
+	if err := o.bindDryRun(qDryRun, qhkDryRun, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qResourceTypeName, qhkResourceTypeName, _ := qs.GetOK("resource_type_name")
 	if err := o.bindResourceTypeName(qResourceTypeName, qhkResourceTypeName, route.Formats); err != nil {
 		res = append(res, err)
@@ -60,6 +78,30 @@ func (o *DeleteResourceTypeByNameParams) BindRequest(r *http.Request, route *mid
 	return nil
 }
 
+// bindDryRun binds and validates parameter DryRun from query.
+func (o *DeleteResourceTypeByNameParams) bindDryRun(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewDeleteResourceTypeByNameParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dry_run", "query", "bool", raw)
+	}
+	o.DryRun = &value
+
+	return nil
+}
+
 // bindResourceTypeName binds and validates parameter ResourceTypeName from query.
 func (o *DeleteResourceTypeByNameParams) bindResourceTypeName(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	if !hasKey {
@@ -37,6 +37,50 @@ func (o *DeleteResourceTypeByNameOK) WriteResponse(rw http.ResponseWriter, produ
 	rw.WriteHeader(200)
 }
 
+// DeleteResourceTypeByNameAcceptedCode is the HTTP code returned for type DeleteResourceTypeByNameAccepted
+const DeleteResourceTypeByNameAcceptedCode int = 202
+
+/*DeleteResourceTypeByNameAccepted Accepted: the request was valid but no changes were made because dry run mode was enabled
+
+swagger:response deleteResourceTypeByNameAccepted
+*/
+type DeleteResourceTypeByNameAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ChangeSet `json:"body,omitempty"`
+}
+
+// NewDeleteResourceTypeByNameAccepted creates DeleteResourceTypeByNameAccepted with default headers values
+func NewDeleteResourceTypeByNameAccepted() *DeleteResourceTypeByNameAccepted {
+
+	return &DeleteResourceTypeByNameAccepted{}
+}
+
+// WithPayload adds the payload to the delete resource type by name accepted response
+func (o *DeleteResourceTypeByNameAccepted) WithPayload(payload *models.ChangeSet) *DeleteResourceTypeByNameAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete resource type by name accepted response
+func (o *DeleteResourceTypeByNameAccepted) SetPayload(payload *models.ChangeSet) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteResourceTypeByNameAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // DeleteResourceTypeByNameBadRequestCode is the HTTP code returned for type DeleteResourceTypeByNameBadRequest
 const DeleteResourceTypeByNameBadRequestCode int = 400
 
@@ -9,10 +9,13 @@ import (
 	"errors"
 	"net/url"
 	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
 )
 
 // DeleteResourceTypeByNameURL generates an URL for the delete resource type by name operation
 type DeleteResourceTypeByNameURL struct {
+	DryRun           *bool
 	ResourceTypeName string
 
 	_basePath string
@@ -46,6 +49,14 @@ func (o *DeleteResourceTypeByNameURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var dryRunQ string
+	if o.DryRun != nil {
+		dryRunQ = swag.FormatBool(*o.DryRun)
+	}
+	if dryRunQ != "" {
+		qs.Set("dry_run", dryRunQ)
+	}
+
 	resourceTypeNameQ := o.ResourceTypeName
 	if resourceTypeNameQ != "" {
 		qs.Set("resource_type_name", resourceTypeNameQ)
@@ -9,16 +9,25 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewDeleteResourceTypesIDParams creates a new DeleteResourceTypesIDParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewDeleteResourceTypesIDParams() DeleteResourceTypesIDParams {
 
-	return DeleteResourceTypesIDParams{}
+	var (
+		// initialize parameters with default values
+
+		dryRunDefault = bool(false)
+	)
+
+	return DeleteResourceTypesIDParams{
+		DryRun: &dryRunDefault,
+	}
 }
 
 // DeleteResourceTypesIDParams contains all the bound params for the delete resource types ID operation
@@ -30,6 +39,11 @@ type DeleteResourceTypesIDParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.
+	  In: query
+	  Default: false
+	*/
+	DryRun *bool
 	/*The resource type ID.
 	  Required: true
 	  In: path
@@ -46,6 +60,13 @@ func (o *DeleteResourceTypesIDParams) BindRequest(r *http.Request, route *middle
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qDryRun, qhkDryRun, _ := qs.GetOK("dry_run")
+	if err := o.bindDryRun(qDryRun, qhkDryRun, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rID, rhkID, _ := route.Params.GetOK("id")
 	if err := o.bindID(rID, rhkID, route.Formats); err != nil {
 		res = append(res, err)
@@ -56,6 +77,30 @@ func (o *DeleteResourceTypesIDParams) BindRequest(r *http.Request, route *middle
 	return nil
 }
 
+// bindDryRun binds and validates parameter DryRun from query.
+func (o *DeleteResourceTypesIDParams) bindDryRun(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewDeleteResourceTypesIDParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dry_run", "query", "bool", raw)
+	}
+	o.DryRun = &value
+
+	return nil
+}
+
 // bindID binds and validates parameter ID from path.
 func (o *DeleteResourceTypesIDParams) bindID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
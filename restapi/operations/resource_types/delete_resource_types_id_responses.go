@@ -37,6 +37,50 @@ func (o *DeleteResourceTypesIDOK) WriteResponse(rw http.ResponseWriter, producer
 	rw.WriteHeader(200)
 }
 
+// DeleteResourceTypesIDAcceptedCode is the HTTP code returned for type DeleteResourceTypesIDAccepted
+const DeleteResourceTypesIDAcceptedCode int = 202
+
+/*DeleteResourceTypesIDAccepted Accepted: the request was valid but no changes were made because dry run mode was enabled
+
+swagger:response deleteResourceTypesIdAccepted
+*/
+type DeleteResourceTypesIDAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ChangeSet `json:"body,omitempty"`
+}
+
+// NewDeleteResourceTypesIDAccepted creates DeleteResourceTypesIDAccepted with default headers values
+func NewDeleteResourceTypesIDAccepted() *DeleteResourceTypesIDAccepted {
+
+	return &DeleteResourceTypesIDAccepted{}
+}
+
+// WithPayload adds the payload to the delete resource types Id accepted response
+func (o *DeleteResourceTypesIDAccepted) WithPayload(payload *models.ChangeSet) *DeleteResourceTypesIDAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete resource types Id accepted response
+func (o *DeleteResourceTypesIDAccepted) SetPayload(payload *models.ChangeSet) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteResourceTypesIDAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // DeleteResourceTypesIDBadRequestCode is the HTTP code returned for type DeleteResourceTypesIDBadRequest
 const DeleteResourceTypesIDBadRequestCode int = 400
 
@@ -10,12 +10,16 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 	"strings"
+
+	"github.com/go-openapi/swag"
 )
 
 // DeleteResourceTypesIDURL generates an URL for the delete resource types ID operation
 type DeleteResourceTypesIDURL struct {
 	ID string
 
+	DryRun *bool
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -52,6 +56,18 @@ func (o *DeleteResourceTypesIDURL) Build() (*url.URL, error) {
 	_basePath := o._basePath
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var dryRunQ string
+	if o.DryRun != nil {
+		dryRunQ = swag.FormatBool(*o.DryRun)
+	}
+	if dryRunQ != "" {
+		qs.Set("dry_run", dryRunQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
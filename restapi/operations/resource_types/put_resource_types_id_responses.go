@@ -21,6 +21,10 @@ const PutResourceTypesIDOKCode int = 200
 swagger:response putResourceTypesIdOK
 */
 type PutResourceTypesIDOK struct {
+	/*The updated version of the resource type.
+	 */
+	ETag string `json:"ETag"`
+
 
 	/*
 	  In: Body
@@ -34,6 +38,17 @@ func NewPutResourceTypesIDOK() *PutResourceTypesIDOK {
 	return &PutResourceTypesIDOK{}
 }
 
+// WithETag adds the eTag to the put resource types Id o k response
+func (o *PutResourceTypesIDOK) WithETag(eTag string) *PutResourceTypesIDOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the put resource types Id o k response
+func (o *PutResourceTypesIDOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithPayload adds the payload to the put resource types Id o k response
 func (o *PutResourceTypesIDOK) WithPayload(payload *models.ResourceTypeOut) *PutResourceTypesIDOK {
 	o.Payload = payload
@@ -48,6 +63,13 @@ func (o *PutResourceTypesIDOK) SetPayload(payload *models.ResourceTypeOut) {
 // WriteResponse to the client
 func (o *PutResourceTypesIDOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	rw.WriteHeader(200)
 	if o.Payload != nil {
 		payload := o.Payload
@@ -145,6 +167,50 @@ func (o *PutResourceTypesIDNotFound) WriteResponse(rw http.ResponseWriter, produ
 	}
 }
 
+// PutResourceTypesIDPreconditionFailedCode is the HTTP code returned for type PutResourceTypesIDPreconditionFailed
+const PutResourceTypesIDPreconditionFailedCode int = 412
+
+/*PutResourceTypesIDPreconditionFailed Precondition Failed
+
+swagger:response putResourceTypesIdPreconditionFailed
+*/
+type PutResourceTypesIDPreconditionFailed struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorOut `json:"body,omitempty"`
+}
+
+// NewPutResourceTypesIDPreconditionFailed creates PutResourceTypesIDPreconditionFailed with default headers values
+func NewPutResourceTypesIDPreconditionFailed() *PutResourceTypesIDPreconditionFailed {
+
+	return &PutResourceTypesIDPreconditionFailed{}
+}
+
+// WithPayload adds the payload to the put resource types Id precondition failed response
+func (o *PutResourceTypesIDPreconditionFailed) WithPayload(payload *models.ErrorOut) *PutResourceTypesIDPreconditionFailed {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put resource types Id precondition failed response
+func (o *PutResourceTypesIDPreconditionFailed) SetPayload(payload *models.ErrorOut) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutResourceTypesIDPreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(412)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // PutResourceTypesIDInternalServerErrorCode is the HTTP code returned for type PutResourceTypesIDInternalServerError
 const PutResourceTypesIDInternalServerErrorCode int = 500
 
@@ -12,15 +12,23 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 )
 
 // NewDeleteResourceByNameParams creates a new DeleteResourceByNameParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewDeleteResourceByNameParams() DeleteResourceByNameParams {
 
-	return DeleteResourceByNameParams{}
+	var (
+		// initialize parameters with default values
+
+		dryRunDefault = bool(false)
+	)
+
+	return DeleteResourceByNameParams{
+		DryRun: &dryRunDefault,
+	}
 }
 
 // DeleteResourceByNameParams contains all the bound params for the delete resource by name operation
@@ -32,6 +40,11 @@ type DeleteResourceByNameParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.
+	  In: query
+	  Default: false
+	*/
+	DryRun *bool
 	/*The resource name to search for.
 	  Required: true
 	  In: query
@@ -55,6 +68,11 @@ func (o *DeleteResourceByNameParams) BindRequest(r *http.Request, route *middlew
 
 	qs := runtime.Values(r.URL.Query())
 
+	qDryRun, qhkDryRun, _ := qs.GetOK("dry_run")
+	if err := o.bindDryRun(qDryRun, qhkDryRun, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qResourceName, qhkResourceName, _ := qs.GetOK("resource_name")
 	if err := o.bindResourceName(qResourceName, qhkResourceName, route.Formats); err != nil {
 		res = append(res, err)
@@ -70,6 +88,30 @@ func (o *DeleteResourceByNameParams) BindRequest(r *http.Request, route *middlew
 	return nil
 }
 
+// bindDryRun binds and validates parameter DryRun from query.
+func (o *DeleteResourceByNameParams) bindDryRun(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewDeleteResourceByNameParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dry_run", "query", "bool", raw)
+	}
+	o.DryRun = &value
+
+	return nil
+}
+
 // bindResourceName binds and validates parameter ResourceName from query.
 func (o *DeleteResourceByNameParams) bindResourceName(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	if !hasKey {
@@ -37,6 +37,50 @@ func (o *DeleteResourceByNameOK) WriteResponse(rw http.ResponseWriter, producer
 	rw.WriteHeader(200)
 }
 
+// DeleteResourceByNameAcceptedCode is the HTTP code returned for type DeleteResourceByNameAccepted
+const DeleteResourceByNameAcceptedCode int = 202
+
+/*DeleteResourceByNameAccepted Accepted: the request was valid but no changes were made because dry run mode was enabled
+
+swagger:response deleteResourceByNameAccepted
+*/
+type DeleteResourceByNameAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ChangeSet `json:"body,omitempty"`
+}
+
+// NewDeleteResourceByNameAccepted creates DeleteResourceByNameAccepted with default headers values
+func NewDeleteResourceByNameAccepted() *DeleteResourceByNameAccepted {
+
+	return &DeleteResourceByNameAccepted{}
+}
+
+// WithPayload adds the payload to the delete resource by name accepted response
+func (o *DeleteResourceByNameAccepted) WithPayload(payload *models.ChangeSet) *DeleteResourceByNameAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete resource by name accepted response
+func (o *DeleteResourceByNameAccepted) SetPayload(payload *models.ChangeSet) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteResourceByNameAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // DeleteResourceByNameNotFoundCode is the HTTP code returned for type DeleteResourceByNameNotFound
 const DeleteResourceByNameNotFoundCode int = 404
 
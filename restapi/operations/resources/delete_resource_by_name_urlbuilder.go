@@ -9,10 +9,13 @@ import (
 	"errors"
 	"net/url"
 	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
 )
 
 // DeleteResourceByNameURL generates an URL for the delete resource by name operation
 type DeleteResourceByNameURL struct {
+	DryRun           *bool
 	ResourceName     string
 	ResourceTypeName string
 
@@ -47,6 +50,14 @@ func (o *DeleteResourceByNameURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var dryRunQ string
+	if o.DryRun != nil {
+		dryRunQ = swag.FormatBool(*o.DryRun)
+	}
+	if dryRunQ != "" {
+		qs.Set("dry_run", dryRunQ)
+	}
+
 	resourceNameQ := o.ResourceName
 	if resourceNameQ != "" {
 		qs.Set("resource_name", resourceNameQ)
@@ -37,6 +37,50 @@ func (o *DeleteResourceOK) WriteResponse(rw http.ResponseWriter, producer runtim
 	rw.WriteHeader(200)
 }
 
+// DeleteResourceAcceptedCode is the HTTP code returned for type DeleteResourceAccepted
+const DeleteResourceAcceptedCode int = 202
+
+/*DeleteResourceAccepted Accepted: the request was valid but no changes were made because dry run mode was enabled
+
+swagger:response deleteResourceAccepted
+*/
+type DeleteResourceAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ChangeSet `json:"body,omitempty"`
+}
+
+// NewDeleteResourceAccepted creates DeleteResourceAccepted with default headers values
+func NewDeleteResourceAccepted() *DeleteResourceAccepted {
+
+	return &DeleteResourceAccepted{}
+}
+
+// WithPayload adds the payload to the delete resource accepted response
+func (o *DeleteResourceAccepted) WithPayload(payload *models.ChangeSet) *DeleteResourceAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete resource accepted response
+func (o *DeleteResourceAccepted) SetPayload(payload *models.ChangeSet) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteResourceAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // DeleteResourceNotFoundCode is the HTTP code returned for type DeleteResourceNotFound
 const DeleteResourceNotFoundCode int = 404
 
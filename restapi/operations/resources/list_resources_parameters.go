@@ -31,6 +31,10 @@ type ListResourcesParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*Limits the results to resources whose labels match the selector: a comma-separated list of requirements, all of which must be met. Each requirement has one of these forms: key=value, key!=value, key in (value1, value2), key notin (value1, value2), key or !key. A resource without the label meets key!=value and key notin requirements. All resources match by default.
+	  In: query
+	*/
+	LabelSelector *string
 	/*The resource name to search for.
 	  In: query
 	*/
@@ -52,6 +56,11 @@ func (o *ListResourcesParams) BindRequest(r *http.Request, route *middleware.Mat
 
 	qs := runtime.Values(r.URL.Query())
 
+	qLabelSelector, qhkLabelSelector, _ := qs.GetOK("label_selector")
+	if err := o.bindLabelSelector(qLabelSelector, qhkLabelSelector, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qResourceName, qhkResourceName, _ := qs.GetOK("resource_name")
 	if err := o.bindResourceName(qResourceName, qhkResourceName, route.Formats); err != nil {
 		res = append(res, err)
@@ -67,6 +76,24 @@ func (o *ListResourcesParams) BindRequest(r *http.Request, route *middleware.Mat
 	return nil
 }
 
+// bindLabelSelector binds and validates parameter LabelSelector from query.
+func (o *ListResourcesParams) bindLabelSelector(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.LabelSelector = &raw
+
+	return nil
+}
+
 // bindResourceName binds and validates parameter ResourceName from query.
 func (o *ListResourcesParams) bindResourceName(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
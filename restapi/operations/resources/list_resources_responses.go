@@ -57,6 +57,50 @@ func (o *ListResourcesOK) WriteResponse(rw http.ResponseWriter, producer runtime
 	}
 }
 
+// ListResourcesBadRequestCode is the HTTP code returned for type ListResourcesBadRequest
+const ListResourcesBadRequestCode int = 400
+
+/*ListResourcesBadRequest Bad Request
+
+swagger:response listResourcesBadRequest
+*/
+type ListResourcesBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorOut `json:"body,omitempty"`
+}
+
+// NewListResourcesBadRequest creates ListResourcesBadRequest with default headers values
+func NewListResourcesBadRequest() *ListResourcesBadRequest {
+
+	return &ListResourcesBadRequest{}
+}
+
+// WithPayload adds the payload to the list resources bad request response
+func (o *ListResourcesBadRequest) WithPayload(payload *models.ErrorOut) *ListResourcesBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list resources bad request response
+func (o *ListResourcesBadRequest) SetPayload(payload *models.ErrorOut) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListResourcesBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // ListResourcesInternalServerErrorCode is the HTTP code returned for type ListResourcesInternalServerError
 const ListResourcesInternalServerErrorCode int = 500
 
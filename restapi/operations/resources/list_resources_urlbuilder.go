@@ -13,6 +13,7 @@ import (
 
 // ListResourcesURL generates an URL for the list resources operation
 type ListResourcesURL struct {
+	LabelSelector    *string
 	ResourceName     *string
 	ResourceTypeName *string
 
@@ -47,6 +48,14 @@ func (o *ListResourcesURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var labelSelectorQ string
+	if o.LabelSelector != nil {
+		labelSelectorQ = *o.LabelSelector
+	}
+	if labelSelectorQ != "" {
+		qs.Set("label_selector", labelSelectorQ)
+	}
+
 	var resourceNameQ string
 	if o.ResourceName != nil {
 		resourceNameQ = *o.ResourceName
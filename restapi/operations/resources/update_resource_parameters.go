@@ -41,6 +41,10 @@ type UpdateResourceParams struct {
 	  In: path
 	*/
 	ID string
+	/*The version of the resource that the changes are based on.
+	  In: header
+	*/
+	IfMatch *string
 	/*The updated resource information.
 	  Required: true
 	  In: body
@@ -62,6 +66,10 @@ func (o *UpdateResourceParams) BindRequest(r *http.Request, route *middleware.Ma
 		res = append(res, err)
 	}
 
+	if err := o.bindIfMatch(r.Header[http.CanonicalHeaderKey("If-Match")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body models.ResourceUpdate
@@ -108,3 +116,20 @@ func (o *UpdateResourceParams) bindID(rawData []string, hasKey bool, formats str
 
 	return nil
 }
+
+// bindIfMatch binds and validates parameter IfMatch from header.
+func (o *UpdateResourceParams) bindIfMatch(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IfMatch = &raw
+
+	return nil
+}
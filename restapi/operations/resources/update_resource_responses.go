@@ -21,6 +21,10 @@ const UpdateResourceOKCode int = 200
 swagger:response updateResourceOK
 */
 type UpdateResourceOK struct {
+	/*The updated version of the resource.
+	 */
+	ETag string `json:"ETag"`
+
 
 	/*
 	  In: Body
@@ -34,6 +38,17 @@ func NewUpdateResourceOK() *UpdateResourceOK {
 	return &UpdateResourceOK{}
 }
 
+// WithETag adds the eTag to the update resource o k response
+func (o *UpdateResourceOK) WithETag(eTag string) *UpdateResourceOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the update resource o k response
+func (o *UpdateResourceOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithPayload adds the payload to the update resource o k response
 func (o *UpdateResourceOK) WithPayload(payload *models.ResourceOut) *UpdateResourceOK {
 	o.Payload = payload
@@ -48,6 +63,13 @@ func (o *UpdateResourceOK) SetPayload(payload *models.ResourceOut) {
 // WriteResponse to the client
 func (o *UpdateResourceOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	rw.WriteHeader(200)
 	if o.Payload != nil {
 		payload := o.Payload
@@ -145,6 +167,50 @@ func (o *UpdateResourceNotFound) WriteResponse(rw http.ResponseWriter, producer
 	}
 }
 
+// UpdateResourcePreconditionFailedCode is the HTTP code returned for type UpdateResourcePreconditionFailed
+const UpdateResourcePreconditionFailedCode int = 412
+
+/*UpdateResourcePreconditionFailed Precondition Failed
+
+swagger:response updateResourcePreconditionFailed
+*/
+type UpdateResourcePreconditionFailed struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorOut `json:"body,omitempty"`
+}
+
+// NewUpdateResourcePreconditionFailed creates UpdateResourcePreconditionFailed with default headers values
+func NewUpdateResourcePreconditionFailed() *UpdateResourcePreconditionFailed {
+
+	return &UpdateResourcePreconditionFailed{}
+}
+
+// WithPayload adds the payload to the update resource precondition failed response
+func (o *UpdateResourcePreconditionFailed) WithPayload(payload *models.ErrorOut) *UpdateResourcePreconditionFailed {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update resource precondition failed response
+func (o *UpdateResourcePreconditionFailed) SetPayload(payload *models.ErrorOut) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateResourcePreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(412)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // UpdateResourceInternalServerErrorCode is the HTTP code returned for type UpdateResourceInternalServerError
 const UpdateResourceInternalServerErrorCode int = 500
 
@@ -12,15 +12,23 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 )
 
 // NewDeleteSubjectByExternalIDParams creates a new DeleteSubjectByExternalIDParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewDeleteSubjectByExternalIDParams() DeleteSubjectByExternalIDParams {
 
-	return DeleteSubjectByExternalIDParams{}
+	var (
+		// initialize parameters with default values
+
+		dryRunDefault = bool(false)
+	)
+
+	return DeleteSubjectByExternalIDParams{
+		DryRun: &dryRunDefault,
+	}
 }
 
 // DeleteSubjectByExternalIDParams contains all the bound params for the delete subject by external Id operation
@@ -32,6 +40,11 @@ type DeleteSubjectByExternalIDParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*True if the changes should be previewed without being applied to the database. This parameter is optional and defaults to False.
+	  In: query
+	  Default: false
+	*/
+	DryRun *bool
 	/*The external subject identifier.
 	  Required: true
 	  In: query
@@ -55,6 +68,11 @@ func (o *DeleteSubjectByExternalIDParams) BindRequest(r *http.Request, route *mi
 
 	qs := runtime.Values(r.URL.Query())
 
+	qDryRun, qhkDryRun, _ := qs.GetOK("dry_run")
+	if err := o.bindDryRun(qDryRun, qhkDryRun, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qSubjectID, qhkSubjectID, _ := qs.GetOK("subject_id")
 	if err := o.bindSubjectID(qSubjectID, qhkSubjectID, route.Formats); err != nil {
 		res = append(res, err)
@@ -70,6 +88,30 @@ func (o *DeleteSubjectByExternalIDParams) BindRequest(r *http.Request, route *mi
 	return nil
 }
 
+// bindDryRun binds and validates parameter DryRun from query.
+func (o *DeleteSubjectByExternalIDParams) bindDryRun(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewDeleteSubjectByExternalIDParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dry_run", "query", "bool", raw)
+	}
+	o.DryRun = &value
+
+	return nil
+}
+
 // bindSubjectID binds and validates parameter SubjectID from query.
 func (o *DeleteSubjectByExternalIDParams) bindSubjectID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	if !hasKey {
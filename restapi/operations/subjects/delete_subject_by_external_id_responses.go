@@ -37,6 +37,50 @@ func (o *DeleteSubjectByExternalIDOK) WriteResponse(rw http.ResponseWriter, prod
 	rw.WriteHeader(200)
 }
 
+// DeleteSubjectByExternalIDAcceptedCode is the HTTP code returned for type DeleteSubjectByExternalIDAccepted
+const DeleteSubjectByExternalIDAcceptedCode int = 202
+
+/*DeleteSubjectByExternalIDAccepted Accepted: the request was valid but no changes were made because dry run mode was enabled
+
+swagger:response deleteSubjectByExternalIdAccepted
+*/
+type DeleteSubjectByExternalIDAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ChangeSet `json:"body,omitempty"`
+}
+
+// NewDeleteSubjectByExternalIDAccepted creates DeleteSubjectByExternalIDAccepted with default headers values
+func NewDeleteSubjectByExternalIDAccepted() *DeleteSubjectByExternalIDAccepted {
+
+	return &DeleteSubjectByExternalIDAccepted{}
+}
+
+// WithPayload adds the payload to the delete subject by external Id accepted response
+func (o *DeleteSubjectByExternalIDAccepted) WithPayload(payload *models.ChangeSet) *DeleteSubjectByExternalIDAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete subject by external Id accepted response
+func (o *DeleteSubjectByExternalIDAccepted) SetPayload(payload *models.ChangeSet) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteSubjectByExternalIDAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // DeleteSubjectByExternalIDNotFoundCode is the HTTP code returned for type DeleteSubjectByExternalIDNotFound
 const DeleteSubjectByExternalIDNotFoundCode int = 404
 
@@ -9,10 +9,13 @@ import (
 	"errors"
 	"net/url"
 	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
 )
 
 // DeleteSubjectByExternalIDURL generates an URL for the delete subject by external Id operation
 type DeleteSubjectByExternalIDURL struct {
+	DryRun      *bool
 	SubjectID   string
 	SubjectType string
 
@@ -47,6 +50,14 @@ func (o *DeleteSubjectByExternalIDURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var dryRunQ string
+	if o.DryRun != nil {
+		dryRunQ = swag.FormatBool(*o.DryRun)
+	}
+	if dryRunQ != "" {
+		qs.Set("dry_run", dryRunQ)
+	}
+
 	subjectIDQ := o.SubjectID
 	if subjectIDQ != "" {
 		qs.Set("subject_id", subjectIDQ)
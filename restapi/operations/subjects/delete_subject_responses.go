@@ -37,6 +37,50 @@ func (o *DeleteSubjectOK) WriteResponse(rw http.ResponseWriter, producer runtime
 	rw.WriteHeader(200)
 }
 
+// DeleteSubjectAcceptedCode is the HTTP code returned for type DeleteSubjectAccepted
+const DeleteSubjectAcceptedCode int = 202
+
+/*DeleteSubjectAccepted Accepted: the request was valid but no changes were made because dry run mode was enabled
+
+swagger:response deleteSubjectAccepted
+*/
+type DeleteSubjectAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ChangeSet `json:"body,omitempty"`
+}
+
+// NewDeleteSubjectAccepted creates DeleteSubjectAccepted with default headers values
+func NewDeleteSubjectAccepted() *DeleteSubjectAccepted {
+
+	return &DeleteSubjectAccepted{}
+}
+
+// WithPayload adds the payload to the delete subject accepted response
+func (o *DeleteSubjectAccepted) WithPayload(payload *models.ChangeSet) *DeleteSubjectAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete subject accepted response
+func (o *DeleteSubjectAccepted) SetPayload(payload *models.ChangeSet) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteSubjectAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // DeleteSubjectNotFoundCode is the HTTP code returned for type DeleteSubjectNotFound
 const DeleteSubjectNotFoundCode int = 404
 
@@ -21,6 +21,10 @@ const UpdateSubjectOKCode int = 200
 swagger:response updateSubjectOK
 */
 type UpdateSubjectOK struct {
+	/*The updated version of the subject.
+	 */
+	ETag string `json:"ETag"`
+
 
 	/*
 	  In: Body
@@ -34,6 +38,17 @@ func NewUpdateSubjectOK() *UpdateSubjectOK {
 	return &UpdateSubjectOK{}
 }
 
+// WithETag adds the eTag to the update subject o k response
+func (o *UpdateSubjectOK) WithETag(eTag string) *UpdateSubjectOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the update subject o k response
+func (o *UpdateSubjectOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithPayload adds the payload to the update subject o k response
 func (o *UpdateSubjectOK) WithPayload(payload *models.SubjectOut) *UpdateSubjectOK {
 	o.Payload = payload
@@ -48,6 +63,13 @@ func (o *UpdateSubjectOK) SetPayload(payload *models.SubjectOut) {
 // WriteResponse to the client
 func (o *UpdateSubjectOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	rw.WriteHeader(200)
 	if o.Payload != nil {
 		payload := o.Payload
@@ -145,6 +167,50 @@ func (o *UpdateSubjectNotFound) WriteResponse(rw http.ResponseWriter, producer r
 	}
 }
 
+// UpdateSubjectPreconditionFailedCode is the HTTP code returned for type UpdateSubjectPreconditionFailed
+const UpdateSubjectPreconditionFailedCode int = 412
+
+/*UpdateSubjectPreconditionFailed Precondition Failed
+
+swagger:response updateSubjectPreconditionFailed
+*/
+type UpdateSubjectPreconditionFailed struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorOut `json:"body,omitempty"`
+}
+
+// NewUpdateSubjectPreconditionFailed creates UpdateSubjectPreconditionFailed with default headers values
+func NewUpdateSubjectPreconditionFailed() *UpdateSubjectPreconditionFailed {
+
+	return &UpdateSubjectPreconditionFailed{}
+}
+
+// WithPayload adds the payload to the update subject precondition failed response
+func (o *UpdateSubjectPreconditionFailed) WithPayload(payload *models.ErrorOut) *UpdateSubjectPreconditionFailed {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update subject precondition failed response
+func (o *UpdateSubjectPreconditionFailed) SetPayload(payload *models.ErrorOut) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateSubjectPreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(412)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // UpdateSubjectInternalServerErrorCode is the HTTP code returned for type UpdateSubjectInternalServerError
 const UpdateSubjectInternalServerErrorCode int = 500
 
@@ -0,0 +1,111 @@
+package restapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/cyverse-de/permissions/logger"
+	"github.com/cyverse-de/permissions/permsync"
+)
+
+// reconcileLockID is the key of the lock used to prevent more than one instance of the service from reconciling at the
+// same time.
+const reconcileLockID = 355060332941169497
+
+// reconcile runs the reconcile checks in a mapping file once, logging every orphaned resource and subject that it
+// finds. The orphans are deleted if requested. The sources of truth are queried before the permissions database
+// transaction starts, and the run is skipped if another instance of the service is already reconciling. Before any
+// orphans are deleted, the sources are queried again so that resources and subjects created while the run was in
+// progress aren't mistaken for orphans.
+func reconcile(ctx context.Context, cfg *viper.Viper, syncCfg *permsync.Config, deleteOrphans bool) error {
+	env, err := permsync.Open(syncCfg, cfg.GetString)
+	if err != nil {
+		return err
+	}
+	defer env.Close()
+	sources, err := permsync.QuerySources(ctx, syncCfg, env)
+	if err != nil {
+		return err
+	}
+
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	// Skip this run if another instance is already reconciling.
+	locked, err := tx.TryLock(reconcileLockID)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		logger.Log.Info("skipping reconcile because another instance of the service is already running it")
+		return tx.Commit()
+	}
+
+	report, err := sources.Reconcile(tx)
+	if err != nil {
+		return err
+	}
+	for _, o := range report.Orphans {
+		logger.Log.Infof("orphaned %s: %s/%s (%d permissions)", o.Kind, o.Type, o.Name, o.Permissions)
+	}
+	if !deleteOrphans {
+		logger.Log.Infof("found %d orphaned resources and %d orphaned subjects", report.Resources, report.Subjects)
+		return tx.Commit()
+	}
+
+	if err := report.Recheck(ctx, syncCfg, env, tx); err != nil {
+		return err
+	}
+	if err := report.Delete(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	logger.Log.Infof("deleted %d orphaned resources and %d orphaned subjects", report.Resources, report.Subjects)
+	return nil
+}
+
+// startReconciler runs the reconcile checks in the configured mapping file periodically until the returned function is
+// called. Nothing is started if no mapping file is configured. The mapping file is loaded immediately so that errors
+// in it, or an interval that isn't positive, prevent the service from starting.
+func startReconciler(cfg *viper.Viper) (func(), error) {
+	path := cfg.GetString("reconcile.mapping_file")
+	if path == "" {
+		return func() {}, nil
+	}
+	interval := cfg.GetDuration("reconcile.interval")
+	if interval <= 0 {
+		return nil, fmt.Errorf("reconcile.interval must be positive: %s", interval)
+	}
+	syncCfg, err := permsync.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	deleteOrphans := cfg.GetBool("reconcile.delete")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := reconcile(ctx, cfg, syncCfg, deleteOrphans); err != nil {
+					logger.Log.Errorf("reconcile failed: %s", err)
+				}
+			}
+		}
+	}()
+
+	logger.Log.Infof("reconciling with %s every %s", path, interval)
+	return cancel, nil
+}
@@ -0,0 +1,41 @@
+package restapi
+
+import (
+	"database/sql"
+
+	"github.com/spf13/viper"
+
+	permsdb "github.com/cyverse-de/permissions/restapi/impl/db"
+	"github.com/cyverse-de/permissions/restapi/impl/db/sqlite"
+)
+
+// newStore returns a Store for the permissions database described by the configuration. SQLite databases don't use
+// schema migrations; the schema is created when the store is.
+func newStore(cfg *viper.Viper, db *sql.DB) (permsdb.Store, error) {
+	if usesSQLite(cfg) {
+		return sqlite.NewStore(db)
+	}
+	return permsdb.NewPostgresStore(db, cfg.GetString("db.schema")), nil
+}
+
+// OpenStore connects to the permissions database described by the configuration, which is normally loaded using
+// DefaultConfig, and returns a Store for it. This allows command-line tools to use the same storage code as the
+// service. The caller is responsible for closing the returned database connection.
+func OpenStore(cfg *viper.Viper) (permsdb.Store, *sql.DB, error) {
+	db, err := connectDB(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	store, err := newStore(cfg, db)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	return store, db, nil
+}
@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTracing returns a handler that starts a span for each request. The span continues the trace identified by the
+// W3C trace-context headers in the request, if there are any.
+func WithTracing(handler http.Handler) http.Handler {
+	return otelhttp.NewHandler(handler, "http.request")
+}
+
+// NameOperationSpans returns a handler that names the span for each request after the swagger operation that the
+// request was routed to. It must be installed after the request has been routed, so that the matched route is
+// available.
+func NameOperationSpans(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route := middleware.MatchedRouteFrom(r); route != nil && route.Operation != nil {
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(route.Operation.ID)
+			span.SetAttributes(attribute.String("http.route", route.PathPattern))
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans installs a tracer provider that records spans in memory for the duration of a test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestEndSpan(t *testing.T) {
+	recorder := recordSpans(t)
+
+	_, span := StartSpan(context.Background(), "succeeds")
+	if err := EndSpan(span, nil); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	expectedErr := errors.New("oops")
+	_, span = StartSpan(context.Background(), "fails")
+	if err := EndSpan(span, expectedErr); err != expectedErr {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("unexpected number of spans: %d", len(spans))
+	}
+	if spans[0].Status().Code != codes.Unset {
+		t.Errorf("unexpected status for successful span: %v", spans[0].Status())
+	}
+	if spans[1].Status().Code != codes.Error || spans[1].Status().Description != "oops" {
+		t.Errorf("unexpected status for failed span: %v", spans[1].Status())
+	}
+}
+
+func TestWithTracing(t *testing.T) {
+	recorder := recordSpans(t)
+	if _, err := Init(context.Background(), Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var childTraceID trace.TraceID
+	handler := WithTracing(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, span := StartSpan(r.Context(), "child")
+		childTraceID = span.SpanContext().TraceID()
+		span.End()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if childTraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("the trace from the request headers was not continued: %s", childTraceID)
+	}
+	if len(recorder.Ended()) != 2 {
+		t.Errorf("unexpected number of spans: %d", len(recorder.Ended()))
+	}
+}
+
+func TestNewExporter(t *testing.T) {
+	ctx := context.Background()
+
+	if exporter, _, err := newExporter(ctx, Config{}); exporter != nil || err != nil {
+		t.Errorf("expected no exporter when tracing is disabled: %v, %v", exporter, err)
+	}
+	if _, _, err := newExporter(ctx, Config{Exporter: "carrier-pigeon"}); err == nil {
+		t.Error("expected an unsupported exporter to be rejected")
+	}
+	if _, _, err := newExporter(ctx, Config{Exporter: ExporterFile}); err == nil {
+		t.Error("expected the file exporter to require a file")
+	}
+
+	exporter, closer, err := newExporter(ctx, Config{
+		Exporter: ExporterFile,
+		File:     filepath.Join(t.TempDir(), "traces.json"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Shutdown(ctx); err != nil {
+		t.Error(err)
+	}
+	if err := closer.Close(); err != nil {
+		t.Error(err)
+	}
+}